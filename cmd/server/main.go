@@ -3,6 +3,10 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 	
 	"github.com/NacerKH/autosphere-mcp-golang/internal/config"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/server"
@@ -13,7 +17,16 @@ func main() {
 	
 	mcpServer := server.NewMCPServer(cfg)
 	
-	if err := mcpServer.Run(context.Background()); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	
+	err := mcpServer.Run(ctx)
+	
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	mcpServer.Shutdown(shutdownCtx)
+	cancel()
+	
+	if err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
 }
@@ -0,0 +1,304 @@
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AlertmanagerClient handles interactions with the Alertmanager v2 API
+type AlertmanagerClient struct {
+	baseURL    string
+	httpClient *http.Client
+	username   string
+	password   string
+	debug      bool
+}
+
+// AlertmanagerConfig contains configuration for Alertmanager client
+type AlertmanagerConfig struct {
+	BaseURL  string
+	Username string
+	Password string
+	Timeout  time.Duration
+	Debug    bool
+}
+
+// NewAlertmanagerClient creates a new Alertmanager client
+func NewAlertmanagerClient(config AlertmanagerConfig) *AlertmanagerClient {
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	transport := &http.Transport{
+		MaxIdleConns:        20,
+		MaxIdleConnsPerHost: 5,
+		IdleConnTimeout:     90 * time.Second,
+		ForceAttemptHTTP2:   true,
+	}
+
+	return &AlertmanagerClient{
+		baseURL: strings.TrimRight(config.BaseURL, "/"),
+		httpClient: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: transport,
+		},
+		username: config.Username,
+		password: config.Password,
+		debug:    config.Debug,
+	}
+}
+
+// Alert is an alert as returned by GET /api/v2/alerts
+type Alert struct {
+	Fingerprint  string            `json:"fingerprint"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Status       AlertStatus       `json:"status"`
+	Receivers    []Receiver        `json:"receivers"`
+}
+
+// AlertStatus tells whether an alert is active or suppressed, and by what
+type AlertStatus struct {
+	State       string   `json:"state"` // active, suppressed or unprocessed
+	SilencedBy  []string `json:"silencedBy"`
+	InhibitedBy []string `json:"inhibitedBy"`
+}
+
+// Receiver is a notification receiver an alert is routed to
+type Receiver struct {
+	Name string `json:"name"`
+}
+
+// IsSilenced reports whether at least one silence matches the alert
+func (a Alert) IsSilenced() bool {
+	return len(a.Status.SilencedBy) > 0
+}
+
+// IsInhibited reports whether the alert is inhibited by another alert
+func (a Alert) IsInhibited() bool {
+	return len(a.Status.InhibitedBy) > 0
+}
+
+// AlertFilter narrows down the alerts returned by GetAlerts. Nil booleans
+// keep the Alertmanager default (included).
+type AlertFilter struct {
+	Active    *bool
+	Silenced  *bool
+	Inhibited *bool
+	Matchers  []Matcher
+}
+
+// Matcher matches a label by value or regular expression
+type Matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// String renders the matcher in Alertmanager filter syntax, e.g. severity=~"warn.*"
+func (m Matcher) String() string {
+	operator := "="
+	switch {
+	case m.IsRegex && m.IsEqual:
+		operator = "=~"
+	case m.IsRegex:
+		operator = "!~"
+	case !m.IsEqual:
+		operator = "!="
+	}
+	return m.Name + operator + strconv.Quote(m.Value)
+}
+
+// ParseMatcher parses a matcher such as alertname=HighCPU, instance=~"node-.*" or env!=dev
+func ParseMatcher(expr string) (Matcher, error) {
+	expr = strings.TrimSpace(expr)
+
+	for _, op := range []struct {
+		token   string
+		isRegex bool
+		isEqual bool
+	}{
+		// Two-character operators first so that "=~" is not read as "="
+		{"=~", true, true},
+		{"!~", true, false},
+		{"!=", false, false},
+		{"=", false, true},
+	} {
+		index := strings.Index(expr, op.token)
+		if index <= 0 {
+			continue
+		}
+
+		name := strings.TrimSpace(expr[:index])
+		value := strings.TrimSpace(expr[index+len(op.token):])
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		if strings.ContainsAny(name, "=!~\" ") {
+			// The operator belongs to the value, try the next one
+			continue
+		}
+
+		return Matcher{Name: name, Value: value, IsRegex: op.isRegex, IsEqual: op.isEqual}, nil
+	}
+
+	return Matcher{}, fmt.Errorf("invalid matcher %q: expected label=value, label!=value, label=~regex or label!~regex", expr)
+}
+
+// Silence is a silence as returned by GET /api/v2/silences
+type Silence struct {
+	ID        string        `json:"id"`
+	Matchers  []Matcher     `json:"matchers"`
+	StartsAt  time.Time     `json:"startsAt"`
+	EndsAt    time.Time     `json:"endsAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	CreatedBy string        `json:"createdBy"`
+	Comment   string        `json:"comment"`
+	Status    SilenceStatus `json:"status"`
+}
+
+// SilenceStatus holds the state of a silence: active, pending or expired
+type SilenceStatus struct {
+	State string `json:"state"`
+}
+
+// PostableSilence is the body of POST /api/v2/silences
+type PostableSilence struct {
+	ID        string    `json:"id,omitempty"`
+	Matchers  []Matcher `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+}
+
+// GetAlerts lists alerts matching the filter
+func (c *AlertmanagerClient) GetAlerts(ctx context.Context, filter AlertFilter) ([]Alert, error) {
+	params := url.Values{}
+	setBool := func(key string, value *bool) {
+		if value != nil {
+			params.Set(key, strconv.FormatBool(*value))
+		}
+	}
+	setBool("active", filter.Active)
+	setBool("silenced", filter.Silenced)
+	setBool("inhibited", filter.Inhibited)
+	for _, matcher := range filter.Matchers {
+		params.Add("filter", matcher.String())
+	}
+
+	var alerts []Alert
+	if err := c.makeRequest(ctx, "GET", "/api/v2/alerts", params, nil, &alerts); err != nil {
+		return nil, fmt.Errorf("failed to get alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// GetSilences lists silences, optionally restricted to those matching the given label matchers
+func (c *AlertmanagerClient) GetSilences(ctx context.Context, matchers []Matcher) ([]Silence, error) {
+	params := url.Values{}
+	for _, matcher := range matchers {
+		params.Add("filter", matcher.String())
+	}
+
+	var silences []Silence
+	if err := c.makeRequest(ctx, "GET", "/api/v2/silences", params, nil, &silences); err != nil {
+		return nil, fmt.Errorf("failed to get silences: %w", err)
+	}
+	return silences, nil
+}
+
+// GetSilence returns a single silence by ID
+func (c *AlertmanagerClient) GetSilence(ctx context.Context, id string) (*Silence, error) {
+	var silence Silence
+	if err := c.makeRequest(ctx, "GET", "/api/v2/silence/"+url.PathEscape(id), nil, nil, &silence); err != nil {
+		return nil, fmt.Errorf("failed to get silence %s: %w", id, err)
+	}
+	return &silence, nil
+}
+
+// CreateSilence creates a silence and returns its ID
+func (c *AlertmanagerClient) CreateSilence(ctx context.Context, silence PostableSilence) (string, error) {
+	var response struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if err := c.makeRequest(ctx, "POST", "/api/v2/silences", nil, silence, &response); err != nil {
+		return "", fmt.Errorf("failed to create silence: %w", err)
+	}
+	return response.SilenceID, nil
+}
+
+// ExpireSilence expires a silence immediately
+func (c *AlertmanagerClient) ExpireSilence(ctx context.Context, id string) error {
+	if err := c.makeRequest(ctx, "DELETE", "/api/v2/silence/"+url.PathEscape(id), nil, nil, nil); err != nil {
+		return fmt.Errorf("failed to expire silence %s: %w", id, err)
+	}
+	return nil
+}
+
+// makeRequest makes a HTTP request to the Alertmanager API
+func (c *AlertmanagerClient) makeRequest(ctx context.Context, method, endpoint string, params url.Values, body interface{}, result interface{}) error {
+	reqURL := c.baseURL + endpoint
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewBuffer(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.username != "" && c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	if c.debug {
+		log.Printf("Alertmanager request: %s %s", method, reqURL)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error: status %d - %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return nil
+}
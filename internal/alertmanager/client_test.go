@@ -0,0 +1,142 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMatcher(t *testing.T) {
+	tests := []struct {
+		expr string
+		want Matcher
+	}{
+		{"alertname=HighCPU", Matcher{Name: "alertname", Value: "HighCPU", IsEqual: true}},
+		{`instance=~"node-.*"`, Matcher{Name: "instance", Value: "node-.*", IsRegex: true, IsEqual: true}},
+		{"env!=dev", Matcher{Name: "env", Value: "dev"}},
+		{"env !~ test.*", Matcher{Name: "env", Value: "test.*", IsRegex: true}},
+		{"summary=a=b", Matcher{Name: "summary", Value: "a=b", IsEqual: true}},
+	}
+	for _, tt := range tests {
+		got, err := ParseMatcher(tt.expr)
+		if err != nil {
+			t.Errorf("ParseMatcher(%q): %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMatcher(%q) = %+v, want %+v", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"", "alertname", "=value"} {
+		if _, err := ParseMatcher(expr); err == nil {
+			t.Errorf("ParseMatcher(%q) succeeded, want an error", expr)
+		}
+	}
+}
+
+func TestMatcherString(t *testing.T) {
+	tests := map[string]Matcher{
+		`severity="critical"`: {Name: "severity", Value: "critical", IsEqual: true},
+		`env!="dev"`:          {Name: "env", Value: "dev"},
+		`job=~"node.*"`:       {Name: "job", Value: "node.*", IsRegex: true, IsEqual: true},
+		`job!~"kube.*"`:       {Name: "job", Value: "kube.*", IsRegex: true},
+	}
+	for want, matcher := range tests {
+		if got := matcher.String(); got != want {
+			t.Errorf("String() = %s, want %s", got, want)
+		}
+	}
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *AlertmanagerClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewAlertmanagerClient(AlertmanagerConfig{BaseURL: server.URL + "/", Username: "am", Password: "secret"})
+}
+
+func TestGetAlertsQuery(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/api/v2/alerts" || query.Get("active") != "false" || query.Get("silenced") != "" ||
+			strings.Join(query["filter"], ",") != `severity="critical",job=~"node.*"` {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if user, password, _ := r.BasicAuth(); user != "am" || password != "secret" {
+			t.Errorf("basic auth = %q %q", user, password)
+		}
+		json.NewEncoder(w).Encode([]Alert{{
+			Fingerprint: "abc",
+			Labels:      map[string]string{"alertname": "NodeDown"},
+			Status:      AlertStatus{State: "suppressed", SilencedBy: []string{"s1"}, InhibitedBy: []string{"a2"}},
+		}})
+	})
+
+	active := false
+	alerts, err := client.GetAlerts(context.Background(), AlertFilter{
+		Active: &active,
+		Matchers: []Matcher{
+			{Name: "severity", Value: "critical", IsEqual: true},
+			{Name: "job", Value: "node.*", IsRegex: true, IsEqual: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetAlerts: %v", err)
+	}
+	if len(alerts) != 1 || !alerts[0].IsSilenced() || !alerts[0].IsInhibited() {
+		t.Errorf("unexpected alerts %+v", alerts)
+	}
+}
+
+func TestSilenceLifecycle(t *testing.T) {
+	var posted PostableSilence
+	var expired string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+			if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+				t.Errorf("decode silence: %v", err)
+			}
+			json.NewEncoder(w).Encode(map[string]string{"silenceID": "s-1"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silence/s-1":
+			json.NewEncoder(w).Encode(Silence{ID: "s-1", Status: SilenceStatus{State: "active"}})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/v2/silence/"):
+			expired = strings.TrimPrefix(r.URL.Path, "/api/v2/silence/")
+		default:
+			http.Error(w, "silence not found", http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	id, err := client.CreateSilence(ctx, PostableSilence{
+		Matchers:  []Matcher{{Name: "alertname", Value: "HighCPU", IsEqual: true}},
+		StartsAt:  now,
+		EndsAt:    now.Add(time.Hour),
+		CreatedBy: "tester",
+		Comment:   "maintenance",
+	})
+	if err != nil || id != "s-1" {
+		t.Fatalf("CreateSilence = %q, %v", id, err)
+	}
+	if posted.Comment != "maintenance" || posted.CreatedBy != "tester" || len(posted.Matchers) != 1 {
+		t.Errorf("posted %+v", posted)
+	}
+
+	silence, err := client.GetSilence(ctx, "s-1")
+	if err != nil || silence.Status.State != "active" {
+		t.Fatalf("GetSilence = %+v, %v", silence, err)
+	}
+	if err := client.ExpireSilence(ctx, "s-1"); err != nil || expired != "s-1" {
+		t.Fatalf("ExpireSilence: %v (expired %q)", err, expired)
+	}
+
+	if _, err := client.GetSilence(ctx, "missing"); err == nil || !strings.Contains(err.Error(), "status 404 - silence not found") {
+		t.Errorf("error = %v, want the status and body", err)
+	}
+}
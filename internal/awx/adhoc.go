@@ -0,0 +1,152 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jobPathTTL is how long the API path of a job is cached; the kind of a job never changes
+const jobPathTTL = 24 * time.Hour
+
+// AdHocCommandRequest runs a single Ansible module against inventory hosts
+type AdHocCommandRequest struct {
+	Inventory     int    `json:"inventory"`
+	Limit         string `json:"limit,omitempty"`
+	ModuleName    string `json:"module_name"`
+	ModuleArgs    string `json:"module_args,omitempty"`
+	Credential    *int   `json:"credential,omitempty"`
+	BecomeEnabled bool   `json:"become_enabled"`
+	Forks         int    `json:"forks,omitempty"`
+	Verbosity     int    `json:"verbosity,omitempty"`
+}
+
+// AdHocCommand is an ad hoc command run. AWX numbers it from the same
+// sequence as jobs, so the job tools accept its ID.
+type AdHocCommand struct {
+	ID            int        `json:"id"`
+	Name          string     `json:"name"`
+	Status        string     `json:"status"`
+	Inventory     int        `json:"inventory"`
+	Limit         string     `json:"limit"`
+	ModuleName    string     `json:"module_name"`
+	ModuleArgs    string     `json:"module_args"`
+	BecomeEnabled bool       `json:"become_enabled"`
+	Forks         int        `json:"forks"`
+	Started       *time.Time `json:"started"`
+	Finished      *time.Time `json:"finished"`
+	Elapsed       float64    `json:"elapsed"`
+}
+
+// RunAdHocCommand starts an ad hoc command
+func (c *Client) RunAdHocCommand(ctx context.Context, request AdHocCommandRequest) (*AdHocCommand, error) {
+	var command AdHocCommand
+	if err := c.makeRequest(ctx, "POST", "/api/v2/ad_hoc_commands/", request, &command); err != nil {
+		return nil, err
+	}
+	// The job tools look the command up by ID; spare them the unified job lookup
+	c.cache.Set(jobPathCacheKey(command.ID), adHocCommandPath(command.ID), jobPathTTL)
+	return &command, nil
+}
+
+func jobPathCacheKey(jobID int) string {
+	return fmt.Sprintf("awx:job-path:%d", jobID)
+}
+
+func adHocCommandPath(commandID int) string {
+	return fmt.Sprintf("/api/v2/ad_hoc_commands/%d/", commandID)
+}
+
+// jobPath returns the API path of a job or ad hoc command, such as
+// /api/v2/jobs/12/ or /api/v2/ad_hoc_commands/13/. IDs AWX does not know map
+// to the jobs path, so callers get the usual not found error.
+func (c *Client) jobPath(ctx context.Context, jobID int) (string, error) {
+	cacheKey := jobPathCacheKey(jobID)
+	if cached, ok := c.cache.Get(cacheKey); ok {
+		if path, ok := cached.(string); ok {
+			return path, nil
+		}
+	}
+
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(jobID))
+	var response page[struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	}]
+	if err := c.makeRequest(ctx, "GET", "/api/v2/unified_jobs/?"+query.Encode(), nil, &response); err != nil {
+		return "", fmt.Errorf("failed to look up job %d: %w", jobID, err)
+	}
+
+	path := fmt.Sprintf("/api/v2/jobs/%d/", jobID)
+	if len(response.Results) > 0 && response.Results[0].Type == "ad_hoc_command" {
+		path = adHocCommandPath(jobID)
+	}
+	c.cache.Set(cacheKey, path, jobPathTTL)
+	return path, nil
+}
+
+// isAdHocPath reports whether a job path returned by jobPath is an ad hoc command
+func isAdHocPath(path string) bool {
+	return strings.HasPrefix(path, "/api/v2/ad_hoc_commands/")
+}
+
+// jobEventsEndpoint returns the events endpoint under a job path
+func jobEventsEndpoint(path string) string {
+	if isAdHocPath(path) {
+		return path + "events/"
+	}
+	return path + "job_events/"
+}
+
+// adHocHostSummaries builds the per-host recap of an ad hoc command from its
+// events, since AWX only records host summaries for playbook jobs
+func (c *Client) adHocHostSummaries(ctx context.Context, path string) ([]JobHostSummary, error) {
+	query := url.Values{}
+	query.Set("order_by", "counter")
+	query.Set("event__startswith", "runner_on_")
+	events, err := listAll[JobEvent](ctx, c, jobEventsEndpoint(path), ListOptions{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []JobHostSummary
+	index := make(map[string]int)
+	for _, event := range events.Results {
+		if event.HostName == "" {
+			continue
+		}
+		i, seen := index[event.HostName]
+		if !seen {
+			i = len(summaries)
+			index[event.HostName] = i
+			summaries = append(summaries, JobHostSummary{Host: event.Host, HostName: event.HostName})
+		}
+		summary := &summaries[i]
+		summary.Processed = 1
+
+		switch event.Event {
+		case "runner_on_ok":
+			summary.OK++
+			if event.Changed {
+				summary.Changed++
+			}
+		case "runner_on_failed":
+			if ignored, _ := event.EventData["ignore_errors"].(bool); ignored {
+				summary.Ignored++
+				continue
+			}
+			summary.Failures++
+			summary.Failed = true
+		case "runner_on_unreachable":
+			summary.Dark++
+			summary.Failed = true
+		case "runner_on_skipped":
+			summary.Skipped++
+		}
+	}
+	return summaries, nil
+}
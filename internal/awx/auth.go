@@ -0,0 +1,300 @@
+package awx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	tokenDescription = "MCP Autosphere Token"
+
+	// tokenRefreshMargin renews a created token this long before AWX expires it
+	tokenRefreshMargin = time.Minute
+)
+
+// authState caches the OAuth token the client created with its username and
+// password. A token from the configuration is used as is and never revoked.
+type authState struct {
+	token     string
+	tokenID   int // ID of the created token, needed to revoke it
+	expires   time.Time
+	basicAuth bool // Token creation is unavailable, use basic auth
+	rejected  bool // AWX rejected the configured token, create one instead
+}
+
+// credentials authorizes a request: a bearer token when one is available or can
+// be created, basic auth otherwise. The returned token is "" for basic auth.
+func (c *Client) credentials(ctx context.Context) (string, error) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.token != "" && !c.auth.rejected {
+		return c.token, nil
+	}
+	if c.username == "" || c.password == "" {
+		return "", nil // Anonymous; AWX answers with 401 where auth is required
+	}
+	if c.auth.basicAuth {
+		return "", nil
+	}
+	if c.auth.token != "" && (c.auth.expires.IsZero() || time.Until(c.auth.expires) > tokenRefreshMargin) {
+		return c.auth.token, nil
+	}
+
+	if c.auth.token != "" {
+		log.Printf("AWX token %d expires at %s, renewing", c.auth.tokenID, c.auth.expires.Format(time.RFC3339))
+		c.revokeToken(ctx, c.auth.tokenID)
+		c.auth = authState{rejected: c.auth.rejected}
+	}
+
+	created, err := c.createToken(ctx)
+	if err != nil {
+		var status *tokenStatusError
+		if errors.As(err, &status) {
+			switch status.StatusCode {
+			case http.StatusUnauthorized:
+				return "", fmt.Errorf("AWX authentication failed for user %s: %w", c.username, err)
+			case http.StatusForbidden, http.StatusNotFound, http.StatusMethodNotAllowed:
+				// External users may not create tokens; basic auth still works for them
+				log.Printf("AWX token creation unavailable (%v), using basic auth", err)
+				c.auth.basicAuth = true
+				return "", nil
+			}
+		}
+		log.Printf("AWX token creation failed (%v), using basic auth for this request", err)
+		return "", nil
+	}
+
+	c.auth.token, c.auth.tokenID, c.auth.expires = created.Token, created.ID, created.Expires
+	log.Printf("Created AWX token %d for user %s", created.ID, c.username)
+	return c.auth.token, nil
+}
+
+// rejectToken drops a token AWX answered 401 to and reports whether a new
+// attempt can use different credentials
+func (c *Client) rejectToken(token string) bool {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	canCreate := c.username != "" && c.password != "" && !c.auth.basicAuth
+	switch {
+	case token == "":
+		return false // Basic auth or anonymous; retrying sends the same credentials
+	case token == c.auth.token:
+		log.Printf("AWX rejected token %d, creating a new one", c.auth.tokenID)
+		c.auth.token, c.auth.tokenID, c.auth.expires = "", 0, time.Time{} // Revoked or expired server side
+		return canCreate
+	case token == c.token && !c.auth.rejected:
+		if !canCreate {
+			return false
+		}
+		log.Printf("AWX rejected the configured token, creating one from username and password")
+		c.auth.rejected = true
+		return true
+	default:
+		return true // Another request already replaced the token
+	}
+}
+
+// do sends an authenticated request to an AWX endpoint. When AWX answers 401
+// the credentials are renewed and the request is sent once more.
+func (c *Client) do(ctx context.Context, method, endpoint string, body []byte) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		token, err := c.credentials(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+endpoint, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else if c.username != "" && c.password != "" {
+			req.SetBasicAuth(c.username, c.password)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make request: %w", err)
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 1 || !c.rejectToken(token) {
+			return resp, nil
+		}
+		resp.Body.Close()
+	}
+}
+
+type createdToken struct {
+	ID      int       `json:"id"`
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
+type tokenStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *tokenStatusError) Error() string {
+	return fmt.Sprintf("token creation failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// createToken creates a personal access token with basic auth
+func (c *Client) createToken(ctx context.Context) (*createdToken, error) {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"description": tokenDescription,
+		"scope":       "write",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token data: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/v2/tokens/", bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return nil, &tokenStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var created createdToken
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if created.Token == "" {
+		return nil, fmt.Errorf("token not found in response")
+	}
+	return &created, nil
+}
+
+// revokeToken deletes a token this client created. Failures are logged only:
+// the token is no longer used either way.
+func (c *Client) revokeToken(ctx context.Context, tokenID int) {
+	if tokenID == 0 {
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/v2/tokens/%d/", c.baseURL, tokenID), nil)
+	if err != nil {
+		log.Printf("Failed to revoke AWX token %d: %v", tokenID, err)
+		return
+	}
+	req.SetBasicAuth(c.username, c.password)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		log.Printf("Failed to revoke AWX token %d: %v", tokenID, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotFound:
+		log.Printf("Revoked AWX token %d", tokenID)
+	default:
+		log.Printf("Failed to revoke AWX token %d: status %d", tokenID, resp.StatusCode)
+	}
+}
+
+// Close revokes the token the client created, if any. A configured token is left alone.
+func (c *Client) Close(ctx context.Context) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	c.revokeToken(ctx, c.auth.tokenID)
+	c.auth.token, c.auth.tokenID, c.auth.expires = "", 0, time.Time{}
+}
+
+// redactBody masks the secrets of a JSON request or response body, such as
+// credential_passwords, tokens and password variables, so it can be logged
+func redactBody(body []byte) string {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return string(body)
+	}
+	if !redactSecrets(fields) {
+		return string(body)
+	}
+	redacted, err := json.Marshal(fields)
+	if err != nil {
+		return "<redacted>"
+	}
+	return string(redacted)
+}
+
+// secretField reports whether a field holds a secret by its name
+func secretField(key string) bool {
+	switch key {
+	case "token", "refresh_token", "ssh_key_data", "ssh_key_unlock":
+		return true
+	}
+	return strings.Contains(key, "password")
+}
+
+// redactSecrets masks the secrets of fields and of the objects nested in them,
+// including variables that AWX returns as JSON or YAML text, and reports
+// whether it masked anything
+func redactSecrets(fields map[string]interface{}) bool {
+	redacted := false
+	for key, value := range fields {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if key == "credential_passwords" {
+				for name := range v {
+					v[name] = encryptedValue
+					redacted = true
+				}
+			} else if redactSecrets(v) {
+				redacted = true
+			}
+		case []interface{}:
+			for _, item := range v {
+				if nested, ok := item.(map[string]interface{}); ok && redactSecrets(nested) {
+					redacted = true
+				}
+			}
+		case string:
+			if secretField(key) {
+				fields[key] = encryptedValue
+				redacted = true
+			} else if key == "variables" || key == "extra_vars" {
+				if vars, err := ParseExtraVars(v); err == nil && redactSecrets(vars) {
+					encoded, _ := json.Marshal(vars)
+					fields[key] = string(encoded)
+					redacted = true
+				}
+			}
+		default:
+			if secretField(key) {
+				fields[key] = encryptedValue
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
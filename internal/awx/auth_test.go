@@ -0,0 +1,56 @@
+package awx
+
+import "testing"
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "not JSON", body: "Bad Gateway", want: "Bad Gateway"},
+		{name: "nothing secret", body: `{"id": 7, "name": "deploy"}`, want: `{"id": 7, "name": "deploy"}`},
+		{
+			name: "credential passwords",
+			body: `{"credential_passwords": {"ssh_password": "s3cret", "vault_password": "v4ult"}, "limit": "web*"}`,
+			want: `{"credential_passwords":{"ssh_password":"$encrypted$","vault_password":"$encrypted$"},"limit":"web*"}`,
+		},
+		{
+			name: "tokens and keys",
+			body: `{"token": "abc", "refresh_token": "def", "ssh_key_data": "-----BEGIN", "ssh_key_unlock": "x", "expires": "2026-01-01"}`,
+			want: `{"expires":"2026-01-01","refresh_token":"$encrypted$","ssh_key_data":"$encrypted$","ssh_key_unlock":"$encrypted$","token":"$encrypted$"}`,
+		},
+		{
+			name: "nested objects and lists",
+			body: `{"count": 1, "results": [{"id": 3, "inputs": {"username": "admin", "password": "p4ss"}}]}`,
+			want: `{"count":1,"results":[{"id":3,"inputs":{"password":"$encrypted$","username":"admin"}}]}`,
+		},
+		{
+			name: "variables given as a map",
+			body: `{"ansible_host": "10.0.0.1", "ansible_become_password": 1234}`,
+			want: `{"ansible_become_password":"$encrypted$","ansible_host":"10.0.0.1"}`,
+		},
+		{
+			name: "variables given as YAML text",
+			body: `{"id": 11, "variables": "ansible_host: 10.0.0.1\nansible_password: p4ss\n"}`,
+			want: `{"id":11,"variables":"{\"ansible_host\":\"10.0.0.1\",\"ansible_password\":\"$encrypted$\"}"}`,
+		},
+		{
+			name: "extra_vars given as JSON text",
+			body: `{"id": 40, "extra_vars": "{\"db\": {\"admin_password\": \"p4ss\"}, \"release\": \"1.2\"}"}`,
+			want: `{"extra_vars":"{\"db\":{\"admin_password\":\"$encrypted$\"},\"release\":\"1.2\"}","id":40}`,
+		},
+		{
+			name: "variables without secrets left as written",
+			body: `{"id": 11, "variables": "ansible_host: 10.0.0.1"}`,
+			want: `{"id": 11, "variables": "ansible_host: 10.0.0.1"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactBody([]byte(tt.body)); got != tt.want {
+				t.Errorf("redactBody = %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,15 +1,16 @@
 package awx
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/NacerKH/autosphere-mcp-golang/internal/cache"
@@ -19,10 +20,20 @@ type Client struct {
 	baseURL    string
 	username   string
 	password   string
-	token      string
+	token      string // Configured token; see auth for the one created from username and password
 	httpClient *http.Client
 	debug      bool
 	cache      *cache.Cache
+
+	pageSize     int
+	maxListItems int
+
+	maxRetries     int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+
+	authMu sync.Mutex
+	auth   authState
 }
 
 type ClientConfig struct {
@@ -32,12 +43,36 @@ type ClientConfig struct {
 	Token    string
 	Timeout  time.Duration
 	Debug    bool
+
+	PageSize     int // Items per page for list endpoints (default 200)
+	MaxListItems int // Safety cap on items collected by a list call (default 5000)
+
+	MaxRetries     int           // Retries of GET requests after transient failures (default 3, negative disables)
+	RetryBaseDelay time.Duration // First backoff ceiling, doubled per retry (default 500ms)
+	RetryMaxDelay  time.Duration // Longest wait between retries, Retry-After included (default 30s)
 }
 
 func NewClient(config ClientConfig) *Client {
 	if config.Timeout == 0 {
 		config.Timeout = 30 * time.Second
 	}
+	if config.PageSize <= 0 {
+		config.PageSize = defaultPageSize
+	}
+	if config.MaxListItems <= 0 {
+		config.MaxListItems = defaultMaxListItems
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = defaultMaxRetries
+	} else if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.RetryBaseDelay <= 0 {
+		config.RetryBaseDelay = defaultRetryBaseDelay
+	}
+	if config.RetryMaxDelay <= 0 {
+		config.RetryMaxDelay = defaultRetryMaxDelay
+	}
 
 	// Configure HTTP transport with connection pooling for better performance
 	transport := &http.Transport{
@@ -60,182 +95,36 @@ func NewClient(config ClientConfig) *Client {
 			Timeout:   config.Timeout,
 			Transport: transport,
 		},
-	}
-}
-
-func (c *Client) authenticate(ctx context.Context) error {
-	if c.token != "" {
-		log.Printf("Using provided AWX token")
-		return nil
-	}
-
-	if c.username == "" || c.password == "" {
-		return fmt.Errorf("either token or username/password must be provided")
-	}
+		pageSize:     config.PageSize,
+		maxListItems: config.MaxListItems,
 
-	log.Printf("Testing Basic Auth with AWX...")
-	if err := c.testBasicAuth(ctx); err == nil {
-		log.Printf("Basic Auth successful - using direct Basic Auth")
-		return nil
+		maxRetries:     config.MaxRetries,
+		retryBaseDelay: config.RetryBaseDelay,
+		retryMaxDelay:  config.RetryMaxDelay,
 	}
-
-	log.Printf("Trying token creation via /api/v2/tokens/...")
-	if err := c.createTokenViaTokensEndpoint(ctx); err == nil {
-		return nil
-	}
-
-	log.Printf("Trying legacy /api/v2/authtoken/...")
-	return c.createTokenViaLegacyEndpoint(ctx)
-}
-
-func (c *Client) testBasicAuth(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/v2/job_templates/", nil)
-	if err != nil {
-		return err
-	}
-
-	req.SetBasicAuth(c.username, c.password)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusOK {
-		return nil
-	}
-	return fmt.Errorf("basic auth test failed with status %d", resp.StatusCode)
-}
-
-func (c *Client) createTokenViaTokensEndpoint(ctx context.Context) error {
-	tokenData := map[string]interface{}{
-		"description": "MCP Autosphere Token",
-		"scope":       "write",
-	}
-
-	jsonData, err := json.Marshal(tokenData)
-	if err != nil {
-		return fmt.Errorf("failed to marshal token data: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/v2/tokens/", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create token request: %w", err)
-	}
-
-	req.SetBasicAuth(c.username, c.password)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to create token: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("token creation failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var tokenResp map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return fmt.Errorf("failed to decode token response: %w", err)
-	}
-
-	if token, ok := tokenResp["token"].(string); ok {
-		c.token = token
-		log.Printf("Successfully created AWX token")
-		return nil
-	}
-
-	return fmt.Errorf("token not found in response")
-}
-
-func (c *Client) createTokenViaLegacyEndpoint(ctx context.Context) error {
-	authData := map[string]string{
-		"username": c.username,
-		"password": c.password,
-	}
-
-	jsonData, err := json.Marshal(authData)
-	if err != nil {
-		return fmt.Errorf("failed to marshal auth data: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/v2/authtoken/", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create auth request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to authenticate with AWX: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var authResp AuthResponse
-	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
-		return fmt.Errorf("failed to decode auth response: %w", err)
-	}
-
-	c.token = authResp.Token
-	log.Printf("Successfully authenticated with AWX (legacy)")
-	return nil
 }
 
 func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
-	// Try to authenticate first if we don't have a token
-	if c.token == "" && c.username != "" {
-		// Just test basic auth, don't fail if it doesn't work
-		c.testBasicAuth(ctx)
-	}
-
-	var reqBody io.Reader
-	var bodyStr string
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
-		bodyStr = string(jsonData)
-	}
-
-	url := c.baseURL + endpoint
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set authentication - prefer token, fallback to basic auth
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
-	} else if c.username != "" && c.password != "" {
-		req.SetBasicAuth(c.username, c.password)
-	}
-	
-	req.Header.Set("Content-Type", "application/json")
-
 	// Only log detailed request info in debug mode to reduce I/O overhead
 	if c.debug {
-		log.Printf("AWX API Request: %s %s", method, url)
-		if bodyStr != "" {
-			log.Printf("Request Body: %s", bodyStr)
+		log.Printf("AWX API Request: %s %s", method, c.baseURL+endpoint)
+		if jsonData != nil {
+			log.Printf("Request Body: %s", redactBody(jsonData))
 		}
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(ctx, method, endpoint, jsonData)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
@@ -245,16 +134,7 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 		if err != nil {
 			return fmt.Errorf("failed to read response body: %w", err)
 		}
-		log.Printf("AWX API Response: %d - %s", resp.StatusCode, string(respBody))
-
-		// Handle errors
-		if resp.StatusCode >= 400 {
-			var errResp ErrorResponse
-			if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Detail != "" {
-				return fmt.Errorf("AWX API error: %s", errResp.Detail)
-			}
-			return fmt.Errorf("AWX API error: status %d - %s", resp.StatusCode, string(respBody))
-		}
+		log.Printf("AWX API Response: %d - %s", resp.StatusCode, redactBody(respBody))
 
 		// Decode result from memory
 		if result != nil {
@@ -266,16 +146,6 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 		// Production mode: stream decode directly without reading full body into memory
 		log.Printf("AWX API: %s %s -> %d", method, endpoint, resp.StatusCode)
 
-		if resp.StatusCode >= 400 {
-			// Only read body for errors
-			respBody, _ := io.ReadAll(resp.Body)
-			var errResp ErrorResponse
-			if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Detail != "" {
-				return fmt.Errorf("AWX API error: %s", errResp.Detail)
-			}
-			return fmt.Errorf("AWX API error: status %d - %s", resp.StatusCode, string(respBody))
-		}
-
 		// Success: stream decode directly (no intermediate buffer)
 		if result != nil {
 			if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
@@ -288,16 +158,26 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 }
 
 func (c *Client) GetJobTemplates(ctx context.Context) ([]JobTemplate, error) {
+	list, err := c.GetJobTemplateList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return list.Results, nil
+}
+
+// GetJobTemplateList returns every job template (following pagination) along
+// with the total count reported by AWX
+func (c *Client) GetJobTemplateList(ctx context.Context) (*ListResult[JobTemplate], error) {
 	// Cache key for job templates
-	cacheKey := "awx:job_templates"
+	cacheKey := jobTemplatesCacheKey
 
 	// Try cache first
 	if cached, ok := c.cache.Get(cacheKey); ok {
-		if templates, ok := cached.([]JobTemplate); ok {
+		if list, ok := cached.(*ListResult[JobTemplate]); ok {
 			if c.debug {
-				log.Printf("Cache HIT: job templates (%d items)", len(templates))
+				log.Printf("Cache HIT: job templates (%d items)", len(list.Results))
 			}
-			return templates, nil
+			return list.clone(), nil
 		}
 	}
 
@@ -305,17 +185,16 @@ func (c *Client) GetJobTemplates(ctx context.Context) ([]JobTemplate, error) {
 		log.Printf("Cache MISS: job templates - fetching from AWX")
 	}
 
-	// Cache miss - fetch from AWX
-	var response JobTemplateList
-	err := c.makeRequest(ctx, "GET", "/api/v2/job_templates/", nil, &response)
+	// Cache miss - fetch every page from AWX
+	list, err := listAll[JobTemplate](ctx, c, "/api/v2/job_templates/", ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
 	// Cache for 5 minutes
-	c.cache.Set(cacheKey, response.Results, 5*time.Minute)
+	c.cache.Set(cacheKey, list, 5*time.Minute)
 
-	return response.Results, nil
+	return list.clone(), nil
 }
 
 func (c *Client) GetJobTemplateByName(ctx context.Context, nameOrID string) (*JobTemplate, error) {
@@ -349,103 +228,78 @@ func (c *Client) GetJobTemplateByName(ctx context.Context, nameOrID string) (*Jo
 	return nil, fmt.Errorf("job template '%s' not found. Available templates: %s", nameOrID, strings.Join(availableTemplates, ", "))
 }
 
-func (c *Client) LaunchJob(ctx context.Context, templateID int, request LaunchJobRequest) (*JobLaunchResponse, error) {
-	var response JobLaunchResponse
-	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/launch/", templateID)
-	
-	// Create a new context without timeout for this specific call
-	ctxNoTimeout := context.Background()
-	
-	// Send minimal request - AWX works fine with empty JSON
-	minimalRequest := map[string]interface{}{}
-	
-	// Only add fields if they have values
-	if len(request.ExtraVars) > 0 {
-		minimalRequest["extra_vars"] = request.ExtraVars
-	}
-	if request.Limit != "" {
-		minimalRequest["limit"] = request.Limit
-	}
-	if request.Tags != "" {
-		minimalRequest["job_tags"] = request.Tags
-	}
-	if request.SkipTags != "" {
-		minimalRequest["skip_tags"] = request.SkipTags
-	}
-	
-	err := c.makeRequest(ctxNoTimeout, "POST", endpoint, minimalRequest, &response)
+// LaunchJob launches a job template through the JobLauncher pipeline
+func (c *Client) LaunchJob(ctx context.Context, options LaunchJobOptions) (*LaunchResult, error) {
+	return NewJobLauncher(c).Launch(ctx, options)
+}
+
+func (c *Client) GetJobs(ctx context.Context, limit int, status string) ([]Job, error) {
+	list, err := c.GetJobList(ctx, limit, status)
 	if err != nil {
 		return nil, err
 	}
-	return &response, nil
+	return list.Results, nil
 }
 
-func (c *Client) GetJobs(ctx context.Context, limit int, status string) ([]Job, error) {
-	var response struct {
-		Count   int   `json:"count"`
-		Results []Job `json:"results"`
-	}
-	
-	endpoint := "/api/v2/jobs/"
-	
-	// Add query parameters
-	params := make([]string, 0)
-	if limit > 0 {
-		params = append(params, fmt.Sprintf("page_size=%d", limit))
-	}
+// GetJobList returns the most recent jobs (newest first), collecting at most
+// limit items across pages, together with the total count of matching jobs
+func (c *Client) GetJobList(ctx context.Context, limit int, status string) (*ListResult[Job], error) {
+	query := url.Values{}
+	query.Set("order_by", "-id")
 	if status != "" {
-		params = append(params, fmt.Sprintf("status=%s", status))
-	}
-	
-	if len(params) > 0 {
-		endpoint += "?" + strings.Join(params, "&")
+		query.Set("status", status)
 	}
-	
-	err := c.makeRequest(ctx, "GET", endpoint, nil, &response)
-	if err != nil {
+
+	return listAll[Job](ctx, c, "/api/v2/jobs/", ListOptions{
+		MaxItems: limit,
+		Query:    query,
+	})
+}
+
+// GetJobHistory returns the jobs that finished since a time, newest first,
+// optionally of one job template (templateID 0 means all). At most maxItems
+// are collected, so a cap drops the oldest.
+func (c *Client) GetJobHistory(ctx context.Context, since time.Time, templateID, maxItems int) (*ListResult[Job], error) {
+	query := url.Values{}
+	query.Set("finished__gte", since.UTC().Format(time.RFC3339))
+	query.Set("order_by", "-finished")
+	if templateID != 0 {
+		query.Set("job_template", strconv.Itoa(templateID))
+	}
+
+	return listAll[Job](ctx, c, "/api/v2/jobs/", ListOptions{
+		MaxItems: maxItems,
+		Query:    query,
+	})
+}
+
+// GetRecentTemplateJobs returns the latest jobs of a job template, newest
+// first. Only the first page is read, so limit is capped by the AWX page size.
+func (c *Client) GetRecentTemplateJobs(ctx context.Context, templateID, limit int) ([]Job, error) {
+	var response page[Job]
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/jobs/?order_by=-id&page_size=%d", templateID, limit)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &response); err != nil {
 		return nil, err
 	}
 	return response.Results, nil
 }
 
 func (c *Client) GetJobOutput(ctx context.Context, jobID int) (string, error) {
-	endpoint := fmt.Sprintf("/api/v2/jobs/%d/stdout/", jobID)
-	
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+endpoint, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-	
-	// Set authentication
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
-	} else if c.username != "" && c.password != "" {
-		req.SetBasicAuth(c.username, c.password)
-	}
-	
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("AWX API error: status %d - %s", resp.StatusCode, string(body))
-	}
-	
-	body, err := io.ReadAll(resp.Body)
+	stdout, err := c.GetJobStdout(ctx, jobID, StdoutOptions{Format: "txt"})
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", err
 	}
-	
-	return string(body), nil
+	return stdout.Content, nil
 }
 
 func (c *Client) CancelJob(ctx context.Context, jobID int) error {
-	endpoint := fmt.Sprintf("/api/v2/jobs/%d/cancel/", jobID)
+	path, err := c.jobPath(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	endpoint := path + "cancel/"
 	
-	err := c.makeRequest(ctx, "POST", endpoint, map[string]interface{}{}, nil)
+	err = c.makeRequest(ctx, "POST", endpoint, map[string]interface{}{}, nil)
 	if err != nil {
 		return fmt.Errorf("failed to cancel job: %w", err)
 	}
@@ -454,16 +308,26 @@ func (c *Client) CancelJob(ctx context.Context, jobID int) error {
 }
 
 func (c *Client) GetInventories(ctx context.Context) ([]Inventory, error) {
+	list, err := c.GetInventoryList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return list.Results, nil
+}
+
+// GetInventoryList returns every inventory (following pagination) along with
+// the total count reported by AWX
+func (c *Client) GetInventoryList(ctx context.Context) (*ListResult[Inventory], error) {
 	// Cache key for inventories
-	cacheKey := "awx:inventories"
+	cacheKey := inventoriesCacheKey
 
 	// Try cache first
 	if cached, ok := c.cache.Get(cacheKey); ok {
-		if inventories, ok := cached.([]Inventory); ok {
+		if list, ok := cached.(*ListResult[Inventory]); ok {
 			if c.debug {
-				log.Printf("Cache HIT: inventories (%d items)", len(inventories))
+				log.Printf("Cache HIT: inventories (%d items)", len(list.Results))
 			}
-			return inventories, nil
+			return list.clone(), nil
 		}
 	}
 
@@ -471,34 +335,39 @@ func (c *Client) GetInventories(ctx context.Context) ([]Inventory, error) {
 		log.Printf("Cache MISS: inventories - fetching from AWX")
 	}
 
-	// Cache miss - fetch from AWX
-	var response struct {
-		Count   int         `json:"count"`
-		Results []Inventory `json:"results"`
-	}
-
-	err := c.makeRequest(ctx, "GET", "/api/v2/inventories/", nil, &response)
+	// Cache miss - fetch every page from AWX
+	list, err := listAll[Inventory](ctx, c, "/api/v2/inventories/", ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
 	// Cache for 5 minutes
-	c.cache.Set(cacheKey, response.Results, 5*time.Minute)
+	c.cache.Set(cacheKey, list, 5*time.Minute)
 
-	return response.Results, nil
+	return list.clone(), nil
 }
 
 func (c *Client) GetProjects(ctx context.Context) ([]Project, error) {
+	list, err := c.GetProjectList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return list.Results, nil
+}
+
+// GetProjectList returns every project (following pagination) along with the
+// total count reported by AWX
+func (c *Client) GetProjectList(ctx context.Context) (*ListResult[Project], error) {
 	// Cache key for projects
-	cacheKey := "awx:projects"
+	cacheKey := projectsCacheKey
 
 	// Try cache first
 	if cached, ok := c.cache.Get(cacheKey); ok {
-		if projects, ok := cached.([]Project); ok {
+		if list, ok := cached.(*ListResult[Project]); ok {
 			if c.debug {
-				log.Printf("Cache HIT: projects (%d items)", len(projects))
+				log.Printf("Cache HIT: projects (%d items)", len(list.Results))
 			}
-			return projects, nil
+			return list.clone(), nil
 		}
 	}
 
@@ -506,29 +375,16 @@ func (c *Client) GetProjects(ctx context.Context) ([]Project, error) {
 		log.Printf("Cache MISS: projects - fetching from AWX")
 	}
 
-	// Cache miss - fetch from AWX
-	var response struct {
-		Count   int       `json:"count"`
-		Results []Project `json:"results"`
-	}
-
-	err := c.makeRequest(ctx, "GET", "/api/v2/projects/", nil, &response)
+	// Cache miss - fetch every page from AWX
+	list, err := listAll[Project](ctx, c, "/api/v2/projects/", ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
 	// Cache for 10 minutes (projects change less frequently)
-	c.cache.Set(cacheKey, response.Results, 10*time.Minute)
-
-	return response.Results, nil
-}
+	c.cache.Set(cacheKey, list, 10*time.Minute)
 
-func (c *Client) LaunchJobByName(ctx context.Context, templateName string, request LaunchJobRequest) (*JobLaunchResponse, error) {
-	template, err := c.GetJobTemplateByName(ctx, templateName)
-	if err != nil {
-		return nil, err
-	}
-	return c.LaunchJob(ctx, template.ID, request)
+	return list.clone(), nil
 }
 
 func (c *Client) GetJob(ctx context.Context, jobID int) (*Job, error) {
@@ -539,11 +395,12 @@ func (c *Client) GetJob(ctx context.Context, jobID int) (*Job, error) {
 	if cached, ok := c.cache.Get(cacheKey); ok {
 		if job, ok := cached.(*Job); ok {
 			// Don't cache completed/failed jobs for too long
-			if job.Status == "successful" || job.Status == "failed" || job.Status == "canceled" {
+			if job.IsFinished() {
 				if c.debug {
 					log.Printf("Cache HIT: job %d (status: %s)", jobID, job.Status)
 				}
-				return job, nil
+				copied := *job
+				return &copied, nil
 			}
 		}
 	}
@@ -552,16 +409,23 @@ func (c *Client) GetJob(ctx context.Context, jobID int) (*Job, error) {
 		log.Printf("Cache MISS: job %d - fetching from AWX", jobID)
 	}
 
-	// Fetch from AWX
+	// Fetch from AWX; ad hoc commands share the job ID sequence
+	endpoint, err := c.jobPath(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
 	var job Job
-	endpoint := fmt.Sprintf("/api/v2/jobs/%d/", jobID)
-	err := c.makeRequest(ctx, "GET", endpoint, nil, &job)
+	err = c.makeRequest(ctx, "GET", endpoint, nil, &job)
 	if err != nil {
 		return nil, err
 	}
 
 	// Set the full URL for the job
-	job.URL = c.baseURL + "/#/jobs/playbook/" + strconv.Itoa(jobID)
+	if isAdHocPath(endpoint) {
+		job.URL = c.baseURL + "/#/jobs/command/" + strconv.Itoa(jobID)
+	} else {
+		job.URL = c.baseURL + "/#/jobs/playbook/" + strconv.Itoa(jobID)
+	}
 
 	// Cache with different TTL based on status
 	var cacheTTL time.Duration
@@ -571,7 +435,8 @@ func (c *Client) GetJob(ctx context.Context, jobID int) (*Job, error) {
 		cacheTTL = 5 * time.Minute // Longer TTL for completed jobs
 	}
 
-	c.cache.Set(cacheKey, &job, cacheTTL)
+	cached := job
+	c.cache.Set(cacheKey, &cached, cacheTTL)
 
 	return &job, nil
 }
@@ -599,7 +464,7 @@ func (c *Client) CreateJobTemplate(ctx context.Context, request CreateJobTemplat
 	}
 
 	// Invalidate job templates cache since we created a new one
-	c.cache.Delete("awx:job_templates")
+	c.cache.Delete(jobTemplatesCacheKey)
 
 	log.Printf("Successfully created job template: %s (ID: %d)", template.Name, template.ID)
 	return &template, nil
@@ -0,0 +1,207 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError is returned when AWX answers with an error status
+type APIError struct {
+	StatusCode  int
+	Method      string
+	Endpoint    string
+	Detail      string              // The "detail" message, if AWX sent one
+	FieldErrors map[string][]string // Validation errors by field; "__all__" holds non-field errors
+	RequestID   string              // X-API-Request-Id, for matching AWX server logs
+	RetryAfter  time.Duration       // From the Retry-After header of 429 and 503 responses
+	Body        string              // Raw body when it is neither a detail nor field errors
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "AWX API error: status %d", e.StatusCode)
+
+	switch {
+	case e.Detail != "":
+		b.WriteString(" - " + e.Detail)
+	case len(e.FieldErrors) > 0:
+		b.WriteString(" - " + e.fieldSummary())
+	case e.Body != "":
+		b.WriteString(" - " + e.Body)
+	}
+	if e.Detail != "" && len(e.FieldErrors) > 0 {
+		b.WriteString(" (" + e.fieldSummary() + ")")
+	}
+	if e.RequestID != "" {
+		b.WriteString(" [request " + e.RequestID + "]")
+	}
+	return b.String()
+}
+
+func (e *APIError) fieldSummary() string {
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages := strings.Join(e.FieldErrors[field], " ")
+		if field == "__all__" {
+			parts = append(parts, messages)
+		} else {
+			parts = append(parts, field+": "+messages)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// newAPIError builds an APIError from an error response and its body
+func newAPIError(resp *http.Response, method, endpoint string, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+		RequestID:  resp.Header.Get("X-API-Request-Id"),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.Header.Get("X-Request-Id")
+	}
+
+	// AWX answers {"detail": "..."} or {"field": ["message", ...], ...}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) == nil {
+		for field, raw := range fields {
+			var message string
+			var messages []string
+			switch {
+			case field == "detail" && json.Unmarshal(raw, &message) == nil:
+				apiErr.Detail = message
+			case json.Unmarshal(raw, &messages) == nil:
+				apiErr.addFieldError(field, messages...)
+			case json.Unmarshal(raw, &message) == nil:
+				apiErr.addFieldError(field, message)
+			default:
+				apiErr.addFieldError(field, string(raw))
+			}
+		}
+	}
+	if apiErr.Detail == "" && len(apiErr.FieldErrors) == 0 {
+		apiErr.Body = truncateBody(strings.TrimSpace(string(body)), 500)
+	}
+	return apiErr
+}
+
+func (e *APIError) addFieldError(field string, messages ...string) {
+	if e.FieldErrors == nil {
+		e.FieldErrors = make(map[string][]string)
+	}
+	e.FieldErrors[field] = append(e.FieldErrors[field], messages...)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+func truncateBody(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
+
+// ErrorKind groups AWX failures by what the caller can do about them
+type ErrorKind string
+
+const (
+	ErrorAuth       ErrorKind = "auth"       // Credentials missing, wrong or expired
+	ErrorPermission ErrorKind = "permission" // Authenticated but not allowed
+	ErrorValidation ErrorKind = "validation" // AWX rejected the request content
+	ErrorNotFound   ErrorKind = "not_found"
+	ErrorTransient  ErrorKind = "transient" // Worth retrying later
+	ErrorOther      ErrorKind = "other"
+)
+
+// Classify reports the kind of an error returned by the client
+func Classify(err error) ErrorKind {
+	if err == nil {
+		return ""
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusUnauthorized:
+			return ErrorAuth
+		case apiErr.StatusCode == http.StatusForbidden:
+			return ErrorPermission
+		case apiErr.StatusCode == http.StatusNotFound:
+			return ErrorNotFound
+		case apiErr.StatusCode == http.StatusRequestTimeout, apiErr.StatusCode == http.StatusTooManyRequests,
+			apiErr.StatusCode >= 500:
+			return ErrorTransient
+		case apiErr.StatusCode >= 400:
+			return ErrorValidation
+		}
+		return ErrorOther
+	}
+
+	var launchErr *LaunchValidationError
+	if errors.As(err, &launchErr) {
+		return ErrorValidation
+	}
+	var resolveErr *ResolveError
+	if errors.As(err, &resolveErr) {
+		if resolveErr.Matches > 1 {
+			return ErrorValidation
+		}
+		return ErrorNotFound
+	}
+	var status *tokenStatusError
+	if errors.As(err, &status) && status.StatusCode == http.StatusUnauthorized {
+		return ErrorAuth
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrorOther // The caller gave up; retrying is its decision
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorTransient
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ErrorTransient
+	}
+	return ErrorOther
+}
+
+// IsNotFound reports whether err is an AWX 404 error
+func IsNotFound(err error) bool {
+	return Classify(err) == ErrorNotFound
+}
+
+// IsTransient reports whether err is worth retrying
+func IsTransient(err error) bool {
+	return Classify(err) == ErrorTransient
+}
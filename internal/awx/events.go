@@ -0,0 +1,212 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// JobHostSummary holds the per-host recap AWX records at the end of a playbook run
+type JobHostSummary struct {
+	ID        int    `json:"id"`
+	Host      *int   `json:"host"`
+	HostName  string `json:"host_name"`
+	OK        int    `json:"ok"`
+	Changed   int    `json:"changed"`
+	Dark      int    `json:"dark"` // unreachable
+	Failures  int    `json:"failures"`
+	Processed int    `json:"processed"`
+	Skipped   int    `json:"skipped"`
+	Rescued   int    `json:"rescued"`
+	Ignored   int    `json:"ignored"`
+	Failed    bool   `json:"failed"`
+}
+
+// JobEvent is a single Ansible callback event emitted during a job run
+type JobEvent struct {
+	ID           int                    `json:"id"`
+	Counter      int                    `json:"counter"`
+	Event        string                 `json:"event"`
+	EventDisplay string                 `json:"event_display"`
+	Host         *int                   `json:"host"`
+	HostName     string                 `json:"host_name"`
+	Play         string                 `json:"play"`
+	Task         string                 `json:"task"`
+	Role         string                 `json:"role"`
+	Failed       bool                   `json:"failed"`
+	Changed      bool                   `json:"changed"`
+	Created      *time.Time             `json:"created"`
+	Stdout       string                 `json:"stdout"`
+	EventData    map[string]interface{} `json:"event_data"`
+}
+
+// Module returns the Ansible module that produced the event, if any
+func (e JobEvent) Module() string {
+	for _, key := range []string{"resolved_action", "task_action"} {
+		if action, ok := e.EventData[key].(string); ok && action != "" {
+			return action
+		}
+	}
+	return ""
+}
+
+// Message extracts the most useful human-readable message from the module result
+func (e JobEvent) Message() string {
+	res, ok := e.EventData["res"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	for _, key := range []string{"msg", "reason", "stderr", "message"} {
+		value, exists := res[key]
+		if !exists || value == nil {
+			continue
+		}
+		if text, ok := value.(string); ok {
+			if text = strings.TrimSpace(text); text != "" {
+				return text
+			}
+			continue
+		}
+		if encoded, err := json.Marshal(value); err == nil {
+			return string(encoded)
+		}
+	}
+	return ""
+}
+
+// JobEventFilter narrows down the events returned by GetJobEvents
+type JobEventFilter struct {
+	Host       string // Only events for this host name
+	EventType  string // AWX event type, e.g. runner_on_failed
+	FailedOnly bool   // Only failed events
+	Limit      int    // Maximum number of events to collect
+}
+
+// HostFailure describes the task that failed on a given host
+type HostFailure struct {
+	Host    string `json:"host"`
+	Task    string `json:"task"`
+	Module  string `json:"module,omitempty"`
+	Message string `json:"message,omitempty"`
+	Event   string `json:"event"`
+}
+
+// GetJobHostSummaries returns the per-host play recap of a job
+func (c *Client) GetJobHostSummaries(ctx context.Context, jobID int) ([]JobHostSummary, error) {
+	path, err := c.jobPath(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if isAdHocPath(path) {
+		summaries, err := c.adHocHostSummaries(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get host summaries for ad hoc command %d: %w", jobID, err)
+		}
+		return summaries, nil
+	}
+
+	list, err := listAll[JobHostSummary](ctx, c, path+"job_host_summaries/", ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host summaries for job %d: %w", jobID, err)
+	}
+	return list.Results, nil
+}
+
+// GetJobEvents returns the events of a job in execution order
+func (c *Client) GetJobEvents(ctx context.Context, jobID int, filter JobEventFilter) (*ListResult[JobEvent], error) {
+	query := url.Values{}
+	query.Set("order_by", "counter")
+	if filter.Host != "" {
+		query.Set("host_name", filter.Host)
+	}
+	if filter.EventType != "" {
+		query.Set("event", filter.EventType)
+	}
+	if filter.FailedOnly {
+		query.Set("failed", "true")
+	}
+
+	path, err := c.jobPath(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := listAll[JobEvent](ctx, c, jobEventsEndpoint(path), ListOptions{
+		MaxItems: filter.Limit,
+		Query:    query,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events for job %d: %w", jobID, err)
+	}
+	return list, nil
+}
+
+// GetJobFailures returns, for each failed or unreachable host, the task that
+// failed and the module message
+func (c *Client) GetJobFailures(ctx context.Context, jobID int) ([]HostFailure, error) {
+	events, err := c.GetJobEvents(ctx, jobID, JobEventFilter{FailedOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []HostFailure
+	for _, event := range events.Results {
+		// Skip aggregate events such as playbook_on_stats, only host results carry a task
+		if event.HostName == "" {
+			continue
+		}
+		switch event.Event {
+		case "runner_on_failed", "runner_on_unreachable", "runner_item_on_failed", "runner_on_async_failed":
+		default:
+			continue
+		}
+		// Failures handled by ignore_errors do not fail the host
+		if ignored, _ := event.EventData["ignore_errors"].(bool); ignored {
+			continue
+		}
+
+		// Ad hoc command events have no task, the module stands in for it
+		task := event.Task
+		if task == "" {
+			task = event.Module()
+		}
+		failures = append(failures, HostFailure{
+			Host:    event.HostName,
+			Task:    task,
+			Module:  event.Module(),
+			Message: event.Message(),
+			Event:   event.Event,
+		})
+	}
+	return failures, nil
+}
+
+// GetLatestJobTask returns the name of the most recently started task of a job
+func (c *Client) GetLatestJobTask(ctx context.Context, jobID int) (string, error) {
+	query := url.Values{}
+	query.Set("event", "playbook_on_task_start")
+	query.Set("order_by", "-counter")
+	query.Set("page_size", "1")
+
+	path, err := c.jobPath(ctx, jobID)
+	if err != nil {
+		return "", err
+	}
+	// An ad hoc command runs a single module, without tasks
+	if isAdHocPath(path) {
+		return "", nil
+	}
+	var response page[JobEvent]
+	endpoint := jobEventsEndpoint(path) + "?" + query.Encode()
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &response); err != nil {
+		return "", fmt.Errorf("failed to get latest task for job %d: %w", jobID, err)
+	}
+
+	if len(response.Results) == 0 {
+		return "", nil
+	}
+	return response.Results[0].Task, nil
+}
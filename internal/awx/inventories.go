@@ -0,0 +1,356 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// inventoriesCacheKey holds the cached inventory list, whose host and group
+// counts go stale when hosts are added or removed
+const inventoriesCacheKey = "awx:inventories"
+
+// Host is an inventory host
+type Host struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	Inventory         int    `json:"inventory"`
+	Enabled           bool   `json:"enabled"`
+	InstanceID        string `json:"instance_id"`
+	Variables         string `json:"variables"` // JSON or YAML text, as AWX stores it
+	HasActiveFailures bool   `json:"has_active_failures"`
+	LastJob           *int   `json:"last_job"`
+	SummaryFields     struct {
+		Groups struct {
+			Count   int `json:"count"`
+			Results []struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			} `json:"results"`
+		} `json:"groups"`
+		LastJob *struct {
+			ID     int    `json:"id"`
+			Name   string `json:"name"`
+			Status string `json:"status"`
+		} `json:"last_job"`
+	} `json:"summary_fields"`
+}
+
+// GroupNames returns the names of the groups the host belongs to, as far as
+// AWX lists them in the host summary
+func (h Host) GroupNames() []string {
+	names := make([]string, len(h.SummaryFields.Groups.Results))
+	for i, group := range h.SummaryFields.Groups.Results {
+		names[i] = group.Name
+	}
+	return names
+}
+
+// Group is an inventory group
+type Group struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Inventory   int    `json:"inventory"`
+	Variables   string `json:"variables"`
+}
+
+// InventorySource is a source an inventory syncs its hosts and groups from
+type InventorySource struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Inventory   int        `json:"inventory"`
+	Source      string     `json:"source"`
+	Status      string     `json:"status"`
+	LastUpdated *time.Time `json:"last_updated"`
+	LastJobRun  *time.Time `json:"last_job_run"`
+}
+
+// InventoryUpdate is one sync run of an inventory source
+type InventoryUpdate struct {
+	ID              int        `json:"id"`
+	Name            string     `json:"name"`
+	Status          string     `json:"status"`
+	Failed          bool       `json:"failed"`
+	Started         *time.Time `json:"started"`
+	Finished        *time.Time `json:"finished"`
+	Elapsed         float64    `json:"elapsed"`
+	InventorySource int        `json:"inventory_source"`
+	JobExplanation  string     `json:"job_explanation"`
+	ResultTraceback string     `json:"result_traceback"`
+}
+
+// IsFinished reports whether the sync reached a terminal state
+func (u InventoryUpdate) IsFinished() bool {
+	return IsFinishedStatus(u.Status)
+}
+
+// HostRequest creates a host
+type HostRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+	Variables   string `json:"variables,omitempty"`
+}
+
+// HostUpdate holds the host fields a PATCH changes. Nil fields are left as they are.
+type HostUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Enabled     *bool   `json:"enabled,omitempty"`
+}
+
+// GroupUpdate holds the group fields a PATCH changes. Nil fields are left as they are.
+type GroupUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// GetInventoryHosts lists the hosts of an inventory, or of one of its groups
+// when groupID is set. search filters on names and descriptions.
+func (c *Client) GetInventoryHosts(ctx context.Context, inventoryID, groupID int, search string, limit int) (*ListResult[Host], error) {
+	endpoint := fmt.Sprintf("/api/v2/inventories/%d/hosts/", inventoryID)
+	if groupID > 0 {
+		endpoint = fmt.Sprintf("/api/v2/groups/%d/all_hosts/", groupID)
+	}
+
+	query := url.Values{"order_by": {"name"}}
+	if search != "" {
+		query.Set("search", search)
+	}
+	return listAll[Host](ctx, c, endpoint, ListOptions{MaxItems: limit, Query: query})
+}
+
+// GetInventoryGroups lists the groups of an inventory
+func (c *Client) GetInventoryGroups(ctx context.Context, inventoryID int) (*ListResult[Group], error) {
+	endpoint := fmt.Sprintf("/api/v2/inventories/%d/groups/", inventoryID)
+	return listAll[Group](ctx, c, endpoint, ListOptions{Query: url.Values{"order_by": {"name"}}})
+}
+
+// GetInventorySources lists the sources of an inventory
+func (c *Client) GetInventorySources(ctx context.Context, inventoryID int) (*ListResult[InventorySource], error) {
+	endpoint := fmt.Sprintf("/api/v2/inventories/%d/inventory_sources/", inventoryID)
+	return listAll[InventorySource](ctx, c, endpoint, ListOptions{Query: url.Values{"order_by": {"name"}}})
+}
+
+// GetHost returns a host of an inventory by name or ID. Host names are unique
+// within an inventory.
+func (c *Client) GetHost(ctx context.Context, inventoryID int, nameOrID string) (*Host, error) {
+	var host Host
+	if err := c.findInInventory(ctx, inventoryID, ResourceHost, nameOrID, &host); err != nil {
+		return nil, err
+	}
+	return &host, nil
+}
+
+// GetGroup returns a group of an inventory by name or ID
+func (c *Client) GetGroup(ctx context.Context, inventoryID int, nameOrID string) (*Group, error) {
+	var group Group
+	if err := c.findInInventory(ctx, inventoryID, ResourceGroup, nameOrID, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// findInInventory looks up a host or group of an inventory by exact name,
+// falling back to the ID for numeric references, and decodes it into result
+func (c *Client) findInInventory(ctx context.Context, inventoryID int, resourceType ResourceType, nameOrID string, result interface{}) error {
+	nameOrID = strings.TrimSpace(nameOrID)
+	if nameOrID == "" {
+		return fmt.Errorf("%s name or ID is required", resourceType)
+	}
+
+	collection := string(resourceType) + "s"
+	endpoint := fmt.Sprintf("/api/v2/inventories/%d/%s/", inventoryID, collection)
+	queries := []url.Values{{"name": {nameOrID}}}
+	if _, err := strconv.Atoi(nameOrID); err == nil {
+		queries = append(queries, url.Values{"id": {nameOrID}})
+	}
+
+	for _, query := range queries {
+		var matches page[candidateResult]
+		if err := c.makeRequest(ctx, "GET", endpoint+"?"+query.Encode(), nil, &matches); err != nil {
+			return fmt.Errorf("failed to look up %s '%s': %w", resourceType, nameOrID, err)
+		}
+		if matches.Count == 0 {
+			continue
+		}
+		detail := fmt.Sprintf("/api/v2/%s/%d/", collection, matches.Results[0].ID)
+		return c.makeRequest(ctx, "GET", detail, nil, result)
+	}
+
+	return &ResolveError{Type: resourceType, Reference: nameOrID, Candidates: c.similarResources(ctx, endpoint, nameOrID)}
+}
+
+// GetHostGroups lists the groups a host belongs to directly
+func (c *Client) GetHostGroups(ctx context.Context, hostID int) (*ListResult[Group], error) {
+	endpoint := fmt.Sprintf("/api/v2/hosts/%d/groups/", hostID)
+	return listAll[Group](ctx, c, endpoint, ListOptions{Query: url.Values{"order_by": {"name"}}})
+}
+
+// GetHostFacts returns the Ansible facts gathered for a host by fact caching
+func (c *Client) GetHostFacts(ctx context.Context, hostID int) (map[string]interface{}, error) {
+	var facts map[string]interface{}
+	endpoint := fmt.Sprintf("/api/v2/hosts/%d/ansible_facts/", hostID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &facts); err != nil {
+		return nil, fmt.Errorf("failed to get facts of host %d: %w", hostID, err)
+	}
+	return facts, nil
+}
+
+// CreateHost adds a host to an inventory
+func (c *Client) CreateHost(ctx context.Context, inventoryID int, request HostRequest) (*Host, error) {
+	var host Host
+	endpoint := fmt.Sprintf("/api/v2/inventories/%d/hosts/", inventoryID)
+	if err := c.makeRequest(ctx, "POST", endpoint, request, &host); err != nil {
+		return nil, fmt.Errorf("failed to add host '%s' to inventory %d: %w", request.Name, inventoryID, err)
+	}
+
+	c.cache.Delete(inventoriesCacheKey)
+
+	log.Printf("Successfully added host: %s (ID: %d) to inventory %d", host.Name, host.ID, inventoryID)
+	return &host, nil
+}
+
+// UpdateHost changes the set fields of a host
+func (c *Client) UpdateHost(ctx context.Context, hostID int, update HostUpdate) (*Host, error) {
+	var host Host
+	endpoint := fmt.Sprintf("/api/v2/hosts/%d/", hostID)
+	if err := c.makeRequest(ctx, "PATCH", endpoint, update, &host); err != nil {
+		return nil, fmt.Errorf("failed to update host %d: %w", hostID, err)
+	}
+	return &host, nil
+}
+
+// DeleteHost removes a host from its inventory
+func (c *Client) DeleteHost(ctx context.Context, hostID int) error {
+	endpoint := fmt.Sprintf("/api/v2/hosts/%d/", hostID)
+	err := c.makeRequest(ctx, "DELETE", endpoint, nil, nil)
+
+	c.cache.Delete(inventoriesCacheKey)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete host %d: %w", hostID, err)
+	}
+
+	log.Printf("Successfully deleted host %d", hostID)
+	return nil
+}
+
+// UpdateGroup changes the set fields of a group
+func (c *Client) UpdateGroup(ctx context.Context, groupID int, update GroupUpdate) (*Group, error) {
+	var group Group
+	endpoint := fmt.Sprintf("/api/v2/groups/%d/", groupID)
+	if err := c.makeRequest(ctx, "PATCH", endpoint, update, &group); err != nil {
+		return nil, fmt.Errorf("failed to update group %d: %w", groupID, err)
+	}
+	return &group, nil
+}
+
+// AssociateGroupHost adds a host to a group, or removes it when disassociate
+// is set. Removing a host from a group keeps it in the inventory.
+func (c *Client) AssociateGroupHost(ctx context.Context, groupID, hostID int, disassociate bool) error {
+	body := map[string]interface{}{"id": hostID}
+	if disassociate {
+		body["disassociate"] = true
+	}
+	endpoint := fmt.Sprintf("/api/v2/groups/%d/hosts/", groupID)
+	if err := c.makeRequest(ctx, "POST", endpoint, body, nil); err != nil {
+		return fmt.Errorf("failed to change host %d of group %d: %w", hostID, groupID, err)
+	}
+	return nil
+}
+
+// HostVariablesEndpoint and GroupVariablesEndpoint address the variables of a
+// host or group for GetVariables and SetVariables
+func HostVariablesEndpoint(hostID int) string {
+	return fmt.Sprintf("/api/v2/hosts/%d/variable_data/", hostID)
+}
+
+func GroupVariablesEndpoint(groupID int) string {
+	return fmt.Sprintf("/api/v2/groups/%d/variable_data/", groupID)
+}
+
+// GetVariables reads the variables of a host or group as an object
+func (c *Client) GetVariables(ctx context.Context, endpoint string) (map[string]interface{}, error) {
+	var vars map[string]interface{}
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &vars); err != nil {
+		return nil, fmt.Errorf("failed to read variables: %w", err)
+	}
+	if vars == nil {
+		vars = map[string]interface{}{}
+	}
+	return vars, nil
+}
+
+// SetVariables changes the variables of a host or group. With replace the
+// given variables become the whole set; otherwise they are merged into the
+// current ones the way var-sets merge, and keys listed in remove are dropped.
+// It returns the variables as stored.
+func (c *Client) SetVariables(ctx context.Context, endpoint string, vars map[string]interface{}, remove []string, replace bool) (map[string]interface{}, error) {
+	merged := make(map[string]interface{})
+	if !replace {
+		current, err := c.GetVariables(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		mergeVars(merged, current)
+	}
+	mergeVars(merged, vars)
+	for _, key := range remove {
+		delete(merged, key)
+	}
+
+	var stored map[string]interface{}
+	if err := c.makeRequest(ctx, "PUT", endpoint, merged, &stored); err != nil {
+		return nil, fmt.Errorf("failed to write variables: %w", err)
+	}
+	if stored == nil {
+		stored = merged
+	}
+	return stored, nil
+}
+
+// SyncInventorySource starts a sync of an inventory source and returns the update it started
+func (c *Client) SyncInventorySource(ctx context.Context, sourceID int) (*InventoryUpdate, error) {
+	var response struct {
+		ID              int    `json:"id"`
+		InventoryUpdate int    `json:"inventory_update"`
+		Status          string `json:"status"`
+	}
+	endpoint := fmt.Sprintf("/api/v2/inventory_sources/%d/update/", sourceID)
+	if err := c.makeRequest(ctx, "POST", endpoint, map[string]interface{}{}, &response); err != nil {
+		return nil, fmt.Errorf("failed to sync inventory source %d: %w", sourceID, err)
+	}
+
+	updateID := response.InventoryUpdate
+	if updateID == 0 {
+		updateID = response.ID
+	}
+	status := response.Status
+	if status == "" {
+		status = "pending"
+	}
+
+	// The inventory list counts change once the sync lands
+	c.cache.Delete(inventoriesCacheKey)
+
+	log.Printf("Started sync %d of inventory source %d", updateID, sourceID)
+	return &InventoryUpdate{ID: updateID, Status: status, InventorySource: sourceID}, nil
+}
+
+// GetInventoryUpdate returns the state of an inventory sync
+func (c *Client) GetInventoryUpdate(ctx context.Context, updateID int) (*InventoryUpdate, error) {
+	var update InventoryUpdate
+	endpoint := fmt.Sprintf("/api/v2/inventory_updates/%d/", updateID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &update); err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
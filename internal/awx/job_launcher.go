@@ -1,18 +1,23 @@
 package awx
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// JobLauncher is the single launch pipeline for job templates: it resolves the
+// template, checks every launch-time field against the template's prompts,
+// resolves names to IDs and sends the launch on the shared client transport
 type JobLauncher struct {
 	client *Client
 }
@@ -21,25 +26,62 @@ func NewJobLauncher(client *Client) *JobLauncher {
 	return &JobLauncher{client: client}
 }
 
+// LaunchJobOptions are the launch-time fields of a job template. Unset fields
+// keep the template's value; pointers tell an explicit zero from unset. Every
+// set field must be prompted on launch by the template.
 type LaunchJobOptions struct {
-	TemplateNameOrID string
-	ExtraVars        map[string]interface{}
-	Inventory        string
-	Limit            string
-	Tags             string
-	SkipTags         string
-	JobType          string
-	Verbosity        int
-	DiffMode         bool
-	Timeout          time.Duration
+	TemplateNameOrID     string
+	ExtraVars            map[string]interface{}
+	Inventory            string   // Name or ID
+	Credentials          []string // Names or IDs; replace the template credentials
+	Limit                string
+	Tags                 string
+	SkipTags             string
+	JobType              string // run or check
+	Verbosity            *int   // 0 (normal) to 5 (WinRM debug)
+	DiffMode             *bool
+	Forks                *int
+	JobTimeout           *int     // Seconds the job may run, 0 for no limit
+	ExecutionEnvironment string   // Name or ID
+	Labels               []string // Names or IDs
+	InstanceGroups       []string // Names or IDs
+	ScmBranch            string
+	JobSliceCount        *int
+	CredentialPasswords  map[string]string // Answers to credential password prompts, such as ssh_password
+
+	Timeout time.Duration // How long AWX may take to accept the launch (default 60s)
 }
 
 type LaunchResult struct {
-	JobID      int    `json:"job_id"`
-	Status     string `json:"status"`
-	URL        string `json:"url"`
-	Message    string `json:"message"`
-	LaunchType string `json:"launch_type"`
+	JobID         int      `json:"job_id"`
+	Status        string   `json:"status"`
+	URL           string   `json:"url"`
+	Message       string   `json:"message"`
+	LaunchType    string   `json:"launch_type"`
+	TemplateID    int      `json:"template_id,omitempty"`
+	TemplateName  string   `json:"template_name,omitempty"`
+	IgnoredFields []string `json:"ignored_fields,omitempty"` // Fields AWX accepted but did not apply
+}
+
+// LaunchValidationError lists the launch fields a template does not accept.
+// Survey answers are reported in FieldErrors as extra_vars.<variable>.
+type LaunchValidationError struct {
+	Template    string
+	Problems    []string
+	FieldErrors map[string][]string
+}
+
+func (e *LaunchValidationError) Error() string {
+	problems := append([]string(nil), e.Problems...)
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		problems = append(problems, fmt.Sprintf("%s %s", field, strings.Join(e.FieldErrors[field], ", ")))
+	}
+	return fmt.Sprintf("cannot launch template '%s': %s", e.Template, strings.Join(problems, "; "))
 }
 
 func (jl *JobLauncher) Launch(ctx context.Context, options LaunchJobOptions) (*LaunchResult, error) {
@@ -60,24 +102,39 @@ func (jl *JobLauncher) Launch(ctx context.Context, options LaunchJobOptions) (*L
 
 	log.Printf("Resolved template '%s' to ID: %d", templateName, templateID)
 
-	if err := jl.validateLaunchPermissions(ctx, templateID); err != nil {
+	requirements, err := jl.client.GetLaunchRequirements(ctx, templateID)
+	if err != nil {
+		if Classify(err) == ErrorPermission {
+			return nil, fmt.Errorf("insufficient permissions to launch template %d: %w", templateID, err)
+		}
 		return nil, fmt.Errorf("launch validation failed for template %d: %w", templateID, err)
 	}
 
-	launchRequest := jl.prepareLaunchRequest(options)
+	launchRequest, err := jl.prepareLaunchRequest(ctx, templateID, templateName, requirements, options)
+	if err != nil {
+		return nil, err
+	}
 
 	response, err := jl.executeLaunchWithRetry(ctx, templateID, launchRequest, options.Timeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to launch job for template %d: %w", templateID, err)
 	}
 
+	jobID := response.Job
+	if jobID == 0 {
+		jobID = response.ID
+	}
+
 	result := &LaunchResult{
-		JobID:      response.Job,
-		Status:     "pending",
-		URL:        response.URL,
-		LaunchType: "api",
-		Message:    jl.createSuccessMessage(templateName, response.Job, options),
+		JobID:         jobID,
+		Status:        "pending",
+		URL:           response.URL,
+		LaunchType:    "api",
+		TemplateID:    templateID,
+		TemplateName:  templateName,
+		IgnoredFields: sortedFieldNames(response.IgnoredFields),
 	}
+	result.Message = jl.createSuccessMessage(templateName, jobID, options, result.IgnoredFields)
 
 	log.Printf("Job launched successfully: ID %d, Template: %s", result.JobID, templateName)
 	return result, nil
@@ -111,128 +168,230 @@ func (jl *JobLauncher) resolveTemplate(ctx context.Context, nameOrID string) (in
 	return 0, "", fmt.Errorf("template not found. Available templates: %s", strings.Join(available, ", "))
 }
 
-func (jl *JobLauncher) validateLaunchPermissions(ctx context.Context, templateID int) error {
-	url := fmt.Sprintf("%s/api/v2/job_templates/%d/launch/", jl.client.baseURL, templateID)
-	
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create validation request: %w", err)
+// GetLaunchRequirements returns what a job template prompts for and still
+// needs before it can start. AWX answers 403 when the user may not launch it.
+func (c *Client) GetLaunchRequirements(ctx context.Context, templateID int) (*LaunchRequirements, error) {
+	var requirements LaunchRequirements
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/launch/", templateID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &requirements); err != nil {
+		return nil, err
 	}
+	return &requirements, nil
+}
 
-	if jl.client.token != "" {
-		req.Header.Set("Authorization", "Bearer "+jl.client.token)
-	} else if jl.client.username != "" && jl.client.password != "" {
-		req.SetBasicAuth(jl.client.username, jl.client.password)
-	}
-	
-	resp, err := jl.client.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to validate launch permissions: %w", err)
+// prepareLaunchRequest checks the options against the template prompts and
+// builds the launch body, reporting every problem at once
+func (jl *JobLauncher) prepareLaunchRequest(ctx context.Context, templateID int, templateName string, requirements *LaunchRequirements, options LaunchJobOptions) (map[string]interface{}, error) {
+	request := make(map[string]interface{})
+	var problems []string
+	fieldErrors := make(map[string][]string)
+
+	// prompted reports whether a set field may be sent, recording a problem when the template does not prompt for it
+	prompted := func(field string, set, asked bool) bool {
+		if set && !asked {
+			problems = append(problems, fmt.Sprintf("%s is not prompted on launch by this template", field))
+		}
+		return set && asked
+	}
+	// resolve turns names into IDs, recording names that do not match exactly one resource
+	resolve := func(resourceType ResourceType, namesOrIDs ...string) ([]int, error) {
+		ids := make([]int, 0, len(namesOrIDs))
+		for _, nameOrID := range namesOrIDs {
+			id, err := jl.client.ResolveID(ctx, resourceType, nameOrID)
+			var resolveErr *ResolveError
+			switch {
+			case errors.As(err, &resolveErr):
+				problems = append(problems, err.Error())
+			case err != nil:
+				return nil, err
+			default:
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == 403 {
-		return fmt.Errorf("insufficient permissions to launch this job template")
+	extraVars := options.ExtraVars
+	if requirements.SurveyEnabled {
+		survey, err := jl.client.GetSurveySpec(ctx, templateID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get survey of template '%s': %w", templateName, err)
+		}
+		var surveyErrors map[string][]string
+		extraVars, surveyErrors = survey.Validate(options.ExtraVars)
+		for variable, messages := range surveyErrors {
+			fieldErrors["extra_vars."+variable] = messages
+		}
+		// Without the variables prompt AWX drops everything the survey does not ask
+		if !requirements.AskVariablesOnLaunch {
+			for variable := range extraVars {
+				if _, ok := survey.Question(variable); !ok {
+					fieldErrors["extra_vars."+variable] = append(fieldErrors["extra_vars."+variable],
+						"is not a survey question and the template does not prompt for variables")
+				}
+			}
+		}
 	}
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("validation failed with status %d: %s", resp.StatusCode, string(body))
+	if prompted("extra_vars", len(extraVars) > 0, requirements.AskVariablesOnLaunch || requirements.SurveyEnabled) {
+		request["extra_vars"] = extraVars
 	}
 
-	return nil
-}
-
-func (jl *JobLauncher) prepareLaunchRequest(options LaunchJobOptions) map[string]interface{} {
-	request := make(map[string]interface{})
-
-	if len(options.ExtraVars) > 0 {
-		request["extra_vars"] = options.ExtraVars
+	if prompted("inventory", options.Inventory != "", requirements.AskInventoryOnLaunch) {
+		ids, err := resolve(ResourceInventory, options.Inventory)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 1 {
+			request["inventory"] = ids[0]
+		}
+	} else if options.Inventory == "" && requirements.InventoryNeededToStart {
+		problems = append(problems, "the template has no inventory, an inventory is required")
 	}
-	if options.Inventory != "" {
-		request["inventory"] = options.Inventory
+
+	if prompted("credentials", len(options.Credentials) > 0, requirements.AskCredentialOnLaunch) {
+		ids, err := resolve(ResourceCredential, options.Credentials...)
+		if err != nil {
+			return nil, err
+		}
+		request["credentials"] = ids
+	} else if len(options.Credentials) == 0 && requirements.CredentialNeededToStart {
+		problems = append(problems, "the template has no credential, a credential is required")
+	}
+	if len(requirements.PasswordsNeededToStart) > 0 {
+		passwords, missing := credentialPasswords(requirements.PasswordsNeededToStart, options.CredentialPasswords)
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("the template credentials prompt for passwords (%s), which were not supplied",
+				strings.Join(missing, ", ")))
+		} else {
+			request["credential_passwords"] = passwords
+		}
 	}
-	if options.Limit != "" {
+
+	if prompted("limit", options.Limit != "", requirements.AskLimitOnLaunch) {
 		request["limit"] = options.Limit
 	}
-	if options.Tags != "" {
+	if prompted("tags", options.Tags != "", requirements.AskTagsOnLaunch) {
 		request["job_tags"] = options.Tags
 	}
-	if options.SkipTags != "" {
+	if prompted("skip_tags", options.SkipTags != "", requirements.AskSkipTagsOnLaunch) {
 		request["skip_tags"] = options.SkipTags
 	}
-	if options.JobType != "" {
-		request["job_type"] = options.JobType
+	if prompted("job_type", options.JobType != "", requirements.AskJobTypeOnLaunch) {
+		if options.JobType != "run" && options.JobType != "check" {
+			problems = append(problems, fmt.Sprintf("job_type must be run or check, not %q", options.JobType))
+		} else {
+			request["job_type"] = options.JobType
+		}
 	}
-	if options.Verbosity > 0 {
-		request["verbosity"] = options.Verbosity
+	if prompted("verbosity", options.Verbosity != nil, requirements.AskVerbosityOnLaunch) {
+		if *options.Verbosity < 0 || *options.Verbosity > 5 {
+			problems = append(problems, fmt.Sprintf("verbosity must be between 0 and 5, not %d", *options.Verbosity))
+		} else {
+			request["verbosity"] = *options.Verbosity
+		}
+	}
+	if prompted("diff_mode", options.DiffMode != nil, requirements.AskDiffModeOnLaunch) {
+		request["diff_mode"] = *options.DiffMode
+	}
+	if prompted("forks", options.Forks != nil, requirements.AskForksOnLaunch) {
+		if *options.Forks < 0 {
+			problems = append(problems, fmt.Sprintf("forks must not be negative, not %d", *options.Forks))
+		} else {
+			request["forks"] = *options.Forks
+		}
+	}
+	if prompted("timeout", options.JobTimeout != nil, requirements.AskTimeoutOnLaunch) {
+		if *options.JobTimeout < 0 {
+			problems = append(problems, fmt.Sprintf("timeout must not be negative, not %d", *options.JobTimeout))
+		} else {
+			request["timeout"] = *options.JobTimeout
+		}
 	}
-	if options.DiffMode {
-		request["diff_mode"] = true
+	if prompted("execution_environment", options.ExecutionEnvironment != "", requirements.AskExecutionEnvironmentOnLaunch) {
+		ids, err := resolve(ResourceExecutionEnvironment, options.ExecutionEnvironment)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 1 {
+			request["execution_environment"] = ids[0]
+		}
+	}
+	if prompted("labels", len(options.Labels) > 0, requirements.AskLabelsOnLaunch) {
+		ids, err := resolve(ResourceLabel, options.Labels...)
+		if err != nil {
+			return nil, err
+		}
+		request["labels"] = ids
+	}
+	if prompted("instance_groups", len(options.InstanceGroups) > 0, requirements.AskInstanceGroupsOnLaunch) {
+		ids, err := resolve(ResourceInstanceGroup, options.InstanceGroups...)
+		if err != nil {
+			return nil, err
+		}
+		request["instance_groups"] = ids
+	}
+	if prompted("scm_branch", options.ScmBranch != "", requirements.AskScmBranchOnLaunch) {
+		request["scm_branch"] = options.ScmBranch
+	}
+	if prompted("job_slice_count", options.JobSliceCount != nil, requirements.AskJobSliceCountOnLaunch) {
+		if *options.JobSliceCount < 1 {
+			problems = append(problems, fmt.Sprintf("job_slice_count must be at least 1, not %d", *options.JobSliceCount))
+		} else {
+			request["job_slice_count"] = *options.JobSliceCount
+		}
 	}
 
-	return request
+	if len(problems) > 0 || len(fieldErrors) > 0 {
+		return nil, &LaunchValidationError{Template: templateName, Problems: problems, FieldErrors: fieldErrors}
+	}
+	return request, nil
 }
 
 func (jl *JobLauncher) executeLaunchWithRetry(ctx context.Context, templateID int, request map[string]interface{}, timeout time.Duration) (*JobLaunchResponse, error) {
-	const maxRetries = 3
-	const retryDelay = 2 * time.Second
-
-	var lastErr error
-	
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		log.Printf("Launch attempt %d/%d for template %d", attempt, maxRetries, templateID)
-		
+	maxAttempts := jl.client.maxRetries + 1
+
+	for attempt := 1; ; attempt++ {
+		log.Printf("Launch attempt %d/%d for template %d", attempt, maxAttempts, templateID)
+
 		response, err := jl.executeSingleLaunch(ctx, templateID, request, timeout)
 		if err == nil {
 			return response, nil
 		}
 
-		lastErr = err
 		log.Printf("Launch attempt %d failed: %v", attempt, err)
 
-		if isNonRetryableError(err) {
-			break
+		if attempt >= maxAttempts || !launchRetryable(err) {
+			return nil, err
 		}
 
-		if attempt < maxRetries {
-			log.Printf("Retrying in %v...", retryDelay)
-			time.Sleep(retryDelay)
+		wait, ok := jl.client.backoff(attempt, err)
+		if !ok {
+			return nil, err
+		}
+		log.Printf("Retrying in %v...", wait.Round(time.Millisecond))
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
 		}
 	}
-
-	return nil, fmt.Errorf("all launch attempts failed, last error: %w", lastErr)
 }
 
 func (jl *JobLauncher) executeSingleLaunch(ctx context.Context, templateID int, request map[string]interface{}, timeout time.Duration) (*JobLaunchResponse, error) {
-	client := &http.Client{
-		Timeout: timeout,
-	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/launch/", templateID)
 
-	url := fmt.Sprintf("%s/api/v2/job_templates/%d/launch/", jl.client.baseURL, templateID)
-	
 	jsonData, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	log.Printf("POST %s", url)
-	log.Printf("Request body: %s", string(jsonData))
-
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	if jl.client.token != "" {
-		req.Header.Set("Authorization", "Bearer "+jl.client.token)
-	} else if jl.client.username != "" && jl.client.password != "" {
-		req.SetBasicAuth(jl.client.username, jl.client.password)
-	}
-	req.Header.Set("Content-Type", "application/json")
+	log.Printf("POST %s%s", jl.client.baseURL, endpoint)
+	log.Printf("Request body: %s", redactBody(jsonData))
 
-	resp, err := client.Do(req)
+	resp, err := jl.client.send(ctx, "POST", endpoint, jsonData)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -241,10 +400,8 @@ func (jl *JobLauncher) executeSingleLaunch(ctx context.Context, templateID int,
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	log.Printf("Response: %d - %s", resp.StatusCode, string(respBody))
-
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("AWX API error: status %d - %s", resp.StatusCode, string(respBody))
+	if jl.client.debug {
+		log.Printf("Response: %d - %s", resp.StatusCode, redactBody(respBody))
 	}
 
 	var response JobLaunchResponse
@@ -255,34 +412,56 @@ func (jl *JobLauncher) executeSingleLaunch(ctx context.Context, templateID int,
 	return &response, nil
 }
 
-func (jl *JobLauncher) createSuccessMessage(templateName string, jobID int, options LaunchJobOptions) string {
+func (jl *JobLauncher) createSuccessMessage(templateName string, jobID int, options LaunchJobOptions, ignored []string) string {
 	message := fmt.Sprintf("Successfully launched job %d using template '%s'", jobID, templateName)
-	
+
 	if len(options.ExtraVars) > 0 {
 		message += fmt.Sprintf(" with %d extra variables", len(options.ExtraVars))
 	}
-	
+
 	if options.Limit != "" {
 		message += fmt.Sprintf(" limited to hosts: %s", options.Limit)
 	}
-	
+
+	if len(ignored) > 0 {
+		message += fmt.Sprintf(" (AWX ignored: %s)", strings.Join(ignored, ", "))
+	}
+
 	return message
 }
 
-func isNonRetryableError(err error) bool {
-	errStr := err.Error()
-	
-	if strings.Contains(errStr, "403") || strings.Contains(errStr, "insufficient permissions") {
-		return true
+// credentialPasswords picks the answers to the password prompts a launch
+// needs, returning the prompts left unanswered
+func credentialPasswords(needed []string, given map[string]string) (map[string]string, []string) {
+	passwords := make(map[string]string, len(needed))
+	var missing []string
+	for _, name := range needed {
+		if given[name] == "" {
+			missing = append(missing, name)
+			continue
+		}
+		passwords[name] = given[name]
 	}
-	
-	if strings.Contains(errStr, "400") || strings.Contains(errStr, "not found") {
-		return true
+	return passwords, missing
+}
+
+func sortedFieldNames(fields map[string]interface{}) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
 	}
-	
-	if strings.Contains(errStr, "401") || strings.Contains(errStr, "authentication") {
-		return true
+	sort.Strings(names)
+	return names
+}
+
+// launchRetryable reports whether a launch certainly did not start a job, so
+// sending it again cannot launch twice: AWX asked to come back later, or the
+// connection was never made
+func launchRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode == http.StatusServiceUnavailable
 	}
-	
-	return false
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
 }
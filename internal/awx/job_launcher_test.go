@@ -0,0 +1,187 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func intOption(v int) *int    { return &v }
+func boolOption(v bool) *bool { return &v }
+
+// launchPromptServer answers the lookups prepareLaunchRequest makes: the
+// survey of template 7 and inventories, of which "staging" is ambiguous
+func launchPromptServer(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/api/v2/job_templates/7/survey_spec/":
+			writeJSON(t, w, http.StatusOK, SurveySpec{Spec: []SurveyQuestion{
+				{QuestionName: "Replicas", Variable: "replicas", Type: "integer", Required: true, Max: bound(10)},
+				{QuestionName: "Color", Variable: "color", Type: "multiplechoice", Choices: SurveyChoices{"blue", "green"}, Default: "blue"},
+			}})
+		case r.URL.Path == "/api/v2/inventories/" && query.Get("name") == "prod":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 1, "results": []candidateResult{{ID: 2, Name: "prod"}}})
+		case r.URL.Path == "/api/v2/inventories/" && query.Get("name") == "staging":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 2, "results": []candidateResult{{ID: 3, Name: "staging"}, {ID: 4, Name: "staging"}}})
+		case r.URL.Path == "/api/v2/inventories/":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 0, "results": []candidateResult{}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestPrepareLaunchRequest(t *testing.T) {
+	everything := LaunchRequirements{
+		AskVariablesOnLaunch: true, AskInventoryOnLaunch: true, AskCredentialOnLaunch: true, AskLimitOnLaunch: true,
+		AskTagsOnLaunch: true, AskSkipTagsOnLaunch: true, AskJobTypeOnLaunch: true, AskVerbosityOnLaunch: true,
+		AskDiffModeOnLaunch: true, AskScmBranchOnLaunch: true, AskExecutionEnvironmentOnLaunch: true, AskLabelsOnLaunch: true,
+		AskForksOnLaunch: true, AskJobSliceCountOnLaunch: true, AskTimeoutOnLaunch: true, AskInstanceGroupsOnLaunch: true,
+	}
+
+	tests := []struct {
+		name         string
+		requirements LaunchRequirements
+		options      LaunchJobOptions
+		want         string // Launch body as JSON
+		err          string // Error substring
+	}{
+		{
+			name:         "nothing prompted, nothing sent",
+			requirements: LaunchRequirements{},
+			want:         `{}`,
+		},
+		{
+			name:         "every prompt answered",
+			requirements: everything,
+			options: LaunchJobOptions{
+				ExtraVars: map[string]interface{}{"release": "1.3"}, Inventory: "prod", Credentials: []string{"4", "5"},
+				Limit: "web*", Tags: "deploy", SkipTags: "slow", JobType: "check", Verbosity: intOption(3),
+				DiffMode: boolOption(false), Forks: intOption(0), JobTimeout: intOption(600), ExecutionEnvironment: "6",
+				Labels: []string{"8"}, InstanceGroups: []string{"3", "1"}, ScmBranch: "main", JobSliceCount: intOption(2),
+			},
+			want: `{"credentials":[4,5],"diff_mode":false,"execution_environment":6,"extra_vars":{"release":"1.3"},"forks":0,` +
+				`"instance_groups":[3,1],"inventory":2,"job_slice_count":2,"job_tags":"deploy","job_type":"check","labels":[8],` +
+				`"limit":"web*","scm_branch":"main","skip_tags":"slow","timeout":600,"verbosity":3}`,
+		},
+		{
+			name:         "fields the template does not prompt for",
+			requirements: LaunchRequirements{AskLimitOnLaunch: true},
+			options:      LaunchJobOptions{ExtraVars: map[string]interface{}{"a": 1}, Limit: "web1", Tags: "deploy", Forks: intOption(5), Labels: []string{"8"}},
+			err:          "cannot launch template 'deploy': extra_vars is not prompted on launch by this template; tags is not prompted on launch by this template; forks is not prompted on launch by this template; labels is not prompted on launch by this template",
+		},
+		{
+			name:         "inventory needed to start",
+			requirements: LaunchRequirements{AskInventoryOnLaunch: true, InventoryNeededToStart: true},
+			err:          "the template has no inventory, an inventory is required",
+		},
+		{
+			name:         "credential needed to start",
+			requirements: LaunchRequirements{AskCredentialOnLaunch: true, CredentialNeededToStart: true},
+			err:          "the template has no credential, a credential is required",
+		},
+		{
+			name:         "credential passwords answered",
+			requirements: LaunchRequirements{PasswordsNeededToStart: []string{"ssh_password"}},
+			options:      LaunchJobOptions{CredentialPasswords: map[string]string{"ssh_password": "secret", "become_password": "unused"}},
+			want:         `{"credential_passwords":{"ssh_password":"secret"}}`,
+		},
+		{
+			name:         "credential passwords missing",
+			requirements: LaunchRequirements{PasswordsNeededToStart: []string{"ssh_password", "vault_password"}},
+			options:      LaunchJobOptions{CredentialPasswords: map[string]string{"ssh_password": "secret"}},
+			err:          "the template credentials prompt for passwords (vault_password), which were not supplied",
+		},
+		{
+			name:         "ambiguous inventory",
+			requirements: everything,
+			options:      LaunchJobOptions{Inventory: "staging"},
+			err:          "2 inventories are named 'staging', use the ID of one of: 'staging' (ID: 3), 'staging' (ID: 4)",
+		},
+		{
+			name:         "unknown inventory",
+			requirements: everything,
+			options:      LaunchJobOptions{Inventory: "qa"},
+			err:          "inventory 'qa' not found",
+		},
+		{
+			name:         "job type",
+			requirements: everything,
+			options:      LaunchJobOptions{JobType: "dry-run"},
+			err:          `job_type must be run or check, not "dry-run"`,
+		},
+		{
+			name:         "verbosity out of range",
+			requirements: everything,
+			options:      LaunchJobOptions{Verbosity: intOption(6)},
+			err:          "verbosity must be between 0 and 5, not 6",
+		},
+		{
+			name:         "negative forks and timeout",
+			requirements: everything,
+			options:      LaunchJobOptions{Forks: intOption(-1), JobTimeout: intOption(-5)},
+			err:          "forks must not be negative, not -1; timeout must not be negative, not -5",
+		},
+		{
+			name:         "job slice count below one",
+			requirements: everything,
+			options:      LaunchJobOptions{JobSliceCount: intOption(0)},
+			err:          "job_slice_count must be at least 1, not 0",
+		},
+		{
+			name:         "survey answers coerced",
+			requirements: LaunchRequirements{SurveyEnabled: true},
+			options:      LaunchJobOptions{ExtraVars: map[string]interface{}{"replicas": "3", "color": "green"}},
+			want:         `{"extra_vars":{"color":"green","replicas":3}}`,
+		},
+		{
+			name:         "survey answers invalid",
+			requirements: LaunchRequirements{SurveyEnabled: true},
+			options:      LaunchJobOptions{ExtraVars: map[string]interface{}{"replicas": 11, "color": "red"}},
+			err:          `extra_vars.color "red" is not one of blue, green; extra_vars.replicas must be at most 10, got 11`,
+		},
+		{
+			name:         "variables outside the survey without the variables prompt",
+			requirements: LaunchRequirements{SurveyEnabled: true},
+			options:      LaunchJobOptions{ExtraVars: map[string]interface{}{"replicas": 2, "debug": true}},
+			err:          "extra_vars.debug is not a survey question and the template does not prompt for variables",
+		},
+		{
+			name:         "variables outside the survey with the variables prompt",
+			requirements: LaunchRequirements{SurveyEnabled: true, AskVariablesOnLaunch: true},
+			options:      LaunchJobOptions{ExtraVars: map[string]interface{}{"replicas": 2, "debug": true}},
+			want:         `{"extra_vars":{"debug":true,"replicas":2}}`,
+		},
+		{
+			name:         "problems reported together",
+			requirements: LaunchRequirements{SurveyEnabled: true, AskInventoryOnLaunch: true},
+			options:      LaunchJobOptions{Inventory: "qa", Limit: "web1"},
+			err:          "cannot launch template 'deploy': inventory 'qa' not found; limit is not prompted on launch by this template; extra_vars.replicas \"Replicas\" is required",
+		},
+	}
+
+	client, _ := newTestClient(t, launchPromptServer(t), ClientConfig{MaxRetries: -1})
+	launcher := NewJobLauncher(client)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := launcher.prepareLaunchRequest(context.Background(), 7, "deploy", &tt.requirements, tt.options)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			body, _ := json.Marshal(request)
+			if string(body) != tt.want {
+				t.Errorf("body = %s\nwant %s", body, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+)
+
+// jobTemplatesCacheKey holds the cached job template list, dropped on every change
+const jobTemplatesCacheKey = "awx:job_templates"
+
+// JobTemplateUpdate holds the job template fields a PATCH changes. Nil fields
+// are left as they are.
+type JobTemplateUpdate struct {
+	Name                 *string `json:"name,omitempty"`
+	Description          *string `json:"description,omitempty"`
+	JobType              *string `json:"job_type,omitempty"`
+	Inventory            *int    `json:"inventory,omitempty"`
+	Project              *int    `json:"project,omitempty"`
+	Playbook             *string `json:"playbook,omitempty"`
+	ScmBranch            *string `json:"scm_branch,omitempty"`
+	Forks                *int    `json:"forks,omitempty"`
+	Limit                *string `json:"limit,omitempty"`
+	Verbosity            *int    `json:"verbosity,omitempty"`
+	ExtraVars            *string `json:"extra_vars,omitempty"` // JSON or YAML text, as AWX stores it
+	JobTags              *string `json:"job_tags,omitempty"`
+	SkipTags             *string `json:"skip_tags,omitempty"`
+	Timeout              *int    `json:"timeout,omitempty"`
+	JobSliceCount        *int    `json:"job_slice_count,omitempty"`
+	DiffMode             *bool   `json:"diff_mode,omitempty"`
+	ExecutionEnvironment *int    `json:"execution_environment,omitempty"`
+	AllowSimultaneous    *bool   `json:"allow_simultaneous,omitempty"`
+	SurveyEnabled        *bool   `json:"survey_enabled,omitempty"`
+
+	AskVariablesOnLaunch            *bool `json:"ask_variables_on_launch,omitempty"`
+	AskInventoryOnLaunch            *bool `json:"ask_inventory_on_launch,omitempty"`
+	AskCredentialOnLaunch           *bool `json:"ask_credential_on_launch,omitempty"`
+	AskLimitOnLaunch                *bool `json:"ask_limit_on_launch,omitempty"`
+	AskTagsOnLaunch                 *bool `json:"ask_tags_on_launch,omitempty"`
+	AskSkipTagsOnLaunch             *bool `json:"ask_skip_tags_on_launch,omitempty"`
+	AskJobTypeOnLaunch              *bool `json:"ask_job_type_on_launch,omitempty"`
+	AskVerbosityOnLaunch            *bool `json:"ask_verbosity_on_launch,omitempty"`
+	AskDiffModeOnLaunch             *bool `json:"ask_diff_mode_on_launch,omitempty"`
+	AskScmBranchOnLaunch            *bool `json:"ask_scm_branch_on_launch,omitempty"`
+	AskExecutionEnvironmentOnLaunch *bool `json:"ask_execution_environment_on_launch,omitempty"`
+	AskLabelsOnLaunch               *bool `json:"ask_labels_on_launch,omitempty"`
+	AskForksOnLaunch                *bool `json:"ask_forks_on_launch,omitempty"`
+	AskJobSliceCountOnLaunch        *bool `json:"ask_job_slice_count_on_launch,omitempty"`
+	AskTimeoutOnLaunch              *bool `json:"ask_timeout_on_launch,omitempty"`
+	AskInstanceGroupsOnLaunch       *bool `json:"ask_instance_groups_on_launch,omitempty"`
+}
+
+// askOnLaunchFields maps the prompt-on-launch flags of a job template to their fields in u
+func (u *JobTemplateUpdate) askOnLaunchFields() map[string]**bool {
+	return map[string]**bool{
+		"ask_variables_on_launch":             &u.AskVariablesOnLaunch,
+		"ask_inventory_on_launch":             &u.AskInventoryOnLaunch,
+		"ask_credential_on_launch":            &u.AskCredentialOnLaunch,
+		"ask_limit_on_launch":                 &u.AskLimitOnLaunch,
+		"ask_tags_on_launch":                  &u.AskTagsOnLaunch,
+		"ask_skip_tags_on_launch":             &u.AskSkipTagsOnLaunch,
+		"ask_job_type_on_launch":              &u.AskJobTypeOnLaunch,
+		"ask_verbosity_on_launch":             &u.AskVerbosityOnLaunch,
+		"ask_diff_mode_on_launch":             &u.AskDiffModeOnLaunch,
+		"ask_scm_branch_on_launch":            &u.AskScmBranchOnLaunch,
+		"ask_execution_environment_on_launch": &u.AskExecutionEnvironmentOnLaunch,
+		"ask_labels_on_launch":                &u.AskLabelsOnLaunch,
+		"ask_forks_on_launch":                 &u.AskForksOnLaunch,
+		"ask_job_slice_count_on_launch":       &u.AskJobSliceCountOnLaunch,
+		"ask_timeout_on_launch":               &u.AskTimeoutOnLaunch,
+		"ask_instance_groups_on_launch":       &u.AskInstanceGroupsOnLaunch,
+	}
+}
+
+// SetAskOnLaunch sets a prompt-on-launch flag by its AWX name, such as
+// ask_limit_on_launch. It reports false for unknown flags.
+func (u *JobTemplateUpdate) SetAskOnLaunch(flag string, value bool) bool {
+	field, ok := u.askOnLaunchFields()[flag]
+	if ok {
+		*field = &value
+	}
+	return ok
+}
+
+// AskOnLaunchFlags lists the AWX names of the prompt-on-launch flags in order
+func AskOnLaunchFlags() []string {
+	fields := (&JobTemplateUpdate{}).askOnLaunchFields()
+	flags := make([]string, 0, len(fields))
+	for flag := range fields {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+	return flags
+}
+
+// CopyCheck is the GET /copy/ view of a job template: whether the user may
+// copy it and which related resources the copy would lose
+type CopyCheck struct {
+	CanCopy                 bool     `json:"can_copy"`
+	CanCopyWithoutUserInput bool     `json:"can_copy_without_user_input"`
+	TemplatesUnableToCopy   []string `json:"templates_unable_to_copy"`
+	CredentialsUnableToCopy []string `json:"credentials_unable_to_copy"`
+	InventoriesUnableToCopy []string `json:"inventories_unable_to_copy"`
+}
+
+// UpdateJobTemplate changes the set fields of a job template
+func (c *Client) UpdateJobTemplate(ctx context.Context, templateID int, update JobTemplateUpdate) (*JobTemplate, error) {
+	var template JobTemplate
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/", templateID)
+	if err := c.makeRequest(ctx, "PATCH", endpoint, update, &template); err != nil {
+		return nil, fmt.Errorf("failed to update job template %d: %w", templateID, err)
+	}
+
+	c.cache.Delete(jobTemplatesCacheKey)
+
+	log.Printf("Successfully updated job template: %s (ID: %d)", template.Name, template.ID)
+	return &template, nil
+}
+
+// AssociateJobTemplateCredential adds a credential to a job template, or removes it when disassociate is set
+func (c *Client) AssociateJobTemplateCredential(ctx context.Context, templateID, credentialID int, disassociate bool) error {
+	body := map[string]interface{}{"id": credentialID}
+	if disassociate {
+		body["disassociate"] = true
+	}
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/credentials/", templateID)
+	if err := c.makeRequest(ctx, "POST", endpoint, body, nil); err != nil {
+		return fmt.Errorf("failed to change credential %d of job template %d: %w", credentialID, templateID, err)
+	}
+	return nil
+}
+
+// AssociateJobTemplateLabel adds a label to a job template, or removes it when disassociate is set
+func (c *Client) AssociateJobTemplateLabel(ctx context.Context, templateID, labelID int, disassociate bool) error {
+	body := map[string]interface{}{"id": labelID}
+	if disassociate {
+		body["disassociate"] = true
+	}
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/labels/", templateID)
+	if err := c.makeRequest(ctx, "POST", endpoint, body, nil); err != nil {
+		return fmt.Errorf("failed to change label %d of job template %d: %w", labelID, templateID, err)
+	}
+	return nil
+}
+
+// CreateJobTemplateLabel creates a label in the organization and adds it to a job template
+func (c *Client) CreateJobTemplateLabel(ctx context.Context, templateID, organizationID int, name string) error {
+	body := map[string]interface{}{"name": name, "organization": organizationID}
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/labels/", templateID)
+	if err := c.makeRequest(ctx, "POST", endpoint, body, nil); err != nil {
+		return fmt.Errorf("failed to add label '%s' to job template %d: %w", name, templateID, err)
+	}
+
+	c.forgetResolved(ResourceLabel)
+	return nil
+}
+
+// GetJobTemplateCopyCheck reports whether a job template can be copied
+func (c *Client) GetJobTemplateCopyCheck(ctx context.Context, templateID int) (*CopyCheck, error) {
+	var check CopyCheck
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/copy/", templateID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &check); err != nil {
+		return nil, err
+	}
+	return &check, nil
+}
+
+// CopyJobTemplate copies a job template, survey and related resources included, under a new name
+func (c *Client) CopyJobTemplate(ctx context.Context, templateID int, name string) (*JobTemplate, error) {
+	var template JobTemplate
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/copy/", templateID)
+	if err := c.makeRequest(ctx, "POST", endpoint, map[string]string{"name": name}, &template); err != nil {
+		return nil, fmt.Errorf("failed to copy job template %d: %w", templateID, err)
+	}
+
+	c.cache.Delete(jobTemplatesCacheKey)
+
+	log.Printf("Successfully copied job template %d to: %s (ID: %d)", templateID, template.Name, template.ID)
+	return &template, nil
+}
+
+// DeleteJobTemplate deletes a job template. AWX refuses while jobs of the template are running.
+func (c *Client) DeleteJobTemplate(ctx context.Context, templateID int) error {
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/", templateID)
+	err := c.makeRequest(ctx, "DELETE", endpoint, nil, nil)
+
+	// Drop the list even on failure: a 404 means the cached entry is already stale
+	c.cache.Delete(jobTemplatesCacheKey)
+
+	if err != nil {
+		return fmt.Errorf("failed to delete job template %d: %w", templateID, err)
+	}
+
+	log.Printf("Successfully deleted job template %d", templateID)
+	return nil
+}
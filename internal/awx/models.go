@@ -4,17 +4,14 @@ import "time"
 
 // AWX API Response Models
 
-type AuthResponse struct {
-	Token string `json:"token"`
-}
-
 type JobTemplate struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Inventory   int    `json:"inventory"`
-	Project     int    `json:"project"`
-	Playbook    string `json:"playbook"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Inventory    int    `json:"inventory"`
+	Project      int    `json:"project"`
+	Playbook     string `json:"playbook"`
+	Organization int    `json:"organization"`
 }
 
 type JobTemplateList struct {
@@ -22,12 +19,33 @@ type JobTemplateList struct {
 	Results []JobTemplate `json:"results"`
 }
 
-type LaunchJobRequest struct {
-	ExtraVars map[string]interface{} `json:"extra_vars,omitempty"`
-	Inventory int                    `json:"inventory,omitempty"`
-	Limit     string                 `json:"limit,omitempty"`
-	Tags      string                 `json:"job_tags,omitempty"`
-	SkipTags  string                 `json:"skip_tags,omitempty"`
+// LaunchRequirements is the GET /launch/ view of a job template: which fields
+// it prompts for on launch and what it still needs before it can start
+type LaunchRequirements struct {
+	AskVariablesOnLaunch            bool `json:"ask_variables_on_launch"`
+	AskInventoryOnLaunch            bool `json:"ask_inventory_on_launch"`
+	AskCredentialOnLaunch           bool `json:"ask_credential_on_launch"`
+	AskLimitOnLaunch                bool `json:"ask_limit_on_launch"`
+	AskTagsOnLaunch                 bool `json:"ask_tags_on_launch"`
+	AskSkipTagsOnLaunch             bool `json:"ask_skip_tags_on_launch"`
+	AskJobTypeOnLaunch              bool `json:"ask_job_type_on_launch"`
+	AskVerbosityOnLaunch            bool `json:"ask_verbosity_on_launch"`
+	AskDiffModeOnLaunch             bool `json:"ask_diff_mode_on_launch"`
+	AskScmBranchOnLaunch            bool `json:"ask_scm_branch_on_launch"`
+	AskExecutionEnvironmentOnLaunch bool `json:"ask_execution_environment_on_launch"`
+	AskLabelsOnLaunch               bool `json:"ask_labels_on_launch"`
+	AskForksOnLaunch                bool `json:"ask_forks_on_launch"`
+	AskJobSliceCountOnLaunch        bool `json:"ask_job_slice_count_on_launch"`
+	AskTimeoutOnLaunch              bool `json:"ask_timeout_on_launch"`
+	AskInstanceGroupsOnLaunch       bool `json:"ask_instance_groups_on_launch"`
+
+	SurveyEnabled            bool                   `json:"survey_enabled"`
+	CanStartWithoutUserInput bool                   `json:"can_start_without_user_input"`
+	VariablesNeededToStart   []string               `json:"variables_needed_to_start"`
+	PasswordsNeededToStart   []string               `json:"passwords_needed_to_start"`
+	InventoryNeededToStart   bool                   `json:"inventory_needed_to_start"`
+	CredentialNeededToStart  bool                   `json:"credential_needed_to_start"`
+	Defaults                 map[string]interface{} `json:"defaults"`
 }
 
 type Job struct {
@@ -40,6 +58,38 @@ type Job struct {
 	JobTemplate     int                    `json:"job_template"`
 	PlaybookResults map[string]interface{} `json:"job_events,omitempty"`
 	URL             string                 `json:"url"`
+	SummaryFields   JobSummaryFields       `json:"summary_fields"`
+}
+
+// JobSummaryFields holds the names AWX embeds in a job next to the related IDs
+type JobSummaryFields struct {
+	JobTemplate *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"job_template"`
+}
+
+// IsFinished reports whether the job reached a terminal state
+func (j Job) IsFinished() bool {
+	return IsFinishedStatus(j.Status)
+}
+
+// TemplateName returns the name of the job's template, or the job name,
+// which AWX copies from the template, once the template is deleted
+func (j Job) TemplateName() string {
+	if j.SummaryFields.JobTemplate != nil && j.SummaryFields.JobTemplate.Name != "" {
+		return j.SummaryFields.JobTemplate.Name
+	}
+	return j.Name
+}
+
+// IsFinishedStatus reports whether an AWX unified job status is terminal
+func IsFinishedStatus(status string) bool {
+	switch status {
+	case "successful", "failed", "error", "canceled":
+		return true
+	}
+	return false
 }
 
 type JobLaunchResponse struct {
@@ -51,10 +101,6 @@ type JobLaunchResponse struct {
 	RelatedJobTemplate string `json:"related_job_template"`
 }
 
-type ErrorResponse struct {
-	Detail string `json:"detail"`
-}
-
 type Inventory struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
@@ -65,13 +111,19 @@ type Inventory struct {
 }
 
 type Project struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	SCMType     string `json:"scm_type"`
-	SCMURL      string `json:"scm_url"`
-	SCMBranch   string `json:"scm_branch"`
+	ID                int        `json:"id"`
+	Name              string     `json:"name"`
+	Description       string     `json:"description"`
+	Status            string     `json:"status"`
+	SCMType           string     `json:"scm_type"`
+	SCMURL            string     `json:"scm_url"`
+	SCMBranch         string     `json:"scm_branch"`
+	SCMRevision       string     `json:"scm_revision"`
+	Credential        *int       `json:"credential"`
+	Organization      int        `json:"organization"`
+	SCMUpdateOnLaunch bool       `json:"scm_update_on_launch"`
+	AllowOverride     bool       `json:"allow_override"`
+	LastUpdated       *time.Time `json:"last_updated"`
 }
 
 type CreateJobTemplateRequest struct {
@@ -83,3 +135,70 @@ type CreateJobTemplateRequest struct {
 	Playbook    string `json:"playbook"`
 	Verbosity   int    `json:"verbosity,omitempty"`
 }
+
+// Workflow models
+
+type WorkflowJobTemplate struct {
+	ID                   int    `json:"id"`
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+	Inventory            *int   `json:"inventory"`
+	Status               string `json:"status"`
+	AskVariablesOnLaunch bool   `json:"ask_variables_on_launch"`
+	AskInventoryOnLaunch bool   `json:"ask_inventory_on_launch"`
+	AskLimitOnLaunch     bool   `json:"ask_limit_on_launch"`
+}
+
+type WorkflowJobLaunchResponse struct {
+	WorkflowJob   int                    `json:"workflow_job"`
+	ID            int                    `json:"id"`
+	IgnoredFields map[string]interface{} `json:"ignored_fields"`
+	URL           string                 `json:"url"`
+}
+
+type WorkflowJob struct {
+	ID                  int        `json:"id"`
+	Name                string     `json:"name"`
+	Status              string     `json:"status"`
+	Failed              bool       `json:"failed"`
+	Started             *time.Time `json:"started"`
+	Finished            *time.Time `json:"finished"`
+	Elapsed             float64    `json:"elapsed"`
+	WorkflowJobTemplate int        `json:"workflow_job_template"`
+	URL                 string     `json:"url"`
+}
+
+type WorkflowJobNode struct {
+	ID                 int    `json:"id"`
+	Identifier         string `json:"identifier"`
+	Job                *int   `json:"job"`
+	UnifiedJobTemplate *int   `json:"unified_job_template"`
+	SuccessNodes       []int  `json:"success_nodes"`
+	FailureNodes       []int  `json:"failure_nodes"`
+	AlwaysNodes        []int  `json:"always_nodes"`
+	DoNotRun           bool   `json:"do_not_run"`
+	SummaryFields      struct {
+		Job *struct {
+			ID      int     `json:"id"`
+			Name    string  `json:"name"`
+			Status  string  `json:"status"`
+			Failed  bool    `json:"failed"`
+			Elapsed float64 `json:"elapsed"`
+			Type    string  `json:"type"`
+		} `json:"job"`
+		UnifiedJobTemplate *struct {
+			ID             int    `json:"id"`
+			Name           string `json:"name"`
+			UnifiedJobType string `json:"unified_job_type"`
+		} `json:"unified_job_template"`
+	} `json:"summary_fields"`
+}
+
+type WorkflowApproval struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Created     *time.Time `json:"created"`
+	TimedOut    bool       `json:"timed_out"`
+}
@@ -0,0 +1,199 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultPageSize is the largest page_size AWX accepts by default
+	defaultPageSize = 200
+	// defaultMaxListItems caps how many items a single list call will collect
+	defaultMaxListItems = 5000
+)
+
+// ListOptions controls how paginated AWX list endpoints are walked
+type ListOptions struct {
+	PageSize int        // Items requested per page (page_size)
+	MaxItems int        // Safety cap on the total number of items collected
+	Query    url.Values // Additional filters (status, order_by, ...)
+}
+
+// ListResult holds the collected items of a list call together with the
+// total count reported by AWX, which may exceed len(Results) when the
+// MaxItems cap was hit
+type ListResult[T any] struct {
+	Count     int
+	Results   []T
+	Truncated bool
+}
+
+// clone returns a copy whose Results can be changed without touching the
+// original, so lists shared through the cache stay intact
+func (r *ListResult[T]) clone() *ListResult[T] {
+	copied := *r
+	copied.Results = append([]T(nil), r.Results...)
+	return &copied
+}
+
+// page mirrors the envelope AWX wraps around every list response
+type page[T any] struct {
+	Count    int     `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []T     `json:"results"`
+}
+
+// PageIterator walks a paginated AWX list endpoint item by item, following
+// the `next` links returned by the API until the last page or MaxItems
+type PageIterator[T any] struct {
+	client   *Client
+	next     string
+	maxItems int
+	buf      []T
+	current  T
+	count    int
+	seen     int
+	err      error
+}
+
+// NewPageIterator creates an iterator over endpoint using the given options.
+// Zero values in opts fall back to the client defaults.
+func NewPageIterator[T any](c *Client, endpoint string, opts ListOptions) *PageIterator[T] {
+	if opts.PageSize <= 0 {
+		opts.PageSize = c.pageSize
+	}
+	if opts.MaxItems <= 0 {
+		opts.MaxItems = c.maxListItems
+	}
+
+	query := url.Values{}
+	base := endpoint
+	if i := strings.Index(endpoint, "?"); i >= 0 {
+		base = endpoint[:i]
+		if parsed, err := url.ParseQuery(endpoint[i+1:]); err == nil {
+			query = parsed
+		}
+	}
+	for key, values := range opts.Query {
+		for _, v := range values {
+			query.Add(key, v)
+		}
+	}
+	// Never ask for more than we are allowed to keep
+	pageSize := opts.PageSize
+	if pageSize > opts.MaxItems {
+		pageSize = opts.MaxItems
+	}
+	query.Set("page_size", strconv.Itoa(pageSize))
+
+	return &PageIterator[T]{
+		client:   c,
+		next:     base + "?" + query.Encode(),
+		maxItems: opts.MaxItems,
+	}
+}
+
+// Next advances to the next item, fetching another page when needed.
+// It returns false once the listing is exhausted, the cap is reached or an
+// error occurred (see Err).
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil || it.seen >= it.maxItems {
+		return false
+	}
+
+	for len(it.buf) == 0 {
+		if it.next == "" {
+			return false
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.current = it.buf[0]
+	it.buf = it.buf[1:]
+	it.seen++
+	return true
+}
+
+// Item returns the current item
+func (it *PageIterator[T]) Item() T {
+	return it.current
+}
+
+// Err returns the first error encountered while paging
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// Count returns the total number of items AWX reports for the listing.
+// It is only meaningful after the first call to Next.
+func (it *PageIterator[T]) Count() int {
+	return it.count
+}
+
+// Truncated reports whether items were left behind because of MaxItems
+func (it *PageIterator[T]) Truncated() bool {
+	return it.seen >= it.maxItems && it.count > it.seen
+}
+
+func (it *PageIterator[T]) fetch(ctx context.Context) error {
+	var response page[T]
+	if err := it.client.makeRequest(ctx, "GET", it.next, nil, &response); err != nil {
+		return err
+	}
+
+	it.count = response.Count
+	it.buf = response.Results
+	it.next = ""
+	if response.Next != nil && *response.Next != "" {
+		next, err := it.client.relativeURL(*response.Next)
+		if err != nil {
+			return fmt.Errorf("invalid next page link %q: %w", *response.Next, err)
+		}
+		it.next = next
+	}
+	return nil
+}
+
+// listAll drains a PageIterator into a ListResult
+func listAll[T any](ctx context.Context, c *Client, endpoint string, opts ListOptions) (*ListResult[T], error) {
+	it := NewPageIterator[T](c, endpoint, opts)
+
+	result := &ListResult[T]{}
+	for it.Next(ctx) {
+		result.Results = append(result.Results, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	result.Count = it.Count()
+	result.Truncated = it.Truncated()
+	if result.Truncated {
+		log.Printf("AWX list %s truncated at %d of %d items", endpoint, len(result.Results), result.Count)
+	}
+	return result, nil
+}
+
+// relativeURL turns a link returned by AWX (absolute or path-only) into an
+// endpoint that can be handed to makeRequest
+func (c *Client) relativeURL(link string) (string, error) {
+	if strings.HasPrefix(link, "/") {
+		return link, nil
+	}
+	if strings.HasPrefix(link, c.baseURL) {
+		return strings.TrimPrefix(link, c.baseURL), nil
+	}
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+	return parsed.RequestURI(), nil
+}
@@ -0,0 +1,188 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestClient returns a client talking to an httptest server running handler
+func newTestClient(t *testing.T, handler http.Handler, config ClientConfig) (*Client, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config.BaseURL = server.URL
+	if config.Token == "" {
+		config.Token = "test-token"
+	}
+	return NewClient(config), server
+}
+
+// writeJSON answers a test request with value encoded as JSON
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, value interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		t.Errorf("encode response: %v", err)
+	}
+}
+
+// pagedTemplates serves total job templates in pages of the requested
+// page_size, with next links in the style selected by absolute
+func pagedTemplates(t *testing.T, total int, absolute bool, requests *int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.URL.Path != "/api/v2/job_templates/" {
+			http.NotFound(w, r)
+			return
+		}
+		size, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+		number, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if number == 0 {
+			number = 1
+		}
+
+		response := map[string]interface{}{"count": total}
+		var results []JobTemplate
+		for id := (number-1)*size + 1; id <= total && id <= number*size; id++ {
+			results = append(results, JobTemplate{ID: id, Name: fmt.Sprintf("template-%d", id)})
+		}
+		response["results"] = results
+		if number*size < total {
+			next := fmt.Sprintf("/api/v2/job_templates/?page=%d&page_size=%d", number+1, size)
+			if absolute {
+				next = "http://" + r.Host + next
+			}
+			response["next"] = next
+		}
+		writeJSON(t, w, http.StatusOK, response)
+	})
+}
+
+func TestListAllFollowsNextLinks(t *testing.T) {
+	for _, absolute := range []bool{false, true} {
+		t.Run(fmt.Sprintf("absolute=%t", absolute), func(t *testing.T) {
+			var requests int32
+			client, _ := newTestClient(t, pagedTemplates(t, 7, absolute, &requests), ClientConfig{PageSize: 3})
+
+			list, err := listAll[JobTemplate](context.Background(), client, "/api/v2/job_templates/", ListOptions{})
+			if err != nil {
+				t.Fatalf("listAll: %v", err)
+			}
+			if list.Count != 7 || len(list.Results) != 7 || list.Truncated {
+				t.Fatalf("got count %d, %d results, truncated %t; want 7, 7, false", list.Count, len(list.Results), list.Truncated)
+			}
+			for i, template := range list.Results {
+				if template.ID != i+1 {
+					t.Errorf("result %d has ID %d", i, template.ID)
+				}
+			}
+			if requests != 3 {
+				t.Errorf("made %d requests, want 3", requests)
+			}
+		})
+	}
+}
+
+func TestListAllStopsAtMaxItems(t *testing.T) {
+	var requests int32
+	client, _ := newTestClient(t, pagedTemplates(t, 10, false, &requests), ClientConfig{PageSize: 3})
+
+	list, err := listAll[JobTemplate](context.Background(), client, "/api/v2/job_templates/", ListOptions{MaxItems: 5})
+	if err != nil {
+		t.Fatalf("listAll: %v", err)
+	}
+	if list.Count != 10 || len(list.Results) != 5 || !list.Truncated {
+		t.Fatalf("got count %d, %d results, truncated %t; want 10, 5, true", list.Count, len(list.Results), list.Truncated)
+	}
+	if requests != 2 {
+		t.Errorf("made %d requests, want 2", requests)
+	}
+}
+
+func TestListAllNotTruncatedWhenCapMatchesCount(t *testing.T) {
+	var requests int32
+	client, _ := newTestClient(t, pagedTemplates(t, 4, false, &requests), ClientConfig{PageSize: 2})
+
+	list, err := listAll[JobTemplate](context.Background(), client, "/api/v2/job_templates/", ListOptions{MaxItems: 4})
+	if err != nil {
+		t.Fatalf("listAll: %v", err)
+	}
+	if len(list.Results) != 4 || list.Truncated {
+		t.Fatalf("got %d results, truncated %t; want 4, false", len(list.Results), list.Truncated)
+	}
+}
+
+func TestListAllCapsPageSize(t *testing.T) {
+	var pageSize string
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pageSize = r.URL.Query().Get("page_size")
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 0, "results": []JobTemplate{}})
+	}), ClientConfig{PageSize: 200})
+
+	if _, err := listAll[JobTemplate](context.Background(), client, "/api/v2/job_templates/?name=x", ListOptions{MaxItems: 20}); err != nil {
+		t.Fatalf("listAll: %v", err)
+	}
+	if pageSize != "20" {
+		t.Errorf("page_size = %q, want 20", pageSize)
+	}
+}
+
+func TestRelativeURL(t *testing.T) {
+	client := NewClient(ClientConfig{BaseURL: "https://awx.example.com/"})
+	tests := []struct {
+		link string
+		want string
+	}{
+		{"/api/v2/jobs/?page=2", "/api/v2/jobs/?page=2"},
+		{"https://awx.example.com/api/v2/jobs/?page=2", "/api/v2/jobs/?page=2"},
+		{"http://awx-internal:8052/api/v2/jobs/?page=3&page_size=50", "/api/v2/jobs/?page=3&page_size=50"},
+	}
+	for _, tt := range tests {
+		got, err := client.relativeURL(tt.link)
+		if err != nil {
+			t.Errorf("relativeURL(%q): %v", tt.link, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("relativeURL(%q) = %q, want %q", tt.link, got, tt.want)
+		}
+	}
+}
+
+func TestCachedListsAreCopies(t *testing.T) {
+	var requests int32
+	client, _ := newTestClient(t, pagedTemplates(t, 3, false, &requests), ClientConfig{})
+	ctx := context.Background()
+
+	first, err := client.GetJobTemplateList(ctx)
+	if err != nil {
+		t.Fatalf("GetJobTemplateList: %v", err)
+	}
+	first.Results[0].Name = "changed"
+	first.Results = first.Results[:1]
+
+	second, err := client.GetJobTemplateList(ctx)
+	if err != nil {
+		t.Fatalf("GetJobTemplateList: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests, want 1 (second call cached)", requests)
+	}
+	if len(second.Results) != 3 || second.Results[0].Name != "template-1" {
+		t.Errorf("cached list was changed by a caller: %+v", second.Results)
+	}
+
+	second.Results[1].Name = "changed"
+	third, _ := client.GetJobTemplateList(ctx)
+	if third.Results[1].Name != "template-2" {
+		t.Errorf("cached list was changed through a cache hit")
+	}
+}
@@ -0,0 +1,149 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// projectsCacheKey holds the cached project list, dropped on every change
+const projectsCacheKey = "awx:projects"
+
+// ProjectRequest creates a project. An empty ScmType makes a manual project
+// whose playbooks live on the AWX servers.
+type ProjectRequest struct {
+	Name              string `json:"name"`
+	Description       string `json:"description,omitempty"`
+	Organization      int    `json:"organization"`
+	ScmType           string `json:"scm_type"`
+	ScmURL            string `json:"scm_url,omitempty"`
+	ScmBranch         string `json:"scm_branch,omitempty"`
+	Credential        *int   `json:"credential,omitempty"`
+	ScmUpdateOnLaunch bool   `json:"scm_update_on_launch"`
+	ScmClean          bool   `json:"scm_clean,omitempty"`
+	AllowOverride     bool   `json:"allow_override,omitempty"`
+}
+
+// ProjectUpdate holds the project fields a PATCH changes. Nil fields are left as they are.
+type ProjectUpdate struct {
+	Name              *string `json:"name,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	ScmType           *string `json:"scm_type,omitempty"`
+	ScmURL            *string `json:"scm_url,omitempty"`
+	ScmBranch         *string `json:"scm_branch,omitempty"`
+	Credential        *int    `json:"credential,omitempty"`
+	ScmUpdateOnLaunch *bool   `json:"scm_update_on_launch,omitempty"`
+	ScmClean          *bool   `json:"scm_clean,omitempty"`
+	AllowOverride     *bool   `json:"allow_override,omitempty"`
+}
+
+// ProjectSync is one SCM update run of a project, a project_update in AWX terms
+type ProjectSync struct {
+	ID             int        `json:"id"`
+	Name           string     `json:"name"`
+	Status         string     `json:"status"`
+	Failed         bool       `json:"failed"`
+	Started        *time.Time `json:"started"`
+	Finished       *time.Time `json:"finished"`
+	Elapsed        float64    `json:"elapsed"`
+	Project        int        `json:"project"`
+	ScmRevision    string     `json:"scm_revision"`
+	JobExplanation string     `json:"job_explanation"`
+}
+
+// IsFinished reports whether the sync reached a terminal state
+func (p ProjectSync) IsFinished() bool {
+	return IsFinishedStatus(p.Status)
+}
+
+// GetProject returns a project by ID, bypassing the list cache
+func (c *Client) GetProject(ctx context.Context, projectID int) (*Project, error) {
+	var project Project
+	endpoint := fmt.Sprintf("/api/v2/projects/%d/", projectID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// CreateProject creates a project. AWX starts an SCM update of new SCM projects by itself.
+func (c *Client) CreateProject(ctx context.Context, request ProjectRequest) (*Project, error) {
+	var project Project
+	if err := c.makeRequest(ctx, "POST", "/api/v2/projects/", request, &project); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	c.cache.Delete(projectsCacheKey)
+	c.forgetResolved(ResourceProject)
+
+	log.Printf("Successfully created project: %s (ID: %d)", project.Name, project.ID)
+	return &project, nil
+}
+
+// UpdateProject changes the set fields of a project
+func (c *Client) UpdateProject(ctx context.Context, projectID int, update ProjectUpdate) (*Project, error) {
+	var project Project
+	endpoint := fmt.Sprintf("/api/v2/projects/%d/", projectID)
+	if err := c.makeRequest(ctx, "PATCH", endpoint, update, &project); err != nil {
+		return nil, fmt.Errorf("failed to update project %d: %w", projectID, err)
+	}
+
+	c.cache.Delete(projectsCacheKey)
+	c.forgetResolved(ResourceProject)
+
+	log.Printf("Successfully updated project: %s (ID: %d)", project.Name, project.ID)
+	return &project, nil
+}
+
+// SyncProject starts an SCM update of a project so AWX picks up pushed changes
+func (c *Client) SyncProject(ctx context.Context, projectID int) (*ProjectSync, error) {
+	var response struct {
+		ID            int    `json:"id"`
+		ProjectUpdate int    `json:"project_update"`
+		Status        string `json:"status"`
+	}
+	endpoint := fmt.Sprintf("/api/v2/projects/%d/update/", projectID)
+	if err := c.makeRequest(ctx, "POST", endpoint, map[string]interface{}{}, &response); err != nil {
+		return nil, fmt.Errorf("failed to sync project %d: %w", projectID, err)
+	}
+
+	syncID := response.ProjectUpdate
+	if syncID == 0 {
+		syncID = response.ID
+	}
+	status := response.Status
+	if status == "" {
+		status = "pending"
+	}
+
+	// The cached list holds the project status and revision
+	c.cache.Delete(projectsCacheKey)
+
+	log.Printf("Started sync %d of project %d", syncID, projectID)
+	return &ProjectSync{ID: syncID, Status: status, Project: projectID}, nil
+}
+
+// GetProjectSync returns the state of a project SCM update
+func (c *Client) GetProjectSync(ctx context.Context, syncID int) (*ProjectSync, error) {
+	var sync ProjectSync
+	endpoint := fmt.Sprintf("/api/v2/project_updates/%d/", syncID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &sync); err != nil {
+		return nil, err
+	}
+	if sync.IsFinished() {
+		// A finished sync changed the project status and revision
+		c.cache.Delete(projectsCacheKey)
+	}
+	return &sync, nil
+}
+
+// GetProjectPlaybooks lists the playbooks AWX found in the last sync of a project
+func (c *Client) GetProjectPlaybooks(ctx context.Context, projectID int) ([]string, error) {
+	var playbooks []string
+	endpoint := fmt.Sprintf("/api/v2/projects/%d/playbooks/", projectID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &playbooks); err != nil {
+		return nil, fmt.Errorf("failed to list playbooks of project %d: %w", projectID, err)
+	}
+	return playbooks, nil
+}
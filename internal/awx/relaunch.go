@@ -0,0 +1,127 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+)
+
+// Relaunch host selections
+const (
+	RelaunchAllHosts    = "all"
+	RelaunchFailedHosts = "failed"
+)
+
+// encryptedValue is what AWX shows in place of secret values, such as survey password answers
+const encryptedValue = "$encrypted$"
+
+// RelaunchRequirements tells what a relaunch of a job needs and how many
+// hosts each host selection would run on
+type RelaunchRequirements struct {
+	PasswordsNeededToStart []string       `json:"passwords_needed_to_start"`
+	RetryCounts            map[string]int `json:"retry_counts"`
+}
+
+// JobLaunchConfig holds the launch-time fields a job ran with. AWX records
+// only the instance group a job ran in, not the list it was launched with.
+type JobLaunchConfig struct {
+	ID                   int    `json:"id"`
+	Name                 string `json:"name"`
+	Status               string `json:"status"`
+	JobTemplate          int    `json:"job_template"`
+	Inventory            int    `json:"inventory"`
+	Limit                string `json:"limit"`
+	ExtraVars            string `json:"extra_vars"`
+	JobTags              string `json:"job_tags"`
+	SkipTags             string `json:"skip_tags"`
+	JobType              string `json:"job_type"`
+	Verbosity            int    `json:"verbosity"`
+	DiffMode             bool   `json:"diff_mode"`
+	ScmBranch            string `json:"scm_branch"`
+	Forks                int    `json:"forks"`
+	Timeout              int    `json:"timeout"`
+	JobSliceCount        int    `json:"job_slice_count"`
+	ExecutionEnvironment int    `json:"execution_environment"`
+	InstanceGroup        int    `json:"instance_group"`
+	LaunchType           string `json:"launch_type"`
+
+	SummaryFields struct {
+		Credentials []struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"credentials"`
+		Labels struct {
+			Results []struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			} `json:"results"`
+		} `json:"labels"`
+	} `json:"summary_fields"`
+}
+
+// Vars returns the extra_vars of the job. Secrets AWX masks cannot be sent
+// back, so they are left out and their names returned.
+func (j JobLaunchConfig) Vars() (map[string]interface{}, []string, error) {
+	vars, err := ParseExtraVars(j.ExtraVars)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read extra_vars of job %d: %w", j.ID, err)
+	}
+	var masked []string
+	for name, value := range vars {
+		if value == encryptedValue {
+			masked = append(masked, name)
+			delete(vars, name)
+		}
+	}
+	return vars, masked, nil
+}
+
+// GetJobLaunchConfig returns the launch-time fields of a playbook job
+func (c *Client) GetJobLaunchConfig(ctx context.Context, jobID int) (*JobLaunchConfig, error) {
+	var config JobLaunchConfig
+	endpoint := fmt.Sprintf("/api/v2/jobs/%d/", jobID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// IsAdHocCommand reports whether an ID belongs to an ad hoc command rather than a job
+func (c *Client) IsAdHocCommand(ctx context.Context, jobID int) (bool, error) {
+	path, err := c.jobPath(ctx, jobID)
+	if err != nil {
+		return false, err
+	}
+	return isAdHocPath(path), nil
+}
+
+// GetRelaunchRequirements returns what a relaunch of a job needs
+func (c *Client) GetRelaunchRequirements(ctx context.Context, jobID int) (*RelaunchRequirements, error) {
+	var requirements RelaunchRequirements
+	endpoint := fmt.Sprintf("/api/v2/jobs/%d/relaunch/", jobID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &requirements); err != nil {
+		return nil, err
+	}
+	return &requirements, nil
+}
+
+// RelaunchJob runs a job again with the same launch-time fields, on all its
+// hosts or only on those that failed, and returns the new job ID
+func (c *Client) RelaunchJob(ctx context.Context, jobID int, hosts string, passwords map[string]string) (int, error) {
+	if passwords == nil {
+		passwords = map[string]string{}
+	}
+	body := map[string]interface{}{
+		"hosts":                hosts,
+		"credential_passwords": passwords,
+	}
+
+	var response JobLaunchResponse
+	endpoint := fmt.Sprintf("/api/v2/jobs/%d/relaunch/", jobID)
+	if err := c.makeRequest(ctx, "POST", endpoint, body, &response); err != nil {
+		return 0, err
+	}
+	if response.Job != 0 {
+		return response.Job, nil
+	}
+	return response.ID, nil
+}
@@ -0,0 +1,195 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResourceType is a kind of AWX resource that tools may reference by name or ID
+type ResourceType string
+
+const (
+	ResourceInventory            ResourceType = "inventory"
+	ResourceProject              ResourceType = "project"
+	ResourceCredential           ResourceType = "credential"
+	ResourceOrganization         ResourceType = "organization"
+	ResourceExecutionEnvironment ResourceType = "execution environment"
+	ResourceLabel                ResourceType = "label"
+	ResourceInstanceGroup        ResourceType = "instance group"
+	ResourceSchedule             ResourceType = "schedule"
+
+	// Hosts and groups are only unique within an inventory; see GetHost and GetGroup
+	ResourceHost  ResourceType = "host"
+	ResourceGroup ResourceType = "group"
+)
+
+var resourceEndpoints = map[ResourceType]string{
+	ResourceInventory:            "/api/v2/inventories/",
+	ResourceProject:              "/api/v2/projects/",
+	ResourceCredential:           "/api/v2/credentials/",
+	ResourceOrganization:         "/api/v2/organizations/",
+	ResourceExecutionEnvironment: "/api/v2/execution_environments/",
+	ResourceLabel:                "/api/v2/labels/",
+	ResourceInstanceGroup:        "/api/v2/instance_groups/",
+	ResourceSchedule:             "/api/v2/schedules/",
+}
+
+const (
+	// resolvedTTL is how long a resolved name is trusted before AWX is asked again
+	resolvedTTL = 5 * time.Minute
+	// maxCandidates caps how many matches a resolve error lists
+	maxCandidates = 10
+)
+
+// ResourceCandidate is a resource a reference may have meant
+type ResourceCandidate struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Organization string `json:"organization,omitempty"`
+}
+
+func (c ResourceCandidate) String() string {
+	if c.Organization != "" {
+		return fmt.Sprintf("'%s' (ID: %d, organization %s)", c.Name, c.ID, c.Organization)
+	}
+	return fmt.Sprintf("'%s' (ID: %d)", c.Name, c.ID)
+}
+
+// ResolveError reports a reference that does not name exactly one resource.
+// Ambiguous references list their matches; unknown ones list similar names.
+type ResolveError struct {
+	Type       ResourceType
+	Reference  string
+	Matches    int // Resources named exactly Reference, more than listed when over maxCandidates
+	Candidates []ResourceCandidate
+}
+
+func (e *ResolveError) Error() string {
+	candidates := make([]string, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		candidates[i] = candidate.String()
+	}
+
+	if e.Matches > 1 {
+		plural := string(e.Type) + "s"
+		if strings.HasSuffix(plural, "ys") {
+			plural = strings.TrimSuffix(plural, "ys") + "ies"
+		}
+		return fmt.Sprintf("%d %s are named '%s', use the ID of one of: %s",
+			e.Matches, plural, e.Reference, strings.Join(candidates, ", "))
+	}
+	if len(candidates) > 0 {
+		return fmt.Sprintf("%s '%s' not found. Similar names: %s", e.Type, e.Reference, strings.Join(candidates, ", "))
+	}
+	return fmt.Sprintf("%s '%s' not found", e.Type, e.Reference)
+}
+
+// candidateResult is the part of a list item needed to tell candidates apart
+type candidateResult struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	SummaryFields struct {
+		Organization struct {
+			Name string `json:"name"`
+		} `json:"organization"`
+	} `json:"summary_fields"`
+}
+
+func (r candidateResult) candidate() ResourceCandidate {
+	return ResourceCandidate{ID: r.ID, Name: r.Name, Organization: r.SummaryFields.Organization.Name}
+}
+
+// ResolveID turns a resource name or ID into its ID. Numeric references are
+// taken as IDs and left for AWX to check; names must match exactly one
+// resource. Resolved names are cached for a few minutes.
+func (c *Client) ResolveID(ctx context.Context, resourceType ResourceType, nameOrID string) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+	if nameOrID == "" {
+		return 0, fmt.Errorf("%s name or ID is required", resourceType)
+	}
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		return id, nil
+	}
+
+	endpoint, ok := resourceEndpoints[resourceType]
+	if !ok {
+		return 0, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+
+	cacheKey := resolveCacheKeyPrefix(resourceType) + nameOrID
+	if cached, ok := c.cache.Get(cacheKey); ok {
+		if id, ok := cached.(int); ok {
+			return id, nil
+		}
+	}
+
+	var matches page[candidateResult]
+	query := url.Values{"name": {nameOrID}, "page_size": {strconv.Itoa(maxCandidates)}, "order_by": {"id"}}
+	if err := c.makeRequest(ctx, "GET", endpoint+"?"+query.Encode(), nil, &matches); err != nil {
+		return 0, fmt.Errorf("failed to look up %s '%s': %w", resourceType, nameOrID, err)
+	}
+
+	switch matches.Count {
+	case 1:
+		id := matches.Results[0].ID
+		c.cache.Set(cacheKey, id, resolvedTTL)
+		if c.debug {
+			log.Printf("Resolved %s '%s' to ID %d", resourceType, nameOrID, id)
+		}
+		return id, nil
+	case 0:
+		return 0, &ResolveError{Type: resourceType, Reference: nameOrID, Candidates: c.similarResources(ctx, endpoint, nameOrID)}
+	default:
+		candidates := make([]ResourceCandidate, len(matches.Results))
+		for i, match := range matches.Results {
+			candidates[i] = match.candidate()
+		}
+		return 0, &ResolveError{Type: resourceType, Reference: nameOrID, Matches: matches.Count, Candidates: candidates}
+	}
+}
+
+// resolveCacheKeyPrefix starts the cache keys of the resolved names of a resource type
+func resolveCacheKeyPrefix(resourceType ResourceType) string {
+	return fmt.Sprintf("awx:resolve:%s:", resourceType)
+}
+
+// forgetResolved drops the resolved names of a resource type, since a
+// created, renamed or deleted resource can change what a name refers to
+func (c *Client) forgetResolved(resourceType ResourceType) {
+	c.cache.DeletePrefix(resolveCacheKeyPrefix(resourceType))
+}
+
+// ResolveIDs resolves several references of one type, stopping at the first failure
+func (c *Client) ResolveIDs(ctx context.Context, resourceType ResourceType, namesOrIDs []string) ([]int, error) {
+	ids := make([]int, 0, len(namesOrIDs))
+	for _, nameOrID := range namesOrIDs {
+		id, err := c.ResolveID(ctx, resourceType, nameOrID)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// similarResources finds resources whose name contains the reference, to
+// suggest in a not-found error. Failures only cost the suggestions.
+func (c *Client) similarResources(ctx context.Context, endpoint, reference string) []ResourceCandidate {
+	var matches page[candidateResult]
+	query := url.Values{"name__icontains": {reference}, "page_size": {"5"}, "order_by": {"name"}}
+	if err := c.makeRequest(ctx, "GET", endpoint+"?"+query.Encode(), nil, &matches); err != nil {
+		log.Printf("Failed to look up names similar to '%s': %v", reference, err)
+		return nil
+	}
+
+	candidates := make([]ResourceCandidate, len(matches.Results))
+	for i, match := range matches.Results {
+		candidates[i] = match.candidate()
+	}
+	return candidates
+}
@@ -0,0 +1,118 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// projectNames serves project lookups: "web" names one project, "app" twelve
+// and name__icontains searches find "web" and "webhooks"
+func projectNames(t *testing.T, webID *int32, lookups *int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v2/projects/5/":
+			writeJSON(t, w, http.StatusOK, Project{ID: 5, Name: "web"})
+		case r.URL.Path != "/api/v2/projects/":
+			http.NotFound(w, r)
+		case query.Get("name__icontains") == "we":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 2, "results": []map[string]interface{}{
+				{"id": 5, "name": "web", "summary_fields": map[string]interface{}{"organization": map[string]string{"name": "ops"}}},
+				{"id": 6, "name": "webhooks"},
+			}})
+		case query.Has("name__icontains"):
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 0, "results": []candidateResult{}})
+		case query.Get("name") == "web":
+			atomic.AddInt32(lookups, 1)
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 1, "results": []candidateResult{{ID: int(atomic.LoadInt32(webID)), Name: "web"}}})
+		case query.Get("name") == "app":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 12, "results": []map[string]interface{}{
+				{"id": 2, "name": "app", "summary_fields": map[string]interface{}{"organization": map[string]string{"name": "ops"}}},
+				{"id": 3, "name": "app", "summary_fields": map[string]interface{}{"organization": map[string]string{"name": "dev"}}},
+			}})
+		default:
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 0, "results": []candidateResult{}})
+		}
+	})
+}
+
+func TestResolveID(t *testing.T) {
+	webID, lookups := int32(5), int32(0)
+	client, _ := newTestClient(t, projectNames(t, &webID, &lookups), ClientConfig{MaxRetries: -1})
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		reference string
+		want      int
+		err       string
+	}{
+		{name: "numeric reference taken as ID", reference: " 42 ", want: 42},
+		{name: "unique name", reference: "web", want: 5},
+		{name: "ambiguous name", reference: "app",
+			err: "12 projects are named 'app', use the ID of one of: 'app' (ID: 2, organization ops), 'app' (ID: 3, organization dev)"},
+		{name: "unknown name with similar ones", reference: "we",
+			err: "project 'we' not found. Similar names: 'web' (ID: 5, organization ops), 'webhooks' (ID: 6)"},
+		{name: "unknown name", reference: "db", err: "project 'db' not found"},
+		{name: "empty reference", reference: " ", err: "project name or ID is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := client.ResolveID(ctx, ResourceProject, tt.reference)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil || id != tt.want {
+				t.Fatalf("ResolveID(%q) = %d, %v, want %d", tt.reference, id, err, tt.want)
+			}
+		})
+	}
+
+	var resolveErr *ResolveError
+	if _, err := client.ResolveID(ctx, ResourceProject, "app"); !errors.As(err, &resolveErr) || resolveErr.Matches != 12 || len(resolveErr.Candidates) != 2 {
+		t.Errorf("ambiguous name error = %#v", err)
+	}
+	if _, err := client.ResolveID(ctx, ResourceType("widget"), "web"); err == nil || err.Error() != "unsupported resource type: widget" {
+		t.Errorf("unsupported type: %v", err)
+	}
+}
+
+func TestResolveIDCacheDroppedOnChange(t *testing.T) {
+	webID, lookups := int32(5), int32(0)
+	client, _ := newTestClient(t, projectNames(t, &webID, &lookups), ClientConfig{MaxRetries: -1})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if id, err := client.ResolveID(ctx, ResourceProject, "web"); err != nil || id != 5 {
+			t.Fatalf("ResolveID = %d, %v", id, err)
+		}
+	}
+	if atomic.LoadInt32(&lookups) != 1 {
+		t.Fatalf("name looked up %d times, want once with the cache", atomic.LoadInt32(&lookups))
+	}
+
+	// Another type's names are kept, and so is this one's until a project changes
+	client.forgetResolved(ResourceInventory)
+	if _, err := client.ResolveID(ctx, ResourceProject, "web"); err != nil || atomic.LoadInt32(&lookups) != 1 {
+		t.Fatalf("cached name dropped by a change of another type: %v, %d lookups", err, atomic.LoadInt32(&lookups))
+	}
+
+	// The name now refers to another project, say after a rename
+	atomic.StoreInt32(&webID, 9)
+	name := "web"
+	if _, err := client.UpdateProject(ctx, 5, ProjectUpdate{Name: &name}); err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+	if id, err := client.ResolveID(ctx, ResourceProject, "web"); err != nil || id != 9 {
+		t.Errorf("ResolveID after the update = %d, %v, want 9", id, err)
+	}
+	if atomic.LoadInt32(&lookups) != 2 {
+		t.Errorf("name looked up %d times, want 2", atomic.LoadInt32(&lookups))
+	}
+}
@@ -0,0 +1,93 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// backoff returns the wait before retry number attempt (1-based): exponential
+// with full jitter, or the server's Retry-After when it asked for one. It
+// reports false when the server asks for a longer wait than the client allows.
+func (c *Client) backoff(attempt int, err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, apiErr.RetryAfter <= c.retryMaxDelay
+	}
+
+	ceiling := c.retryBaseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > c.retryMaxDelay {
+		ceiling = c.retryMaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1)), true
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// send makes an authenticated request and turns error statuses into an
+// APIError. GET requests are retried with backoff on transient failures; other
+// methods are sent once since AWX may have acted on them.
+func (c *Client) send(ctx context.Context, method, endpoint string, body []byte) (*http.Response, error) {
+	attempts := 1
+	if method == http.MethodGet {
+		attempts += c.maxRetries
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendOnce(ctx, method, endpoint, body)
+		if err == nil {
+			return resp, nil
+		}
+		if attempt >= attempts || !IsTransient(err) || ctx.Err() != nil {
+			return nil, err
+		}
+
+		wait, ok := c.backoff(attempt, err)
+		if deadline, hasDeadline := ctx.Deadline(); !ok || hasDeadline && time.Until(deadline) < wait {
+			return nil, err // Waiting would outlast what the server or the caller allows
+		}
+		log.Printf("AWX API: %s %s failed (attempt %d/%d): %v - retrying in %s", method, endpoint, attempt, attempts, err, wait.Round(time.Millisecond))
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) sendOnce(ctx context.Context, method, endpoint string, body []byte) (*http.Response, error) {
+	resp, err := c.do(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 400 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if c.debug {
+		log.Printf("AWX API Response: %d - %s", resp.StatusCode, redactBody(respBody))
+	} else {
+		log.Printf("AWX API: %s %s -> %d", method, endpoint, resp.StatusCode)
+	}
+	return nil, newAPIError(resp, method, endpoint, respBody)
+}
@@ -0,0 +1,223 @@
+package awx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":       0,
+		"5":      5 * time.Second,
+		"0":      0,
+		"-3":     0,
+		"soon":   0,
+		"1.5":    0,
+		"120":    2 * time.Minute,
+		"999999": 999999 * time.Second,
+	}
+	for value, want := range tests {
+		if got := parseRetryAfter(value); got != want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", value, got, want)
+		}
+	}
+
+	future := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(future); got < 80*time.Second || got > 90*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %s, want about 90s", future, got)
+	}
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(past); got != 0 {
+		t.Errorf("parseRetryAfter(%q) = %s, want 0 for a date in the past", past, got)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{"nil", nil, ""},
+		{"401", &APIError{StatusCode: 401}, ErrorAuth},
+		{"403", &APIError{StatusCode: 403}, ErrorPermission},
+		{"404", &APIError{StatusCode: 404}, ErrorNotFound},
+		{"400", &APIError{StatusCode: 400}, ErrorValidation},
+		{"409", &APIError{StatusCode: 409}, ErrorValidation},
+		{"408", &APIError{StatusCode: 408}, ErrorTransient},
+		{"429", &APIError{StatusCode: 429}, ErrorTransient},
+		{"500", &APIError{StatusCode: 500}, ErrorTransient},
+		{"503", &APIError{StatusCode: 503}, ErrorTransient},
+		{"wrapped 502", fmt.Errorf("failed to get project: %w", &APIError{StatusCode: 502}), ErrorTransient},
+		{"launch validation", &LaunchValidationError{Template: "deploy"}, ErrorValidation},
+		{"ambiguous name", &ResolveError{Matches: 2}, ErrorValidation},
+		{"unknown name", &ResolveError{}, ErrorNotFound},
+		{"token rejected", &tokenStatusError{StatusCode: 401}, ErrorAuth},
+		{"token creation failed", &tokenStatusError{StatusCode: 500}, ErrorOther},
+		{"canceled", fmt.Errorf("request: %w", context.Canceled), ErrorOther},
+		{"deadline", context.DeadlineExceeded, ErrorTransient},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, ErrorTransient},
+		{"other", errors.New("boom"), ErrorOther},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.err); got != tt.want {
+			t.Errorf("Classify(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBackoffFullJitter(t *testing.T) {
+	client := NewClient(ClientConfig{BaseURL: "http://awx", RetryBaseDelay: 100 * time.Millisecond, RetryMaxDelay: time.Second})
+
+	for attempt, ceiling := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second, // Capped by RetryMaxDelay
+		70: time.Second, // The shift overflows
+	} {
+		var longest time.Duration
+		for i := 0; i < 500; i++ {
+			wait, ok := client.backoff(attempt, &APIError{StatusCode: 503})
+			if !ok || wait < 0 || wait > ceiling {
+				t.Fatalf("backoff(%d) = %s, %t, want within [0, %s]", attempt, wait, ok, ceiling)
+			}
+			if wait > longest {
+				longest = wait
+			}
+		}
+		if longest < ceiling/2 {
+			t.Errorf("backoff(%d) never exceeded %s over 500 draws, want jitter up to %s", attempt, longest, ceiling)
+		}
+	}
+
+	if wait, ok := client.backoff(1, &APIError{StatusCode: 429, RetryAfter: 700 * time.Millisecond}); wait != 700*time.Millisecond || !ok {
+		t.Errorf("backoff with Retry-After = %s, %t, want the server's wait", wait, ok)
+	}
+	if _, ok := client.backoff(1, &APIError{StatusCode: 429, RetryAfter: 2 * time.Second}); ok {
+		t.Error("backoff accepted a Retry-After above RetryMaxDelay")
+	}
+}
+
+// flakyProject serves project 1 after failing with the given statuses, and
+// counts the requests by method
+func flakyProject(t *testing.T, failures []int, retryAfter string, requests map[string]*int32) http.Handler {
+	var served int32
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests[r.Method] == nil {
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+			return
+		}
+		atomic.AddInt32(requests[r.Method], 1)
+		if n := int(atomic.AddInt32(&served, 1)); n <= len(failures) {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			writeJSON(t, w, failures[n-1], map[string]string{"detail": "try again"})
+			return
+		}
+		writeJSON(t, w, http.StatusOK, Project{ID: 1, Name: "site"})
+	})
+}
+
+func TestGetRetriedOnTransientStatus(t *testing.T) {
+	var gets int32
+	client, _ := newTestClient(t, flakyProject(t, []int{429, 503, 502}, "", map[string]*int32{"GET": &gets}),
+		ClientConfig{RetryBaseDelay: time.Millisecond})
+
+	project, err := client.GetProject(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if project.Name != "site" || gets != 4 {
+		t.Errorf("project %+v after %d requests, want success on the 4th", project, gets)
+	}
+}
+
+func TestGetHonoursRetryAfter(t *testing.T) {
+	var gets int32
+	client, _ := newTestClient(t, flakyProject(t, []int{503}, "1", map[string]*int32{"GET": &gets}),
+		ClientConfig{RetryBaseDelay: time.Millisecond})
+
+	start := time.Now()
+	if _, err := client.GetProject(context.Background(), 1); err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if elapsed := time.Since(start); gets != 2 || elapsed < time.Second {
+		t.Errorf("%d requests in %s, want the retry after the 1s Retry-After", gets, elapsed)
+	}
+}
+
+func TestGetRetryLimits(t *testing.T) {
+	always := []int{503, 503, 503, 503, 503, 503}
+	tests := []struct {
+		name       string
+		failures   []int
+		retryAfter string
+		config     ClientConfig
+		timeout    time.Duration
+		requests   int32
+		kind       ErrorKind
+	}{
+		{name: "retries exhausted", failures: always, config: ClientConfig{MaxRetries: 2}, requests: 3, kind: ErrorTransient},
+		{name: "retries disabled", failures: always, config: ClientConfig{MaxRetries: -1}, requests: 1, kind: ErrorTransient},
+		{name: "not found", failures: []int{404}, requests: 1, kind: ErrorNotFound},
+		{name: "validation", failures: []int{400}, requests: 1, kind: ErrorValidation},
+		{name: "Retry-After above the max delay", failures: always, retryAfter: "5", config: ClientConfig{RetryMaxDelay: time.Second}, requests: 1, kind: ErrorTransient},
+		{name: "Retry-After beyond the deadline", failures: always, retryAfter: "5", timeout: 500 * time.Millisecond, requests: 1, kind: ErrorTransient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gets int32
+			config := tt.config
+			config.RetryBaseDelay = time.Millisecond
+			client, _ := newTestClient(t, flakyProject(t, tt.failures, tt.retryAfter, map[string]*int32{"GET": &gets}), config)
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			start := time.Now()
+			_, err := client.GetProject(ctx, 1)
+			if kind := Classify(err); kind != tt.kind || gets != tt.requests {
+				t.Errorf("%s after %d requests, want %s after %d", kind, gets, tt.kind, tt.requests)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("took %s; a Retry-After that cannot be honoured should fail fast", elapsed)
+			}
+
+			var apiErr *APIError
+			if tt.retryAfter != "" && (!errors.As(err, &apiErr) || strconv.Itoa(int(apiErr.RetryAfter.Seconds())) != tt.retryAfter) {
+				t.Errorf("error = %#v, want the Retry-After kept on the APIError", err)
+			}
+		})
+	}
+}
+
+func TestWritesNeverRetried(t *testing.T) {
+	for _, status := range []int{429, 502, 503} {
+		var posts, deletes int32
+		client, _ := newTestClient(t, flakyProject(t, []int{status, status}, "1", map[string]*int32{"POST": &posts, "DELETE": &deletes}),
+			ClientConfig{RetryBaseDelay: time.Millisecond})
+		ctx := context.Background()
+
+		if _, err := client.CreateHost(ctx, 1, HostRequest{Name: "web1"}); !IsTransient(err) {
+			t.Errorf("CreateHost on %d: %v", status, err)
+		}
+		if err := client.DeleteHost(ctx, 7); !IsTransient(err) {
+			t.Errorf("DeleteHost on %d: %v", status, err)
+		}
+		if posts != 1 || deletes != 1 {
+			t.Errorf("status %d: %d POST and %d DELETE requests, want each sent once", status, posts, deletes)
+		}
+	}
+}
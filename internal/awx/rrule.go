@@ -0,0 +1,660 @@
+package awx
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Schedules name IANA zones; embed them so hosts without zoneinfo can expand rules
+)
+
+const (
+	// maxRRuleCount is the largest COUNT AWX accepts in a schedule rule
+	maxRRuleCount = 999
+	// maxEmptyPeriods and ruleHorizonYears stop expanding a rule whose parts
+	// never match, such as BYMONTH=2;BYMONTHDAY=30
+	maxEmptyPeriods  = 100000
+	ruleHorizonYears = 10
+)
+
+// rruleLayouts are the iCalendar date-time forms DTSTART and UNTIL take
+var rruleLayouts = []string{"20060102T150405", "20060102"}
+
+var rruleFrequencies = map[string]bool{
+	"MINUTELY": true, "HOURLY": true, "DAILY": true, "WEEKLY": true, "MONTHLY": true, "YEARLY": true,
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ScheduleRule is a parsed AWX schedule rule: a DTSTART with its time zone,
+// one or more RRULE lines and optional EXRULE lines removing occurrences
+type ScheduleRule struct {
+	Start      time.Time
+	Location   *time.Location
+	Rules      []RecurrenceRule
+	Exclusions []RecurrenceRule
+}
+
+// RecurrenceRule is one RRULE or EXRULE line, limited to the parts AWX accepts
+type RecurrenceRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time // Zero when the rule has no end
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	ByMonth    []int
+	ByHour     []int
+	ByMinute   []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// ParseScheduleRule parses and validates a schedule rule such as
+// "DTSTART;TZID=Europe/Paris:20250101T020000 RRULE:FREQ=DAILY;INTERVAL=1",
+// applying the restrictions AWX puts on top of RFC 5545 and reporting every
+// problem at once
+func ParseScheduleRule(text string) (*ScheduleRule, error) {
+	var (
+		rule     ScheduleRule
+		problems []string
+		starts   int
+		lines    [][2]string
+	)
+
+	for _, field := range strings.Fields(text) {
+		name, value, ok := strings.Cut(field, ":")
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%q is not a NAME:VALUE line", field))
+			continue
+		}
+		key, params, _ := strings.Cut(name, ";")
+		switch key = strings.ToUpper(key); key {
+		case "DTSTART":
+			starts++
+			start, location, err := parseStart(params, value)
+			if err != nil {
+				problems = append(problems, err.Error())
+				continue
+			}
+			rule.Start, rule.Location = start, location
+		case "RRULE", "EXRULE":
+			lines = append(lines, [2]string{key, value})
+		default:
+			problems = append(problems, fmt.Sprintf("%s lines are not supported; use DTSTART, RRULE and EXRULE", key))
+		}
+	}
+
+	switch {
+	case starts == 0:
+		problems = append(problems, "a DTSTART with a time zone is required, such as DTSTART;TZID=Europe/Paris:20250101T020000")
+	case starts > 1:
+		problems = append(problems, "only one DTSTART is allowed")
+	}
+	if len(lines) == 0 {
+		problems = append(problems, "at least one RRULE is required, such as RRULE:FREQ=DAILY;INTERVAL=1")
+	}
+
+	location := rule.Location
+	if location == nil {
+		location = time.UTC
+	}
+	for _, line := range lines {
+		recurrence, lineProblems := parseRecurrence(line[1], location)
+		for _, problem := range lineProblems {
+			problems = append(problems, fmt.Sprintf("%s: %s", line[0], problem))
+		}
+		if line[0] == "RRULE" {
+			rule.Rules = append(rule.Rules, recurrence)
+		} else {
+			rule.Exclusions = append(rule.Exclusions, recurrence)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid schedule rule: %s", strings.Join(problems, "; "))
+	}
+	return &rule, nil
+}
+
+// parseStart reads a DTSTART, which AWX requires to be in UTC or carry a TZID
+func parseStart(params, value string) (time.Time, *time.Location, error) {
+	location := time.UTC
+	zoned := false
+	for _, param := range strings.Split(params, ";") {
+		if name, zone, ok := strings.Cut(param, "="); ok && strings.EqualFold(name, "TZID") {
+			loaded, err := loadRRuleLocation(zone)
+			if err != nil {
+				return time.Time{}, nil, err
+			}
+			location, zoned = loaded, true
+		}
+	}
+	utc := strings.HasSuffix(value, "Z")
+	if !zoned && !utc {
+		return time.Time{}, nil, fmt.Errorf("DTSTART %s needs a time zone: add ;TZID=<zone> or end it with Z for UTC", value)
+	}
+
+	start, err := parseRRuleTime(strings.TrimSuffix(value, "Z"), location)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("DTSTART %s: %w", value, err)
+	}
+	return start, location, nil
+}
+
+// loadRRuleLocation loads the IANA zone a TZID names; zone names are case sensitive
+func loadRRuleLocation(zone string) (*time.Location, error) {
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q, use an IANA name such as Europe/Paris", zone)
+	}
+	return location, nil
+}
+
+func parseRRuleTime(value string, location *time.Location) (time.Time, error) {
+	for _, layout := range rruleLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return wallClock(parsed.Year(), parsed.Month(), parsed.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), location), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("expected a date-time such as 20250101T020000")
+}
+
+// parseRecurrence reads the parts of one RRULE or EXRULE line
+func parseRecurrence(value string, location *time.Location) (RecurrenceRule, []string) {
+	recurrence := RecurrenceRule{WeekStart: time.Monday}
+	var problems []string
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(value, ";") {
+		name, raw, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || raw == "" {
+			problems = append(problems, fmt.Sprintf("%q is not a NAME=VALUE part", part))
+			continue
+		}
+		if seen[name] {
+			problems = append(problems, fmt.Sprintf("%s is given more than once", name))
+			continue
+		}
+		seen[name] = true
+		raw = strings.ToUpper(raw)
+
+		var err error
+		switch name {
+		case "FREQ":
+			recurrence.Freq = raw
+			if raw == "SECONDLY" {
+				err = fmt.Errorf("FREQ=SECONDLY is not supported by AWX")
+			} else if !rruleFrequencies[raw] {
+				err = fmt.Errorf("FREQ must be MINUTELY, HOURLY, DAILY, WEEKLY, MONTHLY or YEARLY, not %s", raw)
+			}
+		case "INTERVAL":
+			recurrence.Interval, err = strconv.Atoi(raw)
+			if err != nil || recurrence.Interval < 1 {
+				err = fmt.Errorf("INTERVAL must be a positive number, not %s", raw)
+			}
+		case "COUNT":
+			recurrence.Count, err = strconv.Atoi(raw)
+			if err != nil || recurrence.Count < 1 || recurrence.Count > maxRRuleCount {
+				err = fmt.Errorf("COUNT must be between 1 and %d, not %s", maxRRuleCount, raw)
+			}
+		case "UNTIL":
+			// UNTIL ending in Z is UTC, otherwise it is in the DTSTART zone
+			until := location
+			if strings.HasSuffix(raw, "Z") {
+				until = time.UTC
+			}
+			recurrence.Until, err = parseRRuleTime(strings.TrimSuffix(raw, "Z"), until)
+			if err != nil {
+				err = fmt.Errorf("UNTIL %s: %w", raw, err)
+			}
+		case "BYDAY":
+			recurrence.ByDay, err = parseWeekdays(raw)
+		case "WKST":
+			day, ok := rruleWeekdays[raw]
+			if !ok {
+				err = fmt.Errorf("WKST must be a weekday such as MO, not %s", raw)
+			}
+			recurrence.WeekStart = day
+		case "BYMONTHDAY":
+			recurrence.ByMonthDay, err = parseNumbers(name, raw, -31, 31, true)
+		case "BYMONTH":
+			recurrence.ByMonth, err = parseNumbers(name, raw, 1, 12, false)
+		case "BYHOUR":
+			recurrence.ByHour, err = parseNumbers(name, raw, 0, 23, false)
+		case "BYMINUTE":
+			recurrence.ByMinute, err = parseNumbers(name, raw, 0, 59, false)
+		case "BYSETPOS":
+			recurrence.BySetPos, err = parseNumbers(name, raw, -366, 366, true)
+		case "BYYEARDAY", "BYWEEKNO", "BYSECOND":
+			err = fmt.Errorf("%s is not supported by AWX", name)
+		default:
+			err = fmt.Errorf("unknown part %s", name)
+		}
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if !seen["FREQ"] {
+		problems = append(problems, "FREQ is required")
+	}
+	if !seen["INTERVAL"] {
+		problems = append(problems, "INTERVAL is required by AWX, such as INTERVAL=1")
+	}
+	if seen["COUNT"] && seen["UNTIL"] {
+		problems = append(problems, "COUNT and UNTIL cannot both be given")
+	}
+	if len(recurrence.BySetPos) > 0 && len(recurrence.ByDay)+len(recurrence.ByMonthDay)+len(recurrence.ByMonth)+len(recurrence.ByHour)+len(recurrence.ByMinute) == 0 {
+		problems = append(problems, "BYSETPOS needs another BY part to select from, such as BYDAY")
+	}
+	return recurrence, problems
+}
+
+func parseWeekdays(raw string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, item := range strings.Split(raw, ",") {
+		day, ok := rruleWeekdays[item]
+		if !ok {
+			if len(item) > 2 {
+				if _, ok := rruleWeekdays[item[len(item)-2:]]; ok {
+					return nil, fmt.Errorf("BYDAY with a numeric prefix such as %s is not supported by AWX; use BYDAY=%s;BYSETPOS=%s instead",
+						item, item[len(item)-2:], item[:len(item)-2])
+				}
+			}
+			return nil, fmt.Errorf("BYDAY must list weekdays such as MO,WE,FR, not %s", item)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseNumbers(name, raw string, min, max int, signed bool) ([]int, error) {
+	var numbers []int
+	for _, item := range strings.Split(raw, ",") {
+		number, err := strconv.Atoi(item)
+		if err != nil || number < min || number > max || (signed && number == 0) {
+			return nil, fmt.Errorf("%s values must be between %d and %d, not %s", name, min, max, item)
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+// Occurrences returns up to limit occurrences after the given time, in the
+// rule's time zone. A non-zero before stops at that time; limit 0 means no
+// limit, which then requires before.
+func (r *ScheduleRule) Occurrences(after, before time.Time, limit int) []time.Time {
+	if limit <= 0 && before.IsZero() {
+		return nil
+	}
+
+	rules := make([]*ruleIterator, len(r.Rules))
+	heads := make([]time.Time, len(r.Rules))
+	live := make([]bool, len(r.Rules))
+	for i, rule := range r.Rules {
+		rules[i] = newRuleIterator(rule, r.Start, after)
+		heads[i], live[i] = rules[i].next()
+	}
+	exclusions := make([]*ruleIterator, len(r.Exclusions))
+	excluded := make([]time.Time, len(r.Exclusions))
+	excludedLive := make([]bool, len(r.Exclusions))
+	for i, rule := range r.Exclusions {
+		exclusions[i] = newRuleIterator(rule, r.Start, after)
+		excluded[i], excludedLive[i] = exclusions[i].next()
+	}
+
+	var occurrences []time.Time
+	for limit <= 0 || len(occurrences) < limit {
+		// Take the earliest head across the rules, dropping duplicates
+		first := -1
+		for i := range rules {
+			if live[i] && (first < 0 || heads[i].Before(heads[first])) {
+				first = i
+			}
+		}
+		if first < 0 {
+			break
+		}
+		occurrence := heads[first]
+		for i := range rules {
+			for live[i] && heads[i].Equal(occurrence) {
+				heads[i], live[i] = rules[i].next()
+			}
+		}
+
+		if !occurrence.After(after) {
+			continue
+		}
+		if !before.IsZero() && occurrence.After(before) {
+			break
+		}
+
+		skip := false
+		for i := range exclusions {
+			for excludedLive[i] && excluded[i].Before(occurrence) {
+				excluded[i], excludedLive[i] = exclusions[i].next()
+			}
+			if excludedLive[i] && excluded[i].Equal(occurrence) {
+				skip = true
+			}
+		}
+		if !skip {
+			occurrences = append(occurrences, occurrence.In(r.Location))
+		}
+	}
+	return occurrences
+}
+
+// ruleIterator yields the occurrences of one rule in order, expanding one
+// period of the rule's frequency at a time
+type ruleIterator struct {
+	rule    RecurrenceRule
+	start   time.Time
+	base    time.Time // Start of the period holding DTSTART
+	horizon time.Time // No occurrence is looked for past this
+	period  int       // Index of the next period to expand
+	pending []time.Time
+	emitted int
+	empty   int
+	done    bool
+}
+
+// newRuleIterator starts a rule at DTSTART or, when it has no COUNT to keep,
+// a period or so before from, so long-running schedules are not replayed
+func newRuleIterator(rule RecurrenceRule, start, from time.Time) *ruleIterator {
+	it := &ruleIterator{rule: rule, start: start, base: periodBase(rule, start)}
+	it.horizon = start.AddDate(ruleHorizonYears, 0, 0)
+	if from.After(start) {
+		it.horizon = from.AddDate(ruleHorizonYears, 0, 0)
+	}
+	if rule.Count == 0 && from.After(start) {
+		if skipped := periodsBetween(rule, it.base, from.In(start.Location()))/rule.Interval - 1; skipped > 0 {
+			it.period = skipped
+		}
+	}
+	return it
+}
+
+func (it *ruleIterator) next() (time.Time, bool) {
+	for !it.done {
+		if len(it.pending) > 0 {
+			occurrence := it.pending[0]
+			it.pending = it.pending[1:]
+			if occurrence.Before(it.start) {
+				continue
+			}
+			if !it.rule.Until.IsZero() && occurrence.After(it.rule.Until) {
+				it.done = true
+				break
+			}
+			it.emitted++
+			if it.rule.Count > 0 && it.emitted >= it.rule.Count {
+				it.done = true
+			}
+			return occurrence, true
+		}
+
+		periodStart := periodAt(it.rule, it.base, it.period*it.rule.Interval)
+		it.period++
+		if periodStart.After(it.horizon) || (!it.rule.Until.IsZero() && periodStart.After(it.rule.Until)) {
+			it.done = true
+			break
+		}
+		it.pending = it.rule.expand(periodStart, it.start)
+		if len(it.pending) == 0 {
+			if it.empty++; it.empty > maxEmptyPeriods {
+				it.done = true
+			}
+		} else {
+			it.empty = 0
+		}
+	}
+	return time.Time{}, false
+}
+
+// periodBase returns the start of the period of the rule's frequency that holds t
+func periodBase(rule RecurrenceRule, t time.Time) time.Time {
+	year, month, day := t.Date()
+	location := t.Location()
+	switch rule.Freq {
+	case "YEARLY":
+		return wallClock(year, 1, 1, 0, 0, 0, location)
+	case "MONTHLY":
+		return wallClock(year, month, 1, 0, 0, 0, location)
+	case "WEEKLY":
+		back := (int(t.Weekday()) - int(rule.WeekStart) + 7) % 7
+		return wallClock(year, month, day-back, 0, 0, 0, location)
+	case "DAILY":
+		return wallClock(year, month, day, 0, 0, 0, location)
+	case "HOURLY":
+		return wallClock(year, month, day, t.Hour(), 0, 0, location)
+	default:
+		return wallClock(year, month, day, t.Hour(), t.Minute(), 0, location)
+	}
+}
+
+// periodAt returns the start of the period n frequency units after base.
+// Days and longer follow the wall clock; hours and minutes are elapsed time,
+// so an hourly rule keeps its pace across daylight saving changes.
+func periodAt(rule RecurrenceRule, base time.Time, n int) time.Time {
+	year, month, day := base.Date()
+	switch rule.Freq {
+	case "YEARLY":
+		return wallClock(year+n, 1, 1, 0, 0, 0, base.Location())
+	case "MONTHLY":
+		return wallClock(year, month+time.Month(n), 1, 0, 0, 0, base.Location())
+	case "WEEKLY":
+		return wallClock(year, month, day+7*n, 0, 0, 0, base.Location())
+	case "DAILY":
+		return wallClock(year, month, day+n, 0, 0, 0, base.Location())
+	case "HOURLY":
+		return base.Add(time.Duration(n) * time.Hour)
+	default:
+		return base.Add(time.Duration(n) * time.Minute)
+	}
+}
+
+// periodsBetween counts the whole frequency units from base to t
+func periodsBetween(rule RecurrenceRule, base, t time.Time) int {
+	switch rule.Freq {
+	case "YEARLY":
+		return t.Year() - base.Year()
+	case "MONTHLY":
+		return (t.Year()-base.Year())*12 + int(t.Month()) - int(base.Month())
+	case "WEEKLY":
+		return daysBetween(base, t) / 7
+	case "DAILY":
+		return daysBetween(base, t)
+	case "HOURLY":
+		return int(t.Sub(base) / time.Hour)
+	default:
+		return int(t.Sub(base) / time.Minute)
+	}
+}
+
+// wallClock returns a local time in location like time.Date, except that a
+// time skipped by a daylight saving change moves forward by the length of the
+// gap, as dateutil does: 02:30 on a day clocks jump from 02:00 to 03:00 is
+// 03:30. time.Date may resolve it to 01:30 instead, or to the previous day for
+// a gap at midnight.
+func wallClock(year int, month time.Month, day, hour, minute, second int, location *time.Location) time.Time {
+	t := time.Date(year, month, day, hour, minute, second, 0, location)
+	naive := time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+	if t.Year() == naive.Year() && t.YearDay() == naive.YearDay() && t.Hour() == naive.Hour() && t.Minute() == naive.Minute() {
+		return t
+	}
+
+	// The time is in a gap between the offsets before and after the change:
+	// time.Date applied one of them and t shows the other. Reading the wall
+	// clock with the earlier, smaller offset lands past the gap.
+	applied := naive.Unix() - t.Unix()
+	_, shown := t.Zone()
+	before := applied
+	if int64(shown) < before {
+		before = int64(shown)
+	}
+	return time.Unix(naive.Unix()-before, 0).In(location)
+}
+
+func daysBetween(from, to time.Time) int {
+	fromYear, fromMonth, fromDay := from.Date()
+	toYear, toMonth, toDay := to.Date()
+	start := time.Date(fromYear, fromMonth, fromDay, 0, 0, 0, 0, time.UTC)
+	end := time.Date(toYear, toMonth, toDay, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// expand returns the occurrences of the rule within the period starting at
+// periodStart, in order. BY parts finer than the frequency add occurrences,
+// coarser ones filter them; parts left out default to DTSTART's.
+func (r RecurrenceRule) expand(periodStart, start time.Time) []time.Time {
+	location := start.Location()
+	months, monthDays, weekdays := r.ByMonth, r.ByMonthDay, r.ByDay
+	switch r.Freq {
+	case "YEARLY":
+		if len(months)+len(monthDays)+len(weekdays) == 0 {
+			months = []int{int(start.Month())}
+		}
+		if len(monthDays)+len(weekdays) == 0 {
+			monthDays = []int{start.Day()}
+		}
+	case "MONTHLY":
+		if len(monthDays)+len(weekdays) == 0 {
+			monthDays = []int{start.Day()}
+		}
+	case "WEEKLY":
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{start.Weekday()}
+		}
+	}
+
+	// Days of the period
+	year, month, day := periodStart.Date()
+	var first, days int
+	switch r.Freq {
+	case "YEARLY":
+		first, days = 1, time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+		month = 1
+	case "MONTHLY":
+		first, days = 1, time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	case "WEEKLY":
+		first, days = day, 7
+	default:
+		first, days = day, 1
+	}
+
+	var occurrences []time.Time
+	for offset := 0; offset < days; offset++ {
+		date := time.Date(year, month, first+offset, 12, 0, 0, 0, location)
+		if !matchesDay(date, months, monthDays, weekdays) {
+			continue
+		}
+
+		switch r.Freq {
+		case "HOURLY":
+			if containsInt(r.ByHour, periodStart.Hour()) {
+				for _, minute := range valuesOr(r.ByMinute, start.Minute()) {
+					occurrences = append(occurrences, periodStart.Add(time.Duration(minute)*time.Minute+time.Duration(start.Second())*time.Second))
+				}
+			}
+		case "MINUTELY":
+			if containsInt(r.ByHour, periodStart.Hour()) && containsInt(r.ByMinute, periodStart.Minute()) {
+				occurrences = append(occurrences, periodStart.Add(time.Duration(start.Second())*time.Second))
+			}
+		default:
+			y, m, d := date.Date()
+			for _, hour := range valuesOr(r.ByHour, start.Hour()) {
+				for _, minute := range valuesOr(r.ByMinute, start.Minute()) {
+					occurrences = append(occurrences, wallClock(y, m, d, hour, minute, start.Second(), location))
+				}
+			}
+		}
+	}
+
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Before(occurrences[j]) })
+	occurrences = uniqueTimes(occurrences)
+	if len(r.BySetPos) > 0 {
+		occurrences = selectPositions(occurrences, r.BySetPos)
+	}
+	return occurrences
+}
+
+func matchesDay(date time.Time, months, monthDays []int, weekdays []time.Weekday) bool {
+	if len(months) > 0 && !containsInt(months, int(date.Month())) {
+		return false
+	}
+	if len(weekdays) > 0 {
+		found := false
+		for _, weekday := range weekdays {
+			found = found || weekday == date.Weekday()
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(monthDays) > 0 {
+		// Negative days count back from the end of the month
+		last := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		found := false
+		for _, monthDay := range monthDays {
+			found = found || monthDay == date.Day() || last+monthDay+1 == date.Day()
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// containsInt reports whether value is in values; an empty list holds everything
+func containsInt(values []int, value int) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func valuesOr(values []int, fallback int) []int {
+	if len(values) == 0 {
+		return []int{fallback}
+	}
+	return values
+}
+
+func uniqueTimes(times []time.Time) []time.Time {
+	unique := times[:0]
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+// selectPositions keeps the BYSETPOS positions of a period's occurrences,
+// counting from 1 at the start or from -1 at the end
+func selectPositions(occurrences []time.Time, positions []int) []time.Time {
+	var selected []time.Time
+	for _, position := range positions {
+		index := position - 1
+		if position < 0 {
+			index = len(occurrences) + position
+		}
+		if index >= 0 && index < len(occurrences) {
+			selected = append(selected, occurrences[index])
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Before(selected[j]) })
+	return uniqueTimes(selected)
+}
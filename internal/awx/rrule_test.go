@@ -0,0 +1,217 @@
+package awx
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScheduleRuleOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		after string // RFC 3339
+		limit int
+		want  []string // In the rule's zone, as 2006-01-02 15:04 MST
+	}{
+		{
+			name:  "DTSTART with TZID",
+			rule:  "DTSTART;TZID=Europe/Paris:20250101T020000 RRULE:FREQ=DAILY;INTERVAL=1",
+			after: "2024-12-31T00:00:00Z",
+			limit: 3,
+			want:  []string{"2025-01-01 02:00 CET", "2025-01-02 02:00 CET", "2025-01-03 02:00 CET"},
+		},
+		{
+			name:  "DTSTART in UTC, every other week",
+			rule:  "DTSTART:20250101T100000Z RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR",
+			after: "2024-12-31T00:00:00Z",
+			limit: 4,
+			want:  []string{"2025-01-03 10:00 UTC", "2025-01-13 10:00 UTC", "2025-01-17 10:00 UTC", "2025-01-27 10:00 UTC"},
+		},
+		{
+			name:  "COUNT",
+			rule:  "DTSTART:20250101T100000Z RRULE:FREQ=DAILY;INTERVAL=1;COUNT=3",
+			after: "2024-12-31T00:00:00Z",
+			limit: 10,
+			want:  []string{"2025-01-01 10:00 UTC", "2025-01-02 10:00 UTC", "2025-01-03 10:00 UTC"},
+		},
+		{
+			name:  "COUNT is counted from DTSTART",
+			rule:  "DTSTART:20250101T100000Z RRULE:FREQ=DAILY;INTERVAL=1;COUNT=3",
+			after: "2025-01-02T12:00:00Z",
+			limit: 10,
+			want:  []string{"2025-01-03 10:00 UTC"},
+		},
+		{
+			name:  "UNTIL in UTC is inclusive",
+			rule:  "DTSTART;TZID=America/New_York:20250101T090000 RRULE:FREQ=DAILY;INTERVAL=1;UNTIL=20250103T140000Z",
+			after: "2024-12-31T00:00:00Z",
+			limit: 10,
+			want:  []string{"2025-01-01 09:00 EST", "2025-01-02 09:00 EST", "2025-01-03 09:00 EST"},
+		},
+		{
+			name:  "UNTIL in the DTSTART zone",
+			rule:  "DTSTART;TZID=Asia/Tokyo:20250101T090000 RRULE:FREQ=DAILY;INTERVAL=1;UNTIL=20250102T085959",
+			after: "2024-12-31T00:00:00Z",
+			limit: 10,
+			want:  []string{"2025-01-01 09:00 JST"},
+		},
+		{
+			name:  "BYSETPOS last Friday",
+			rule:  "DTSTART:20250101T120000Z RRULE:FREQ=MONTHLY;INTERVAL=1;BYDAY=FR;BYSETPOS=-1",
+			after: "2024-12-31T00:00:00Z",
+			limit: 3,
+			want:  []string{"2025-01-31 12:00 UTC", "2025-02-28 12:00 UTC", "2025-03-28 12:00 UTC"},
+		},
+		{
+			name:  "BYSETPOS first weekday",
+			rule:  "DTSTART:20250101T120000Z RRULE:FREQ=MONTHLY;INTERVAL=1;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1",
+			after: "2024-12-31T00:00:00Z",
+			limit: 3,
+			want:  []string{"2025-01-01 12:00 UTC", "2025-02-03 12:00 UTC", "2025-03-03 12:00 UTC"},
+		},
+		{
+			name:  "DTSTART on the 31st skips shorter months",
+			rule:  "DTSTART:20250131T080000Z RRULE:FREQ=MONTHLY;INTERVAL=1",
+			after: "2025-01-01T00:00:00Z",
+			limit: 3,
+			want:  []string{"2025-01-31 08:00 UTC", "2025-03-31 08:00 UTC", "2025-05-31 08:00 UTC"},
+		},
+		{
+			name:  "last day of the month",
+			rule:  "DTSTART:20250101T080000Z RRULE:FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=-1",
+			after: "2025-01-01T00:00:00Z",
+			limit: 3,
+			want:  []string{"2025-01-31 08:00 UTC", "2025-02-28 08:00 UTC", "2025-03-31 08:00 UTC"},
+		},
+		{
+			name:  "February 29th",
+			rule:  "DTSTART:20250101T000000Z RRULE:FREQ=YEARLY;INTERVAL=1;BYMONTH=2;BYMONTHDAY=29",
+			after: "2025-01-01T00:00:00Z",
+			limit: 2,
+			want:  []string{"2028-02-29 00:00 UTC", "2032-02-29 00:00 UTC"},
+		},
+		{
+			name:  "EXRULE removes weekends",
+			rule:  "DTSTART:20250106T090000Z RRULE:FREQ=DAILY;INTERVAL=1 EXRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=SA,SU",
+			after: "2025-01-06T00:00:00Z",
+			limit: 6,
+			want: []string{"2025-01-06 09:00 UTC", "2025-01-07 09:00 UTC", "2025-01-08 09:00 UTC",
+				"2025-01-09 09:00 UTC", "2025-01-10 09:00 UTC", "2025-01-13 09:00 UTC"},
+		},
+		{
+			name:  "several RRULEs merge without duplicates",
+			rule:  "DTSTART:20250101T090000Z RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=WE RRULE:FREQ=WEEKLY;INTERVAL=1;BYDAY=WE,FR",
+			after: "2024-12-31T00:00:00Z",
+			limit: 3,
+			want:  []string{"2025-01-01 09:00 UTC", "2025-01-03 09:00 UTC", "2025-01-08 09:00 UTC"},
+		},
+		{
+			name:  "daily INTERVAL keeps its alignment after years",
+			rule:  "DTSTART:20200101T000000Z RRULE:FREQ=DAILY;INTERVAL=3",
+			after: "2025-03-01T12:00:00Z",
+			limit: 3,
+			want:  []string{"2025-03-02 00:00 UTC", "2025-03-05 00:00 UTC", "2025-03-08 00:00 UTC"},
+		},
+		{
+			name:  "monthly INTERVAL keeps its alignment after years",
+			rule:  "DTSTART:20200115T060000Z RRULE:FREQ=MONTHLY;INTERVAL=5",
+			after: "2025-03-01T00:00:00Z",
+			limit: 3,
+			want:  []string{"2025-06-15 06:00 UTC", "2025-11-15 06:00 UTC", "2026-04-15 06:00 UTC"},
+		},
+		{
+			name:  "hourly INTERVAL keeps its alignment after months",
+			rule:  "DTSTART:20250101T000000Z RRULE:FREQ=HOURLY;INTERVAL=7",
+			after: "2025-03-01T00:30:00Z",
+			limit: 3,
+			want:  []string{"2025-03-01 05:00 UTC", "2025-03-01 12:00 UTC", "2025-03-01 19:00 UTC"},
+		},
+		{
+			name:  "nonexistent local time moves past the DST gap",
+			rule:  "DTSTART;TZID=America/New_York:20250301T023000 RRULE:FREQ=DAILY;INTERVAL=1",
+			after: "2025-03-08T00:00:00-05:00",
+			limit: 3,
+			want:  []string{"2025-03-08 02:30 EST", "2025-03-09 03:30 EDT", "2025-03-10 02:30 EDT"},
+		},
+		{
+			name:  "DST gap at midnight keeps the day",
+			rule:  "DTSTART;TZID=America/Havana:20250301T120000 RRULE:FREQ=DAILY;INTERVAL=1",
+			after: "2025-03-08T00:00:00-05:00",
+			limit: 3,
+			want:  []string{"2025-03-08 12:00 CST", "2025-03-09 12:00 CDT", "2025-03-10 12:00 CDT"},
+		},
+		{
+			name:  "daily rules keep the wall clock when DST ends",
+			rule:  "DTSTART;TZID=Europe/Paris:20250101T090000 RRULE:FREQ=DAILY;INTERVAL=1",
+			after: "2025-10-25T00:00:00Z",
+			limit: 2,
+			want:  []string{"2025-10-25 09:00 CEST", "2025-10-26 09:00 CET"},
+		},
+		{
+			name:  "hourly rules keep their pace across DST",
+			rule:  "DTSTART;TZID=America/New_York:20250309T000000 RRULE:FREQ=HOURLY;INTERVAL=1",
+			after: "2025-03-08T00:00:00Z",
+			limit: 4,
+			want:  []string{"2025-03-09 00:00 EST", "2025-03-09 01:00 EST", "2025-03-09 03:00 EDT", "2025-03-09 04:00 EDT"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseScheduleRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseScheduleRule: %v", err)
+			}
+			after, err := time.Parse(time.RFC3339, tt.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, occurrence := range rule.Occurrences(after, time.Time{}, tt.limit) {
+				got = append(got, occurrence.Format("2006-01-02 15:04 MST"))
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("occurrences:\n got %s\nwant %s", strings.Join(got, ", "), strings.Join(tt.want, ", "))
+			}
+		})
+	}
+}
+
+func TestOccurrencesBefore(t *testing.T) {
+	rule, err := ParseScheduleRule("DTSTART:20250101T000000Z RRULE:FREQ=HOURLY;INTERVAL=6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := rule.Occurrences(after, after.Add(24*time.Hour), 0); len(got) != 4 {
+		t.Errorf("got %d occurrences in a day, want 4: %v", len(got), got)
+	}
+	if got := rule.Occurrences(after, time.Time{}, 0); got != nil {
+		t.Errorf("an unbounded expansion returned %v", got)
+	}
+}
+
+func TestParseScheduleRuleErrors(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{"RRULE:FREQ=DAILY;INTERVAL=1", "a DTSTART with a time zone is required"},
+		{"DTSTART:20250101T000000 RRULE:FREQ=DAILY;INTERVAL=1", "needs a time zone"},
+		{"DTSTART;TZID=Mars/Olympus:20250101T000000 RRULE:FREQ=DAILY;INTERVAL=1", `unknown time zone "Mars/Olympus"`},
+		{"DTSTART:20250101T000000Z", "at least one RRULE is required"},
+		{"DTSTART:20250101T000000Z RRULE:FREQ=DAILY", "INTERVAL is required by AWX"},
+		{"DTSTART:20250101T000000Z RRULE:FREQ=SECONDLY;INTERVAL=1", "FREQ=SECONDLY is not supported"},
+		{"DTSTART:20250101T000000Z RRULE:FREQ=DAILY;INTERVAL=1;COUNT=2;UNTIL=20250201T000000Z", "COUNT and UNTIL cannot both be given"},
+		{"DTSTART:20250101T000000Z RRULE:FREQ=DAILY;INTERVAL=1;COUNT=1000", "COUNT must be between 1 and 999"},
+		{"DTSTART:20250101T000000Z RRULE:FREQ=MONTHLY;INTERVAL=1;BYDAY=-1FR", "use BYDAY=FR;BYSETPOS=-1 instead"},
+		{"DTSTART:20250101T000000Z RRULE:FREQ=MONTHLY;INTERVAL=1;BYSETPOS=1", "BYSETPOS needs another BY part"},
+		{"DTSTART:20250101T000000Z RRULE:FREQ=DAILY;INTERVAL=1 EXDATE:20250102T000000Z", "EXDATE lines are not supported"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseScheduleRule(tt.rule); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseScheduleRule(%q) error = %v, want %q", tt.rule, err, tt.want)
+		}
+	}
+}
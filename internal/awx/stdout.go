@@ -0,0 +1,132 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// defaultMaxStdoutBytes bounds how much of /stdout/ is read into memory
+const defaultMaxStdoutBytes = 4 << 20
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// StripANSI removes terminal color and cursor escape sequences
+func StripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
+
+// StdoutOptions selects the format and line range of a job stdout request
+type StdoutOptions struct {
+	Format    string // txt (default), ansi or json
+	StartLine int    // First line (0-based, inclusive)
+	EndLine   int    // Last line (exclusive); 0 means until the end
+	MaxBytes  int    // Read at most this many bytes (default 4 MiB)
+}
+
+// JobStdout is a slice of a job's stdout. StartLine/EndLine/TotalLines are
+// only known for the json format, otherwise TotalLines is -1.
+type JobStdout struct {
+	Format     string
+	Content    string
+	StartLine  int
+	EndLine    int
+	TotalLines int
+	Truncated  bool
+}
+
+type stdoutJSON struct {
+	Range struct {
+		Start       int `json:"start"`
+		End         int `json:"end"`
+		AbsoluteEnd int `json:"absolute_end"`
+	} `json:"range"`
+	Content string `json:"content"`
+}
+
+// GetJobStdout retrieves a range of a job's stdout in the requested format
+func (c *Client) GetJobStdout(ctx context.Context, jobID int, opts StdoutOptions) (*JobStdout, error) {
+	format := opts.Format
+	if format == "" {
+		format = "txt"
+	}
+	if format != "txt" && format != "ansi" && format != "json" {
+		return nil, fmt.Errorf("unsupported stdout format %q (supported: txt, ansi, json)", format)
+	}
+
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxStdoutBytes
+	}
+
+	query := url.Values{}
+	query.Set("format", format)
+	if opts.StartLine > 0 {
+		query.Set("start_line", strconv.Itoa(opts.StartLine))
+	}
+	if opts.EndLine > 0 {
+		query.Set("end_line", strconv.Itoa(opts.EndLine))
+	}
+
+	path, err := c.jobPath(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := path + "stdout/?" + query.Encode()
+
+	resp, err := c.send(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read one byte past the cap to detect truncation
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxBytes)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	truncated := len(body) > maxBytes
+	if truncated {
+		body = body[:maxBytes]
+	}
+
+	stdout := &JobStdout{
+		Format:     format,
+		StartLine:  opts.StartLine,
+		EndLine:    opts.EndLine,
+		TotalLines: -1,
+		Truncated:  truncated,
+	}
+
+	if format != "json" {
+		stdout.Content = string(body)
+		return stdout, nil
+	}
+
+	if truncated {
+		return nil, fmt.Errorf("stdout for job %d exceeds %d bytes, request a smaller line range", jobID, maxBytes)
+	}
+
+	var decoded stdoutJSON
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode stdout: %w", err)
+	}
+	stdout.Content = decoded.Content
+	stdout.StartLine = decoded.Range.Start
+	stdout.EndLine = decoded.Range.End
+	stdout.TotalLines = decoded.Range.AbsoluteEnd
+	return stdout, nil
+}
+
+// GetJobStdoutLineCount returns the total number of stdout lines of a job
+func (c *Client) GetJobStdoutLineCount(ctx context.Context, jobID int) (int, error) {
+	stdout, err := c.GetJobStdout(ctx, jobID, StdoutOptions{Format: "json", StartLine: 0, EndLine: 1})
+	if err != nil {
+		return 0, err
+	}
+	return stdout.TotalLines, nil
+}
@@ -0,0 +1,349 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SurveySpec is the survey a job template asks before launch
+type SurveySpec struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Spec        []SurveyQuestion `json:"spec"`
+}
+
+// SurveyQuestion is one survey question. Min and Max bound the length of text
+// answers and the value of numeric ones.
+type SurveyQuestion struct {
+	QuestionName        string        `json:"question_name"`
+	QuestionDescription string        `json:"question_description"`
+	Variable            string        `json:"variable"`
+	Type                string        `json:"type"` // text, textarea, password, integer, float, multiplechoice, multiselect
+	Required            bool          `json:"required"`
+	Min                 *float64      `json:"min"`
+	Max                 *float64      `json:"max"`
+	Default             interface{}   `json:"default"`
+	Choices             SurveyChoices `json:"choices"`
+}
+
+// SurveyChoices accepts both forms AWX stores choices in: a newline-separated
+// string (older surveys) or a list
+type SurveyChoices []string
+
+func (c *SurveyChoices) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*c = list
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return nil // Choices are irrelevant to the types that send something else
+	}
+	*c = splitLines(text)
+	return nil
+}
+
+func splitLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// GetSurveySpec returns the survey of a job template. Templates without a survey return an empty spec.
+func (c *Client) GetSurveySpec(ctx context.Context, templateID int) (*SurveySpec, error) {
+	var spec SurveySpec
+	endpoint := fmt.Sprintf("/api/v2/job_templates/%d/survey_spec/", templateID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Question returns the question setting variable
+func (s *SurveySpec) Question(variable string) (SurveyQuestion, bool) {
+	for _, question := range s.Spec {
+		if question.Variable == variable {
+			return question, true
+		}
+	}
+	return SurveyQuestion{}, false
+}
+
+// JSONSchema describes the extra_vars the survey accepts as a JSON schema.
+// additionalVars allows variables outside the survey, as templates that
+// prompt for variables do.
+func (s *SurveySpec) JSONSchema(additionalVars bool) map[string]interface{} {
+	properties := make(map[string]interface{}, len(s.Spec))
+	required := []string{}
+
+	for _, question := range s.Spec {
+		property := map[string]interface{}{"title": question.QuestionName}
+		if question.QuestionDescription != "" {
+			property["description"] = question.QuestionDescription
+		}
+
+		switch question.Type {
+		case "integer", "float":
+			property["type"] = "integer"
+			if question.Type == "float" {
+				property["type"] = "number"
+			}
+			if question.Min != nil {
+				property["minimum"] = *question.Min
+			}
+			if question.Max != nil {
+				property["maximum"] = *question.Max
+			}
+		case "multiplechoice":
+			property["type"] = "string"
+			property["enum"] = question.Choices
+		case "multiselect":
+			property["type"] = "array"
+			property["items"] = map[string]interface{}{"type": "string", "enum": question.Choices}
+			property["uniqueItems"] = true
+		default: // text, textarea, password
+			property["type"] = "string"
+			if question.Min != nil {
+				property["minLength"] = int(*question.Min)
+			}
+			if question.Max != nil {
+				property["maxLength"] = int(*question.Max)
+			}
+			if question.Type == "password" {
+				property["format"] = "password"
+				property["writeOnly"] = true
+			}
+		}
+
+		if question.Type != "password" && question.Default != nil && question.Default != "" {
+			if value, err := question.coerce(question.Default); err == nil {
+				property["default"] = value
+			}
+		}
+		if question.Required {
+			required = append(required, question.Variable)
+		}
+		properties[question.Variable] = property
+	}
+
+	return map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": additionalVars,
+	}
+}
+
+// Validate checks extra_vars against the survey and returns them coerced to
+// the survey types, with field errors keyed by variable. Variables the survey
+// does not ask are passed through untouched.
+func (s *SurveySpec) Validate(vars map[string]interface{}) (map[string]interface{}, map[string][]string) {
+	coerced := make(map[string]interface{}, len(vars))
+	for name, value := range vars {
+		coerced[name] = value
+	}
+	fieldErrors := make(map[string][]string)
+
+	for _, question := range s.Spec {
+		value, ok := vars[question.Variable]
+		if !ok || isBlank(value) {
+			// AWX fills in the default of unanswered questions
+			if question.Required && (question.Default == nil || question.Default == "") {
+				fieldErrors[question.Variable] = append(fieldErrors[question.Variable], fmt.Sprintf("%q is required", question.QuestionName))
+			}
+			if ok {
+				delete(coerced, question.Variable)
+			}
+			continue
+		}
+
+		converted, err := question.coerce(value)
+		if err == nil {
+			err = question.check(converted)
+		}
+		if err != nil {
+			fieldErrors[question.Variable] = append(fieldErrors[question.Variable], err.Error())
+			continue
+		}
+		coerced[question.Variable] = converted
+	}
+
+	if len(fieldErrors) == 0 {
+		return coerced, nil
+	}
+	return coerced, fieldErrors
+}
+
+func isBlank(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	case []string:
+		return len(v) == 0
+	}
+	return false
+}
+
+// coerce converts an answer to the type of the question. Strings are accepted
+// for every type since tool arguments often arrive as text.
+func (q SurveyQuestion) coerce(value interface{}) (interface{}, error) {
+	switch q.Type {
+	case "integer":
+		switch v := value.(type) {
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, fmt.Errorf("must be a whole number, got %v", v)
+			}
+			return int64(v), nil
+		case int:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case string:
+			parsed, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("must be an integer, got %q", v)
+			}
+			return parsed, nil
+		}
+		return nil, fmt.Errorf("must be an integer, got %T", value)
+
+	case "float":
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("must be a number, got %q", v)
+			}
+			return parsed, nil
+		}
+		return nil, fmt.Errorf("must be a number, got %T", value)
+
+	case "multiselect":
+		switch v := value.(type) {
+		case []string:
+			return v, nil
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				text, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("must be a list of strings, got %T in the list", item)
+				}
+				items = append(items, text)
+			}
+			return items, nil
+		case string:
+			// A single string holds one choice per line, or comma-separated choices
+			if strings.Contains(v, "\n") {
+				return splitLines(v), nil
+			}
+			var items []string
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			return items, nil
+		}
+		return nil, fmt.Errorf("must be a list of choices, got %T", value)
+
+	default: // text, textarea, password, multiplechoice
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case float64:
+			if q.Type == "password" {
+				return nil, fmt.Errorf("must be a string")
+			}
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case int:
+			if q.Type == "password" {
+				return nil, fmt.Errorf("must be a string")
+			}
+			return strconv.Itoa(v), nil
+		case bool:
+			if q.Type == "password" {
+				return nil, fmt.Errorf("must be a string")
+			}
+			return strconv.FormatBool(v), nil
+		}
+		return nil, fmt.Errorf("must be a string, got %T", value)
+	}
+}
+
+// check applies the bounds and choices of the question to a coerced answer.
+// Password values are never quoted back.
+func (q SurveyQuestion) check(value interface{}) error {
+	switch v := value.(type) {
+	case int64:
+		return q.checkRange(float64(v), strconv.FormatInt(v, 10))
+	case float64:
+		return q.checkRange(v, strconv.FormatFloat(v, 'f', -1, 64))
+	case []string:
+		seen := make(map[string]bool, len(v))
+		for _, item := range v {
+			if !q.hasChoice(item) {
+				return fmt.Errorf("%q is not one of %s", item, strings.Join(q.Choices, ", "))
+			}
+			if seen[item] {
+				return fmt.Errorf("%q is selected more than once", item)
+			}
+			seen[item] = true
+		}
+	case string:
+		if q.Type == "multiplechoice" {
+			if !q.hasChoice(v) {
+				return fmt.Errorf("%q is not one of %s", v, strings.Join(q.Choices, ", "))
+			}
+			return nil
+		}
+		length := len([]rune(v))
+		if q.Min != nil && float64(length) < *q.Min {
+			return fmt.Errorf("must be at least %d characters, got %d", int(*q.Min), length)
+		}
+		if q.Max != nil && float64(length) > *q.Max {
+			return fmt.Errorf("must be at most %d characters, got %d", int(*q.Max), length)
+		}
+	}
+	return nil
+}
+
+func (q SurveyQuestion) checkRange(value float64, text string) error {
+	if q.Min != nil && value < *q.Min {
+		return fmt.Errorf("must be at least %v, got %s", *q.Min, text)
+	}
+	if q.Max != nil && value > *q.Max {
+		return fmt.Errorf("must be at most %v, got %s", *q.Max, text)
+	}
+	return nil
+}
+
+func (q SurveyQuestion) hasChoice(value string) bool {
+	for _, choice := range q.Choices {
+		if choice == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func bound(v float64) *float64 { return &v }
+
+func TestSurveyChoicesUnmarshal(t *testing.T) {
+	tests := map[string][]string{
+		`["a","b"]`:           {"a", "b"},
+		`"a\n b \n\nc\n"`:     {"a", "b", "c"},
+		`""`:                  nil,
+		`123`:                 nil, // Numeric questions store whatever AWX put there
+		`{"unexpected":true}`: nil,
+	}
+	for data, want := range tests {
+		var choices SurveyChoices
+		if err := json.Unmarshal([]byte(data), &choices); err != nil {
+			t.Errorf("unmarshal %s: %v", data, err)
+		}
+		if !reflect.DeepEqual([]string(choices), want) {
+			t.Errorf("unmarshal %s = %q, want %q", data, choices, want)
+		}
+	}
+}
+
+func TestSurveyCoerce(t *testing.T) {
+	tests := []struct {
+		name     string
+		question SurveyQuestion
+		value    interface{}
+		want     interface{}
+		err      string // Error substring of coerce or check
+	}{
+		{name: "integer from JSON number", question: SurveyQuestion{Type: "integer"}, value: 8080.0, want: int64(8080)},
+		{name: "integer from string", question: SurveyQuestion{Type: "integer"}, value: " 42 ", want: int64(42)},
+		{name: "integer from int", question: SurveyQuestion{Type: "integer"}, value: 3, want: int64(3)},
+		{name: "integer fraction", question: SurveyQuestion{Type: "integer"}, value: 3.5, err: "must be a whole number, got 3.5"},
+		{name: "integer text", question: SurveyQuestion{Type: "integer"}, value: "many", err: `must be an integer, got "many"`},
+		{name: "integer bool", question: SurveyQuestion{Type: "integer"}, value: true, err: "must be an integer, got bool"},
+		{name: "integer below min", question: SurveyQuestion{Type: "integer", Min: bound(1)}, value: "0", err: "must be at least 1, got 0"},
+		{name: "integer above max", question: SurveyQuestion{Type: "integer", Max: bound(10)}, value: 11.0, err: "must be at most 10, got 11"},
+		{name: "integer at bounds", question: SurveyQuestion{Type: "integer", Min: bound(1), Max: bound(10)}, value: 10.0, want: int64(10)},
+		{name: "float from string", question: SurveyQuestion{Type: "float"}, value: "0.25", want: 0.25},
+		{name: "float from int", question: SurveyQuestion{Type: "float"}, value: 2, want: 2.0},
+		{name: "float text", question: SurveyQuestion{Type: "float"}, value: "half", err: `must be a number, got "half"`},
+		{name: "float above max", question: SurveyQuestion{Type: "float", Max: bound(1)}, value: 1.5, err: "must be at most 1, got 1.5"},
+		{name: "text from number", question: SurveyQuestion{Type: "text"}, value: 1.5, want: "1.5"},
+		{name: "text from bool", question: SurveyQuestion{Type: "textarea"}, value: true, want: "true"},
+		{name: "text from list", question: SurveyQuestion{Type: "text"}, value: []interface{}{"a"}, err: "must be a string, got []interface {}"},
+		{name: "text too short", question: SurveyQuestion{Type: "text", Min: bound(3)}, value: "ab", err: "must be at least 3 characters, got 2"},
+		{name: "text counts runes", question: SurveyQuestion{Type: "text", Max: bound(3)}, value: "été", want: "été"},
+		{name: "text too long", question: SurveyQuestion{Type: "text", Max: bound(3)}, value: "abcd", err: "must be at most 3 characters, got 4"},
+		{name: "password", question: SurveyQuestion{Type: "password", Min: bound(8)}, value: "correct horse", want: "correct horse"},
+		{name: "password from number", question: SurveyQuestion{Type: "password"}, value: 1234.0, err: "must be a string"},
+		{name: "password too short", question: SurveyQuestion{Type: "password", Min: bound(8)}, value: "hunter2", err: "must be at least 8 characters, got 7"},
+		{name: "choice", question: SurveyQuestion{Type: "multiplechoice", Choices: SurveyChoices{"dev", "prod"}}, value: "prod", want: "prod"},
+		{name: "choice not listed", question: SurveyQuestion{Type: "multiplechoice", Choices: SurveyChoices{"dev", "prod"}}, value: "qa", err: `"qa" is not one of dev, prod`},
+		{name: "multiselect list", question: SurveyQuestion{Type: "multiselect", Choices: SurveyChoices{"a", "b", "c"}}, value: []interface{}{"a", "c"}, want: []string{"a", "c"}},
+		{name: "multiselect commas", question: SurveyQuestion{Type: "multiselect", Choices: SurveyChoices{"a", "b", "c"}}, value: "a, b,", want: []string{"a", "b"}},
+		{name: "multiselect lines", question: SurveyQuestion{Type: "multiselect", Choices: SurveyChoices{"a,1", "b"}}, value: "a,1\nb", want: []string{"a,1", "b"}},
+		{name: "multiselect duplicate", question: SurveyQuestion{Type: "multiselect", Choices: SurveyChoices{"a", "b"}}, value: []string{"a", "a"}, err: `"a" is selected more than once`},
+		{name: "multiselect not listed", question: SurveyQuestion{Type: "multiselect", Choices: SurveyChoices{"a", "b"}}, value: []string{"z"}, err: `"z" is not one of a, b`},
+		{name: "multiselect mixed list", question: SurveyQuestion{Type: "multiselect"}, value: []interface{}{"a", 1.0}, err: "got float64 in the list"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.question.coerce(tt.value)
+			if err == nil {
+				err = tt.question.check(got)
+			}
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// deploySurvey asks the questions the validation tests answer
+func deploySurvey() *SurveySpec {
+	return &SurveySpec{Spec: []SurveyQuestion{
+		{QuestionName: "Environment", Variable: "env", Type: "multiplechoice", Required: true, Choices: SurveyChoices{"dev", "prod"}},
+		{QuestionName: "Port", Variable: "port", Type: "integer", Required: true, Min: bound(1), Max: bound(65535)},
+		{QuestionName: "Replicas", Variable: "replicas", Type: "integer", Required: true, Default: 2.0},
+		{QuestionName: "Ratio", Variable: "ratio", Type: "float"},
+		{QuestionName: "Admin password", Variable: "admin_password", Type: "password", Min: bound(12)},
+		{QuestionName: "Regions", Variable: "regions", Type: "multiselect", Choices: SurveyChoices{"eu", "us"}},
+	}}
+}
+
+func TestSurveyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		vars   map[string]interface{}
+		want   map[string]interface{}
+		errors map[string][]string
+	}{
+		{
+			name: "coerced answers and pass-through variables",
+			vars: map[string]interface{}{"env": "prod", "port": "8080", "ratio": 1.0, "regions": "eu,us", "build": 7.0},
+			want: map[string]interface{}{"env": "prod", "port": int64(8080), "ratio": 1.0, "regions": []string{"eu", "us"}, "build": 7.0},
+		},
+		{
+			name: "blank optional answers are dropped",
+			vars: map[string]interface{}{"env": "dev", "port": 22.0, "ratio": " ", "regions": []interface{}{}},
+			want: map[string]interface{}{"env": "dev", "port": int64(22)},
+		},
+		{
+			name: "required questions without a default",
+			vars: map[string]interface{}{"port": ""},
+			want: map[string]interface{}{},
+			errors: map[string][]string{
+				"env":  {`"Environment" is required`},
+				"port": {`"Port" is required`},
+			},
+		},
+		{
+			name: "every invalid answer is reported",
+			vars: map[string]interface{}{"env": "qa", "port": 70000.0, "replicas": "two", "admin_password": "short", "regions": []string{"eu", "eu"}},
+			want: map[string]interface{}{"env": "qa", "port": 70000.0, "replicas": "two", "admin_password": "short", "regions": []string{"eu", "eu"}},
+			errors: map[string][]string{
+				"env":            {`"qa" is not one of dev, prod`},
+				"port":           {"must be at most 65535, got 70000"},
+				"replicas":       {`must be an integer, got "two"`},
+				"admin_password": {"must be at least 12 characters, got 5"},
+				"regions":        {`"eu" is selected more than once`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, fieldErrors := deploySurvey().Validate(tt.vars)
+			if !reflect.DeepEqual(fieldErrors, tt.errors) {
+				t.Errorf("errors = %v, want %v", fieldErrors, tt.errors)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("vars = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	// Password values are never quoted back
+	_, fieldErrors := deploySurvey().Validate(map[string]interface{}{"env": "dev", "port": 1.0, "admin_password": "s3cret"})
+	if len(fieldErrors["admin_password"]) != 1 || strings.Contains(fieldErrors["admin_password"][0], "s3cret") {
+		t.Errorf("password errors = %v, want one that does not quote the value", fieldErrors)
+	}
+}
+
+func TestSurveyJSONSchema(t *testing.T) {
+	schema := deploySurvey().JSONSchema(false)
+	properties := schema["properties"].(map[string]interface{})
+
+	if got := schema["required"]; !reflect.DeepEqual(got, []string{"env", "port", "replicas"}) {
+		t.Errorf("required = %v", got)
+	}
+	port := properties["port"].(map[string]interface{})
+	if port["type"] != "integer" || port["minimum"] != 1.0 || port["maximum"] != 65535.0 {
+		t.Errorf("port = %v", port)
+	}
+	if replicas := properties["replicas"].(map[string]interface{}); replicas["default"] != int64(2) {
+		t.Errorf("replicas default = %#v, want the coerced default", replicas["default"])
+	}
+	password := properties["admin_password"].(map[string]interface{})
+	if password["writeOnly"] != true || password["minLength"] != 12 {
+		t.Errorf("admin_password = %v", password)
+	}
+	if schema["additionalProperties"] != false {
+		t.Error("additionalProperties should follow the variables prompt")
+	}
+}
+
+// surveyTemplate serves template 5 "deploy" with the deploy survey and
+// records the launch body
+func surveyTemplate(t *testing.T, askVariables bool, launched *map[string]interface{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/job_templates/":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"count": 1, "results": []JobTemplate{{ID: 5, Name: "deploy"}}})
+		case r.URL.Path == "/api/v2/job_templates/5/survey_spec/":
+			writeJSON(t, w, http.StatusOK, deploySurvey())
+		case r.URL.Path == "/api/v2/job_templates/5/launch/" && r.Method == http.MethodGet:
+			writeJSON(t, w, http.StatusOK, LaunchRequirements{SurveyEnabled: true, AskVariablesOnLaunch: askVariables})
+		case r.URL.Path == "/api/v2/job_templates/5/launch/" && r.Method == http.MethodPost:
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			*launched = body
+			writeJSON(t, w, http.StatusCreated, JobLaunchResponse{Job: 42})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestLaunchReportsSurveyErrors(t *testing.T) {
+	var launched map[string]interface{}
+	client, _ := newTestClient(t, surveyTemplate(t, false, &launched), ClientConfig{})
+
+	_, err := client.LaunchJob(context.Background(), LaunchJobOptions{
+		TemplateNameOrID: "deploy",
+		ExtraVars:        map[string]interface{}{"env": "staging", "port": "http", "debug": true},
+	})
+	var launchErr *LaunchValidationError
+	if !errors.As(err, &launchErr) || Classify(err) != ErrorValidation {
+		t.Fatalf("error = %v, want a LaunchValidationError", err)
+	}
+	want := map[string][]string{
+		"extra_vars.env":   {`"staging" is not one of dev, prod`},
+		"extra_vars.port":  {`must be an integer, got "http"`},
+		"extra_vars.debug": {"is not a survey question and the template does not prompt for variables"},
+	}
+	if !reflect.DeepEqual(launchErr.FieldErrors, want) {
+		t.Errorf("field errors = %v, want %v", launchErr.FieldErrors, want)
+	}
+	if launchErr.Template != "deploy" || !strings.Contains(err.Error(), "extra_vars.port must be an integer") {
+		t.Errorf("error = %v", err)
+	}
+	if launched != nil {
+		t.Errorf("launched %v despite the survey errors", launched)
+	}
+}
+
+func TestLaunchSendsCoercedSurveyAnswers(t *testing.T) {
+	var launched map[string]interface{}
+	client, _ := newTestClient(t, surveyTemplate(t, true, &launched), ClientConfig{})
+
+	result, err := client.LaunchJob(context.Background(), LaunchJobOptions{
+		TemplateNameOrID: "5",
+		ExtraVars:        map[string]interface{}{"env": "prod", "port": "8443", "regions": "eu", "debug": true},
+	})
+	if err != nil {
+		t.Fatalf("LaunchJob: %v", err)
+	}
+	if result.JobID != 42 {
+		t.Errorf("job ID = %d, want 42", result.JobID)
+	}
+	want := map[string]interface{}{"env": "prod", "port": 8443.0, "regions": []interface{}{"eu"}, "debug": true}
+	if !reflect.DeepEqual(launched["extra_vars"], want) {
+		t.Errorf("extra_vars = %#v, want %#v", launched["extra_vars"], want)
+	}
+}
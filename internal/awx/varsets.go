@@ -0,0 +1,216 @@
+package awx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VarSet is a named group of extra_vars kept on the server, such as the
+// defaults of one environment
+type VarSet struct {
+	Description string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	Vars        map[string]interface{} `yaml:"vars" json:"vars"`
+}
+
+// VarSets holds the var-sets launches can merge, by name
+type VarSets map[string]VarSet
+
+// LoadVarSets reads a var-set file. YAML and JSON are accepted:
+//
+//	var_sets:
+//	  production:
+//	    description: Production defaults
+//	    vars:
+//	      environment: production
+//	      resource_limits: {cpu: "2", memory: 4Gi}
+func LoadVarSets(path string) (VarSets, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read var-sets: %w", err)
+	}
+
+	// Vars are kept as nodes so that their dates can be decoded as written
+	var file struct {
+		VarSets map[string]struct {
+			Description string    `yaml:"description"`
+			Vars        yaml.Node `yaml:"vars"`
+		} `yaml:"var_sets"`
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse var-sets %s: %w", path, err)
+	}
+
+	sets := make(VarSets, len(file.VarSets))
+	for name, set := range file.VarSets {
+		vars, err := decodeVars(&set.Vars)
+		if err != nil {
+			return nil, fmt.Errorf("invalid var-set %s in %s: %w", name, path, err)
+		}
+		sets[name] = VarSet{Description: set.Description, Vars: vars}
+	}
+	return sets, nil
+}
+
+// Names returns the var-set names in order
+func (v VarSets) Names() []string {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Merge layers the named var-sets in order and then extraVars on top. Nested
+// maps are merged key by key; any other value, lists included, replaces the
+// earlier one. The inputs are not modified.
+func (v VarSets) Merge(names []string, extraVars map[string]interface{}) (map[string]interface{}, error) {
+	merged := make(map[string]interface{})
+	for _, name := range names {
+		set, ok := v[name]
+		if !ok {
+			if len(v) == 0 {
+				return nil, fmt.Errorf("var-set '%s' not found, no var-sets are configured", name)
+			}
+			return nil, fmt.Errorf("var-set '%s' not found. Available var-sets: %s", name, strings.Join(v.Names(), ", "))
+		}
+		mergeVars(merged, set.Vars)
+	}
+	mergeVars(merged, extraVars)
+	return merged, nil
+}
+
+// MergeExtraVars layers override on top of base, merging nested maps key by
+// key like Merge. The inputs are not modified.
+func MergeExtraVars(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	mergeVars(merged, base)
+	mergeVars(merged, override)
+	return merged
+}
+
+func mergeVars(into, from map[string]interface{}) {
+	for key, value := range from {
+		source, isMap := value.(map[string]interface{})
+		if !isMap {
+			into[key] = value
+			continue
+		}
+		// Maps in into are always built here, so merging never writes into a var-set
+		target, ok := into[key].(map[string]interface{})
+		if !ok {
+			target = make(map[string]interface{}, len(source))
+			into[key] = target
+		}
+		mergeVars(target, source)
+	}
+}
+
+// ParseExtraVars reads extra_vars given as a JSON object or a YAML block
+// mapping, keeping the types of the values: numbers, booleans, lists and
+// nested objects.
+func ParseExtraVars(text string) (map[string]interface{}, error) {
+	if strings.TrimSpace(text) == "" {
+		return map[string]interface{}{}, nil
+	}
+
+	var vars map[string]interface{}
+	jsonErr := json.Unmarshal([]byte(text), &vars)
+	if jsonErr == nil {
+		if vars == nil {
+			vars = map[string]interface{}{}
+		}
+		return vars, nil
+	}
+	if strings.HasPrefix(strings.TrimSpace(text), "{") {
+		// Text that looks like JSON is held to JSON: YAML would read a typo as a null value
+		return nil, fmt.Errorf("extra_vars is not a valid JSON object: %w", jsonErr)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &node); err != nil {
+		return nil, fmt.Errorf("extra_vars is not a valid JSON or YAML object: %w", err)
+	}
+	vars, err := decodeVars(&node)
+	if err != nil {
+		return nil, fmt.Errorf("extra_vars is not a valid JSON or YAML object: %w", err)
+	}
+	return vars, nil
+}
+
+// decodeVars decodes a YAML mapping into JSON-compatible values. Unquoted
+// dates stay strings as written: decoded, they would become time.Time values
+// rendered as "2024-05-01T00:00:00Z".
+func decodeVars(node *yaml.Node) (map[string]interface{}, error) {
+	keepTimestampsAsText(node)
+	var vars map[string]interface{}
+	if node.Kind != 0 { // Empty documents and missing vars decode to no vars
+		if err := node.Decode(&vars); err != nil {
+			return nil, err
+		}
+	}
+	return normalizeVars(vars)
+}
+
+func keepTimestampsAsText(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!timestamp" {
+		node.Tag = "!!str"
+	}
+	for _, child := range node.Content {
+		keepTimestampsAsText(child)
+	}
+}
+
+// normalizeVars converts what YAML decodes into JSON-compatible values:
+// mappings with non-string keys become string-keyed maps
+func normalizeVars(vars map[string]interface{}) (map[string]interface{}, error) {
+	normalized := make(map[string]interface{}, len(vars))
+	for key, value := range vars {
+		converted, err := normalizeValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		normalized[key] = converted
+	}
+	return normalized, nil
+}
+
+func normalizeValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return normalizeVars(v)
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			switch key.(type) {
+			case string, int, int64, uint64, float64, bool:
+			default:
+				return nil, fmt.Errorf("unsupported key type %T", key)
+			}
+			normalized, err := normalizeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			converted[fmt.Sprint(key)] = normalized
+		}
+		return converted, nil
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized, err := normalizeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = normalized
+		}
+		return items, nil
+	}
+	return value, nil
+}
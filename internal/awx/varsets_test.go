@@ -0,0 +1,185 @@
+package awx
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseExtraVars(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string // JSON of the parsed vars
+		err  string // Error substring
+	}{
+		{name: "empty", text: "  ", want: `{}`},
+		{name: "JSON", text: `{"replicas": 3, "debug": true, "zones": ["a", "b"], "limits": {"cpu": "2"}}`,
+			want: `{"debug":true,"limits":{"cpu":"2"},"replicas":3,"zones":["a","b"]}`},
+		{name: "JSON null", text: `null`, want: `{}`},
+		{name: "YAML keeps types", text: "replicas: 3\nratio: 0.5\ndebug: yes\nenabled: true\nzones: [a, b]\nlimits:\n  cpu: \"2\"\n  memory: 4Gi\n",
+			want: `{"debug":"yes","enabled":true,"limits":{"cpu":"2","memory":"4Gi"},"ratio":0.5,"replicas":3,"zones":["a","b"]}`},
+		{name: "YAML dates stay as written", text: "release_date: 2024-05-01\nwindow:\n  start: 2024-05-01 22:00:00\n  days: [2024-05-02, \"2024-05-03\"]\n",
+			want: `{"release_date":"2024-05-01","window":{"days":["2024-05-02","2024-05-03"],"start":"2024-05-01 22:00:00"}}`},
+		{name: "YAML non-string keys", text: "ports:\n  80: http\n  443: https\n", want: `{"ports":{"443":"https","80":"http"}}`},
+		{name: "YAML anchors", text: "base: &base {cpu: \"1\", since: 2024-01-01}\nweb: *base\n",
+			want: `{"base":{"cpu":"1","since":"2024-01-01"},"web":{"cpu":"1","since":"2024-01-01"}}`},
+		{name: "YAML comments only", text: "# nothing to set\n", want: `{}`},
+		{name: "JSON typo", text: `{"replicas": 3,}`, err: "not a valid JSON object"},
+		{name: "not a mapping", text: "- a\n- b\n", err: "not a valid JSON or YAML object"},
+		{name: "scalar", text: "production", err: "not a valid JSON or YAML object"},
+		{name: "invalid YAML", text: "a: [b\n", err: "not a valid JSON or YAML object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars, err := ParseExtraVars(tt.text)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseExtraVars: %v", err)
+			}
+			got, err := json.Marshal(vars)
+			if err != nil {
+				t.Fatalf("vars are not JSON-compatible: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("vars = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func testVarSets() VarSets {
+	return VarSets{
+		"production": {Vars: map[string]interface{}{
+			"environment": "production",
+			"replicas":    3,
+			"zones":       []interface{}{"a", "b", "c"},
+			"limits":      map[string]interface{}{"cpu": "2", "memory": "4Gi"},
+		}},
+		"maintenance": {Vars: map[string]interface{}{
+			"drain":  true,
+			"zones":  []interface{}{"a"},
+			"limits": map[string]interface{}{"cpu": "1", "tuning": map[string]interface{}{"gc": "aggressive"}},
+		}},
+	}
+}
+
+func TestVarSetsMerge(t *testing.T) {
+	tests := []struct {
+		name      string
+		names     []string
+		extraVars map[string]interface{}
+		want      string // JSON of the merged vars
+		err       string
+	}{
+		{name: "nothing", want: `{}`},
+		{name: "extra_vars only", extraVars: map[string]interface{}{"replicas": 1}, want: `{"replicas":1}`},
+		{name: "one set", names: []string{"production"},
+			want: `{"environment":"production","limits":{"cpu":"2","memory":"4Gi"},"replicas":3,"zones":["a","b","c"]}`},
+		{name: "nested maps merge and lists are replaced", names: []string{"production", "maintenance"},
+			want: `{"drain":true,"environment":"production","limits":{"cpu":"1","memory":"4Gi","tuning":{"gc":"aggressive"}},"replicas":3,"zones":["a"]}`},
+		{name: "order matters", names: []string{"maintenance", "production"},
+			want: `{"drain":true,"environment":"production","limits":{"cpu":"2","memory":"4Gi","tuning":{"gc":"aggressive"}},"replicas":3,"zones":["a","b","c"]}`},
+		{name: "extra_vars come last", names: []string{"production"},
+			extraVars: map[string]interface{}{"replicas": 5, "limits": map[string]interface{}{"memory": "8Gi"}, "zones": []interface{}{}},
+			want:      `{"environment":"production","limits":{"cpu":"2","memory":"8Gi"},"replicas":5,"zones":[]}`},
+		{name: "a scalar replaces a map", names: []string{"production"}, extraVars: map[string]interface{}{"limits": "none"},
+			want: `{"environment":"production","limits":"none","replicas":3,"zones":["a","b","c"]}`},
+		{name: "a map replaces a scalar", names: []string{"production"}, extraVars: map[string]interface{}{"replicas": map[string]interface{}{"min": 1}},
+			want: `{"environment":"production","limits":{"cpu":"2","memory":"4Gi"},"replicas":{"min":1},"zones":["a","b","c"]}`},
+		{name: "unknown set", names: []string{"staging"}, err: "var-set 'staging' not found. Available var-sets: maintenance, production"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sets := testVarSets()
+			merged, err := sets.Merge(tt.names, tt.extraVars)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Merge: %v", err)
+			}
+			if got, _ := json.Marshal(merged); string(got) != tt.want {
+				t.Errorf("merged = %s, want %s", got, tt.want)
+			}
+
+			// Writing into the result must not reach the var-sets either
+			if limits, ok := merged["limits"].(map[string]interface{}); ok {
+				limits["cpu"] = "changed"
+				if tuning, ok := limits["tuning"].(map[string]interface{}); ok {
+					tuning["gc"] = "changed"
+				}
+			}
+			if !reflect.DeepEqual(sets, testVarSets()) {
+				t.Errorf("Merge modified the var-sets: %v", sets)
+			}
+		})
+	}
+
+	if _, err := (VarSets{}).Merge([]string{"production"}, nil); err == nil || !strings.Contains(err.Error(), "no var-sets are configured") {
+		t.Errorf("error without var-sets = %v", err)
+	}
+}
+
+func TestMergeExtraVars(t *testing.T) {
+	base := map[string]interface{}{"limits": map[string]interface{}{"cpu": "1"}, "zones": []interface{}{"a"}}
+	override := map[string]interface{}{"limits": map[string]interface{}{"memory": "2Gi"}, "zones": []interface{}{"b"}}
+
+	merged := MergeExtraVars(base, override)
+	if got, _ := json.Marshal(merged); string(got) != `{"limits":{"cpu":"1","memory":"2Gi"},"zones":["b"]}` {
+		t.Errorf("merged = %s", got)
+	}
+	if limits := base["limits"].(map[string]interface{}); len(limits) != 1 {
+		t.Errorf("MergeExtraVars modified its base: %v", base)
+	}
+}
+
+func TestLoadVarSets(t *testing.T) {
+	sets, err := LoadVarSets("../../var-sets.example.yaml")
+	if err != nil {
+		t.Fatalf("example var-sets: %v", err)
+	}
+	if len(sets.Names()) == 0 {
+		t.Fatal("the example defines no var-sets")
+	}
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	sets, err = LoadVarSets(write("dates.yaml", "var_sets:\n  release:\n    description: Next release\n    vars:\n      release_date: 2024-05-01\n      ports: {80: http}\n  empty:\n    description: No vars\n"))
+	if err != nil {
+		t.Fatalf("LoadVarSets: %v", err)
+	}
+	if got, _ := json.Marshal(sets["release"].Vars); string(got) != `{"ports":{"80":"http"},"release_date":"2024-05-01"}` || sets["release"].Description != "Next release" {
+		t.Errorf("release = %s (%q)", got, sets["release"].Description)
+	}
+	if vars := sets["empty"].Vars; vars == nil || len(vars) != 0 {
+		t.Errorf("empty vars = %#v, want an empty map", vars)
+	}
+
+	for name, content := range map[string]string{
+		"unknown field": "var_sets:\n  release:\n    variables: {a: 1}\n",
+		"vars list":     "var_sets:\n  release:\n    vars: [a, b]\n",
+	} {
+		if _, err := LoadVarSets(write(strings.ReplaceAll(name, " ", "-")+".yaml", content)); err == nil {
+			t.Errorf("%s: expected LoadVarSets to fail", name)
+		}
+	}
+}
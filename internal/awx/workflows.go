@@ -0,0 +1,250 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LaunchWorkflowOptions mirrors LaunchJobOptions for workflow job templates
+type LaunchWorkflowOptions struct {
+	TemplateNameOrID string
+	ExtraVars        map[string]interface{}
+	Inventory        string
+	Limit            string
+}
+
+// WorkflowNodeStatus describes one node of a workflow job graph together with
+// the status of the job it spawned (if any)
+type WorkflowNodeStatus struct {
+	NodeID       int     `json:"node_id"`
+	Identifier   string  `json:"identifier,omitempty"`
+	TemplateName string  `json:"template_name"`
+	TemplateType string  `json:"template_type"`
+	JobID        int     `json:"job_id,omitempty"`
+	JobType      string  `json:"job_type,omitempty"`
+	Status       string  `json:"status"`
+	Elapsed      float64 `json:"elapsed,omitempty"`
+	DoNotRun     bool    `json:"do_not_run,omitempty"`
+	SuccessNodes []int   `json:"success_nodes,omitempty"`
+	FailureNodes []int   `json:"failure_nodes,omitempty"`
+	AlwaysNodes  []int   `json:"always_nodes,omitempty"`
+}
+
+// IsPendingApproval reports whether the node is an approval step waiting for a decision
+func (n WorkflowNodeStatus) IsPendingApproval() bool {
+	return n.JobType == "workflow_approval" && n.Status == "pending"
+}
+
+func (c *Client) GetWorkflowJobTemplates(ctx context.Context) ([]WorkflowJobTemplate, error) {
+	list, err := c.GetWorkflowJobTemplateList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return list.Results, nil
+}
+
+// GetWorkflowJobTemplateList returns every workflow job template (following
+// pagination) along with the total count reported by AWX
+func (c *Client) GetWorkflowJobTemplateList(ctx context.Context) (*ListResult[WorkflowJobTemplate], error) {
+	// Cache key for workflow job templates
+	cacheKey := "awx:workflow_job_templates"
+
+	// Try cache first
+	if cached, ok := c.cache.Get(cacheKey); ok {
+		if list, ok := cached.(*ListResult[WorkflowJobTemplate]); ok {
+			if c.debug {
+				log.Printf("Cache HIT: workflow job templates (%d items)", len(list.Results))
+			}
+			return list.clone(), nil
+		}
+	}
+
+	if c.debug {
+		log.Printf("Cache MISS: workflow job templates - fetching from AWX")
+	}
+
+	list, err := listAll[WorkflowJobTemplate](ctx, c, "/api/v2/workflow_job_templates/", ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache for 5 minutes
+	c.cache.Set(cacheKey, list, 5*time.Minute)
+
+	return list.clone(), nil
+}
+
+func (c *Client) GetWorkflowJobTemplateByName(ctx context.Context, nameOrID string) (*WorkflowJobTemplate, error) {
+	templates, err := c.GetWorkflowJobTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates {
+		if template.Name == nameOrID {
+			return &template, nil
+		}
+	}
+
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		for _, template := range templates {
+			if template.ID == id {
+				return &template, nil
+			}
+		}
+	}
+
+	var available []string
+	for _, template := range templates {
+		available = append(available, fmt.Sprintf("%s (ID: %d)", template.Name, template.ID))
+	}
+
+	return nil, fmt.Errorf("workflow job template '%s' not found. Available workflow templates: %s", nameOrID, strings.Join(available, ", "))
+}
+
+// LaunchWorkflow launches a workflow job template with optional extra_vars,
+// inventory and limit
+func (c *Client) LaunchWorkflow(ctx context.Context, options LaunchWorkflowOptions) (*LaunchResult, error) {
+	if options.TemplateNameOrID == "" {
+		return nil, fmt.Errorf("workflow template name or ID is required")
+	}
+
+	template, err := c.GetWorkflowJobTemplateByName(ctx, options.TemplateNameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	request := make(map[string]interface{})
+	if len(options.ExtraVars) > 0 {
+		request["extra_vars"] = options.ExtraVars
+	}
+	if options.Inventory != "" {
+		inventory, err := c.ResolveID(ctx, ResourceInventory, options.Inventory)
+		if err != nil {
+			return nil, err
+		}
+		request["inventory"] = inventory
+	}
+	if options.Limit != "" {
+		request["limit"] = options.Limit
+	}
+
+	var response WorkflowJobLaunchResponse
+	endpoint := fmt.Sprintf("/api/v2/workflow_job_templates/%d/launch/", template.ID)
+	if err := c.makeRequest(ctx, "POST", endpoint, request, &response); err != nil {
+		return nil, fmt.Errorf("failed to launch workflow '%s': %w", template.Name, err)
+	}
+
+	workflowJobID := response.WorkflowJob
+	if workflowJobID == 0 {
+		workflowJobID = response.ID
+	}
+
+	message := fmt.Sprintf("Successfully launched workflow job %d using workflow template '%s'", workflowJobID, template.Name)
+	if len(options.ExtraVars) > 0 {
+		message += fmt.Sprintf(" with %d extra variables", len(options.ExtraVars))
+	}
+	if len(response.IgnoredFields) > 0 {
+		var ignored []string
+		for field := range response.IgnoredFields {
+			ignored = append(ignored, field)
+		}
+		message += fmt.Sprintf(" (AWX ignored: %s)", strings.Join(ignored, ", "))
+	}
+
+	log.Printf("Workflow launched successfully: ID %d, Template: %s", workflowJobID, template.Name)
+
+	return &LaunchResult{
+		JobID:      workflowJobID,
+		Status:     "pending",
+		URL:        c.workflowJobURL(workflowJobID),
+		LaunchType: "workflow",
+		Message:    message,
+	}, nil
+}
+
+func (c *Client) GetWorkflowJob(ctx context.Context, workflowJobID int) (*WorkflowJob, error) {
+	var job WorkflowJob
+	endpoint := fmt.Sprintf("/api/v2/workflow_jobs/%d/", workflowJobID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &job); err != nil {
+		return nil, err
+	}
+
+	job.URL = c.workflowJobURL(workflowJobID)
+	return &job, nil
+}
+
+// GetWorkflowJobNodes returns the node graph of a workflow job with the
+// status of every spawned job
+func (c *Client) GetWorkflowJobNodes(ctx context.Context, workflowJobID int) ([]WorkflowNodeStatus, error) {
+	endpoint := fmt.Sprintf("/api/v2/workflow_jobs/%d/workflow_nodes/", workflowJobID)
+	list, err := listAll[WorkflowJobNode](ctx, c, endpoint, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]WorkflowNodeStatus, 0, len(list.Results))
+	for _, node := range list.Results {
+		status := WorkflowNodeStatus{
+			NodeID:       node.ID,
+			Identifier:   node.Identifier,
+			Status:       "not_started",
+			DoNotRun:     node.DoNotRun,
+			SuccessNodes: node.SuccessNodes,
+			FailureNodes: node.FailureNodes,
+			AlwaysNodes:  node.AlwaysNodes,
+		}
+
+		if ujt := node.SummaryFields.UnifiedJobTemplate; ujt != nil {
+			status.TemplateName = ujt.Name
+			status.TemplateType = ujt.UnifiedJobType
+		}
+		if job := node.SummaryFields.Job; job != nil {
+			status.JobID = job.ID
+			status.JobType = job.Type
+			status.Status = job.Status
+			status.Elapsed = job.Elapsed
+		} else if node.DoNotRun {
+			status.Status = "skipped"
+		}
+
+		nodes = append(nodes, status)
+	}
+
+	return nodes, nil
+}
+
+func (c *Client) GetWorkflowApproval(ctx context.Context, approvalID int) (*WorkflowApproval, error) {
+	var approval WorkflowApproval
+	endpoint := fmt.Sprintf("/api/v2/workflow_approvals/%d/", approvalID)
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &approval); err != nil {
+		return nil, err
+	}
+	return &approval, nil
+}
+
+// ApproveWorkflowApproval approves a pending workflow approval node
+func (c *Client) ApproveWorkflowApproval(ctx context.Context, approvalID int) error {
+	endpoint := fmt.Sprintf("/api/v2/workflow_approvals/%d/approve/", approvalID)
+	if err := c.makeRequest(ctx, "POST", endpoint, map[string]interface{}{}, nil); err != nil {
+		return fmt.Errorf("failed to approve workflow approval %d: %w", approvalID, err)
+	}
+	return nil
+}
+
+// DenyWorkflowApproval denies a pending workflow approval node
+func (c *Client) DenyWorkflowApproval(ctx context.Context, approvalID int) error {
+	endpoint := fmt.Sprintf("/api/v2/workflow_approvals/%d/deny/", approvalID)
+	if err := c.makeRequest(ctx, "POST", endpoint, map[string]interface{}{}, nil); err != nil {
+		return fmt.Errorf("failed to deny workflow approval %d: %w", approvalID, err)
+	}
+	return nil
+}
+
+func (c *Client) workflowJobURL(workflowJobID int) string {
+	return c.baseURL + "/#/jobs/workflow/" + strconv.Itoa(workflowJobID) + "/output"
+}
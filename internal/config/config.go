@@ -14,6 +14,31 @@ type Config struct {
 	AWXPassword  string
 	AWXToken     string
 	EnableDebug  bool
+
+	AWXPageSize     int
+	AWXMaxListItems int
+
+	PrometheusURL      string
+	PrometheusUsername string
+	PrometheusPassword string
+
+	AlertmanagerURL      string
+	AlertmanagerUsername string
+	AlertmanagerPassword string
+
+	KubeAPIServer  string
+	KubeToken      string
+	KubeCAFile     string
+	KubeInsecure   bool
+	Kubeconfig     string
+	KubeNamespace  string
+
+	AutoscaleMode     string
+	AutoscaleTemplate string
+	ScalingPolicyFile string
+	HealthConfigFile  string
+	VarSetsFile       string
+	AdHocPolicyFile   string
 }
 
 func LoadConfig() *Config {
@@ -23,6 +48,26 @@ func LoadConfig() *Config {
 	awxUsername := flag.String("awx-username", "", "AWX username")
 	awxPassword := flag.String("awx-password", "", "AWX password")
 	awxToken := flag.String("awx-token", "", "AWX API token (alternative to username/password)")
+	awxPageSize := flag.Int("awx-page-size", 200, "page size used when listing AWX resources")
+	awxMaxListItems := flag.Int("awx-max-list-items", 5000, "maximum number of items collected by a single AWX list call")
+	prometheusURL := flag.String("prometheus-url", "", "Prometheus base URL (enables observability tools)")
+	prometheusUsername := flag.String("prometheus-username", "", "Prometheus basic auth username")
+	prometheusPassword := flag.String("prometheus-password", "", "Prometheus basic auth password")
+	alertmanagerURL := flag.String("alertmanager-url", "", "Alertmanager base URL (enables alert and silence tools)")
+	alertmanagerUsername := flag.String("alertmanager-username", "", "Alertmanager basic auth username")
+	alertmanagerPassword := flag.String("alertmanager-password", "", "Alertmanager basic auth password")
+	kubeAPIServer := flag.String("k8s-api-url", "", "Kubernetes API server URL (default: kubeconfig or in-cluster service account)")
+	kubeToken := flag.String("k8s-token", "", "Kubernetes bearer token used with -k8s-api-url")
+	kubeCAFile := flag.String("k8s-ca-file", "", "CA certificate file for the Kubernetes API server")
+	kubeInsecure := flag.Bool("k8s-insecure", false, "skip TLS verification of the Kubernetes API server")
+	kubeconfig := flag.String("kubeconfig", "", "path to a kubeconfig file")
+	kubeNamespace := flag.String("k8s-namespace", "", "Kubernetes namespace of the Autosphere deployments (default: from kubeconfig or service account)")
+	autoscaleMode := flag.String("autoscale-mode", "awx", "how autoscale applies changes: awx (launch the autoscale job template) or patch (scale the deployment directly)")
+	autoscaleTemplate := flag.String("autoscale-template", "autosphere-autoscale", "AWX job template launched by autoscale in awx mode")
+	scalingPolicyFile := flag.String("scaling-policy", "", "YAML or JSON scaling policy file (default: built-in policy)")
+	healthConfigFile := flag.String("health-config", "", "YAML or JSON file configuring the health probes of each component (default: probe the configured backends)")
+	varSetsFile := flag.String("var-sets", "", "YAML or JSON file of named extra_vars sets (such as per-environment defaults) that launches can merge")
+	adHocPolicyFile := flag.String("ad-hoc-policy", "", "YAML or JSON allowlist of the modules and arguments ad hoc commands may use (default: built-in read-only allowlist)")
 	
 	flag.Parse()
 
@@ -35,15 +80,51 @@ func LoadConfig() *Config {
 		AWXPassword:  *awxPassword,
 		AWXToken:     *awxToken,
 		EnableDebug:  *enableDebug,
+
+		AWXPageSize:     *awxPageSize,
+		AWXMaxListItems: *awxMaxListItems,
+
+		PrometheusURL:      *prometheusURL,
+		PrometheusUsername: *prometheusUsername,
+		PrometheusPassword: *prometheusPassword,
+
+		AlertmanagerURL:      *alertmanagerURL,
+		AlertmanagerUsername: *alertmanagerUsername,
+		AlertmanagerPassword: *alertmanagerPassword,
+
+		KubeAPIServer: *kubeAPIServer,
+		KubeToken:     *kubeToken,
+		KubeCAFile:    *kubeCAFile,
+		KubeInsecure:  *kubeInsecure,
+		Kubeconfig:    *kubeconfig,
+		KubeNamespace: *kubeNamespace,
+
+		AutoscaleMode:     *autoscaleMode,
+		AutoscaleTemplate: *autoscaleTemplate,
+		ScalingPolicyFile: *scalingPolicyFile,
+		HealthConfigFile:  *healthConfigFile,
+		VarSetsFile:       *varSetsFile,
+		AdHocPolicyFile:   *adHocPolicyFile,
 	}
 
 	if config.EnableDebug {
-		log.Printf("Configuration loaded: %+v", config)
+		log.Printf("Configuration loaded: %+v", config.redacted())
 	}
 
 	return config
 }
 
+// redacted returns a copy safe to log, with passwords and tokens masked
+func (c *Config) redacted() Config {
+	copied := *c
+	for _, secret := range []*string{&copied.AWXPassword, &copied.AWXToken, &copied.PrometheusPassword, &copied.AlertmanagerPassword, &copied.KubeToken} {
+		if *secret != "" {
+			*secret = "[REDACTED]"
+		}
+	}
+	return copied
+}
+
 func (c *Config) IsHTTPMode() bool {
 	return c.HTTPAddr != ""
 }
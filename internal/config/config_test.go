@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRedactedHidesSecrets(t *testing.T) {
+	config := &Config{
+		AWXUsername:          "admin",
+		AWXPassword:          "awx-pass",
+		AWXToken:             "awx-token",
+		PrometheusPassword:   "prom-pass",
+		AlertmanagerPassword: "am-pass",
+		KubeToken:            "kube-token",
+	}
+
+	logged := fmt.Sprintf("%+v", config.redacted())
+	for _, secret := range []string{"awx-pass", "awx-token", "prom-pass", "am-pass", "kube-token"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("%q is logged: %s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, "admin") {
+		t.Errorf("non-secret fields are missing: %s", logged)
+	}
+	if config.AWXPassword != "awx-pass" {
+		t.Error("redacted changed the loaded configuration")
+	}
+}
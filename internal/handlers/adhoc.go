@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (h *AutomationHandler) RunAdHocCommand(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.RunAdHocCommandArgs{}
+
+	inventory, err := request.RequireString("inventory")
+	if err != nil {
+		return mcp.NewToolResultError("inventory is required"), nil
+	}
+	args.Inventory = inventory
+
+	module, err := request.RequireString("module_name")
+	if err != nil {
+		return mcp.NewToolResultError("module_name is required"), nil
+	}
+	args.ModuleName = module
+	args.ModuleArgs = request.GetString("module_args", "")
+	args.Limit = request.GetString("limit", "")
+	args.Credential = request.GetString("credential", "")
+
+	if forks := request.GetString("forks", ""); forks != "" {
+		value, err := strconv.Atoi(forks)
+		if err != nil {
+			return mcp.NewToolResultError("forks must be an integer"), nil
+		}
+		args.Forks = value
+	}
+	for name, target := range map[string]*bool{
+		"become": &args.Become,
+		"wait":   &args.Wait,
+	} {
+		value, err := optionalBool(request, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		*target = value != nil && *value
+	}
+	if timeout, err := strconv.Atoi(request.GetString("timeout", "600")); err == nil {
+		args.Timeout = timeout
+	}
+	if interval, err := strconv.Atoi(request.GetString("poll_interval", "10")); err == nil {
+		args.PollInterval = interval
+	}
+
+	reporter := newProgressReporter(ctx, request)
+	onProgress := func(p models.JobProgress) {
+		message := fmt.Sprintf("Ad hoc command %d %s (elapsed %s)", p.JobID, p.Status, p.Elapsed.Round(time.Second))
+		reporter.Report(p.Elapsed.Seconds(), p.Timeout.Seconds(), message)
+	}
+
+	output, err := h.automationService.RunAdHocCommand(ctx, args, onProgress)
+	if err != nil {
+		log.Printf("Run ad hoc command failed: %v", err)
+		return awxToolError("Failed to run ad hoc command", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s Ad Hoc Command %d: %s\n\n", jobStatusEmoji(output.Status), output.JobID, output.Status))
+	builder.WriteString(fmt.Sprintf("**Module:** %s", output.ModuleName))
+	if output.ModuleArgs != "" {
+		builder.WriteString(fmt.Sprintf(" `%s`", output.ModuleArgs))
+	}
+	builder.WriteString("\n")
+	builder.WriteString(fmt.Sprintf("**Inventory:** %d", output.InventoryID))
+	if output.Limit != "" {
+		builder.WriteString(fmt.Sprintf(" (limit: %s)", output.Limit))
+	}
+	builder.WriteString("\n")
+	if output.Become {
+		builder.WriteString("**Become:** yes\n")
+	}
+
+	if result := output.Result; result != nil {
+		if result.TimedOut {
+			builder.WriteString(fmt.Sprintf("\n⏰ Stopped waiting after %s\n", result.Waited))
+		}
+		if len(result.Job.HostResults) > 0 {
+			builder.WriteString("\n📊 **Host Recap:**\n")
+			for _, host := range result.Job.HostResults {
+				builder.WriteString(fmt.Sprintf("- **%s**: ok=%d changed=%d failed=%d unreachable=%d\n",
+					host.Host, host.OK, host.Changed, host.Failed, host.Unreachable))
+			}
+		}
+		if len(result.Job.Failures) > 0 {
+			builder.WriteString("\n❌ **Failures:**\n")
+			for _, failure := range result.Job.Failures {
+				builder.WriteString(fmt.Sprintf("- **%s**: %s\n", failure.Host, failure.Message))
+			}
+		}
+	}
+	if output.Output != "" {
+		builder.WriteString(fmt.Sprintf("\n**Output (last lines):**\n```\n%s```\n", output.Output))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+func (h *AutomationHandler) ListAdHocModules(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	output, err := h.automationService.ListAdHocModules(ctx, models.ListAdHocModulesArgs{})
+	if err != nil {
+		log.Printf("List ad hoc modules failed: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list ad hoc modules: %v", err)), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🛡️ Ad Hoc Command Allowlist (%d modules)\n\n", len(output.Modules)))
+	for _, module := range output.Modules {
+		builder.WriteString(fmt.Sprintf("• **%s**", module.Name))
+		if module.Description != "" {
+			builder.WriteString(fmt.Sprintf(" - %s", module.Description))
+		}
+		builder.WriteString("\n")
+		if len(module.Args) == 0 {
+			builder.WriteString("   - no module_args\n")
+		}
+		for _, pattern := range module.Args {
+			builder.WriteString(fmt.Sprintf("   - `%s`\n", pattern))
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("\n**Max forks:** %d\n", output.MaxForks))
+	builder.WriteString(fmt.Sprintf("**Become allowed:** %t\n", output.AllowBecome))
+	builder.WriteString(fmt.Sprintf("**Whole-inventory runs allowed:** %t\n", output.AllowNoLimit))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
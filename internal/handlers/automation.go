@@ -7,7 +7,9 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/interfaces"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -35,38 +37,125 @@ func (h *AutomationHandler) LaunchAWXJob(ctx context.Context, request mcp.CallTo
 	}
 	args.JobTemplate = jobTemplate
 	
-	// Optional: extra_vars (parse as JSON string)
-	extraVarsStr := request.GetString("extra_vars", "")
-	if extraVarsStr != "" {
-		extraVars := make(map[string]string)
-		if err := json.Unmarshal([]byte(extraVarsStr), &extraVars); err != nil {
-			log.Printf("Warning: Failed to parse extra_vars: %v", err)
-		} else {
-			args.ExtraVars = extraVars
-		}
+	// Optional: extra_vars (JSON or YAML) layered over the named var-sets
+	extraVars, err := extraVarsArgument(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
+	args.ExtraVars = extraVars
+	args.VarSets = splitList(request.GetString("var_sets", ""))
 	
 	// Optional parameters using GetString with defaults
 	args.Inventory = request.GetString("inventory", "")
 	args.Limit = request.GetString("limit", "")
 	args.Tags = request.GetString("tags", "")
 	args.SkipTags = request.GetString("skip_tags", "")
+	args.JobType = request.GetString("job_type", "")
+	args.ExecutionEnvironment = request.GetString("execution_environment", "")
+	args.ScmBranch = request.GetString("scm_branch", "")
+	args.Credentials = splitList(request.GetString("credentials", ""))
+	args.Labels = splitList(request.GetString("labels", ""))
+	args.InstanceGroups = splitList(request.GetString("instance_groups", ""))
+	
+	// Numeric prompts are only sent when given, so an explicit 0 overrides the template
+	intParams := map[string]**int{
+		"verbosity":       &args.Verbosity,
+		"forks":           &args.Forks,
+		"timeout":         &args.JobTimeout,
+		"job_slice_count": &args.JobSliceCount,
+	}
+	for name, target := range intParams {
+		value := request.GetString(name, "")
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s must be an integer", name)), nil
+		}
+		*target = &parsed
+	}
+	if value := request.GetString("diff_mode", ""); value != "" {
+		diffMode, err := strconv.ParseBool(value)
+		if err != nil {
+			return mcp.NewToolResultError("diff_mode must be true or false"), nil
+		}
+		args.DiffMode = &diffMode
+	}
 	
 	// Call the actual automation service
 	output, err := h.automationService.LaunchJob(ctx, args)
 	if err != nil {
 		log.Printf("AWX job launch failed: %v", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to launch AWX job: %v", err)), nil
+		return awxToolError("Failed to launch AWX job", err), nil
 	}
 	
 	// Format successful response
 	resultJSON, _ := json.MarshalIndent(output, "", "  ")
-	message := fmt.Sprintf("✅ AWX Job Launched Successfully\n\n**Job Details:**\n- Job ID: %d\n- Status: %s\n- AWX URL: %s\n\n**Full Response:**\n```json\n%s\n```", 
-		output.JobID, output.Status, output.URL, string(resultJSON))
+	var ignored string
+	if len(output.IgnoredFields) > 0 {
+		ignored = fmt.Sprintf("\n⚠️ AWX ignored: %s\n", strings.Join(output.IgnoredFields, ", "))
+	}
+	if len(output.VarSets) > 0 {
+		ignored = fmt.Sprintf("- Var-sets: %s\n", strings.Join(output.VarSets, ", ")) + ignored
+	}
+	message := fmt.Sprintf("✅ AWX Job Launched Successfully\n\n**Job Details:**\n- Job ID: %d\n- Template: %s\n- Status: %s\n- AWX URL: %s\n%s\n**Full Response:**\n```json\n%s\n```", 
+		output.JobID, output.Template, output.Status, output.URL, ignored, string(resultJSON))
 	
 	return mcp.NewToolResultText(message), nil
 }
 
+// extraVarsArgument reads the extra_vars argument, given either as an object
+// or as JSON or YAML text, keeping the types of its values
+func extraVarsArgument(request mcp.CallToolRequest) (map[string]interface{}, error) {
+	return varsArgument(request, "extra_vars")
+}
+
+// varsArgument reads a variables argument such as extra_vars or host
+// variables, given either as an object or as JSON or YAML text
+func varsArgument(request mcp.CallToolRequest, name string) (map[string]interface{}, error) {
+	switch value := request.GetArguments()[name].(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return value, nil
+	case string:
+		vars, err := awx.ParseExtraVars(value)
+		if err != nil && name != "extra_vars" {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return vars, err
+	default:
+		return nil, fmt.Errorf("%s must be a JSON or YAML object, not %T", name, value)
+	}
+}
+
+// ListVarSets lists the server-side var-sets launches can merge
+func (h *AutomationHandler) ListVarSets(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	output, err := h.automationService.ListVarSets(ctx, models.ListVarSetsArgs{})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list var-sets: %v", err)), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("📦 Var-Sets\n\n**Found %d var-sets:**\n\n", output.Total))
+	if output.Total == 0 {
+		builder.WriteString("No var-sets are configured. Start the server with -var-sets to add some.\n\n")
+	}
+	for _, set := range output.VarSets {
+		builder.WriteString(fmt.Sprintf("**%s**\n", set.Name))
+		if set.Description != "" {
+			builder.WriteString(fmt.Sprintf("   - Description: %s\n", set.Description))
+		}
+		builder.WriteString(fmt.Sprintf("   - Variables: %s\n\n", strings.Join(sortedKeys(set.Vars), ", ")))
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
 // CheckAWXJobStatus checks the status of a running or completed AWX job
 func (h *AutomationHandler) CheckAWXJobStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := models.AWXStatusArgs{}
@@ -87,7 +176,7 @@ func (h *AutomationHandler) CheckAWXJobStatus(ctx context.Context, request mcp.C
 	output, err := h.automationService.CheckJobStatus(ctx, args)
 	if err != nil {
 		log.Printf("AWX job status check failed: %v", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to check AWX job status: %v", err)), nil
+		return awxToolError("Failed to check AWX job status", err), nil
 	}
 	
 	// Format response with status information
@@ -112,6 +201,105 @@ func (h *AutomationHandler) CheckAWXJobStatus(ctx context.Context, request mcp.C
 		message += fmt.Sprintf("- Finished: %s\n", output.FinishedAt)
 	}
 	
+	// Add play recap per host
+	if len(output.HostResults) > 0 {
+		message += "\n📊 **Play Recap:**\n"
+		for _, host := range output.HostResults {
+			message += fmt.Sprintf("- **%s**: ok=%d changed=%d failed=%d unreachable=%d skipped=%d\n",
+				host.Host, host.OK, host.Changed, host.Failed, host.Unreachable, host.Skipped)
+		}
+	}
+	
+	// Add failing tasks
+	if len(output.Failures) > 0 {
+		message += "\n❌ **Failures:**\n"
+		for _, failure := range output.Failures {
+			message += fmt.Sprintf("- **%s** — task '%s'", failure.Host, failure.Task)
+			if failure.Module != "" {
+				message += fmt.Sprintf(" (%s)", failure.Module)
+			}
+			if failure.Message != "" {
+				message += fmt.Sprintf(": %s", failure.Message)
+			}
+			message += "\n"
+		}
+	}
+	
+	message += fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON))
+	
+	return mcp.NewToolResultText(message), nil
+}
+
+// WaitForAWXJob blocks until an AWX job finishes, reporting progress while it waits
+func (h *AutomationHandler) WaitForAWXJob(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.WaitForJobArgs{}
+	
+	// Required: job_id
+	jobIDStr, err := request.RequireString("job_id")
+	if err != nil {
+		return mcp.NewToolResultError("job_id is required"), nil
+	}
+	
+	if jobID, err := strconv.Atoi(jobIDStr); err == nil {
+		args.JobID = jobID
+	} else {
+		return mcp.NewToolResultError("job_id must be a valid integer"), nil
+	}
+	
+	// Optional parameters
+	if timeout, err := strconv.Atoi(request.GetString("timeout", "600")); err == nil {
+		args.Timeout = timeout
+	}
+	if interval, err := strconv.Atoi(request.GetString("poll_interval", "10")); err == nil {
+		args.PollInterval = interval
+	}
+	
+	// Forward every poll to the client as a progress notification
+	reporter := newProgressReporter(ctx, request)
+	onProgress := func(p models.JobProgress) {
+		message := fmt.Sprintf("Job %d %s (elapsed %s)", p.JobID, p.Status, p.Elapsed.Round(time.Second))
+		if p.Task != "" {
+			message += fmt.Sprintf(" - task: %s", p.Task)
+		}
+		reporter.Report(p.Elapsed.Seconds(), p.Timeout.Seconds(), message)
+	}
+	
+	output, err := h.automationService.WaitForJob(ctx, args, onProgress)
+	if err != nil {
+		log.Printf("Wait for AWX job failed: %v", err)
+		return awxToolError("Failed to wait for AWX job", err), nil
+	}
+	
+	job := output.Job
+	header := fmt.Sprintf("%s AWX Job %d finished: %s", jobStatusEmoji(job.Status), job.JobID, job.Status)
+	if output.TimedOut {
+		header = fmt.Sprintf("⏰ Timed out waiting for AWX Job %d (still %s)", job.JobID, job.Status)
+	}
+	
+	message := fmt.Sprintf("%s\n\n**Waited:** %s\n", header, output.Waited)
+	if output.LastTask != "" {
+		message += fmt.Sprintf("**Last task:** %s\n", output.LastTask)
+	}
+	if job.ElapsedTime != "" {
+		message += fmt.Sprintf("**Job duration:** %s\n", job.ElapsedTime)
+	}
+	
+	if len(job.HostResults) > 0 {
+		message += "\n📊 **Play Recap:**\n"
+		for _, host := range job.HostResults {
+			message += fmt.Sprintf("- **%s**: ok=%d changed=%d failed=%d unreachable=%d skipped=%d\n",
+				host.Host, host.OK, host.Changed, host.Failed, host.Unreachable, host.Skipped)
+		}
+	}
+	
+	if len(job.Failures) > 0 {
+		message += "\n❌ **Failures:**\n"
+		for _, failure := range job.Failures {
+			message += fmt.Sprintf("- **%s** — task '%s': %s\n", failure.Host, failure.Task, failure.Message)
+		}
+	}
+	
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
 	message += fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON))
 	
 	return mcp.NewToolResultText(message), nil
@@ -151,7 +339,8 @@ func (h *AutomationHandler) CheckAutosphereHealth(ctx context.Context, request m
 	
 	// Add component details
 	message += "**Component Status:**\n"
-	for name, component := range output.Components {
+	for _, name := range sortedKeys(output.Components) {
+		component := output.Components[name]
 		componentEmoji := "✅"
 		switch component.Status {
 		case "healthy":
@@ -164,6 +353,15 @@ func (h *AutomationHandler) CheckAutosphereHealth(ctx context.Context, request m
 			componentEmoji = "❓"
 		}
 		message += fmt.Sprintf("- %s **%s**: %s - %s\n", componentEmoji, name, component.Status, component.Details)
+		for _, probe := range component.Probes {
+			line := fmt.Sprintf("  - %s %s [%s]: %s in %s", healthStatusEmoji(probe.Status), probe.Name, probe.Type, probe.Status, probe.Latency)
+			if probe.Error != "" {
+				line += " - " + probe.Error
+			} else if probe.Message != "" {
+				line += " - " + probe.Message
+			}
+			message += line + "\n"
+		}
 	}
 	
 	// Add recommendations if any
@@ -181,6 +379,53 @@ func (h *AutomationHandler) CheckAutosphereHealth(ctx context.Context, request m
 	return mcp.NewToolResultText(message), nil
 }
 
+// GetHealthHistory reports uptime, flapping and recent samples from the background health probes
+func (h *AutomationHandler) GetHealthHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.GetHealthHistoryArgs{
+		Component: request.GetString("component", ""),
+		Window:    request.GetString("window", ""),
+	}
+	if samples, err := strconv.Atoi(request.GetString("samples", "10")); err == nil {
+		args.Samples = samples
+	}
+	
+	output, err := h.automationService.GetHealthHistory(ctx, args)
+	if err != nil {
+		log.Printf("Get health history failed: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get health history: %v", err)), nil
+	}
+	
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s Autosphere Health History\n\n**Overall Status: %s**\n📅 Window: %s (probed every %s)\n\n",
+		healthStatusEmoji(output.OverallStatus), output.OverallStatus, output.Window, output.Interval))
+	
+	for _, component := range output.Components {
+		builder.WriteString(fmt.Sprintf("%s **%s**: %s since %s\n", healthStatusEmoji(component.Status), component.Component, component.Status, component.StatusSince))
+		builder.WriteString(fmt.Sprintf("- Uptime: %.2f%% (healthy %.2f%%) over %d samples, %d state changes\n",
+			component.UptimePercent, component.HealthyPercent, component.Samples, component.Transitions))
+		builder.WriteString(fmt.Sprintf("- Latency: avg %s, max %s\n", component.AvgLatency, component.MaxLatency))
+		if component.Flapping {
+			builder.WriteString(fmt.Sprintf("- 🔁 Flapping: %d state changes within %s (threshold %d)\n", component.FlapChanges, output.FlapWindow, output.FlapThreshold))
+		}
+		if component.LastError != "" {
+			builder.WriteString(fmt.Sprintf("- Last problem (%s): %s\n", component.LastErrorAt, component.LastError))
+		}
+		if len(component.Recent) > 0 {
+			timeline := ""
+			for _, sample := range component.Recent {
+				timeline += healthStatusEmoji(sample.Status)
+			}
+			builder.WriteString(fmt.Sprintf("- Recent: %s (oldest → newest)\n", timeline))
+		}
+		builder.WriteString("\n")
+	}
+	
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+	
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
 // AutoscaleAutosphere manages autoscaling of Autosphere services based on metrics and thresholds
 func (h *AutomationHandler) AutoscaleAutosphere(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := models.AutoscaleArgs{}
@@ -194,6 +439,7 @@ func (h *AutomationHandler) AutoscaleAutosphere(ctx context.Context, request mcp
 	
 	// Optional parameters using GetString with defaults
 	args.Service = request.GetString("service", "")
+	args.Namespace = request.GetString("namespace", "")
 	args.Threshold = request.GetString("threshold", "")
 	
 	// Parse replicas if provided
@@ -206,7 +452,7 @@ func (h *AutomationHandler) AutoscaleAutosphere(ctx context.Context, request mcp
 	output, err := h.automationService.Autoscale(ctx, args)
 	if err != nil {
 		log.Printf("Autoscaling failed: %v", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to perform autoscaling: %v", err)), nil
+		return awxToolError("Failed to perform autoscaling", err), nil
 	}
 	
 	// Format autoscale response
@@ -221,12 +467,41 @@ func (h *AutomationHandler) AutoscaleAutosphere(ctx context.Context, request mcp
 	case "auto":
 		actionEmoji = "🤖"
 	}
+	if output.Status == "refused" {
+		actionEmoji = "🚫"
+	}
 	
-	message := fmt.Sprintf("%s Autoscaling Action: %s\n\n**Service:** %s\n**Scaling:** %d → %d replicas\n**Reason:** %s\n**Status:** %s\n", 
-		actionEmoji, output.Action, output.Service, output.OldReplicas, output.NewReplicas, output.Reason, output.Status)
+	message := fmt.Sprintf("%s Autoscaling Action: %s\n\n**Service:** %s (namespace %s)\n**Scaling:** %d → %d replicas (%d ready)\n**Reason:** %s\n**Status:** %s\n", 
+		actionEmoji, output.Action, output.Service, output.Namespace, output.OldReplicas, output.NewReplicas, output.ReadyReplicas, output.Reason, output.Status)
+	
+	if output.HPA != nil {
+		message += fmt.Sprintf("**HPA:** %s (min %d, max %d, desired %d)\n", output.HPA.Name, output.HPA.MinReplicas, output.HPA.MaxReplicas, output.HPA.DesiredReplicas)
+	}
 	
 	if output.JobID > 0 {
-		message += fmt.Sprintf("**AWX Job ID:** %d\n", output.JobID)
+		message += fmt.Sprintf("**AWX Job ID:** %d\n**AWX URL:** %s\n", output.JobID, output.JobURL)
+	}
+	
+	if policy := output.Policy; policy != nil {
+		message += fmt.Sprintf("\n**Policy:** %s → deployment %s, %d-%d replicas, step +%d/-%d, cooldown up %s / down %s\n",
+			policy.Rule, policy.Deployment, policy.MinReplicas, policy.MaxReplicas, policy.ScaleUpStep, policy.ScaleDownStep,
+			policy.ScaleUpCooldown, policy.ScaleDownCooldown)
+		if policy.LastScaled != "" {
+			message += fmt.Sprintf("**Last Scaled:** %s\n", policy.LastScaled)
+		}
+		if policy.CooldownRemaining != "" {
+			message += fmt.Sprintf("**Cooldown Remaining:** %s\n", policy.CooldownRemaining)
+		}
+		if len(policy.Metrics) > 0 {
+			message += fmt.Sprintf("**Metrics (window %s):**\n", policy.EvaluationWindow)
+			for _, metric := range policy.Metrics {
+				message += fmt.Sprintf("- %s [%s]: %s\n", metric.Name, metric.Source, formatScalingMetric(metric))
+			}
+		}
+	}
+	
+	for _, warning := range output.Warnings {
+		message += fmt.Sprintf("⚠️ %s\n", warning)
 	}
 	
 	// Add full JSON response
@@ -236,6 +511,25 @@ func (h *AutomationHandler) AutoscaleAutosphere(ctx context.Context, request mcp
 	return mcp.NewToolResultText(message), nil
 }
 
+// formatScalingMetric renders a metric reading with its thresholds and verdict
+func formatScalingMetric(metric models.ScalingMetricEvaluation) string {
+	if metric.Value == nil {
+		if metric.Error != "" {
+			return fmt.Sprintf("unavailable (%s)", metric.Error)
+		}
+		return "unavailable"
+	}
+
+	thresholds := []string{}
+	if metric.ScaleUpAbove != nil {
+		thresholds = append(thresholds, fmt.Sprintf("up > %g", *metric.ScaleUpAbove))
+	}
+	if metric.ScaleDownBelow != nil {
+		thresholds = append(thresholds, fmt.Sprintf("down < %g", *metric.ScaleDownBelow))
+	}
+	return fmt.Sprintf("%g (%s) → %s", *metric.Value, strings.Join(thresholds, ", "), metric.Verdict)
+}
+
 // ListAWXJobs lists AWX jobs with optional filtering
 func (h *AutomationHandler) ListAWXJobs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := models.ListJobsArgs{
@@ -254,7 +548,7 @@ func (h *AutomationHandler) ListAWXJobs(ctx context.Context, request mcp.CallToo
 	output, err := h.automationService.ListJobs(ctx, args)
 	if err != nil {
 		log.Printf("List AWX jobs failed: %v", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list AWX jobs: %v", err)), nil
+		return awxToolError("Failed to list AWX jobs", err), nil
 	}
 	
 	// Use strings.Builder for efficient string concatenation
@@ -307,19 +601,120 @@ func (h *AutomationHandler) GetAWXJobOutput(ctx context.Context, request mcp.Cal
 		return mcp.NewToolResultError("job_id must be a valid integer"), nil
 	}
 	
+	// Optional range, filter and formatting parameters
+	args.Format = request.GetString("format", "txt")
+	args.Grep = request.GetString("grep", "")
+	args.Cursor = request.GetString("cursor", "")
+	args.StripANSI = request.GetString("strip_ansi", "true") == "true"
+	
+	intParams := map[string]*int{
+		"start_line":    &args.StartLine,
+		"end_line":      &args.EndLine,
+		"tail":          &args.Tail,
+		"context_lines": &args.ContextLines,
+		"max_bytes":     &args.MaxBytes,
+	}
+	for name, target := range intParams {
+		value := request.GetString(name, "")
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("%s must be a non-negative integer", name)), nil
+		}
+		*target = parsed
+	}
+	
 	// Call the automation service
 	output, err := h.automationService.GetJobOutput(ctx, args)
 	if err != nil {
 		log.Printf("Get AWX job output failed: %v", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get AWX job output: %v", err)), nil
+		return awxToolError("Failed to get AWX job output", err), nil
 	}
 	
 	// Format job output response
-	message := fmt.Sprintf("📜 AWX Job %d Output\n\n**Job Logs:**\n```\n%s\n```", output.JobID, output.Output)
+	rangeInfo := fmt.Sprintf("lines %d-%d", output.StartLine+1, output.EndLine)
+	if output.TotalLines > 0 {
+		rangeInfo += fmt.Sprintf(" of %d", output.TotalLines)
+	}
+	if args.Grep != "" {
+		rangeInfo += fmt.Sprintf(", %d lines matching `%s`", output.Matches, args.Grep)
+	}
+	
+	message := fmt.Sprintf("📜 AWX Job %d Output (%s)\n\n**Job Logs:**\n```\n%s```", output.JobID, rangeInfo, output.Output)
+	if output.Truncated {
+		message += fmt.Sprintf("\n\n✂️ Output truncated. Call get_job_output again with cursor=\"%s\" (and the same filters) to continue.", output.NextCursor)
+	}
 	
 	return mcp.NewToolResultText(message), nil
 }
 
+// GetAWXJobEvents gets the Ansible events of a specific AWX job with optional filtering
+func (h *AutomationHandler) GetAWXJobEvents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.GetJobEventsArgs{}
+	
+	// Required: job_id
+	jobIDStr, err := request.RequireString("job_id")
+	if err != nil {
+		return mcp.NewToolResultError("job_id is required"), nil
+	}
+	
+	if jobID, err := strconv.Atoi(jobIDStr); err == nil {
+		args.JobID = jobID
+	} else {
+		return mcp.NewToolResultError("job_id must be a valid integer"), nil
+	}
+	
+	// Optional filters
+	args.Host = request.GetString("host", "")
+	args.EventType = request.GetString("event_type", "")
+	args.FailedOnly = request.GetString("failed_only", "false") == "true"
+	if limit, err := strconv.Atoi(request.GetString("limit", "50")); err == nil {
+		args.Limit = limit
+	}
+	
+	// Call the automation service
+	output, err := h.automationService.GetJobEvents(ctx, args)
+	if err != nil {
+		log.Printf("Get AWX job events failed: %v", err)
+		return awxToolError("Failed to get AWX job events", err), nil
+	}
+	
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("📜 AWX Job %d Events\n\n**Found %d events (showing %d):**\n\n", output.JobID, output.Total, len(output.Events)))
+	
+	for _, event := range output.Events {
+		eventEmoji := "•"
+		if event.Failed {
+			eventEmoji = "❌"
+		} else if event.Changed {
+			eventEmoji = "🔶"
+		}
+		
+		builder.WriteString(fmt.Sprintf("%s [%d] %s", eventEmoji, event.Counter, event.Event))
+		if event.Host != "" {
+			builder.WriteString(fmt.Sprintf(" **%s**", event.Host))
+		}
+		if event.Task != "" {
+			builder.WriteString(fmt.Sprintf(" — %s", event.Task))
+		}
+		if event.Module != "" {
+			builder.WriteString(fmt.Sprintf(" (%s)", event.Module))
+		}
+		builder.WriteString("\n")
+		if event.Message != "" && (event.Failed || args.Host != "") {
+			builder.WriteString(fmt.Sprintf("   - %s\n", event.Message))
+		}
+	}
+	
+	// Add full JSON response
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+	
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
 // CancelAWXJob cancels a running AWX job
 func (h *AutomationHandler) CancelAWXJob(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := models.CancelJobArgs{}
@@ -340,7 +735,7 @@ func (h *AutomationHandler) CancelAWXJob(ctx context.Context, request mcp.CallTo
 	output, err := h.automationService.CancelJob(ctx, args)
 	if err != nil {
 		log.Printf("Cancel AWX job failed: %v", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to cancel AWX job: %v", err)), nil
+		return awxToolError("Failed to cancel AWX job", err), nil
 	}
 	
 	// Format cancellation response
@@ -370,7 +765,7 @@ func (h *AutomationHandler) ListAWXResources(ctx context.Context, request mcp.Ca
 	output, err := h.automationService.ListResources(ctx, args)
 	if err != nil {
 		log.Printf("List AWX resources failed: %v", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list AWX resources: %v", err)), nil
+		return awxToolError("Failed to list AWX resources", err), nil
 	}
 	
 	// Format resource list response
@@ -428,7 +823,7 @@ func (h *AutomationHandler) ListJobTemplates(ctx context.Context, request mcp.Ca
 	output, err := h.automationService.ListJobTemplates(ctx, args)
 	if err != nil {
 		log.Printf("List job templates failed: %v", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list job templates: %v", err)), nil
+		return awxToolError("Failed to list job templates", err), nil
 	}
 
 	// Use strings.Builder for efficient string concatenation
@@ -454,6 +849,100 @@ func (h *AutomationHandler) ListJobTemplates(ctx context.Context, request mcp.Ca
 	return mcp.NewToolResultText(message), nil
 }
 
+// DescribeJobTemplate shows the survey and launch-time prompts of a job template
+func (h *AutomationHandler) DescribeJobTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.DescribeJobTemplateArgs{}
+
+	jobTemplate, err := request.RequireString("job_template")
+	if err != nil {
+		return mcp.NewToolResultError("job_template is required"), nil
+	}
+	args.JobTemplate = jobTemplate
+
+	output, err := h.automationService.DescribeJobTemplate(ctx, args)
+	if err != nil {
+		log.Printf("Describe job template failed: %v", err)
+		return awxToolError("Failed to describe job template", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🔧 AWX Job Template: %s (ID: %d)\n\n", output.Name, output.ID))
+	if output.Description != "" {
+		builder.WriteString(fmt.Sprintf("- Description: %s\n", output.Description))
+	}
+	builder.WriteString(fmt.Sprintf("- Playbook: %s\n", output.Playbook))
+	if output.CanStartWithoutUserInput {
+		builder.WriteString("- ✅ Can launch without any input\n")
+	} else {
+		builder.WriteString("- ⚠️ Needs input before it can launch\n")
+	}
+	if output.InventoryNeededToStart {
+		builder.WriteString("- An inventory must be given\n")
+	}
+	if output.CredentialNeededToStart {
+		builder.WriteString("- A credential must be given\n")
+	}
+	if len(output.PasswordsNeededToStart) > 0 {
+		builder.WriteString(fmt.Sprintf("- 🔐 Credential passwords prompted: %s\n", strings.Join(output.PasswordsNeededToStart, ", ")))
+	}
+
+	builder.WriteString("\n**Launch Prompts:**\n")
+	if len(output.Prompts) == 0 {
+		builder.WriteString("No fields are prompted on launch; launch_awx_job accepts only job_template")
+		if output.SurveyEnabled {
+			builder.WriteString(" and the survey answers")
+		}
+		builder.WriteString("\n")
+	}
+	for _, prompt := range output.Prompts {
+		if prompt.Default == nil || prompt.Default == "" {
+			builder.WriteString(fmt.Sprintf("• %s\n", prompt.Field))
+			continue
+		}
+		defaultJSON, _ := json.Marshal(prompt.Default)
+		builder.WriteString(fmt.Sprintf("• %s (default: %s)\n", prompt.Field, string(defaultJSON)))
+	}
+
+	if output.SurveyEnabled {
+		builder.WriteString("\n📝 **Survey** (answer through extra_vars):\n")
+		for _, question := range output.Survey {
+			required := ""
+			if question.Required {
+				required = ", required"
+			}
+			builder.WriteString(fmt.Sprintf("• **%s** (%s%s): %s\n", question.Variable, question.Type, required, question.Question))
+			if question.Description != "" {
+				builder.WriteString(fmt.Sprintf("   - %s\n", question.Description))
+			}
+			if len(question.Choices) > 0 {
+				builder.WriteString(fmt.Sprintf("   - Choices: %s\n", strings.Join(question.Choices, ", ")))
+			}
+			if question.Min != nil || question.Max != nil {
+				bound := func(value *float64) string {
+					if value == nil {
+						return "-"
+					}
+					return strconv.FormatFloat(*value, 'f', -1, 64)
+				}
+				limit := "Range"
+				if question.Type == "text" || question.Type == "textarea" || question.Type == "password" {
+					limit = "Length"
+				}
+				builder.WriteString(fmt.Sprintf("   - %s: %s to %s\n", limit, bound(question.Min), bound(question.Max)))
+			}
+			if question.Default != nil {
+				builder.WriteString(fmt.Sprintf("   - Default: %v\n", question.Default))
+			}
+		}
+		builder.WriteString("\nThe JSON schema extra_vars must match is extra_vars_schema below.\n")
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
 // CreateJobTemplate creates a new AWX job template
 func (h *AutomationHandler) CreateJobTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := models.CreateJobTemplateArgs{}
@@ -465,27 +954,18 @@ func (h *AutomationHandler) CreateJobTemplate(ctx context.Context, request mcp.C
 	}
 	args.Name = name
 
-	// Required: inventory (as integer)
-	inventoryStr, err := request.RequireString("inventory")
+	// Required: inventory and project, by name or ID
+	inventory, err := request.RequireString("inventory")
 	if err != nil {
-		return mcp.NewToolResultError("inventory ID is required"), nil
-	}
-	if inventory, err := strconv.Atoi(inventoryStr); err == nil {
-		args.Inventory = inventory
-	} else {
-		return mcp.NewToolResultError("inventory must be a valid integer ID"), nil
+		return mcp.NewToolResultError("inventory is required"), nil
 	}
+	args.Inventory = inventory
 
-	// Required: project (as integer)
-	projectStr, err := request.RequireString("project")
+	project, err := request.RequireString("project")
 	if err != nil {
-		return mcp.NewToolResultError("project ID is required"), nil
-	}
-	if project, err := strconv.Atoi(projectStr); err == nil {
-		args.Project = project
-	} else {
-		return mcp.NewToolResultError("project must be a valid integer ID"), nil
+		return mcp.NewToolResultError("project is required"), nil
 	}
+	args.Project = project
 
 	// Required: playbook
 	playbook, err := request.RequireString("playbook")
@@ -507,7 +987,7 @@ func (h *AutomationHandler) CreateJobTemplate(ctx context.Context, request mcp.C
 	output, err := h.automationService.CreateJobTemplate(ctx, args)
 	if err != nil {
 		log.Printf("Create job template failed: %v", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create job template: %v", err)), nil
+		return awxToolError("Failed to create job template", err), nil
 	}
 
 	// Format creation response
@@ -521,6 +1001,195 @@ func (h *AutomationHandler) CreateJobTemplate(ctx context.Context, request mcp.C
 	return mcp.NewToolResultText(message), nil
 }
 
+// UpdateJobTemplate changes the given fields, credentials and labels of a job template
+func (h *AutomationHandler) UpdateJobTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.UpdateJobTemplateArgs{}
+
+	jobTemplate, err := request.RequireString("job_template")
+	if err != nil {
+		return mcp.NewToolResultError("job_template is required"), nil
+	}
+	args.JobTemplate = jobTemplate
+
+	// Only given fields are changed; an empty string clears a text field
+	arguments := request.GetArguments()
+	stringParams := map[string]**string{
+		"name":                  &args.Name,
+		"description":           &args.Description,
+		"job_type":              &args.JobType,
+		"inventory":             &args.Inventory,
+		"project":               &args.Project,
+		"playbook":              &args.Playbook,
+		"scm_branch":            &args.ScmBranch,
+		"limit":                 &args.Limit,
+		"tags":                  &args.Tags,
+		"skip_tags":             &args.SkipTags,
+		"execution_environment": &args.ExecutionEnvironment,
+	}
+	for name, target := range stringParams {
+		if value, ok := arguments[name].(string); ok {
+			*target = &value
+		}
+	}
+	intParams := map[string]**int{
+		"forks":           &args.Forks,
+		"verbosity":       &args.Verbosity,
+		"timeout":         &args.Timeout,
+		"job_slice_count": &args.JobSliceCount,
+	}
+	for name, target := range intParams {
+		value := request.GetString(name, "")
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s must be an integer", name)), nil
+		}
+		*target = &parsed
+	}
+	boolParams := map[string]**bool{
+		"diff_mode":          &args.DiffMode,
+		"allow_simultaneous": &args.AllowSimultaneous,
+		"survey_enabled":     &args.SurveyEnabled,
+	}
+	for name, target := range boolParams {
+		value := request.GetString(name, "")
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s must be true or false", name)), nil
+		}
+		*target = &parsed
+	}
+	for _, flag := range awx.AskOnLaunchFlags() {
+		value := request.GetString(flag, "")
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s must be true or false", flag)), nil
+		}
+		if args.AskOnLaunch == nil {
+			args.AskOnLaunch = make(map[string]bool)
+		}
+		args.AskOnLaunch[flag] = parsed
+	}
+
+	// An empty extra_vars leaves the variables alone; {} clears them
+	if value, ok := arguments["extra_vars"].(string); !ok || strings.TrimSpace(value) != "" {
+		extraVars, err := extraVarsArgument(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		args.ExtraVars = extraVars
+	}
+
+	args.AddCredentials = splitList(request.GetString("add_credentials", ""))
+	args.RemoveCredentials = splitList(request.GetString("remove_credentials", ""))
+	args.AddLabels = splitList(request.GetString("add_labels", ""))
+	args.RemoveLabels = splitList(request.GetString("remove_labels", ""))
+
+	output, err := h.automationService.UpdateJobTemplate(ctx, args)
+	if err != nil {
+		log.Printf("Update job template failed: %v", err)
+		return awxToolError("Failed to update job template", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("✅ Job Template Updated\n\n**Template:** %s (ID: %d)\n", output.Name, output.ID))
+	if len(output.UpdatedFields) > 0 {
+		builder.WriteString(fmt.Sprintf("**Changed Fields:** %s\n", strings.Join(output.UpdatedFields, ", ")))
+	}
+	if len(output.Associations) > 0 {
+		builder.WriteString("\n**Credentials and Labels:**\n")
+		for _, association := range output.Associations {
+			builder.WriteString(fmt.Sprintf("• %s\n", association))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// CopyJobTemplate copies a job template under a new name
+func (h *AutomationHandler) CopyJobTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.CopyJobTemplateArgs{}
+
+	jobTemplate, err := request.RequireString("job_template")
+	if err != nil {
+		return mcp.NewToolResultError("job_template is required"), nil
+	}
+	args.JobTemplate = jobTemplate
+	args.Name = request.GetString("name", "")
+
+	output, err := h.automationService.CopyJobTemplate(ctx, args)
+	if err != nil {
+		log.Printf("Copy job template failed: %v", err)
+		return awxToolError("Failed to copy job template", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("✅ Job Template Copied\n\n**Copy:** %s (ID: %d)\n**Source:** %s (ID: %d)\n",
+		output.Name, output.ID, output.SourceName, output.SourceID))
+	for _, warning := range output.Warnings {
+		builder.WriteString(fmt.Sprintf("⚠️ %s\n", warning))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// DeleteJobTemplate deletes a job template once the caller confirms with a token
+func (h *AutomationHandler) DeleteJobTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.DeleteJobTemplateArgs{}
+
+	jobTemplate, err := request.RequireString("job_template")
+	if err != nil {
+		return mcp.NewToolResultError("job_template is required"), nil
+	}
+	args.JobTemplate = jobTemplate
+	args.ConfirmationToken = request.GetString("confirmation_token", "")
+
+	output, err := h.automationService.DeleteJobTemplate(ctx, args)
+	if err != nil {
+		log.Printf("Delete job template failed: %v", err)
+		return awxToolError("Failed to delete job template", err), nil
+	}
+
+	var builder strings.Builder
+	if output.Deleted {
+		builder.WriteString(fmt.Sprintf("🗑️ Job Template Deleted\n\n**Template:** %s (ID: %d)\n", output.Name, output.ID))
+	} else {
+		builder.WriteString(fmt.Sprintf("⚠️ Confirm Job Template Deletion\n\n**Template:** %s (ID: %d)\n", output.Name, output.ID))
+		builder.WriteString(fmt.Sprintf("**Confirmation Token:** %s (expires %s)\n", output.ConfirmationToken, output.ExpiresAt))
+		if len(output.RecentJobs) > 0 {
+			builder.WriteString("\n**Recent Jobs:**\n")
+			for _, job := range output.RecentJobs {
+				builder.WriteString(fmt.Sprintf("• Job %d: %s\n", job.ID, job.Status))
+			}
+		}
+		for _, warning := range output.Warnings {
+			builder.WriteString(fmt.Sprintf("⚠️ %s\n", warning))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
 // GetCacheStats retrieves cache statistics
 func (h *AutomationHandler) GetCacheStats(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := models.GetCacheStatsArgs{}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// awxToolError reports a failed AWX call, saying what kind of failure it was
+// and what the caller can do about it. action reads like "Failed to launch AWX job".
+func awxToolError(action string, err error) *mcp.CallToolResult {
+	var emoji, label, hint string
+	switch awx.Classify(err) {
+	case awx.ErrorAuth:
+		emoji, label = "🔐", "Authentication failed"
+		hint = "AWX rejected the server's credentials. Check the configured AWX token or username/password."
+	case awx.ErrorPermission:
+		emoji, label = "🚫", "Permission denied"
+		hint = "The AWX user lacks the role this action needs, such as execute on the job template."
+	case awx.ErrorValidation:
+		emoji, label = "❌", "Invalid request"
+		hint = "AWX rejected the request content. Correct the fields below and try again."
+	case awx.ErrorNotFound:
+		emoji, label = "🔍", "Not found"
+		hint = "Check the name or ID; list_awx_resources and list_job_templates show what exists."
+	case awx.ErrorTransient:
+		emoji, label = "⏳", "Temporary failure"
+		hint = "AWX is unreachable or overloaded. Retrying shortly is safe for reads; check list_awx_jobs before relaunching."
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %v", action, err))
+	}
+
+	var resolveErr *awx.ResolveError
+	if errors.As(err, &resolveErr) {
+		if resolveErr.Matches > 1 {
+			hint = "The name matches several resources; pass the ID of the intended one instead."
+		} else {
+			switch resolveErr.Type {
+			case awx.ResourceHost:
+				hint = "Check the host name; IDs work too, and list_inventory_hosts shows what exists."
+			case awx.ResourceGroup:
+				hint = "Check the group name; IDs work too, and list_inventory_groups shows what exists."
+			default:
+				hint = fmt.Sprintf("Check the %s name; IDs work too, and list_awx_resources shows what exists.", resolveErr.Type)
+			}
+		}
+	}
+
+	var builder strings.Builder
+	var apiErr *awx.APIError
+	var launchErr *awx.LaunchValidationError
+	if errors.As(err, &launchErr) {
+		// Launch checks come back as data so callers can fix every field at once
+		builder.WriteString(fmt.Sprintf("%s %s - %s: template '%s' rejected the launch options\n", emoji, label, action, launchErr.Template))
+		if len(launchErr.Problems) > 0 {
+			builder.WriteString("\n**Problems:**\n")
+			for _, problem := range launchErr.Problems {
+				builder.WriteString(fmt.Sprintf("• %s\n", problem))
+			}
+		}
+		if len(launchErr.FieldErrors) > 0 {
+			builder.WriteString("\n**Field Errors:**\n")
+			for _, field := range sortedKeys(launchErr.FieldErrors) {
+				builder.WriteString(fmt.Sprintf("• %s: %s\n", field, strings.Join(launchErr.FieldErrors[field], " ")))
+			}
+		}
+		details, _ := json.MarshalIndent(struct {
+			Kind        awx.ErrorKind       `json:"kind"`
+			Template    string              `json:"template"`
+			Problems    []string            `json:"problems,omitempty"`
+			FieldErrors map[string][]string `json:"field_errors,omitempty"`
+		}{awx.ErrorValidation, launchErr.Template, launchErr.Problems, launchErr.FieldErrors}, "", "  ")
+		builder.WriteString(fmt.Sprintf("\n**Details:**\n```json\n%s\n```\n", string(details)))
+		hint = "Fix the fields above; describe_job_template shows the survey and what the template prompts for."
+	} else if !errors.As(err, &apiErr) || len(apiErr.FieldErrors) == 0 {
+		builder.WriteString(fmt.Sprintf("%s %s - %s: %v\n", emoji, label, action, err))
+	} else {
+		// Field errors are easier to act on one per line
+		message := fmt.Sprintf("AWX API error: status %d", apiErr.StatusCode)
+		if apiErr.Detail != "" {
+			message += " - " + apiErr.Detail
+		}
+		builder.WriteString(fmt.Sprintf("%s %s - %s: %s\n", emoji, label, action, message))
+
+		builder.WriteString("\n**Field Errors:**\n")
+		for _, field := range sortedKeys(apiErr.FieldErrors) {
+			name := field
+			if field == "__all__" {
+				name = "request"
+			}
+			builder.WriteString(fmt.Sprintf("• %s: %s\n", name, strings.Join(apiErr.FieldErrors[field], " ")))
+		}
+		if apiErr.RequestID != "" {
+			builder.WriteString(fmt.Sprintf("\n**AWX Request ID:** %s\n", apiErr.RequestID))
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("\n💡 %s", hint))
+	return mcp.NewToolResultError(builder.String())
+}
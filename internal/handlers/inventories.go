@@ -0,0 +1,520 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// optionalBool reads a true/false argument, nil when it was not given
+func optionalBool(request mcp.CallToolRequest, name string) (*bool, error) {
+	value := request.GetString(name, "")
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be true or false", name)
+	}
+	return &parsed, nil
+}
+
+// optionalString reads a text argument, nil when it was not given; an empty
+// string is kept so that fields can be cleared
+func optionalString(request mcp.CallToolRequest, name string) *string {
+	if value, ok := request.GetArguments()[name].(string); ok {
+		return &value
+	}
+	return nil
+}
+
+func formatHostLine(host models.InventoryHostSummary) string {
+	state := "🟢"
+	if !host.Enabled {
+		state = "⚫ disabled"
+	}
+	line := fmt.Sprintf("%s **%s** (ID: %d)", state, host.Name, host.ID)
+	if len(host.Groups) > 0 {
+		line += fmt.Sprintf(" - groups: %s", strings.Join(host.Groups, ", "))
+	}
+	if host.LastJobStatus != "" {
+		line += fmt.Sprintf(" - last job: %s %s", jobStatusEmoji(host.LastJobStatus), host.LastJobStatus)
+	}
+	return line + "\n"
+}
+
+// ListInventoryHosts lists the hosts of an inventory or group
+func (h *AutomationHandler) ListInventoryHosts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.ListInventoryHostsArgs{}
+
+	inventory, err := request.RequireString("inventory")
+	if err != nil {
+		return mcp.NewToolResultError("inventory is required"), nil
+	}
+	args.Inventory = inventory
+	args.Group = request.GetString("group", "")
+	args.Search = request.GetString("search", "")
+	if value := request.GetString("limit", ""); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return mcp.NewToolResultError("limit must be an integer"), nil
+		}
+		args.Limit = limit
+	}
+
+	output, err := h.automationService.ListInventoryHosts(ctx, args)
+	if err != nil {
+		log.Printf("List inventory hosts failed: %v", err)
+		return awxToolError("Failed to list inventory hosts", err), nil
+	}
+
+	var builder strings.Builder
+	scope := fmt.Sprintf("inventory %d", output.InventoryID)
+	if output.Group != "" {
+		scope = fmt.Sprintf("group %s of %s", output.Group, scope)
+	}
+	builder.WriteString(fmt.Sprintf("🖥️ Hosts of %s\n\n**Found %d hosts:**\n\n", scope, output.Total))
+	for _, host := range output.Hosts {
+		builder.WriteString(formatHostLine(host))
+	}
+	if output.Truncated {
+		builder.WriteString(fmt.Sprintf("\n... showing %d of %d hosts; raise limit or narrow with search\n", len(output.Hosts), output.Total))
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// ListInventoryGroups lists the groups of an inventory
+func (h *AutomationHandler) ListInventoryGroups(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.ListInventoryGroupsArgs{}
+
+	inventory, err := request.RequireString("inventory")
+	if err != nil {
+		return mcp.NewToolResultError("inventory is required"), nil
+	}
+	args.Inventory = inventory
+
+	output, err := h.automationService.ListInventoryGroups(ctx, args)
+	if err != nil {
+		log.Printf("List inventory groups failed: %v", err)
+		return awxToolError("Failed to list inventory groups", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🗂️ Groups of inventory %d\n\n**Found %d groups:**\n\n", output.InventoryID, output.Total))
+	for _, group := range output.Groups {
+		builder.WriteString(fmt.Sprintf("**%s** (ID: %d)\n", group.Name, group.ID))
+		if group.Description != "" {
+			builder.WriteString(fmt.Sprintf("   - Description: %s\n", group.Description))
+		}
+		if len(group.Variables) > 0 {
+			builder.WriteString(fmt.Sprintf("   - Variables: %s\n", strings.Join(sortedKeys(group.Variables), ", ")))
+		}
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// GetInventoryHost shows a host with its variables and, optionally, its facts
+func (h *AutomationHandler) GetInventoryHost(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.GetInventoryHostArgs{}
+
+	inventory, err := request.RequireString("inventory")
+	if err != nil {
+		return mcp.NewToolResultError("inventory is required"), nil
+	}
+	args.Inventory = inventory
+
+	host, err := request.RequireString("host")
+	if err != nil {
+		return mcp.NewToolResultError("host is required"), nil
+	}
+	args.Host = host
+
+	facts, err := optionalBool(request, "facts")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	args.Facts = facts != nil && *facts
+	args.FactNames = splitList(request.GetString("fact_names", ""))
+
+	output, err := h.automationService.GetInventoryHost(ctx, args)
+	if err != nil {
+		log.Printf("Get inventory host failed: %v", err)
+		return awxToolError("Failed to get inventory host", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🖥️ Host %s (ID: %d) of inventory %d\n\n", output.Name, output.ID, output.InventoryID))
+	if output.Enabled {
+		builder.WriteString("- Enabled: yes\n")
+	} else {
+		builder.WriteString("- Enabled: ⚫ no, jobs skip this host\n")
+	}
+	if output.Description != "" {
+		builder.WriteString(fmt.Sprintf("- Description: %s\n", output.Description))
+	}
+	if output.InstanceID != "" {
+		builder.WriteString(fmt.Sprintf("- Instance ID: %s\n", output.InstanceID))
+	}
+	if len(output.Groups) > 0 {
+		builder.WriteString(fmt.Sprintf("- Groups: %s\n", strings.Join(output.Groups, ", ")))
+	}
+	if output.LastJobStatus != "" {
+		builder.WriteString(fmt.Sprintf("- Last job: %s %s\n", jobStatusEmoji(output.LastJobStatus), output.LastJobStatus))
+	}
+	if len(output.Variables) > 0 {
+		builder.WriteString(fmt.Sprintf("- Variables: %s\n", strings.Join(sortedKeys(output.Variables), ", ")))
+	}
+	if args.Facts || len(args.FactNames) > 0 {
+		builder.WriteString(fmt.Sprintf("- Facts: showing %d of %d gathered\n", len(output.Facts), output.FactCount))
+	}
+	for _, warning := range output.Warnings {
+		builder.WriteString(fmt.Sprintf("⚠️ %s\n", warning))
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// AddInventoryHost adds a host to an inventory
+func (h *AutomationHandler) AddInventoryHost(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.AddInventoryHostArgs{}
+
+	inventory, err := request.RequireString("inventory")
+	if err != nil {
+		return mcp.NewToolResultError("inventory is required"), nil
+	}
+	args.Inventory = inventory
+
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("name is required"), nil
+	}
+	args.Name = name
+	args.Description = request.GetString("description", "")
+	args.Groups = splitList(request.GetString("groups", ""))
+
+	if args.Enabled, err = optionalBool(request, "enabled"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if args.Variables, err = varsArgument(request, "variables"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output, err := h.automationService.AddInventoryHost(ctx, args)
+	if err != nil {
+		log.Printf("Add inventory host failed: %v", err)
+		return awxToolError("Failed to add inventory host", err), nil
+	}
+
+	return hostChangeResult("✅ Host Added", output), nil
+}
+
+// UpdateInventoryHost edits a host, its variables and its group memberships
+func (h *AutomationHandler) UpdateInventoryHost(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.UpdateInventoryHostArgs{}
+
+	inventory, err := request.RequireString("inventory")
+	if err != nil {
+		return mcp.NewToolResultError("inventory is required"), nil
+	}
+	args.Inventory = inventory
+
+	host, err := request.RequireString("host")
+	if err != nil {
+		return mcp.NewToolResultError("host is required"), nil
+	}
+	args.Host = host
+
+	args.Name = optionalString(request, "name")
+	args.Description = optionalString(request, "description")
+	if args.Enabled, err = optionalBool(request, "enabled"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if args.Variables, err = varsArgument(request, "variables"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	replace, err := optionalBool(request, "replace_variables")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	args.ReplaceVariables = replace != nil && *replace
+	args.RemoveVariables = splitList(request.GetString("remove_variables", ""))
+	args.AddGroups = splitList(request.GetString("add_groups", ""))
+	args.RemoveGroups = splitList(request.GetString("remove_groups", ""))
+	args.ConfirmationToken = request.GetString("confirmation_token", "")
+
+	output, err := h.automationService.UpdateInventoryHost(ctx, args)
+	if err != nil {
+		log.Printf("Update inventory host failed: %v", err)
+		return awxToolError("Failed to update inventory host", err), nil
+	}
+
+	if output.ConfirmationToken != "" {
+		return hostChangeResult("⚠️ Confirm Clearing Host Variables", output), nil
+	}
+	return hostChangeResult("✅ Host Updated", output), nil
+}
+
+func hostChangeResult(title string, output models.InventoryHostChangeOutput) *mcp.CallToolResult {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s\n\n**Host:** %s (ID: %d) in inventory %d\n", title, output.Name, output.ID, output.InventoryID))
+	if output.Enabled {
+		builder.WriteString("**Enabled:** yes\n")
+	} else {
+		builder.WriteString("**Enabled:** no\n")
+	}
+	if len(output.Changes) > 0 {
+		builder.WriteString("\n**Changes:**\n")
+		for _, change := range output.Changes {
+			builder.WriteString(fmt.Sprintf("• %s\n", change))
+		}
+	}
+	if output.ConfirmationToken != "" {
+		builder.WriteString(fmt.Sprintf("**Confirmation Token:** %s (expires %s)\n", output.ConfirmationToken, output.ExpiresAt))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String())
+}
+
+// RemoveInventoryHost deletes a host once the caller confirms with a token
+func (h *AutomationHandler) RemoveInventoryHost(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.RemoveInventoryHostArgs{}
+
+	inventory, err := request.RequireString("inventory")
+	if err != nil {
+		return mcp.NewToolResultError("inventory is required"), nil
+	}
+	args.Inventory = inventory
+
+	host, err := request.RequireString("host")
+	if err != nil {
+		return mcp.NewToolResultError("host is required"), nil
+	}
+	args.Host = host
+	args.ConfirmationToken = request.GetString("confirmation_token", "")
+
+	output, err := h.automationService.RemoveInventoryHost(ctx, args)
+	if err != nil {
+		log.Printf("Remove inventory host failed: %v", err)
+		return awxToolError("Failed to remove inventory host", err), nil
+	}
+
+	var builder strings.Builder
+	if output.Removed {
+		builder.WriteString(fmt.Sprintf("🗑️ Host Removed\n\n**Host:** %s (ID: %d) from inventory %d\n", output.Name, output.ID, output.InventoryID))
+	} else {
+		builder.WriteString(fmt.Sprintf("⚠️ Confirm Host Removal\n\n**Host:** %s (ID: %d) in inventory %d\n", output.Name, output.ID, output.InventoryID))
+		if len(output.Groups) > 0 {
+			builder.WriteString(fmt.Sprintf("**Groups:** %s\n", strings.Join(output.Groups, ", ")))
+		}
+		builder.WriteString(fmt.Sprintf("**Confirmation Token:** %s (expires %s)\n", output.ConfirmationToken, output.ExpiresAt))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// UpdateInventoryGroup edits a group and its variables
+func (h *AutomationHandler) UpdateInventoryGroup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.UpdateInventoryGroupArgs{}
+
+	inventory, err := request.RequireString("inventory")
+	if err != nil {
+		return mcp.NewToolResultError("inventory is required"), nil
+	}
+	args.Inventory = inventory
+
+	group, err := request.RequireString("group")
+	if err != nil {
+		return mcp.NewToolResultError("group is required"), nil
+	}
+	args.Group = group
+
+	args.Name = optionalString(request, "name")
+	args.Description = optionalString(request, "description")
+	if args.Variables, err = varsArgument(request, "variables"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	replace, err := optionalBool(request, "replace_variables")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	args.ReplaceVariables = replace != nil && *replace
+	args.RemoveVariables = splitList(request.GetString("remove_variables", ""))
+	args.ConfirmationToken = request.GetString("confirmation_token", "")
+
+	output, err := h.automationService.UpdateInventoryGroup(ctx, args)
+	if err != nil {
+		log.Printf("Update inventory group failed: %v", err)
+		return awxToolError("Failed to update inventory group", err), nil
+	}
+
+	title := "✅ Group Updated"
+	if output.ConfirmationToken != "" {
+		title = "⚠️ Confirm Clearing Group Variables"
+	}
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s\n\n**Group:** %s (ID: %d) in inventory %d\n", title, output.Name, output.ID, output.InventoryID))
+	if len(output.Changes) > 0 {
+		builder.WriteString("\n**Changes:**\n")
+		for _, change := range output.Changes {
+			builder.WriteString(fmt.Sprintf("• %s\n", change))
+		}
+	}
+	if output.ConfirmationToken != "" {
+		builder.WriteString(fmt.Sprintf("**Confirmation Token:** %s (expires %s)\n", output.ConfirmationToken, output.ExpiresAt))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// ListInventorySources lists the sources of an inventory
+func (h *AutomationHandler) ListInventorySources(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.ListInventorySourcesArgs{}
+
+	inventory, err := request.RequireString("inventory")
+	if err != nil {
+		return mcp.NewToolResultError("inventory is required"), nil
+	}
+	args.Inventory = inventory
+
+	output, err := h.automationService.ListInventorySources(ctx, args)
+	if err != nil {
+		log.Printf("List inventory sources failed: %v", err)
+		return awxToolError("Failed to list inventory sources", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🔄 Sources of inventory %d\n\n**Found %d sources:**\n\n", output.InventoryID, output.Total))
+	for _, source := range output.Sources {
+		builder.WriteString(fmt.Sprintf("%s **%s** (ID: %d) - %s, last sync %s", jobStatusEmoji(source.Status), source.Name, source.ID, source.Source, source.Status))
+		if source.LastUpdated != "" {
+			builder.WriteString(fmt.Sprintf(", updated %s", source.LastUpdated))
+		}
+		builder.WriteString("\n")
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// SyncInventorySource syncs inventory sources, optionally waiting for them with progress updates
+func (h *AutomationHandler) SyncInventorySource(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.SyncInventorySourceArgs{}
+
+	inventory, err := request.RequireString("inventory")
+	if err != nil {
+		return mcp.NewToolResultError("inventory is required"), nil
+	}
+	args.Inventory = inventory
+	args.Source = request.GetString("source", "")
+
+	wait, err := optionalBool(request, "wait")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	args.Wait = wait != nil && *wait
+	if timeout, err := strconv.Atoi(request.GetString("timeout", "600")); err == nil {
+		args.Timeout = timeout
+	}
+	if interval, err := strconv.Atoi(request.GetString("poll_interval", "10")); err == nil {
+		args.PollInterval = interval
+	}
+
+	reporter := newProgressReporter(ctx, request)
+	onProgress := func(p models.JobProgress) {
+		message := fmt.Sprintf("Inventory update %d %s (elapsed %s) - %s", p.JobID, p.Status, p.Elapsed.Round(time.Second), p.Task)
+		reporter.Report(p.Elapsed.Seconds(), p.Timeout.Seconds(), message)
+	}
+
+	output, err := h.automationService.SyncInventorySource(ctx, args, onProgress)
+	if err != nil {
+		log.Printf("Sync inventory source failed: %v", err)
+		return awxToolError("Failed to sync inventory source", err), nil
+	}
+
+	return inventorySyncResult(fmt.Sprintf("🔄 Inventory %d Sync", output.InventoryID), output), nil
+}
+
+// CheckInventorySync reports the state of inventory syncs
+func (h *AutomationHandler) CheckInventorySync(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.CheckInventorySyncArgs{}
+
+	ids, err := request.RequireString("update_ids")
+	if err != nil {
+		return mcp.NewToolResultError("update_ids is required"), nil
+	}
+	for _, value := range splitList(ids) {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return mcp.NewToolResultError("update_ids must be comma-separated integers"), nil
+		}
+		args.UpdateIDs = append(args.UpdateIDs, id)
+	}
+
+	output, err := h.automationService.CheckInventorySync(ctx, args)
+	if err != nil {
+		log.Printf("Check inventory sync failed: %v", err)
+		return awxToolError("Failed to check inventory sync", err), nil
+	}
+
+	return inventorySyncResult("🔄 Inventory Sync Status", output), nil
+}
+
+func inventorySyncResult(title string, output models.InventorySyncOutput) *mcp.CallToolResult {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s\n\n", title))
+	for _, update := range output.Updates {
+		builder.WriteString(fmt.Sprintf("%s **Update %d**", jobStatusEmoji(update.Status), update.UpdateID))
+		if update.SourceName != "" {
+			builder.WriteString(fmt.Sprintf(" of %s", update.SourceName))
+		}
+		builder.WriteString(fmt.Sprintf(": %s", update.Status))
+		if update.Elapsed > 0 {
+			builder.WriteString(fmt.Sprintf(" (%.1fs)", update.Elapsed))
+		}
+		builder.WriteString("\n")
+		if update.Explanation != "" {
+			builder.WriteString(fmt.Sprintf("   - %s\n", update.Explanation))
+		}
+	}
+	if output.TimedOut {
+		builder.WriteString(fmt.Sprintf("\n⏰ Stopped waiting after %s\n", output.Waited))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String())
+}
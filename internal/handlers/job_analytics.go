@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// formatSeconds renders a duration in seconds such as 1m30s, or - when unknown
+func formatSeconds(seconds float64) string {
+	if seconds <= 0 {
+		return "-"
+	}
+	return (time.Duration(seconds * float64(time.Second))).Round(time.Second).String()
+}
+
+func (h *AutomationHandler) AnalyzeJobHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.AnalyzeJobHistoryArgs{
+		JobTemplate: request.GetString("job_template", ""),
+	}
+	if days := request.GetString("days", ""); days != "" {
+		value, err := strconv.Atoi(days)
+		if err != nil {
+			return mcp.NewToolResultError("days must be an integer"), nil
+		}
+		args.Days = value
+	}
+	format := strings.ToLower(request.GetString("format", "markdown"))
+	if format != "markdown" && format != "json" {
+		return mcp.NewToolResultError("format must be markdown or json"), nil
+	}
+
+	output, err := h.automationService.AnalyzeJobHistory(ctx, args)
+	if err != nil {
+		log.Printf("Analyze job history failed: %v", err)
+		return awxToolError("Failed to analyze job history", err), nil
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	if format == "json" {
+		return mcp.NewToolResultText(string(resultJSON)), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString("📈 AWX Job History Analytics\n\n")
+	builder.WriteString(fmt.Sprintf("**Window:** %s → %s\n", output.From, output.To))
+	builder.WriteString(fmt.Sprintf("**Jobs:** %d analyzed (%d successful, %d failed, %d canceled), %.1f%% success\n",
+		output.JobsAnalyzed, output.Successful, output.Failed, output.Canceled, output.SuccessRate))
+
+	if len(output.Templates) > 0 {
+		builder.WriteString("\n📋 **Templates** (most failures first):\n")
+	}
+	for _, template := range output.Templates {
+		builder.WriteString(fmt.Sprintf("• **%s**: %d runs, %.1f%% success", template.TemplateName, template.Runs, template.SuccessRate))
+		if template.Flaky {
+			builder.WriteString(" 🔀 flaky")
+		}
+		if template.CurrentlyFailing {
+			builder.WriteString(fmt.Sprintf(" 🔴 failing since %s", template.FailingSince))
+		}
+		builder.WriteString("\n")
+		builder.WriteString(fmt.Sprintf("   - Duration: p50 %s, p95 %s\n", formatSeconds(template.P50Seconds), formatSeconds(template.P95Seconds)))
+		if template.Recoveries > 0 {
+			builder.WriteString(fmt.Sprintf("   - MTTR: %s over %d recoveries\n", formatSeconds(template.MTTRSeconds), template.Recoveries))
+		}
+		builder.WriteString(fmt.Sprintf("   - Last job: %d (%s), outcome changed %d times\n", template.LastJobID, template.LastStatus, template.OutcomeFlips))
+	}
+
+	if len(output.FlakyTemplates) > 0 {
+		builder.WriteString(fmt.Sprintf("\n🔀 **Flaky templates:** %s\n", strings.Join(output.FlakyTemplates, ", ")))
+	}
+	if len(output.FailingHosts) > 0 {
+		builder.WriteString(fmt.Sprintf("\n🖥️ **Hosts failing repeatedly** (from %d failed jobs):\n", output.FailedJobsProbed))
+		for _, host := range output.FailingHosts {
+			builder.WriteString(fmt.Sprintf("• **%s**: failed in %d jobs", host.Host, host.FailedJobs))
+			if host.Unreachable > 0 {
+				builder.WriteString(fmt.Sprintf(" (unreachable in %d)", host.Unreachable))
+			}
+			builder.WriteString(fmt.Sprintf(" of %s, last in job %d at %s\n", strings.Join(host.Templates, ", "), host.LastFailedJob, host.LastFailure))
+		}
+	}
+	for _, warning := range output.Warnings {
+		builder.WriteString(fmt.Sprintf("⚠️ %s\n", warning))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
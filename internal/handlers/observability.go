@@ -2,12 +2,21 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/NacerKH/autosphere-mcp-golang/internal/interfaces"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// maxDisplayedMetrics limits how many series are rendered in the text summary
+const maxDisplayedMetrics = 20
+
 type ObservabilityHandler struct {
 	observabilityService interfaces.ObservabilityService
 }
@@ -18,20 +27,386 @@ func NewObservabilityHandler(observabilityService interfaces.ObservabilityServic
 	}
 }
 
-// Updated to match mcp-go function signature: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
-
+// QueryPrometheus executes a PromQL query and renders the resulting series
 func (h *ObservabilityHandler) QueryPrometheus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	log.Printf("Handling Prometheus query request")
-	// For now, return a simple response - you'll need to adapt this based on your actual models
-	return mcp.NewToolResultText("Prometheus query functionality - needs adaptation for mcp-go"), nil
+	args := models.QueryPrometheusArgs{}
+
+	// Required: query
+	query, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+	args.Query = query
+
+	// Optional range query parameters
+	args.StartTime = request.GetString("start_time", "")
+	args.EndTime = request.GetString("end_time", "")
+	args.Step = request.GetString("step", "")
+	if maxPointsStr := request.GetString("max_points", ""); maxPointsStr != "" {
+		maxPoints, err := strconv.Atoi(maxPointsStr)
+		if err != nil || maxPoints <= 0 {
+			return mcp.NewToolResultError("max_points must be a positive integer"), nil
+		}
+		args.MaxPoints = maxPoints
+	}
+
+	output, err := h.observabilityService.QueryPrometheus(ctx, args)
+	if err != nil {
+		log.Printf("Prometheus query failed: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to query Prometheus: %v", err)), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString("📈 Prometheus Query Results\n\n")
+	builder.WriteString(fmt.Sprintf("**Query:** `%s`\n", output.Query))
+	builder.WriteString(fmt.Sprintf("**Result Type:** %s\n", output.ResultType))
+	if output.Start != "" {
+		builder.WriteString(fmt.Sprintf("**Range:** %s → %s (step %s)\n", output.Start, output.End, output.Step))
+	}
+	builder.WriteString(fmt.Sprintf("**Summary:** %s\n\n", output.Summary))
+
+	if len(output.Metrics) > 0 {
+		builder.WriteString("**Series:**\n")
+		for i, metric := range output.Metrics {
+			if i >= maxDisplayedMetrics {
+				builder.WriteString(fmt.Sprintf("... and %d more\n", len(output.Metrics)-maxDisplayedMetrics))
+				break
+			}
+			builder.WriteString(fmt.Sprintf("- `%s` = **%s**", formatLabels(metric.Labels), metric.Value))
+			if metric.Time != "" {
+				builder.WriteString(fmt.Sprintf(" (at %s)", metric.Time))
+			}
+			builder.WriteString("\n")
+			if stats := metric.Stats; stats != nil {
+				builder.WriteString(fmt.Sprintf("   - min %s, max %s, avg %s, last %s, rate %s/s over %d samples\n",
+					formatStat(stats.Min), formatStat(stats.Max), formatStat(stats.Avg), formatStat(stats.Last), formatStat(stats.RateOfChange), stats.Samples))
+			}
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("\n📅 **Queried at:** %s\n", output.QueryTime))
+
+	// Add full JSON response
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
 }
 
+// GetSystemMetrics renders key system metrics with a health assessment
 func (h *ObservabilityHandler) GetSystemMetrics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	log.Printf("Handling system metrics request")
-	return mcp.NewToolResultText("System metrics functionality - needs adaptation for mcp-go"), nil
+	args := models.GetSystemMetricsArgs{}
+
+	// Optional parameters
+	args.TimeRange = request.GetString("time_range", "")
+	args.Nodes = request.GetString("nodes", "")
+
+	output, err := h.observabilityService.GetSystemMetrics(ctx, args)
+	if err != nil {
+		log.Printf("Get system metrics failed: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get system metrics: %v", err)), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s System Metrics\n\n**Overall Health: %s**\n📅 Collected at: %s (averaged over %s)\n\n",
+		healthStatusEmoji(output.OverallHealth), output.OverallHealth, output.Timestamp, output.TimeRange))
+
+	builder.WriteString("**Metrics:**\n")
+	names := make([]string, 0, len(output.Metrics))
+	for name := range output.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		builder.WriteString(fmt.Sprintf("- %s: %.2f\n", name, output.Metrics[name]))
+	}
+	if len(names) == 0 {
+		builder.WriteString("- No metrics returned by Prometheus\n")
+	}
+
+	if len(output.NodeMetrics) > 0 {
+		builder.WriteString("\n**Per-node Metrics:**\n")
+		nodes := make([]string, 0, len(output.NodeMetrics))
+		for node := range output.NodeMetrics {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+		for _, node := range nodes {
+			builder.WriteString(fmt.Sprintf("- **%s**:", node))
+			for _, name := range sortedKeys(output.NodeMetrics[node]) {
+				builder.WriteString(fmt.Sprintf(" %s=%.2f", name, output.NodeMetrics[node][name]))
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	if len(output.Errors) > 0 {
+		builder.WriteString("\n**⚠️ Failed Queries:**\n")
+		for _, name := range sortedKeys(output.Errors) {
+			builder.WriteString(fmt.Sprintf("- %s: %s\n", name, output.Errors[name]))
+		}
+	}
+
+	if len(output.Alerts) > 0 {
+		builder.WriteString("\n**🚨 Alerts:**\n")
+		for _, alert := range output.Alerts {
+			builder.WriteString(fmt.Sprintf("- %s\n", alert))
+		}
+	}
+
+	if len(output.Recommendations) > 0 {
+		builder.WriteString("\n**💡 Recommendations:**\n")
+		for _, rec := range output.Recommendations {
+			builder.WriteString(fmt.Sprintf("- %s\n", rec))
+		}
+	}
+
+	// Add full JSON response
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
 }
 
+// GetAlerts renders alerts filtered by severity, service and active status
 func (h *ObservabilityHandler) GetAlerts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	log.Printf("Handling alerts request")
-	return mcp.NewToolResultText("Alerts functionality - needs adaptation for mcp-go"), nil
+	args := models.GetAlertsArgs{}
+
+	// Optional filters
+	args.Severity = request.GetString("severity", "")
+	args.Service = request.GetString("service", "")
+	switch request.GetString("active", "") {
+	case "":
+	case "true":
+		active := true
+		args.Active = &active
+	case "false":
+		active := false
+		args.Active = &active
+	default:
+		return mcp.NewToolResultError("active must be true or false"), nil
+	}
+
+	output, err := h.observabilityService.GetAlerts(ctx, args)
+	if err != nil {
+		log.Printf("Get alerts failed: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get alerts: %v", err)), nil
+	}
+
+	statusEmoji := "✅"
+	if output.Critical > 0 {
+		statusEmoji = "❌"
+	} else if output.Warning > 0 {
+		statusEmoji = "⚠️"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s Alerts\n\n**%s**\n📅 Retrieved at: %s\n\n", statusEmoji, output.Summary, output.Timestamp))
+
+	for _, alert := range output.ActiveAlerts {
+		builder.WriteString(fmt.Sprintf("%s **%s** (%s) - %s\n", healthStatusEmoji(alert.Severity), alert.Name, alert.Severity, alert.Status))
+		if summary := alert.Annotations["summary"]; summary != "" {
+			builder.WriteString(fmt.Sprintf("   - Summary: %s\n", summary))
+		}
+		if description := alert.Annotations["description"]; description != "" {
+			builder.WriteString(fmt.Sprintf("   - Description: %s\n", description))
+		}
+		if alert.ActiveSince != "" {
+			builder.WriteString(fmt.Sprintf("   - Active since: %s\n", alert.ActiveSince))
+		}
+		if alert.Value != "" {
+			builder.WriteString(fmt.Sprintf("   - Value: %s\n", alert.Value))
+		}
+		if len(alert.SilencedBy) > 0 {
+			builder.WriteString(fmt.Sprintf("   - 🔕 Silenced by: %s\n", strings.Join(alert.SilencedBy, ", ")))
+		}
+		if len(alert.InhibitedBy) > 0 {
+			builder.WriteString(fmt.Sprintf("   - 🔇 Inhibited by: %s\n", strings.Join(alert.InhibitedBy, ", ")))
+		}
+		builder.WriteString(fmt.Sprintf("   - Labels: `%s`\n", formatLabels(alert.Labels)))
+	}
+
+	// Add full JSON response
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// ListSilences lists Alertmanager silences
+func (h *ObservabilityHandler) ListSilences(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.ListSilencesArgs{}
+
+	// Optional filters
+	args.State = request.GetString("state", "")
+	matchers, err := parseMatcherList(request.GetString("matchers", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	args.Matchers = matchers
+
+	output, err := h.observabilityService.ListSilences(ctx, args)
+	if err != nil {
+		log.Printf("List silences failed: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list silences: %v", err)), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🔕 Alertmanager Silences\n\n**Found %d silences:**\n\n", output.Total))
+
+	for _, silence := range output.Silences {
+		builder.WriteString(fmt.Sprintf("**%s** (%s)\n", silence.ID, silence.State))
+		builder.WriteString(fmt.Sprintf("   - Matchers: `%s`\n", strings.Join(silence.Matchers, ", ")))
+		builder.WriteString(fmt.Sprintf("   - Window: %s → %s\n", silence.StartsAt, silence.EndsAt))
+		builder.WriteString(fmt.Sprintf("   - Created by: %s\n", silence.CreatedBy))
+		builder.WriteString(fmt.Sprintf("   - Comment: %s\n\n", silence.Comment))
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// CreateSilence silences the alerts selected by label matchers for a duration
+func (h *ObservabilityHandler) CreateSilence(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.CreateSilenceArgs{}
+
+	// Required: matchers, duration, comment
+	matchersStr, err := request.RequireString("matchers")
+	if err != nil {
+		return mcp.NewToolResultError("matchers is required"), nil
+	}
+	matchers, err := parseMatcherList(matchersStr)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	args.Matchers = matchers
+
+	duration, err := request.RequireString("duration")
+	if err != nil {
+		return mcp.NewToolResultError("duration is required"), nil
+	}
+	args.Duration = duration
+
+	comment, err := request.RequireString("comment")
+	if err != nil {
+		return mcp.NewToolResultError("comment is required"), nil
+	}
+	args.Comment = comment
+
+	args.CreatedBy = request.GetString("created_by", "")
+
+	output, err := h.observabilityService.CreateSilence(ctx, args)
+	if err != nil {
+		log.Printf("Create silence failed: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create silence: %v", err)), nil
+	}
+
+	message := fmt.Sprintf("🔕 Silence Created\n\n**Silence ID:** %s\n**Matchers:** `%s`\n**Until:** %s\n**Matching alerts:** %d\n\n%s",
+		output.Silence.ID, strings.Join(output.Silence.Matchers, ", "), output.Silence.EndsAt, output.MatchingAlerts, output.Message)
+	if output.MatchingAlerts == 0 {
+		message += "\n\n⚠️ No current alert matches this silence, double-check the matchers"
+	}
+
+	return mcp.NewToolResultText(message), nil
+}
+
+// ExpireSilence expires an Alertmanager silence
+func (h *ObservabilityHandler) ExpireSilence(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.ExpireSilenceArgs{}
+
+	// Required: silence_id
+	silenceID, err := request.RequireString("silence_id")
+	if err != nil {
+		return mcp.NewToolResultError("silence_id is required"), nil
+	}
+	args.SilenceID = silenceID
+
+	output, err := h.observabilityService.ExpireSilence(ctx, args)
+	if err != nil {
+		log.Printf("Expire silence failed: %v", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to expire silence: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("🔔 Silence Expired\n\n%s", output.Message)), nil
+}
+
+// parseMatcherList accepts label matchers as a JSON array or a comma-separated
+// list. Use the JSON form when a regular expression contains a comma.
+func parseMatcherList(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(value, "[") {
+		var matchers []string
+		if err := json.Unmarshal([]byte(value), &matchers); err != nil {
+			return nil, fmt.Errorf("matchers must be a JSON array of strings or a comma-separated list: %v", err)
+		}
+		return matchers, nil
+	}
+
+	var matchers []string
+	for _, matcher := range strings.Split(value, ",") {
+		if matcher = strings.TrimSpace(matcher); matcher != "" {
+			matchers = append(matchers, matcher)
+		}
+	}
+	return matchers, nil
+}
+
+// formatStat renders a statistic compactly, keeping small rates readable
+func formatStat(value float64) string {
+	return strconv.FormatFloat(value, 'g', 4, 64)
+}
+
+// formatLabels renders a label set in PromQL notation, metric name first
+func formatLabels(labels map[string]string) string {
+	name := labels["__name__"]
+
+	var pairs []string
+	for _, key := range sortedKeys(labels) {
+		if key == "__name__" {
+			continue
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%q", key, labels[key]))
+	}
+
+	return name + "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// splitList splits a comma-separated parameter, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// healthStatusEmoji maps health and severity levels to the emoji used across tool output
+func healthStatusEmoji(status string) string {
+	switch status {
+	case "healthy", "info":
+		return "✅"
+	case "warning", "degraded":
+		return "⚠️"
+	case "critical":
+		return "❌"
+	default:
+		return "❓"
+	}
 }
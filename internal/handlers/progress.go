@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressReporter sends notifications/progress messages for a tool call when
+// the client asked for them by attaching a progress token to the request
+type progressReporter struct {
+	ctx    context.Context
+	server *server.MCPServer
+	token  mcp.ProgressToken
+}
+
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest) *progressReporter {
+	reporter := &progressReporter{ctx: ctx, server: server.ServerFromContext(ctx)}
+	if request.Params.Meta != nil {
+		reporter.token = request.Params.Meta.ProgressToken
+	}
+	return reporter
+}
+
+// Report sends a progress notification; it is a no-op when the client did not
+// request progress updates
+func (p *progressReporter) Report(progress, total float64, message string) {
+	if p.server == nil || p.token == nil {
+		return
+	}
+
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      progress,
+		"message":       message,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+
+	if err := p.server.SendNotificationToClient(p.ctx, "notifications/progress", params); err != nil {
+		log.Printf("Failed to send progress notification: %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (h *AutomationHandler) ListProjectPlaybooks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	project, err := request.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError("project is required"), nil
+	}
+
+	output, err := h.automationService.ListProjectPlaybooks(ctx, models.ListProjectPlaybooksArgs{Project: project})
+	if err != nil {
+		log.Printf("List project playbooks failed: %v", err)
+		return awxToolError("Failed to list project playbooks", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("📘 Playbooks of %s (ID: %d)\n\n", output.Name, output.ProjectID))
+	builder.WriteString(fmt.Sprintf("**Last Sync:** %s", output.Status))
+	if output.ScmRevision != "" {
+		builder.WriteString(fmt.Sprintf(" at revision %s", output.ScmRevision))
+	}
+	builder.WriteString("\n\n")
+	if len(output.Playbooks) == 0 {
+		builder.WriteString("No playbooks found; run sync_project and check that it succeeds.\n")
+	}
+	for _, playbook := range output.Playbooks {
+		builder.WriteString(fmt.Sprintf("• %s\n", playbook))
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+func (h *AutomationHandler) SyncProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.SyncProjectArgs{}
+
+	project, err := request.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError("project is required"), nil
+	}
+	args.Project = project
+
+	wait, err := optionalBool(request, "wait")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	args.Wait = wait != nil && *wait
+	if timeout, err := strconv.Atoi(request.GetString("timeout", "600")); err == nil {
+		args.Timeout = timeout
+	}
+	if interval, err := strconv.Atoi(request.GetString("poll_interval", "10")); err == nil {
+		args.PollInterval = interval
+	}
+
+	reporter := newProgressReporter(ctx, request)
+	onProgress := func(p models.JobProgress) {
+		message := fmt.Sprintf("Project sync %d %s (elapsed %s) - %s", p.JobID, p.Status, p.Elapsed.Round(time.Second), p.Task)
+		reporter.Report(p.Elapsed.Seconds(), p.Timeout.Seconds(), message)
+	}
+
+	output, err := h.automationService.SyncProject(ctx, args, onProgress)
+	if err != nil {
+		log.Printf("Sync project failed: %v", err)
+		return awxToolError("Failed to sync project", err), nil
+	}
+
+	return projectSyncResult(fmt.Sprintf("🔄 Project %s Sync", output.Name), output), nil
+}
+
+func (h *AutomationHandler) CheckProjectSync(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	syncIDStr, err := request.RequireString("sync_id")
+	if err != nil {
+		return mcp.NewToolResultError("sync_id is required"), nil
+	}
+	syncID, err := strconv.Atoi(syncIDStr)
+	if err != nil {
+		return mcp.NewToolResultError("sync_id must be an integer"), nil
+	}
+
+	output, err := h.automationService.CheckProjectSync(ctx, models.CheckProjectSyncArgs{SyncID: syncID})
+	if err != nil {
+		log.Printf("Check project sync failed: %v", err)
+		return awxToolError("Failed to check project sync", err), nil
+	}
+
+	return projectSyncResult("🔄 Project Sync Status", output), nil
+}
+
+func projectSyncResult(title string, output models.ProjectSyncOutput) *mcp.CallToolResult {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s\n\n", title))
+	builder.WriteString(fmt.Sprintf("%s **Sync %d** of project %d: %s", jobStatusEmoji(output.Status), output.SyncID, output.ProjectID, output.Status))
+	if output.Elapsed > 0 {
+		builder.WriteString(fmt.Sprintf(" (%.1fs)", output.Elapsed))
+	}
+	builder.WriteString("\n")
+	if output.ScmRevision != "" {
+		builder.WriteString(fmt.Sprintf("**Revision:** %s\n", output.ScmRevision))
+	}
+	if output.Explanation != "" {
+		builder.WriteString(fmt.Sprintf("**Explanation:** %s\n", output.Explanation))
+	}
+	if output.TimedOut {
+		builder.WriteString(fmt.Sprintf("\n⏰ Stopped waiting after %s\n", output.Waited))
+	}
+	if len(output.Playbooks) > 0 {
+		builder.WriteString("\n**Playbooks:**\n")
+		for _, playbook := range output.Playbooks {
+			builder.WriteString(fmt.Sprintf("• %s\n", playbook))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String())
+}
+
+func (h *AutomationHandler) CreateProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.CreateProjectArgs{}
+
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("name is required"), nil
+	}
+	args.Name = name
+
+	organization, err := request.RequireString("organization")
+	if err != nil {
+		return mcp.NewToolResultError("organization is required"), nil
+	}
+	args.Organization = organization
+
+	args.Description = request.GetString("description", "")
+	args.ScmType = request.GetString("scm_type", "")
+	args.ScmURL = request.GetString("scm_url", "")
+	args.ScmBranch = request.GetString("scm_branch", "")
+	args.Credential = request.GetString("credential", "")
+
+	for name, target := range map[string]*bool{
+		"update_on_launch": &args.UpdateOnLaunch,
+		"allow_override":   &args.AllowOverride,
+	} {
+		value, err := optionalBool(request, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		*target = value != nil && *value
+	}
+
+	output, err := h.automationService.CreateProject(ctx, args)
+	if err != nil {
+		log.Printf("Create project failed: %v", err)
+		return awxToolError("Failed to create project", err), nil
+	}
+
+	return projectChangeResult("✅ Project Created", output), nil
+}
+
+func (h *AutomationHandler) UpdateProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.UpdateProjectArgs{}
+
+	project, err := request.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError("project is required"), nil
+	}
+	args.Project = project
+
+	args.Name = optionalString(request, "name")
+	args.Description = optionalString(request, "description")
+	args.ScmType = optionalString(request, "scm_type")
+	args.ScmURL = optionalString(request, "scm_url")
+	args.ScmBranch = optionalString(request, "scm_branch")
+	args.Credential = optionalString(request, "credential")
+
+	if args.UpdateOnLaunch, err = optionalBool(request, "update_on_launch"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if args.AllowOverride, err = optionalBool(request, "allow_override"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output, err := h.automationService.UpdateProject(ctx, args)
+	if err != nil {
+		log.Printf("Update project failed: %v", err)
+		return awxToolError("Failed to update project", err), nil
+	}
+
+	return projectChangeResult("✅ Project Updated", output), nil
+}
+
+func projectChangeResult(title string, output models.ProjectChangeOutput) *mcp.CallToolResult {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s\n\n**Project:** %s (ID: %d)\n", title, output.Name, output.ID))
+	if output.ScmType != "" {
+		builder.WriteString(fmt.Sprintf("**Source:** %s %s", output.ScmType, output.ScmURL))
+		if output.ScmBranch != "" {
+			builder.WriteString(fmt.Sprintf(" (%s)", output.ScmBranch))
+		}
+		builder.WriteString("\n")
+	} else {
+		builder.WriteString("**Source:** manual\n")
+	}
+	if output.Status != "" {
+		builder.WriteString(fmt.Sprintf("**Status:** %s\n", output.Status))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String())
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// passwordsArgument reads credential passwords given as a JSON object of strings
+func passwordsArgument(request mcp.CallToolRequest, name string) (map[string]string, error) {
+	passwords := make(map[string]string)
+	switch value := request.GetArguments()[name].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.TrimSpace(value) == "" {
+			return nil, nil
+		}
+		if err := json.Unmarshal([]byte(value), &passwords); err != nil {
+			return nil, fmt.Errorf("%s must be a JSON object of strings, such as {\"ssh_password\": \"...\"}", name)
+		}
+	case map[string]interface{}:
+		for key, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s.%s must be a string", name, key)
+			}
+			passwords[key] = text
+		}
+	default:
+		return nil, fmt.Errorf("%s must be a JSON object of strings, not %T", name, value)
+	}
+	return passwords, nil
+}
+
+// RelaunchAWXJob runs a job again on all or only its failed hosts
+func (h *AutomationHandler) RelaunchAWXJob(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.RelaunchJobArgs{}
+
+	jobIDStr, err := request.RequireString("job_id")
+	if err != nil {
+		return mcp.NewToolResultError("job_id is required"), nil
+	}
+	if args.JobID, err = strconv.Atoi(jobIDStr); err != nil {
+		return mcp.NewToolResultError("job_id must be a valid integer"), nil
+	}
+	args.Hosts = request.GetString("hosts", "all")
+
+	if args.ExtraVars, err = varsArgument(request, "extra_vars"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if args.CredentialPasswords, err = passwordsArgument(request, "credential_passwords"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output, err := h.automationService.RelaunchJob(ctx, args)
+	if err != nil {
+		log.Printf("Relaunch AWX job failed: %v", err)
+		return awxToolError("Failed to relaunch AWX job", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🔁 AWX Job %d Relaunched as Job %d\n\n", output.RelaunchOf, output.JobID))
+	builder.WriteString(fmt.Sprintf("**Hosts:** %s", output.Hosts))
+	if output.HostCount > 0 {
+		builder.WriteString(fmt.Sprintf(" (%d)", output.HostCount))
+	}
+	builder.WriteString("\n")
+	if len(output.FailedHosts) > 0 {
+		builder.WriteString(fmt.Sprintf("**Failed hosts:** %s\n", strings.Join(output.FailedHosts, ", ")))
+	}
+	if output.Method == "launch" {
+		builder.WriteString(fmt.Sprintf("**Launched:** template '%s' (ID: %d) with the original launch fields\n", output.TemplateName, output.TemplateID))
+	}
+	if len(output.OverriddenVars) > 0 {
+		builder.WriteString(fmt.Sprintf("**Overridden vars:** %s\n", strings.Join(output.OverriddenVars, ", ")))
+	}
+	if len(output.DroppedVars) > 0 {
+		builder.WriteString(fmt.Sprintf("⚠️ **Not sent again (masked by AWX):** %s\n", strings.Join(output.DroppedVars, ", ")))
+	}
+
+	chain := make([]string, len(output.RetryChain))
+	for i, id := range output.RetryChain {
+		chain[i] = strconv.Itoa(id)
+	}
+	builder.WriteString(fmt.Sprintf("**Retry chain:** %s\n", strings.Join(chain, " → ")))
+	builder.WriteString(fmt.Sprintf("**URL:** %s\n", output.URL))
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
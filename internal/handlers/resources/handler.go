@@ -6,15 +6,21 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/NacerKH/autosphere-mcp-golang/internal/interfaces"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+const (
+	healthReportWindow  = 24 * time.Hour
+	healthReportSamples = 5
+)
+
 type ResourceHandler struct {
-	// Add any dependencies you need (config, services, etc.)
+	healthService interfaces.HealthService
 }
 
-func NewResourceHandler() *ResourceHandler {
-	return &ResourceHandler{}
+func NewResourceHandler(healthService interfaces.HealthService) *ResourceHandler {
+	return &ResourceHandler{healthService: healthService}
 }
 
 // GetAutosphereConfig returns the Autosphere system configuration
@@ -162,47 +168,11 @@ spec:
 	}, nil
 }
 
-// GetHealthCheckReport returns a sample health check report
+// GetHealthCheckReport returns the component health history of the last 24 hours
 func (h *ResourceHandler) GetHealthCheckReport(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	report := map[string]interface{}{
-		"timestamp": time.Now().Format(time.RFC3339),
-		"overall_status": "healthy",
-		"services": map[string]interface{}{
-			"api": map[string]interface{}{
-				"status": "healthy",
-				"response_time": "45ms",
-				"cpu_usage": "23%",
-				"memory_usage": "67%",
-				"last_check": time.Now().Add(-30 * time.Second).Format(time.RFC3339),
-			},
-			"database": map[string]interface{}{
-				"status": "healthy",
-				"connections": 12,
-				"cpu_usage": "15%",
-				"memory_usage": "45%",
-				"last_check": time.Now().Add(-30 * time.Second).Format(time.RFC3339),
-			},
-			"cache": map[string]interface{}{
-				"status": "healthy",
-				"hit_rate": "94%",
-				"memory_usage": "34%",
-				"last_check": time.Now().Add(-30 * time.Second).Format(time.RFC3339),
-			},
-			"workers": map[string]interface{}{
-				"status": "healthy",
-				"active_jobs": 3,
-				"queue_length": 7,
-				"last_check": time.Now().Add(-30 * time.Second).Format(time.RFC3339),
-			},
-		},
-		"alerts": []map[string]interface{}{
-			{
-				"severity": "warning",
-				"component": "api",
-				"message": "Memory usage approaching 70% threshold",
-				"timestamp": time.Now().Add(-5 * time.Minute).Format(time.RFC3339),
-			},
-		},
+	report, err := h.healthService.History("", healthReportWindow, healthReportSamples)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build health report: %w", err)
 	}
 
 	reportJSON, err := json.MarshalIndent(report, "", "  ")
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (h *AutomationHandler) ListSchedules(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.ListSchedulesArgs{
+		JobTemplate:      request.GetString("job_template", ""),
+		WorkflowTemplate: request.GetString("workflow_template", ""),
+	}
+	var err error
+	if args.Enabled, err = optionalBool(request, "enabled"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output, err := h.automationService.ListSchedules(ctx, args)
+	if err != nil {
+		log.Printf("List schedules failed: %v", err)
+		return awxToolError("Failed to list schedules", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("📅 AWX Schedules (%d of %d)\n\n", len(output.Schedules), output.Total))
+	if len(output.Schedules) == 0 {
+		builder.WriteString("No schedules found.\n")
+	}
+	for _, schedule := range output.Schedules {
+		state := "✅"
+		if !schedule.Enabled {
+			state = "⏸️"
+		}
+		builder.WriteString(fmt.Sprintf("%s **%s** (ID: %d) - %s '%s'\n", state, schedule.Name, schedule.ID, schedule.TemplateType, schedule.TemplateName))
+		builder.WriteString(fmt.Sprintf("   - Rule: `%s`\n", schedule.Rrule))
+		if schedule.NextRun != "" && schedule.Enabled {
+			builder.WriteString(fmt.Sprintf("   - Next run: %s\n", schedule.NextRun))
+		}
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+func (h *AutomationHandler) CreateSchedule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.CreateScheduleArgs{}
+
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("name is required"), nil
+	}
+	args.Name = name
+	rrule, err := request.RequireString("rrule")
+	if err != nil {
+		return mcp.NewToolResultError("rrule is required"), nil
+	}
+	args.Rrule = rrule
+	args.JobTemplate = request.GetString("job_template", "")
+	args.WorkflowTemplate = request.GetString("workflow_template", "")
+	args.Description = request.GetString("description", "")
+
+	if args.Enabled, err = optionalBool(request, "enabled"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if args.ExtraData, err = varsArgument(request, "extra_data"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output, err := h.automationService.CreateSchedule(ctx, args)
+	if err != nil {
+		log.Printf("Create schedule failed: %v", err)
+		return awxToolError("Failed to create schedule", err), nil
+	}
+
+	return scheduleChangeResult("✅ Schedule Created", output), nil
+}
+
+func (h *AutomationHandler) UpdateSchedule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.UpdateScheduleArgs{}
+
+	schedule, err := request.RequireString("schedule")
+	if err != nil {
+		return mcp.NewToolResultError("schedule is required"), nil
+	}
+	args.Schedule = schedule
+
+	args.Name = optionalString(request, "name")
+	args.Description = optionalString(request, "description")
+	args.Rrule = optionalString(request, "rrule")
+
+	if args.Enabled, err = optionalBool(request, "enabled"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if args.ExtraData, err = varsArgument(request, "extra_data"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output, err := h.automationService.UpdateSchedule(ctx, args)
+	if err != nil {
+		log.Printf("Update schedule failed: %v", err)
+		return awxToolError("Failed to update schedule", err), nil
+	}
+
+	return scheduleChangeResult("✅ Schedule Updated", output), nil
+}
+
+func (h *AutomationHandler) SetScheduleEnabled(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.SetScheduleEnabledArgs{}
+
+	schedule, err := request.RequireString("schedule")
+	if err != nil {
+		return mcp.NewToolResultError("schedule is required"), nil
+	}
+	args.Schedule = schedule
+
+	enabled, err := optionalBool(request, "enabled")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if enabled == nil {
+		return mcp.NewToolResultError("enabled is required: true to enable the schedule, false to disable it"), nil
+	}
+	args.Enabled = *enabled
+
+	output, err := h.automationService.SetScheduleEnabled(ctx, args)
+	if err != nil {
+		log.Printf("Set schedule enabled failed: %v", err)
+		return awxToolError("Failed to change schedule", err), nil
+	}
+
+	title := "▶️ Schedule Enabled"
+	if !output.Schedule.Enabled {
+		title = "⏸️ Schedule Disabled"
+	}
+	return scheduleChangeResult(title, output), nil
+}
+
+func scheduleChangeResult(title string, output models.ScheduleChangeOutput) *mcp.CallToolResult {
+	schedule := output.Schedule
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s\n\n**Schedule:** %s (ID: %d)\n", title, schedule.Name, schedule.ID))
+	builder.WriteString(fmt.Sprintf("**Template:** %s (ID: %d, %s)\n", schedule.TemplateName, schedule.TemplateID, schedule.TemplateType))
+	builder.WriteString(fmt.Sprintf("**Rule:** `%s`\n", schedule.Rrule))
+	builder.WriteString(fmt.Sprintf("**Enabled:** %t\n", schedule.Enabled))
+	if len(output.NextRuns) > 0 {
+		builder.WriteString("\n**Next Runs:**\n")
+		for _, run := range output.NextRuns {
+			builder.WriteString(fmt.Sprintf("• %s\n", run))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String())
+}
+
+func (h *AutomationHandler) DeleteSchedule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.DeleteScheduleArgs{}
+
+	schedule, err := request.RequireString("schedule")
+	if err != nil {
+		return mcp.NewToolResultError("schedule is required"), nil
+	}
+	args.Schedule = schedule
+	args.ConfirmationToken = request.GetString("confirmation_token", "")
+
+	output, err := h.automationService.DeleteSchedule(ctx, args)
+	if err != nil {
+		log.Printf("Delete schedule failed: %v", err)
+		return awxToolError("Failed to delete schedule", err), nil
+	}
+
+	var builder strings.Builder
+	if output.Deleted {
+		builder.WriteString(fmt.Sprintf("🗑️ Schedule Deleted\n\n**Schedule:** %s (ID: %d)\n", output.Name, output.ID))
+	} else {
+		builder.WriteString(fmt.Sprintf("⚠️ Confirm Schedule Deletion\n\n**Schedule:** %s (ID: %d)\n", output.Name, output.ID))
+		builder.WriteString(fmt.Sprintf("**Template:** %s\n", output.TemplateName))
+		if output.Enabled && output.NextRun != "" {
+			builder.WriteString(fmt.Sprintf("**Next run:** %s\n", output.NextRun))
+		}
+		builder.WriteString(fmt.Sprintf("**Confirmation Token:** %s (expires %s)\n", output.ConfirmationToken, output.ExpiresAt))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+func (h *AutomationHandler) PreviewSchedule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.PreviewScheduleArgs{
+		Rrule:    request.GetString("rrule", ""),
+		Schedule: request.GetString("schedule", ""),
+		TimeZone: request.GetString("time_zone", ""),
+	}
+	if count := request.GetString("count", ""); count != "" {
+		value, err := strconv.Atoi(count)
+		if err != nil {
+			return mcp.NewToolResultError("count must be an integer"), nil
+		}
+		args.Count = value
+	}
+
+	output, err := h.automationService.PreviewSchedule(ctx, args)
+	if err != nil {
+		log.Printf("Preview schedule failed: %v", err)
+		return awxToolError("Failed to preview schedule", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🔮 Schedule Preview (%s)\n\n", output.TimeZone))
+	builder.WriteString(fmt.Sprintf("**Rule:** `%s`\n\n", output.Rrule))
+	for i, occurrence := range output.Occurrences {
+		builder.WriteString(fmt.Sprintf("%d. %s (UTC %s)\n", i+1, occurrence.Local, occurrence.UTC))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+func (h *AutomationHandler) GetUpcomingScheduleRuns(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.UpcomingScheduleRunsArgs{
+		TimeZone: request.GetString("time_zone", ""),
+	}
+	if hours := request.GetString("hours", ""); hours != "" {
+		value, err := strconv.Atoi(hours)
+		if err != nil {
+			return mcp.NewToolResultError("hours must be an integer"), nil
+		}
+		args.Hours = value
+	}
+
+	output, err := h.automationService.GetUpcomingScheduleRuns(ctx, args)
+	if err != nil {
+		log.Printf("Get upcoming schedule runs failed: %v", err)
+		return awxToolError("Failed to get upcoming schedule runs", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("⏰ Upcoming Schedule Runs (%s)\n\n", output.TimeZone))
+	builder.WriteString(fmt.Sprintf("**Window:** %s → %s\n\n", output.From, output.To))
+	if len(output.Runs) == 0 {
+		builder.WriteString("No scheduled runs in this window.\n")
+	}
+	for _, run := range output.Runs {
+		builder.WriteString(fmt.Sprintf("• %s - **%s** (schedule %d) runs %s '%s'\n", run.Time, run.ScheduleName, run.ScheduleID, run.TemplateType, run.TemplateName))
+	}
+	for _, warning := range output.Warnings {
+		builder.WriteString(fmt.Sprintf("⚠️ %s\n", warning))
+	}
+	builder.WriteString(fmt.Sprintf("\n**Message:** %s\n", output.Message))
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ListWorkflowTemplates lists all AWX workflow job templates
+func (h *AutomationHandler) ListWorkflowTemplates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.ListWorkflowTemplatesArgs{}
+
+	output, err := h.automationService.ListWorkflowTemplates(ctx, args)
+	if err != nil {
+		log.Printf("List workflow templates failed: %v", err)
+		return awxToolError("Failed to list workflow templates", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🔀 AWX Workflow Templates\n\n**Found %d workflow templates:**\n\n", output.Total))
+
+	for _, template := range output.Templates {
+		builder.WriteString(fmt.Sprintf("**%s** (ID: %d)\n", template.Name, template.ID))
+		if template.Description != "" {
+			builder.WriteString(fmt.Sprintf("   - Description: %s\n", template.Description))
+		}
+		if template.Status != "" {
+			builder.WriteString(fmt.Sprintf("   - Last run: %s\n", template.Status))
+		}
+		builder.WriteString("\n")
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// LaunchAWXWorkflow launches an AWX workflow job template
+func (h *AutomationHandler) LaunchAWXWorkflow(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.LaunchWorkflowArgs{}
+
+	// Required: workflow_template
+	workflowTemplate, err := request.RequireString("workflow_template")
+	if err != nil {
+		return mcp.NewToolResultError("workflow_template is required"), nil
+	}
+	args.WorkflowTemplate = workflowTemplate
+
+	// Optional: extra_vars (JSON or YAML) layered over the named var-sets
+	extraVars, err := extraVarsArgument(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	args.ExtraVars = extraVars
+	args.VarSets = splitList(request.GetString("var_sets", ""))
+
+	args.Inventory = request.GetString("inventory", "")
+	args.Limit = request.GetString("limit", "")
+
+	output, err := h.automationService.LaunchWorkflow(ctx, args)
+	if err != nil {
+		log.Printf("AWX workflow launch failed: %v", err)
+		return awxToolError("Failed to launch AWX workflow", err), nil
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	message := fmt.Sprintf("✅ AWX Workflow Launched Successfully\n\n**Workflow Job Details:**\n- Workflow Job ID: %d\n- Status: %s\n- AWX URL: %s\n\n%s\n\n**Full Response:**\n```json\n%s\n```",
+		output.WorkflowJobID, output.Status, output.URL, output.Message, string(resultJSON))
+
+	return mcp.NewToolResultText(message), nil
+}
+
+// CheckAWXWorkflowStatus shows the node graph and per-node status of a workflow job
+func (h *AutomationHandler) CheckAWXWorkflowStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.WorkflowStatusArgs{}
+
+	// Required: workflow_job_id
+	jobIDStr, err := request.RequireString("workflow_job_id")
+	if err != nil {
+		return mcp.NewToolResultError("workflow_job_id is required"), nil
+	}
+
+	if jobID, err := strconv.Atoi(jobIDStr); err == nil {
+		args.WorkflowJobID = jobID
+	} else {
+		return mcp.NewToolResultError("workflow_job_id must be a valid integer"), nil
+	}
+
+	output, err := h.automationService.GetWorkflowStatus(ctx, args)
+	if err != nil {
+		log.Printf("AWX workflow status check failed: %v", err)
+		return awxToolError("Failed to check AWX workflow status", err), nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s AWX Workflow Status\n\n**Workflow Job %d (%s): %s**\n\n",
+		jobStatusEmoji(output.Status), output.WorkflowJobID, output.Name, output.Status))
+
+	if output.StartedAt != "" {
+		builder.WriteString(fmt.Sprintf("📅 **Timeline:**\n- Started: %s\n- Elapsed: %s\n", output.StartedAt, output.ElapsedTime))
+		if output.FinishedAt != "" {
+			builder.WriteString(fmt.Sprintf("- Finished: %s\n", output.FinishedAt))
+		}
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("**Nodes:**\n")
+	for _, node := range output.Nodes {
+		builder.WriteString(fmt.Sprintf("%s **Node %d**: %s (%s) - %s", jobStatusEmoji(node.Status), node.NodeID, node.Template, node.TemplateType, node.Status))
+		if node.JobID > 0 {
+			builder.WriteString(fmt.Sprintf(", job %d", node.JobID))
+		}
+		if node.ElapsedTime != "" {
+			builder.WriteString(fmt.Sprintf(", %s", node.ElapsedTime))
+		}
+		builder.WriteString("\n")
+
+		if len(node.OnSuccess) > 0 {
+			builder.WriteString(fmt.Sprintf("   - on success → %s\n", formatNodeIDs(node.OnSuccess)))
+		}
+		if len(node.OnFailure) > 0 {
+			builder.WriteString(fmt.Sprintf("   - on failure → %s\n", formatNodeIDs(node.OnFailure)))
+		}
+		if len(node.Always) > 0 {
+			builder.WriteString(fmt.Sprintf("   - always → %s\n", formatNodeIDs(node.Always)))
+		}
+	}
+
+	if len(output.PendingApprovals) > 0 {
+		builder.WriteString("\n**⏸️ Pending approvals** (use approve_workflow_step):\n")
+		for _, approvalID := range output.PendingApprovals {
+			builder.WriteString(fmt.Sprintf("- Approval ID: %d\n", approvalID))
+		}
+	}
+
+	resultJSON, _ := json.MarshalIndent(output, "", "  ")
+	builder.WriteString(fmt.Sprintf("\n**Full Response:**\n```json\n%s\n```", string(resultJSON)))
+
+	return mcp.NewToolResultText(builder.String()), nil
+}
+
+// DecideWorkflowApproval approves or denies a pending workflow approval node
+func (h *AutomationHandler) DecideWorkflowApproval(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := models.WorkflowApprovalArgs{}
+
+	// Required: approval_id
+	approvalIDStr, err := request.RequireString("approval_id")
+	if err != nil {
+		return mcp.NewToolResultError("approval_id is required"), nil
+	}
+
+	if approvalID, err := strconv.Atoi(approvalIDStr); err == nil {
+		args.ApprovalID = approvalID
+	} else {
+		return mcp.NewToolResultError("approval_id must be a valid integer"), nil
+	}
+
+	// Required: action
+	action, err := request.RequireString("action")
+	if err != nil {
+		return mcp.NewToolResultError("action is required (approve, deny)"), nil
+	}
+	args.Action = action
+
+	output, err := h.automationService.DecideWorkflowApproval(ctx, args)
+	if err != nil {
+		log.Printf("Workflow approval failed: %v", err)
+		return awxToolError(fmt.Sprintf("Failed to %s workflow approval", args.Action), err), nil
+	}
+
+	statusEmoji := "✅"
+	if output.Action == "deny" {
+		statusEmoji = "🚫"
+	}
+
+	message := fmt.Sprintf("%s Workflow Approval\n\n**Approval %d**: %s\n**Decision:** %s\n**Message:** %s",
+		statusEmoji, output.ApprovalID, output.Name, output.Action, output.Message)
+
+	return mcp.NewToolResultText(message), nil
+}
+
+// jobStatusEmoji maps an AWX job status to the emoji used across tool output
+func jobStatusEmoji(status string) string {
+	switch status {
+	case "successful":
+		return "✅"
+	case "failed", "error":
+		return "❌"
+	case "canceled":
+		return "🚫"
+	case "running", "waiting":
+		return "🔄"
+	case "pending":
+		return "⏳"
+	default:
+		return "⚪"
+	}
+}
+
+func formatNodeIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
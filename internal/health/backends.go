@@ -0,0 +1,192 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/prometheus"
+)
+
+const (
+	defaultLaunchTimeout = 10 * time.Minute
+	awxJobPollInterval   = 5 * time.Second
+	recentJobsChecked    = 10
+)
+
+type prometheusProbe struct {
+	config ProbeConfig
+	client *prometheus.PrometheusClient
+}
+
+func newPrometheusProbe(config ProbeConfig, deps Dependencies) (Probe, error) {
+	if deps.Prometheus == nil {
+		return nil, fmt.Errorf("prometheus probes require a Prometheus URL")
+	}
+	if config.Query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	if config.WarningAbove == nil && config.CriticalAbove == nil && config.WarningBelow == nil && config.CriticalBelow == nil {
+		return nil, fmt.Errorf("at least one of warning_above, critical_above, warning_below and critical_below is required")
+	}
+	return &prometheusProbe{config: config, client: deps.Prometheus}, nil
+}
+
+// Check evaluates the query; with several series the highest value is held
+// against the *_above thresholds and the lowest against the *_below ones
+func (p *prometheusProbe) Check(ctx context.Context) Outcome {
+	result, err := p.client.Query(ctx, p.config.Query)
+	if err != nil {
+		return Failed(err)
+	}
+
+	low, high, series := math.Inf(1), math.Inf(-1), 0
+	for _, r := range result.Data.Result {
+		sample, err := r.InstantSample()
+		if err != nil || !sample.IsNumber() {
+			continue
+		}
+		low, high, series = math.Min(low, sample.Value), math.Max(high, sample.Value), series+1
+	}
+	if series == 0 {
+		return Failed(fmt.Errorf("query %q returned no numeric result", p.config.Query))
+	}
+
+	metrics := map[string]string{"series": strconv.Itoa(series)}
+	value := fmt.Sprintf("%g", high)
+	if low != high {
+		metrics["min"], metrics["max"] = fmt.Sprintf("%g", low), fmt.Sprintf("%g", high)
+		value = fmt.Sprintf("%g..%g", low, high)
+	} else {
+		metrics["value"] = value
+	}
+
+	var breaches []string
+	status := StatusHealthy
+	check := func(breached bool, severity, format string, threshold float64, observed float64) {
+		if breached {
+			status = Worst(status, severity)
+			breaches = append(breaches, fmt.Sprintf(format, observed, threshold))
+		}
+	}
+	if t := p.config.CriticalAbove; t != nil {
+		check(high > *t, StatusCritical, "%g > critical_above %g", *t, high)
+	}
+	if t := p.config.WarningAbove; t != nil && status != StatusCritical {
+		check(high > *t, StatusWarning, "%g > warning_above %g", *t, high)
+	}
+	if t := p.config.CriticalBelow; t != nil {
+		check(low < *t, StatusCritical, "%g < critical_below %g", *t, low)
+	}
+	if t := p.config.WarningBelow; t != nil && status != StatusCritical {
+		check(low < *t, StatusWarning, "%g < warning_below %g", *t, low)
+	}
+
+	if len(breaches) > 0 {
+		return Outcome{Status: status, Metrics: metrics, Message: fmt.Sprintf("%s: %s", p.config.Query, strings.Join(breaches, ", "))}
+	}
+	return Outcome{Status: StatusHealthy, Metrics: metrics, Message: fmt.Sprintf("%s = %s within thresholds", p.config.Query, value)}
+}
+
+type awxJobProbe struct {
+	config ProbeConfig
+	client *awx.Client
+}
+
+func newAWXJobProbe(config ProbeConfig, deps Dependencies) (Probe, error) {
+	if deps.AWX == nil {
+		return nil, fmt.Errorf("awx_job probes require an AWX client")
+	}
+	if config.Template == "" {
+		return nil, fmt.Errorf("template is required")
+	}
+	return &awxJobProbe{config: config, client: deps.AWX}, nil
+}
+
+// Check either launches the template and waits for the job, or judges the
+// latest finished job of the template
+func (p *awxJobProbe) Check(ctx context.Context) Outcome {
+	template, err := p.client.GetJobTemplateByName(ctx, p.config.Template)
+	if err != nil {
+		return Failed(err)
+	}
+
+	if p.config.Launch {
+		return p.launch(ctx, template)
+	}
+
+	jobs, err := p.client.GetRecentTemplateJobs(ctx, template.ID, recentJobsChecked)
+	if err != nil {
+		return Failed(fmt.Errorf("failed to list jobs of template %s: %w", template.Name, err))
+	}
+
+	for _, job := range jobs {
+		if !job.IsFinished() {
+			continue
+		}
+
+		metrics := map[string]string{"last_job": strconv.Itoa(job.ID), "last_status": job.Status}
+		finished := "unknown time"
+		if job.Finished != nil {
+			finished = job.Finished.Format(time.RFC3339)
+			metrics["last_finished"] = finished
+		}
+
+		switch job.Status {
+		case "successful":
+			if p.config.MaxAge > 0 && job.Finished != nil && time.Since(*job.Finished) > p.config.MaxAge {
+				return Outcome{Status: StatusWarning, Metrics: metrics,
+					Message: fmt.Sprintf("last successful job %d of %s finished %s ago, older than max_age %s",
+						job.ID, template.Name, time.Since(*job.Finished).Round(time.Minute), p.config.MaxAge)}
+			}
+			return Outcome{Status: StatusHealthy, Metrics: metrics,
+				Message: fmt.Sprintf("job %d of %s succeeded at %s", job.ID, template.Name, finished)}
+		case "canceled":
+			return Outcome{Status: StatusWarning, Metrics: metrics,
+				Message: fmt.Sprintf("job %d of %s was canceled at %s", job.ID, template.Name, finished)}
+		default:
+			return Outcome{Status: StatusCritical, Metrics: metrics,
+				Err: fmt.Errorf("job %d of %s finished with status %s at %s", job.ID, template.Name, job.Status, finished)}
+		}
+	}
+
+	return Outcome{Status: StatusWarning, Message: fmt.Sprintf("no finished job of %s among the latest %d", template.Name, recentJobsChecked)}
+}
+
+func (p *awxJobProbe) launch(ctx context.Context, template *awx.JobTemplate) Outcome {
+	launcher := awx.NewJobLauncher(p.client)
+	launched, err := launcher.Launch(ctx, awx.LaunchJobOptions{TemplateNameOrID: strconv.Itoa(template.ID)})
+	if err != nil {
+		return Failed(err)
+	}
+
+	ticker := time.NewTicker(awxJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := p.client.GetJob(ctx, launched.JobID)
+		if err != nil {
+			return Failed(fmt.Errorf("failed to get status of job %d: %w", launched.JobID, err))
+		}
+
+		if job.IsFinished() {
+			metrics := map[string]string{"job": strconv.Itoa(job.ID), "status": job.Status, "elapsed": fmt.Sprintf("%.1fs", job.Elapsed)}
+			if job.Status != "successful" {
+				return Outcome{Status: StatusCritical, Metrics: metrics,
+					Err: fmt.Errorf("launched job %d of %s finished with status %s", job.ID, template.Name, job.Status)}
+			}
+			return Outcome{Status: StatusHealthy, Metrics: metrics,
+				Message: fmt.Sprintf("launched job %d of %s succeeded in %.1fs", job.ID, template.Name, job.Elapsed)}
+		}
+
+		select {
+		case <-ctx.Done():
+			return Failed(fmt.Errorf("job %d of %s still %s: %w", job.ID, template.Name, job.Status, ctx.Err()))
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package health
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/prometheus"
+	"gopkg.in/yaml.v3"
+)
+
+// Config lists the probes of each component and how often they run in the background
+type Config struct {
+	Monitor    MonitorSettings            `yaml:"monitor" json:"monitor"`
+	Defaults   ProbeDefaults              `yaml:"defaults" json:"defaults"`
+	Components map[string]ComponentConfig `yaml:"components" json:"components"`
+}
+
+// ProbeDefaults apply to every probe that does not set the field itself
+type ProbeDefaults struct {
+	Timeout         time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	WarningLatency  time.Duration `yaml:"warning_latency,omitempty" json:"warning_latency,omitempty"`
+	CriticalLatency time.Duration `yaml:"critical_latency,omitempty" json:"critical_latency,omitempty"`
+}
+
+// ComponentConfig holds the probes of one component
+type ComponentConfig struct {
+	Probes []ProbeConfig `yaml:"probes" json:"probes"`
+}
+
+// ProbeConfig configures a probe. Common fields come first; the others are
+// only read by the probe types named in their comment.
+type ProbeConfig struct {
+	Name            string        `yaml:"name,omitempty" json:"name,omitempty"` // Defaults to the type
+	Type            string        `yaml:"type" json:"type"`
+	Deep            bool          `yaml:"deep,omitempty" json:"deep,omitempty"` // Only run for deep checks
+	Timeout         time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	WarningLatency  time.Duration `yaml:"warning_latency,omitempty" json:"warning_latency,omitempty"`
+	CriticalLatency time.Duration `yaml:"critical_latency,omitempty" json:"critical_latency,omitempty"`
+
+	// http
+	URL          string            `yaml:"url,omitempty" json:"url,omitempty"`
+	Method       string            `yaml:"method,omitempty" json:"method,omitempty"`
+	Headers      map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	ExpectStatus []int             `yaml:"expect_status,omitempty" json:"expect_status,omitempty"` // Default: any 2xx or 3xx
+	ExpectBody   string            `yaml:"expect_body,omitempty" json:"expect_body,omitempty"`     // Substring the body must contain
+
+	// tcp, redis, postgres
+	Address string `yaml:"address,omitempty" json:"address,omitempty"`
+
+	// redis, postgres
+	TLS string `yaml:"tls,omitempty" json:"tls,omitempty"` // disable, prefer (postgres only) or require. Default: require for postgres, disable for redis
+
+	// http, redis, postgres (when TLS is used)
+	Insecure bool   `yaml:"insecure,omitempty" json:"insecure,omitempty"` // Skip certificate verification
+	CAFile   string `yaml:"ca_file,omitempty" json:"ca_file,omitempty"`   // PEM certificates trusted instead of the system roots
+
+	// http (basic auth), redis, postgres
+	Username    string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password    string `yaml:"password,omitempty" json:"password,omitempty"`
+	PasswordEnv string `yaml:"password_env,omitempty" json:"password_env,omitempty"` // Environment variable holding the password
+
+	// dns
+	Host          string `yaml:"host,omitempty" json:"host,omitempty"`
+	Resolver      string `yaml:"resolver,omitempty" json:"resolver,omitempty"` // host:port, default system resolver
+	MinAddresses  int    `yaml:"min_addresses,omitempty" json:"min_addresses,omitempty"`
+	ExpectAddress string `yaml:"expect_address,omitempty" json:"expect_address,omitempty"`
+
+	// prometheus, postgres
+	Query string `yaml:"query,omitempty" json:"query,omitempty"`
+
+	// prometheus
+	WarningAbove  *float64 `yaml:"warning_above,omitempty" json:"warning_above,omitempty"`
+	CriticalAbove *float64 `yaml:"critical_above,omitempty" json:"critical_above,omitempty"`
+	WarningBelow  *float64 `yaml:"warning_below,omitempty" json:"warning_below,omitempty"`
+	CriticalBelow *float64 `yaml:"critical_below,omitempty" json:"critical_below,omitempty"`
+
+	// awx_job
+	Template string        `yaml:"template,omitempty" json:"template,omitempty"` // Job template name or ID
+	MaxAge   time.Duration `yaml:"max_age,omitempty" json:"max_age,omitempty"`   // Warn when the last successful job is older
+	Launch   bool          `yaml:"launch,omitempty" json:"launch,omitempty"`     // Launch the template and wait for it
+
+	// postgres
+	Database string `yaml:"database,omitempty" json:"database,omitempty"`
+
+	// redis
+	Key                string `yaml:"key,omitempty" json:"key,omitempty"`                                   // Key used for the set/get round trip
+	AllowPlaintextAuth bool   `yaml:"allow_plaintext_auth,omitempty" json:"allow_plaintext_auth,omitempty"` // Send AUTH without TLS
+}
+
+// Dependencies are the clients probes may need
+type Dependencies struct {
+	Prometheus *prometheus.PrometheusClient
+	AWX        *awx.Client
+}
+
+// Endpoints are the backend URLs the default configuration probes
+type Endpoints struct {
+	AWX                  string
+	Prometheus           string
+	PrometheusUsername   string
+	PrometheusPassword   string
+	Alertmanager         string
+	AlertmanagerUsername string
+	AlertmanagerPassword string
+}
+
+// DefaultConfig probes the backends the server is configured with. Application
+// components (api, database, cache, ...) need a configuration file.
+func DefaultConfig(endpoints Endpoints) *Config {
+	config := &Config{Components: map[string]ComponentConfig{}}
+
+	if endpoints.AWX != "" {
+		config.Components["awx"] = ComponentConfig{Probes: []ProbeConfig{
+			{Name: "ping", Type: "http", URL: strings.TrimRight(endpoints.AWX, "/") + "/api/v2/ping/", WarningLatency: time.Second},
+		}}
+	}
+
+	var monitoring []ProbeConfig
+	if endpoints.Prometheus != "" {
+		monitoring = append(monitoring,
+			ProbeConfig{Name: "prometheus", Type: "http", URL: strings.TrimRight(endpoints.Prometheus, "/") + "/-/healthy",
+				Username: endpoints.PrometheusUsername, Password: endpoints.PrometheusPassword},
+			ProbeConfig{Name: "scrape_targets", Type: "prometheus", Deep: true, Query: "avg(up)", WarningBelow: floatPtr(1), CriticalBelow: floatPtr(0.5)},
+		)
+	}
+	if endpoints.Alertmanager != "" {
+		monitoring = append(monitoring,
+			ProbeConfig{Name: "alertmanager", Type: "http", URL: strings.TrimRight(endpoints.Alertmanager, "/") + "/-/healthy",
+				Username: endpoints.AlertmanagerUsername, Password: endpoints.AlertmanagerPassword},
+		)
+	}
+	if len(monitoring) > 0 {
+		config.Components["monitoring"] = ComponentConfig{Probes: monitoring}
+	}
+
+	return config
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+// LoadConfig reads and validates a probe configuration file. YAML and JSON are accepted.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read health config: %w", err)
+	}
+
+	var config Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse health config %s: %w", path, err)
+	}
+	if config.Components == nil {
+		config.Components = map[string]ComponentConfig{}
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid health config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// Validate checks probe names, thresholds and that probes writing to their
+// target only run for deep checks. Other type-specific fields are checked
+// when probes are built.
+func (c *Config) Validate() error {
+	for _, component := range c.ComponentNames() {
+		seen := make(map[string]bool)
+		for i, probe := range c.Components[component].Probes {
+			probe = c.withDefaults(probe)
+			if probe.Type == "" {
+				return fmt.Errorf("components.%s.probes[%d]: type is required", component, i)
+			}
+			if seen[probe.Name] {
+				return fmt.Errorf("components.%s.probes[%d]: duplicate probe name %q, set distinct names", component, i, probe.Name)
+			}
+			seen[probe.Name] = true
+
+			if probe.Timeout < 0 || probe.WarningLatency < 0 || probe.CriticalLatency < 0 {
+				return fmt.Errorf("components.%s.%s: timeout and latency thresholds must not be negative", component, probe.Name)
+			}
+			if probe.WarningLatency > 0 && probe.CriticalLatency > 0 && probe.WarningLatency > probe.CriticalLatency {
+				return fmt.Errorf("components.%s.%s: warning_latency must not exceed critical_latency", component, probe.Name)
+			}
+			// The background monitor runs every non-deep probe each interval
+			if effect := probe.writeEffect(); effect != "" && !probe.Deep {
+				return fmt.Errorf("components.%s.%s: %s on every check, set deep: true", component, probe.Name, effect)
+			}
+		}
+	}
+	return nil
+}
+
+// writeEffect describes what the probe changes on its target, or returns "" for read-only probes
+func (p ProbeConfig) writeEffect() string {
+	switch {
+	case p.Type == "awx_job" && p.Launch:
+		return "launch: true runs the job template"
+	case p.Type == "redis":
+		return "redis probes SET and DEL a key"
+	}
+	return ""
+}
+
+// ComponentNames returns the configured component names
+func (c *Config) ComponentNames() []string {
+	names := make([]string, 0, len(c.Components))
+	for name := range c.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// withDefaults fills the unset common fields of a probe
+func (c *Config) withDefaults(probe ProbeConfig) ProbeConfig {
+	if probe.Name == "" {
+		probe.Name = probe.Type
+	}
+	if probe.Timeout == 0 {
+		probe.Timeout = c.Defaults.Timeout
+	}
+	if probe.Timeout == 0 {
+		probe.Timeout = defaultProbeTimeout
+		// Launched AWX jobs run a whole playbook
+		if probe.Type == "awx_job" && probe.Launch {
+			probe.Timeout = defaultLaunchTimeout
+		}
+	}
+	if probe.WarningLatency == 0 {
+		probe.WarningLatency = c.Defaults.WarningLatency
+	}
+	if probe.CriticalLatency == 0 {
+		probe.CriticalLatency = c.Defaults.CriticalLatency
+	}
+	return probe
+}
+
+// password returns the configured password, reading it from the environment if requested
+func (p ProbeConfig) password() string {
+	if p.PasswordEnv != "" {
+		return os.Getenv(p.PasswordEnv)
+	}
+	return p.Password
+}
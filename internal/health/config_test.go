@@ -0,0 +1,47 @@
+package health
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		probes []ProbeConfig
+		want   string // Error substring, empty when valid
+	}{
+		{name: "read-only probes", probes: []ProbeConfig{
+			{Type: "http", URL: "http://api/healthz"},
+			{Type: "awx_job", Template: "health", MaxAge: time.Hour},
+			{Type: "postgres", Address: "db:5432", Username: "health"},
+		}},
+		{name: "deep launch", probes: []ProbeConfig{{Type: "awx_job", Template: "health", Launch: true, Deep: true}}},
+		{name: "deep redis", probes: []ProbeConfig{{Type: "redis", Address: "cache:6379", Deep: true}}},
+		{name: "launch without deep", probes: []ProbeConfig{{Type: "awx_job", Template: "health", Launch: true}}, want: "launch: true runs the job template on every check, set deep: true"},
+		{name: "redis without deep", probes: []ProbeConfig{{Name: "set_get", Type: "redis", Address: "cache:6379"}}, want: "components.app.set_get: redis probes SET and DEL a key"},
+		{name: "missing type", probes: []ProbeConfig{{Name: "ping"}}, want: "type is required"},
+		{name: "duplicate name", probes: []ProbeConfig{{Type: "tcp", Address: "a:1"}, {Type: "tcp", Address: "b:1"}}, want: `duplicate probe name "tcp"`},
+		{name: "negative timeout", probes: []ProbeConfig{{Type: "tcp", Timeout: -time.Second}}, want: "must not be negative"},
+		{name: "inverted latencies", probes: []ProbeConfig{{Type: "tcp", WarningLatency: time.Second, CriticalLatency: time.Millisecond}}, want: "warning_latency must not exceed critical_latency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Components: map[string]ComponentConfig{"app": {Probes: tt.probes}}}
+			err := config.Validate()
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("Validate: %v", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("Validate error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExampleConfigIsValid(t *testing.T) {
+	if _, err := LoadConfig("../../health-probes.example.yaml"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,282 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Component and probe statuses, ordered from best to worst
+const (
+	StatusHealthy  = "healthy"
+	StatusWarning  = "warning"
+	StatusCritical = "critical"
+	StatusUnknown  = "unknown"
+)
+
+const defaultProbeTimeout = 5 * time.Second
+
+// Probe checks one aspect of a component. The context carries the probe timeout.
+type Probe interface {
+	Check(ctx context.Context) Outcome
+}
+
+// Outcome is what a probe observed. A non-nil Err always means critical.
+type Outcome struct {
+	Status  string
+	Message string
+	Metrics map[string]string
+	Err     error
+}
+
+// Healthy builds a healthy outcome
+func Healthy(format string, args ...interface{}) Outcome {
+	return Outcome{Status: StatusHealthy, Message: fmt.Sprintf(format, args...)}
+}
+
+// Failed builds a critical outcome from an error
+func Failed(err error) Outcome {
+	return Outcome{Status: StatusCritical, Err: err}
+}
+
+// ProbeResult is the outcome of a probe run, with its measured latency
+type ProbeResult struct {
+	Name    string
+	Type    string
+	Deep    bool
+	Status  string
+	Latency time.Duration
+	Message string
+	Error   string
+	Metrics map[string]string
+}
+
+// ComponentResult aggregates the probe results of a component
+type ComponentResult struct {
+	Component string
+	Status    string // Worst status of the probes that ran
+	Probes    []ProbeResult
+	CheckedAt time.Time
+}
+
+// Failures returns the probe results that are not healthy
+func (r ComponentResult) Failures() []ProbeResult {
+	var failures []ProbeResult
+	for _, probe := range r.Probes {
+		if probe.Status != StatusHealthy {
+			failures = append(failures, probe)
+		}
+	}
+	return failures
+}
+
+// Factory builds a probe from its configuration
+type Factory func(config ProbeConfig, deps Dependencies) (Probe, error)
+
+// Registry maps probe types to their factories
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry creates a registry with the built-in probe types
+func NewRegistry() *Registry {
+	r := &Registry{factories: make(map[string]Factory)}
+	r.Register("http", newHTTPProbe)
+	r.Register("tcp", newTCPProbe)
+	r.Register("dns", newDNSProbe)
+	r.Register("prometheus", newPrometheusProbe)
+	r.Register("awx_job", newAWXJobProbe)
+	r.Register("redis", newRedisProbe)
+	r.Register("postgres", newPostgresProbe)
+	return r
+}
+
+// Register adds or replaces the factory of a probe type
+func (r *Registry) Register(probeType string, factory Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[probeType] = factory
+}
+
+// Types returns the registered probe types
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.factories))
+	for probeType := range r.factories {
+		types = append(types, probeType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Build creates a probe from its configuration
+func (r *Registry) Build(config ProbeConfig, deps Dependencies) (Probe, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[config.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown probe type %q (available: %s)", config.Type, strings.Join(r.Types(), ", "))
+	}
+	return factory(config, deps)
+}
+
+type configuredProbe struct {
+	config ProbeConfig
+	probe  Probe
+}
+
+// Checker runs the configured probes of each component
+type Checker struct {
+	components map[string][]configuredProbe
+}
+
+// NewChecker builds every probe of the configuration
+func NewChecker(config *Config, registry *Registry, deps Dependencies) (*Checker, error) {
+	checker := &Checker{components: make(map[string][]configuredProbe)}
+
+	for _, component := range config.ComponentNames() {
+		for _, probeConfig := range config.Components[component].Probes {
+			probeConfig = config.withDefaults(probeConfig)
+			probe, err := registry.Build(probeConfig, deps)
+			if err != nil {
+				return nil, fmt.Errorf("component %s, probe %s: %w", component, probeConfig.Name, err)
+			}
+			checker.components[component] = append(checker.components[component], configuredProbe{config: probeConfig, probe: probe})
+		}
+	}
+
+	return checker, nil
+}
+
+// Components returns the names of the components with probes
+func (c *Checker) Components() []string {
+	names := make([]string, 0, len(c.components))
+	for name := range c.components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MonitoredComponents returns the components the background monitor checks
+func (c *Checker) MonitoredComponents() []string {
+	var names []string
+	for _, name := range c.Components() {
+		if c.Monitored(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Monitored reports whether the component has a probe that runs without deep;
+// a background check of the others would run no probe at all
+func (c *Checker) Monitored(component string) bool {
+	for _, probe := range c.components[component] {
+		if !probe.config.Deep {
+			return true
+		}
+	}
+	return false
+}
+
+// Has reports whether the component has probes
+func (c *Checker) Has(component string) bool {
+	_, ok := c.components[component]
+	return ok
+}
+
+// Check runs the probes of a component concurrently. Deep probes only run when deep is set.
+func (c *Checker) Check(ctx context.Context, component string, deep bool) ComponentResult {
+	result := ComponentResult{Component: component, Status: StatusUnknown, CheckedAt: time.Now()}
+
+	var probes []configuredProbe
+	for _, probe := range c.components[component] {
+		if deep || !probe.config.Deep {
+			probes = append(probes, probe)
+		}
+	}
+	if len(probes) == 0 {
+		return result
+	}
+
+	result.Probes = make([]ProbeResult, len(probes))
+	var wg sync.WaitGroup
+	for i, probe := range probes {
+		wg.Add(1)
+		go func(i int, probe configuredProbe) {
+			defer wg.Done()
+			result.Probes[i] = runProbe(ctx, probe)
+		}(i, probe)
+	}
+	wg.Wait()
+
+	result.Status = StatusHealthy
+	for _, probe := range result.Probes {
+		result.Status = Worst(result.Status, probe.Status)
+	}
+	return result
+}
+
+// runProbe runs a probe within its timeout and applies its latency thresholds
+func runProbe(ctx context.Context, configured configuredProbe) ProbeResult {
+	config := configured.config
+	probeCtx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	outcome := configured.probe.Check(probeCtx)
+	latency := time.Since(start)
+
+	result := ProbeResult{
+		Name:    config.Name,
+		Type:    config.Type,
+		Deep:    config.Deep,
+		Status:  outcome.Status,
+		Latency: latency,
+		Message: outcome.Message,
+		Metrics: outcome.Metrics,
+	}
+
+	if outcome.Err != nil {
+		result.Status = StatusCritical
+		result.Error = outcome.Err.Error()
+		if probeCtx.Err() == context.DeadlineExceeded {
+			result.Error = fmt.Sprintf("timed out after %s: %v", config.Timeout, outcome.Err)
+		}
+		return result
+	}
+	if result.Status == "" {
+		result.Status = StatusHealthy
+	}
+
+	switch {
+	case config.CriticalLatency > 0 && latency > config.CriticalLatency:
+		result.Status = Worst(result.Status, StatusCritical)
+		result.Error = fmt.Sprintf("latency %s above critical_latency %s", latency.Round(time.Millisecond), config.CriticalLatency)
+	case config.WarningLatency > 0 && latency > config.WarningLatency:
+		result.Status = Worst(result.Status, StatusWarning)
+		result.Error = fmt.Sprintf("latency %s above warning_latency %s", latency.Round(time.Millisecond), config.WarningLatency)
+	}
+	return result
+}
+
+var statusRank = map[string]int{
+	StatusHealthy:  0,
+	StatusUnknown:  1,
+	StatusWarning:  2,
+	StatusCritical: 3,
+}
+
+// Worst returns the more severe of two statuses
+func Worst(a, b string) string {
+	if statusRank[b] > statusRank[a] {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,123 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeProbe returns a fixed outcome after an optional delay
+type fakeProbe struct {
+	outcome Outcome
+	delay   time.Duration
+}
+
+func (p fakeProbe) Check(ctx context.Context) Outcome {
+	select {
+	case <-time.After(p.delay):
+		return p.outcome
+	case <-ctx.Done():
+		return Failed(ctx.Err())
+	}
+}
+
+// fakeChecker builds a checker whose "fake" probes answer the outcome of their name
+func fakeChecker(t *testing.T, config *Config, probes map[string]fakeProbe) *Checker {
+	t.Helper()
+	registry := NewRegistry()
+	registry.Register("fake", func(config ProbeConfig, deps Dependencies) (Probe, error) {
+		probe, ok := probes[config.Name]
+		if !ok {
+			return nil, errors.New("no fake outcome")
+		}
+		return probe, nil
+	})
+	checker, err := NewChecker(config, registry, Dependencies{})
+	if err != nil {
+		t.Fatalf("NewChecker: %v", err)
+	}
+	return checker
+}
+
+func TestCheckerCheck(t *testing.T) {
+	config := &Config{Components: map[string]ComponentConfig{
+		"api": {Probes: []ProbeConfig{
+			{Name: "up", Type: "fake"},
+			{Name: "slow", Type: "fake", WarningLatency: 10 * time.Millisecond, CriticalLatency: time.Second},
+			{Name: "round_trip", Type: "fake", Deep: true},
+		}},
+		"db": {Probes: []ProbeConfig{
+			{Name: "query", Type: "fake", Deep: true},
+		}},
+		"queue": {Probes: []ProbeConfig{
+			{Name: "hung", Type: "fake", Timeout: 20 * time.Millisecond},
+		}},
+	}}
+	checker := fakeChecker(t, config, map[string]fakeProbe{
+		"up":         {outcome: Healthy("ok")},
+		"slow":       {outcome: Healthy("ok"), delay: 50 * time.Millisecond},
+		"round_trip": {outcome: Failed(errors.New("connection refused"))},
+		"query":      {outcome: Healthy("1 row")},
+		"hung":       {outcome: Healthy("ok"), delay: time.Second},
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		component string
+		deep      bool
+		status    string
+		probes    string // Name=status of the probes that ran
+		err       string // Error substring of the first failure
+	}{
+		{component: "api", status: StatusWarning, probes: "up=healthy,slow=warning", err: "above warning_latency 10ms"},
+		{component: "api", deep: true, status: StatusCritical, probes: "up=healthy,slow=warning,round_trip=critical", err: "above warning_latency"},
+		{component: "db", status: StatusUnknown},
+		{component: "db", deep: true, status: StatusHealthy, probes: "query=healthy"},
+		{component: "queue", status: StatusCritical, probes: "hung=critical", err: "timed out after 20ms"},
+		{component: "missing", status: StatusUnknown},
+	}
+	for _, tt := range tests {
+		result := checker.Check(ctx, tt.component, tt.deep)
+		var probes []string
+		for _, probe := range result.Probes {
+			probes = append(probes, probe.Name+"="+probe.Status)
+		}
+		if result.Status != tt.status || strings.Join(probes, ",") != tt.probes {
+			t.Errorf("Check(%s, deep=%t) = %s [%s], want %s [%s]", tt.component, tt.deep, result.Status, strings.Join(probes, ","), tt.status, tt.probes)
+		}
+		if failures := result.Failures(); tt.err != "" && (len(failures) == 0 || !strings.Contains(failures[0].Error, tt.err)) {
+			t.Errorf("Check(%s, deep=%t) failures = %+v, want %q", tt.component, tt.deep, failures, tt.err)
+		}
+	}
+
+	if got := strings.Join(checker.Components(), ","); got != "api,db,queue" {
+		t.Errorf("components = %s", got)
+	}
+	if got := strings.Join(checker.MonitoredComponents(), ","); got != "api,queue" {
+		t.Errorf("monitored components = %s, want the components with non-deep probes", got)
+	}
+}
+
+func TestNewCheckerReportsBuildErrors(t *testing.T) {
+	config := &Config{Components: map[string]ComponentConfig{"api": {Probes: []ProbeConfig{{Name: "ping", Type: "smoke"}}}}}
+	if _, err := NewChecker(config, NewRegistry(), Dependencies{}); err == nil || !strings.Contains(err.Error(), `component api, probe ping: unknown probe type "smoke"`) {
+		t.Errorf("NewChecker error = %v", err)
+	}
+}
+
+func TestWorst(t *testing.T) {
+	order := []string{StatusHealthy, StatusUnknown, StatusWarning, StatusCritical}
+	for i, a := range order {
+		for j, b := range order {
+			want := a
+			if j > i {
+				want = b
+			}
+			if got := Worst(a, b); got != want {
+				t.Errorf("Worst(%s, %s) = %s, want %s", a, b, got, want)
+			}
+		}
+	}
+}
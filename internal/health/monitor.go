@@ -0,0 +1,266 @@
+package health
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMonitorInterval = time.Minute
+	defaultHistorySize     = 1440 // 24 hours at the default interval
+	defaultFlapWindow      = 15 * time.Minute
+	defaultFlapThreshold   = 4
+)
+
+// MonitorSettings control background probing
+type MonitorSettings struct {
+	Disabled      bool          `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	Interval      time.Duration `yaml:"interval,omitempty" json:"interval,omitempty"`
+	HistorySize   int           `yaml:"history_size,omitempty" json:"history_size,omitempty"`     // Samples kept per component
+	FlapWindow    time.Duration `yaml:"flap_window,omitempty" json:"flap_window,omitempty"`       // Window state changes are counted in
+	FlapThreshold int           `yaml:"flap_threshold,omitempty" json:"flap_threshold,omitempty"` // State changes in the window that count as flapping
+}
+
+func (s MonitorSettings) withDefaults() MonitorSettings {
+	if s.Interval <= 0 {
+		s.Interval = defaultMonitorInterval
+	}
+	if s.HistorySize <= 0 {
+		s.HistorySize = defaultHistorySize
+	}
+	if s.FlapWindow <= 0 {
+		s.FlapWindow = defaultFlapWindow
+	}
+	if s.FlapThreshold <= 0 {
+		s.FlapThreshold = defaultFlapThreshold
+	}
+	return s
+}
+
+// Sample is one background check of a component
+type Sample struct {
+	Time    time.Time
+	Status  string
+	Latency time.Duration // Slowest probe
+	Error   string        // First probe problem, if any
+}
+
+// Summary describes the history of a component over a window
+type Summary struct {
+	Component      string
+	Status         string    // Latest status
+	StatusSince    time.Time // When the latest status began, as far as the history goes
+	Samples        int
+	UptimePercent  float64 // Samples that were not critical or unknown
+	HealthyPercent float64 // Samples that were healthy
+	Transitions    int     // Status changes within the window
+	Flapping       bool    // Status changes within the flap window reached the threshold
+	FlapChanges    int     // Status changes within the flap window
+	AvgLatency     time.Duration
+	MaxLatency     time.Duration
+	LastError      string
+	LastErrorAt    time.Time
+	Recent         []Sample // Newest last
+}
+
+// Monitor probes every component in the background and keeps a bounded history
+type Monitor struct {
+	checker  *Checker
+	settings MonitorSettings
+
+	mu       sync.RWMutex
+	history  map[string][]Sample
+	flapping map[string]bool
+}
+
+// NewMonitor creates a monitor; call Start to begin probing
+func NewMonitor(checker *Checker, settings MonitorSettings) *Monitor {
+	return &Monitor{
+		checker:  checker,
+		settings: settings.withDefaults(),
+		history:  make(map[string][]Sample),
+		flapping: make(map[string]bool),
+	}
+}
+
+// Settings returns the effective monitor settings
+func (m *Monitor) Settings() MonitorSettings {
+	return m.settings
+}
+
+// Start probes all components on the configured interval until ctx is canceled
+func (m *Monitor) Start(ctx context.Context) {
+	if m.settings.Disabled {
+		log.Printf("Background health probing disabled")
+		return
+	}
+
+	log.Printf("Background health probing every %s (history: %d samples per component)", m.settings.Interval, m.settings.HistorySize)
+	go func() {
+		ticker := time.NewTicker(m.settings.Interval)
+		defer ticker.Stop()
+
+		for {
+			m.probeAll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (m *Monitor) probeAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, component := range m.checker.MonitoredComponents() {
+		wg.Add(1)
+		go func(component string) {
+			defer wg.Done()
+			result := m.checker.Check(ctx, component, false)
+			if ctx.Err() != nil {
+				return // Probes interrupted by shutdown say nothing about the component
+			}
+			m.Record(result)
+		}(component)
+	}
+	wg.Wait()
+}
+
+// Record adds a check result to the history of its component
+func (m *Monitor) Record(result ComponentResult) {
+	sample := Sample{Time: result.CheckedAt, Status: result.Status}
+	for _, probe := range result.Probes {
+		if probe.Latency > sample.Latency {
+			sample.Latency = probe.Latency
+		}
+		if sample.Error == "" && probe.Status != StatusHealthy {
+			sample.Error = probe.Name + ": " + probe.Error
+			if probe.Error == "" {
+				sample.Error = probe.Name + ": " + probe.Message
+			}
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	samples := m.history[result.Component]
+	if len(samples) >= m.settings.HistorySize {
+		copy(samples, samples[1:])
+		samples[len(samples)-1] = sample
+	} else {
+		samples = append(samples, sample)
+	}
+	m.history[result.Component] = samples
+
+	changes := countTransitions(samples, sample.Time.Add(-m.settings.FlapWindow))
+	flapping := changes >= m.settings.FlapThreshold
+	if flapping != m.flapping[result.Component] {
+		if flapping {
+			log.Printf("⚠️  Component %s is flapping: %d state changes in %s", result.Component, changes, m.settings.FlapWindow)
+		} else {
+			log.Printf("Component %s stopped flapping", result.Component)
+		}
+		m.flapping[result.Component] = flapping
+	}
+}
+
+// Components returns the components with recorded history
+func (m *Monitor) Components() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.history))
+	for name := range m.history {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Summarize describes the history of a component within window (zero: all
+// history), including up to recent of the newest samples
+func (m *Monitor) Summarize(component string, window time.Duration, recent int) (Summary, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	all, ok := m.history[component]
+	if !ok || len(all) == 0 {
+		return Summary{Component: component}, false
+	}
+
+	now := time.Now()
+	samples := all
+	if window > 0 {
+		samples = since(all, now.Add(-window))
+	}
+
+	latest := all[len(all)-1]
+	summary := Summary{
+		Component:   component,
+		Status:      latest.Status,
+		StatusSince: all[0].Time,
+		Samples:     len(samples),
+		Transitions: countTransitions(samples, time.Time{}),
+		FlapChanges: countTransitions(all, now.Add(-m.settings.FlapWindow)),
+	}
+	summary.Flapping = summary.FlapChanges >= m.settings.FlapThreshold
+
+	for i := len(all) - 1; i > 0; i-- {
+		if all[i-1].Status != latest.Status {
+			summary.StatusSince = all[i].Time
+			break
+		}
+	}
+
+	up, healthy := 0, 0
+	var totalLatency time.Duration
+	for _, sample := range samples {
+		if sample.Status == StatusHealthy || sample.Status == StatusWarning {
+			up++
+		}
+		if sample.Status == StatusHealthy {
+			healthy++
+		}
+		totalLatency += sample.Latency
+		if sample.Latency > summary.MaxLatency {
+			summary.MaxLatency = sample.Latency
+		}
+		if sample.Error != "" {
+			summary.LastError, summary.LastErrorAt = sample.Error, sample.Time
+		}
+	}
+	if len(samples) > 0 {
+		summary.UptimePercent = 100 * float64(up) / float64(len(samples))
+		summary.HealthyPercent = 100 * float64(healthy) / float64(len(samples))
+		summary.AvgLatency = totalLatency / time.Duration(len(samples))
+	}
+
+	if recent > len(samples) {
+		recent = len(samples)
+	}
+	summary.Recent = append([]Sample(nil), samples[len(samples)-recent:]...)
+
+	return summary, true
+}
+
+// since returns the samples taken at or after from
+func since(samples []Sample, from time.Time) []Sample {
+	i := sort.Search(len(samples), func(i int) bool { return !samples[i].Time.Before(from) })
+	return samples[i:]
+}
+
+// countTransitions counts status changes between consecutive samples taken at or after from
+func countTransitions(samples []Sample, from time.Time) int {
+	samples = since(samples, from)
+	changes := 0
+	for i := 1; i < len(samples); i++ {
+		if samples[i].Status != samples[i-1].Status {
+			changes++
+		}
+	}
+	return changes
+}
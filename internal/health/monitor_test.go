@@ -0,0 +1,163 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// statusSamples builds one sample per status, a minute apart and ending at end
+func statusSamples(end time.Time, statuses ...string) []Sample {
+	samples := make([]Sample, len(statuses))
+	for i, status := range statuses {
+		samples[i] = Sample{Time: end.Add(time.Duration(i-len(statuses)+1) * time.Minute), Status: status}
+	}
+	return samples
+}
+
+func TestCountTransitions(t *testing.T) {
+	end := time.Now()
+	h, w, c := StatusHealthy, StatusWarning, StatusCritical
+
+	tests := []struct {
+		name     string
+		statuses []string
+		from     time.Duration // Before end; zero counts every sample
+		want     int
+	}{
+		{name: "no samples", want: 0},
+		{name: "steady", statuses: []string{h, h, h}, want: 0},
+		{name: "every change counts", statuses: []string{h, w, c, h}, want: 3},
+		{name: "flapping", statuses: []string{h, c, h, c, h}, want: 4},
+		{name: "window", statuses: []string{h, c, h, c, h}, from: 2 * time.Minute, want: 2},
+		{name: "window with one sample", statuses: []string{c, h}, from: time.Nanosecond, want: 0},
+	}
+	for _, tt := range tests {
+		from := time.Time{}
+		if tt.from != 0 {
+			from = end.Add(-tt.from)
+		}
+		if got := countTransitions(statusSamples(end, tt.statuses...), from); got != tt.want {
+			t.Errorf("%s: countTransitions = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// record adds samples to the monitor history, a minute apart and ending at end
+func record(m *Monitor, component string, end time.Time, statuses ...string) {
+	for _, sample := range statusSamples(end, statuses...) {
+		result := ComponentResult{Component: component, Status: sample.Status, CheckedAt: sample.Time,
+			Probes: []ProbeResult{{Name: "ping", Status: sample.Status, Latency: 10 * time.Millisecond}}}
+		if sample.Status != StatusHealthy {
+			result.Probes[0].Error = sample.Status + " at " + sample.Time.Format(time.RFC3339Nano)
+		}
+		m.Record(result)
+	}
+}
+
+func TestMonitorSummarize(t *testing.T) {
+	h, w, c, u := StatusHealthy, StatusWarning, StatusCritical, StatusUnknown
+
+	tests := []struct {
+		name        string
+		statuses    []string
+		window      time.Duration
+		samples     int
+		uptime      float64
+		healthy     float64
+		transitions int
+		flapping    bool
+		sinceIndex  int // Sample the latest status began with
+	}{
+		{name: "always healthy", statuses: []string{h, h, h, h}, samples: 4, uptime: 100, healthy: 100, sinceIndex: 0},
+		{name: "warnings count as up", statuses: []string{h, w, w, h}, samples: 4, uptime: 100, healthy: 50, transitions: 2, sinceIndex: 3},
+		{name: "critical and unknown count as down", statuses: []string{h, c, u, h, c}, samples: 5, uptime: 40, healthy: 40, transitions: 4, flapping: true, sinceIndex: 4},
+		{name: "window", statuses: []string{c, c, c, h, h}, window: 90 * time.Second, samples: 2, uptime: 100, healthy: 100, sinceIndex: 3},
+		{name: "history is bounded", statuses: []string{c, c, c, c, h, h, h, h, h, h}, samples: 8, uptime: 75, healthy: 75, transitions: 1, sinceIndex: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor := NewMonitor(nil, MonitorSettings{HistorySize: 8, FlapWindow: 10 * time.Minute, FlapThreshold: 4})
+			end := time.Now()
+			record(monitor, "api", end, tt.statuses...)
+			all := statusSamples(end, tt.statuses...)
+
+			summary, ok := monitor.Summarize("api", tt.window, 3)
+			if !ok {
+				t.Fatal("no history recorded")
+			}
+			if summary.Samples != tt.samples || summary.UptimePercent != tt.uptime || summary.HealthyPercent != tt.healthy {
+				t.Errorf("samples %d, uptime %.1f%%, healthy %.1f%%, want %d, %.1f%%, %.1f%%",
+					summary.Samples, summary.UptimePercent, summary.HealthyPercent, tt.samples, tt.uptime, tt.healthy)
+			}
+			if summary.Transitions != tt.transitions || summary.Flapping != tt.flapping {
+				t.Errorf("transitions %d, flapping %t, want %d, %t", summary.Transitions, summary.Flapping, tt.transitions, tt.flapping)
+			}
+			if want := all[tt.sinceIndex].Time; !summary.StatusSince.Equal(want) {
+				t.Errorf("status since %s, want %s", summary.StatusSince, want)
+			}
+			if summary.Status != tt.statuses[len(tt.statuses)-1] || len(summary.Recent) != min(3, summary.Samples) {
+				t.Errorf("status %s with %d recent samples", summary.Status, len(summary.Recent))
+			}
+			if summary.AvgLatency != 10*time.Millisecond || summary.MaxLatency != 10*time.Millisecond {
+				t.Errorf("latency avg %s, max %s, want 10ms", summary.AvgLatency, summary.MaxLatency)
+			}
+		})
+	}
+
+	monitor := NewMonitor(nil, MonitorSettings{})
+	if _, ok := monitor.Summarize("api", 0, 10); ok {
+		t.Error("expected no summary without history")
+	}
+}
+
+func TestMonitorFlapDetection(t *testing.T) {
+	monitor := NewMonitor(nil, MonitorSettings{FlapWindow: 5 * time.Minute, FlapThreshold: 3})
+	h, c := StatusHealthy, StatusCritical
+
+	// Changes older than the flap window only count as transitions
+	record(monitor, "api", time.Now(), h, c, h, c, h, h, h, h, h, h)
+	summary, _ := monitor.Summarize("api", 0, 0)
+	if summary.Flapping || summary.FlapChanges != 0 || summary.Transitions != 4 {
+		t.Errorf("flapping %t with %d recent and %d total changes, want 0 recent and 4 total", summary.Flapping, summary.FlapChanges, summary.Transitions)
+	}
+	if !strings.HasPrefix(summary.LastError, "ping: critical") {
+		t.Errorf("last error = %q", summary.LastError)
+	}
+
+	record(monitor, "db", time.Now(), h, c, h, c)
+	if summary, _ := monitor.Summarize("db", 0, 0); !summary.Flapping || summary.FlapChanges != 3 {
+		t.Errorf("flapping %t with %d changes, want flapping at 3", summary.Flapping, summary.FlapChanges)
+	}
+	monitor.mu.RLock()
+	flapping := monitor.flapping["db"]
+	monitor.mu.RUnlock()
+	if !flapping {
+		t.Error("Record did not mark db as flapping")
+	}
+}
+
+func TestMonitorSkipsDeepOnlyComponents(t *testing.T) {
+	config := &Config{Components: map[string]ComponentConfig{
+		"api":   {Probes: []ProbeConfig{{Name: "up", Type: "fake"}, {Name: "slow_query", Type: "fake", Deep: true}}},
+		"cache": {Probes: []ProbeConfig{{Name: "set_get", Type: "fake", Deep: true}}},
+	}}
+	checker := fakeChecker(t, config, map[string]fakeProbe{
+		"up":         {outcome: Healthy("ok")},
+		"slow_query": {outcome: Failed(errors.New("deep probes must not run in the background"))},
+		"set_get":    {outcome: Failed(errors.New("deep probes must not run in the background"))},
+	})
+	monitor := NewMonitor(checker, MonitorSettings{})
+
+	monitor.probeAll(context.Background())
+	monitor.probeAll(context.Background())
+
+	if got := strings.Join(monitor.Components(), ","); got != "api" {
+		t.Fatalf("components with history = %s, want only api", got)
+	}
+	if summary, _ := monitor.Summarize("api", 0, 0); summary.Samples != 2 || summary.UptimePercent != 100 {
+		t.Errorf("api: %d samples, %.0f%% uptime, want 2 healthy samples", summary.Samples, summary.UptimePercent)
+	}
+}
@@ -0,0 +1,368 @@
+package health
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	defaultPostgresQuery = "SELECT 1"
+	postgresProtocol     = 196608   // Protocol version 3.0
+	postgresSSLRequest   = 80877103 // SSLRequest code
+)
+
+// postgresProbe connects with the PostgreSQL wire protocol (over TLS unless
+// tls: disable or prefer says otherwise), authenticates (trust, password, md5
+// or SCRAM-SHA-256) and runs a query. Cleartext passwords are only sent over TLS.
+type postgresProbe struct {
+	config    ProbeConfig
+	tlsMode   string
+	tlsConfig *tls.Config
+}
+
+func newPostgresProbe(config ProbeConfig, deps Dependencies) (Probe, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+	if config.Username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+	if config.Database == "" {
+		config.Database = config.Username
+	}
+	if config.Query == "" {
+		config.Query = defaultPostgresQuery
+	}
+
+	probe := &postgresProbe{config: config}
+	var err error
+	if probe.tlsMode, err = config.tlsMode(tlsRequire, tlsDisable, tlsPrefer, tlsRequire); err != nil {
+		return nil, err
+	}
+	if probe.tlsMode != tlsDisable {
+		if probe.tlsConfig, err = config.addressTLSConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return probe, nil
+}
+
+func (p *postgresProbe) Check(ctx context.Context) Outcome {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.config.Address)
+	if err != nil {
+		return Failed(err)
+	}
+	defer func() { conn.Close() }()
+	setDeadline(ctx, conn)
+
+	encrypted := false
+	if p.tlsMode != tlsDisable {
+		if conn, encrypted, err = p.startTLS(ctx, conn); err != nil {
+			return Failed(err)
+		}
+	}
+
+	pg := &postgresConn{conn: conn, reader: bufio.NewReader(conn), encrypted: encrypted}
+	defer pg.send('X', nil) // Terminate
+
+	if err := pg.startup(p.config.Username, p.config.Database, p.config.password()); err != nil {
+		return Failed(err)
+	}
+
+	start := time.Now()
+	rows, err := pg.query(p.config.Query)
+	if err != nil {
+		return Failed(fmt.Errorf("query %q failed: %w", p.config.Query, err))
+	}
+	roundTrip := time.Since(start)
+
+	outcome := Healthy("%q returned %d rows from %s/%s", p.config.Query, rows, p.config.Address, p.config.Database)
+	outcome.Metrics = map[string]string{
+		"query_round_trip": roundTrip.Round(time.Microsecond).String(),
+		"rows":             strconv.Itoa(rows),
+		"tls":              strconv.FormatBool(encrypted),
+	}
+	return outcome
+}
+
+// startTLS sends an SSLRequest and upgrades the connection when the server
+// accepts it. The returned connection replaces conn, which stays open.
+func (p *postgresProbe) startTLS(ctx context.Context, conn net.Conn) (net.Conn, bool, error) {
+	request := make([]byte, 8)
+	binary.BigEndian.PutUint32(request, 8)
+	binary.BigEndian.PutUint32(request[4:], postgresSSLRequest)
+	if _, err := conn.Write(request); err != nil {
+		return conn, false, err
+	}
+
+	// The answer is read unbuffered: nothing may be read past it before the handshake
+	answer := make([]byte, 1)
+	if _, err := io.ReadFull(conn, answer); err != nil {
+		return conn, false, fmt.Errorf("SSLRequest failed: %w", err)
+	}
+	switch answer[0] {
+	case 'S':
+		tlsConn, err := startTLS(ctx, conn, p.tlsConfig)
+		if err != nil {
+			return conn, false, err
+		}
+		return tlsConn, true, nil
+	case 'N':
+		// With tls: prefer the connection silently stays unencrypted, which is why it is opt-in
+		if p.tlsMode == tlsRequire {
+			return conn, false, fmt.Errorf("server does not support TLS; set tls: prefer or disable to connect without it")
+		}
+		return conn, false, nil
+	default:
+		return conn, false, fmt.Errorf("unexpected SSLRequest answer %q", answer[0])
+	}
+}
+
+type postgresConn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	encrypted bool // Cleartext passwords are only sent over TLS
+}
+
+// send writes a message; a zero type sends an untyped startup message
+func (c *postgresConn) send(msgType byte, payload []byte) error {
+	var buf bytes.Buffer
+	if msgType != 0 {
+		buf.WriteByte(msgType)
+	}
+	binary.Write(&buf, binary.BigEndian, int32(len(payload)+4))
+	buf.Write(payload)
+	_, err := c.conn.Write(buf.Bytes())
+	return err
+}
+
+func (c *postgresConn) receive() (byte, []byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return 0, nil, err
+	}
+	length := int(binary.BigEndian.Uint32(header[1:])) - 4
+	if length < 0 || length > 16*1024*1024 {
+		return 0, nil, fmt.Errorf("invalid message length %d", length)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[0], payload, nil
+}
+
+func (c *postgresConn) startup(user, database, password string) error {
+	var params bytes.Buffer
+	binary.Write(&params, binary.BigEndian, int32(postgresProtocol))
+	for _, kv := range [][2]string{{"user", user}, {"database", database}, {"application_name", "autosphere-health"}} {
+		params.WriteString(kv[0] + "\x00" + kv[1] + "\x00")
+	}
+	params.WriteByte(0)
+	if err := c.send(0, params.Bytes()); err != nil {
+		return err
+	}
+
+	var scram *scramClient
+	verified := false // The server proved it knows the password in SASL final
+	for {
+		msgType, payload, err := c.receive()
+		if err != nil {
+			return err
+		}
+
+		switch msgType {
+		case 'E':
+			return postgresError(payload)
+		case 'Z': // ReadyForQuery
+			if scram != nil && !verified {
+				return fmt.Errorf("server accepted SCRAM authentication without proving its identity")
+			}
+			return nil
+		case 'R':
+			if len(payload) < 4 {
+				return fmt.Errorf("invalid authentication message")
+			}
+			code, data := binary.BigEndian.Uint32(payload), payload[4:]
+			switch code {
+			case 0: // AuthenticationOk
+				if scram != nil && !verified {
+					return fmt.Errorf("server accepted SCRAM authentication without proving its identity")
+				}
+			case 3: // Cleartext password
+				if !c.encrypted {
+					return fmt.Errorf("server requested a cleartext password over an unencrypted connection; use tls: require or md5/SCRAM authentication")
+				}
+				if err := c.send('p', []byte(password+"\x00")); err != nil {
+					return err
+				}
+			case 5: // MD5 password
+				inner := md5.Sum([]byte(password + user))
+				outer := md5.Sum(append([]byte(hex.EncodeToString(inner[:])), data[:4]...))
+				if err := c.send('p', []byte("md5"+hex.EncodeToString(outer[:])+"\x00")); err != nil {
+					return err
+				}
+			case 10: // SASL
+				if !bytes.Contains(data, []byte("SCRAM-SHA-256\x00")) {
+					return fmt.Errorf("server requires an unsupported SASL mechanism")
+				}
+				scram = newSCRAMClient(password)
+				first := scram.clientFirst()
+				var msg bytes.Buffer
+				msg.WriteString("SCRAM-SHA-256\x00")
+				binary.Write(&msg, binary.BigEndian, int32(len(first)))
+				msg.WriteString(first)
+				if err := c.send('p', msg.Bytes()); err != nil {
+					return err
+				}
+			case 11: // SASL continue
+				if scram == nil {
+					return fmt.Errorf("unexpected SASL continue message")
+				}
+				final, err := scram.clientFinal(string(data))
+				if err != nil {
+					return err
+				}
+				if err := c.send('p', []byte(final)); err != nil {
+					return err
+				}
+			case 12: // SASL final
+				if scram == nil || !scram.verifyServer(string(data)) {
+					return fmt.Errorf("server SCRAM signature mismatch")
+				}
+				verified = true
+			default:
+				return fmt.Errorf("unsupported authentication method %d", code)
+			}
+		}
+		// ParameterStatus, BackendKeyData and notices need no handling
+	}
+}
+
+// query runs a simple query and counts the returned rows
+func (c *postgresConn) query(sql string) (int, error) {
+	if err := c.send('Q', []byte(sql+"\x00")); err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	var queryErr error
+	for {
+		msgType, payload, err := c.receive()
+		if err != nil {
+			return 0, err
+		}
+		switch msgType {
+		case 'D':
+			rows++
+		case 'E':
+			queryErr = postgresError(payload)
+		case 'Z':
+			return rows, queryErr
+		}
+	}
+}
+
+// postgresError decodes the fields of an ErrorResponse
+func postgresError(payload []byte) error {
+	fields := make(map[byte]string)
+	for _, field := range bytes.Split(payload, []byte{0}) {
+		if len(field) > 1 {
+			fields[field[0]] = string(field[1:])
+		}
+	}
+	return fmt.Errorf("%s %s: %s", fields['S'], fields['C'], fields['M'])
+}
+
+// scramClient implements the client side of SCRAM-SHA-256 (RFC 7677) without channel binding
+type scramClient struct {
+	password        string
+	nonce           string
+	clientFirstBare string
+	authMessage     string
+	saltedPassword  []byte
+}
+
+func newSCRAMClient(password string) *scramClient {
+	raw := make([]byte, 18)
+	rand.Read(raw)
+	return &scramClient{password: password, nonce: base64.RawStdEncoding.EncodeToString(raw)}
+}
+
+func (s *scramClient) clientFirst() string {
+	// PostgreSQL takes the user from the startup message, so the SCRAM user name is empty
+	s.clientFirstBare = "n=,r=" + s.nonce
+	return "n,," + s.clientFirstBare
+}
+
+func (s *scramClient) clientFinal(serverFirst string) (string, error) {
+	attributes := make(map[string]string)
+	for _, part := range strings.Split(serverFirst, ",") {
+		if len(part) > 2 && part[1] == '=' {
+			attributes[part[:1]] = part[2:]
+		}
+	}
+
+	nonce, encodedSalt, iterations := attributes["r"], attributes["s"], attributes["i"]
+	if !strings.HasPrefix(nonce, s.nonce) {
+		return "", fmt.Errorf("server SCRAM nonce does not extend the client nonce")
+	}
+	salt, err := base64.StdEncoding.DecodeString(encodedSalt)
+	if err != nil {
+		return "", fmt.Errorf("invalid SCRAM salt: %w", err)
+	}
+	rounds, err := strconv.Atoi(iterations)
+	if err != nil || rounds < 1 {
+		return "", fmt.Errorf("invalid SCRAM iteration count %q", iterations)
+	}
+
+	s.saltedPassword = pbkdf2.Key([]byte(s.password), salt, rounds, sha256.Size, sha256.New)
+	clientFinalWithoutProof := "c=biws,r=" + nonce
+	s.authMessage = s.clientFirstBare + "," + serverFirst + "," + clientFinalWithoutProof
+
+	clientKey := hmacSHA256(s.saltedPassword, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+	signature := hmacSHA256(storedKey[:], s.authMessage)
+	proof := make([]byte, len(clientKey))
+	for i := range clientKey {
+		proof[i] = clientKey[i] ^ signature[i]
+	}
+
+	return clientFinalWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof), nil
+}
+
+func (s *scramClient) verifyServer(serverFinal string) bool {
+	if s.saltedPassword == nil {
+		return false // No proof was sent yet, so there is nothing to verify
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(serverFinal, "v="))
+	if err != nil {
+		return false
+	}
+	serverKey := hmacSHA256(s.saltedPassword, "Server Key")
+	return hmac.Equal(signature, hmacSHA256(serverKey, s.authMessage))
+}
+
+func hmacSHA256(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,370 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// testTLS returns a server TLS configuration valid for 127.0.0.1 and a
+// ca_file trusting it
+func testTLS(t *testing.T) (*tls.Config, string) {
+	t.Helper()
+	server := httptest.NewUnstartedServer(nil)
+	server.StartTLS()
+	config := &tls.Config{Certificates: server.TLS.Certificates}
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	server.Close()
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, ca, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return config, path
+}
+
+// serveOnce accepts a single connection on a local listener. It returns the
+// listener address and a channel closed once serve has returned.
+func serveOnce(t *testing.T, serve func(net.Conn)) (string, <-chan struct{}) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		serve(conn)
+	}()
+	t.Cleanup(func() {
+		listener.Close()
+		<-done
+	})
+	return listener.Addr().String(), done
+}
+
+// fakePostgres speaks enough of the backend protocol to authenticate a
+// client with one method and answer a simple query
+type fakePostgres struct {
+	tls          *tls.Config // Accept SSLRequest when set
+	auth         string      // trust, password, md5 or scram
+	password     string
+	badSignature bool // Send a wrong SCRAM server signature
+	skipFinal    bool // Accept a SCRAM client without sending SASL final
+
+	sslRequested bool
+	received     string // Password message (cleartext or md5)
+}
+
+func (f *fakePostgres) serve(conn net.Conn) {
+	startup, err := readStartup(conn)
+	if err != nil {
+		return
+	}
+	if binary.BigEndian.Uint32(startup) == postgresSSLRequest {
+		f.sslRequested = true
+		if f.tls == nil {
+			conn.Write([]byte("N"))
+		} else {
+			conn.Write([]byte("S"))
+			tlsConn := tls.Server(conn, f.tls)
+			if tlsConn.Handshake() != nil {
+				return
+			}
+			conn = tlsConn
+		}
+		if startup, err = readStartup(conn); err != nil {
+			return
+		}
+	}
+	user := startupParams(startup)["user"]
+
+	ok := true
+	switch f.auth {
+	case "password":
+		writeMessage(conn, 'R', authRequest(3, nil))
+		_, payload, err := readMessage(conn)
+		if err != nil {
+			return
+		}
+		f.received = strings.TrimSuffix(string(payload), "\x00")
+		ok = f.received == f.password
+	case "md5":
+		salt := []byte{1, 2, 3, 4}
+		writeMessage(conn, 'R', authRequest(5, salt))
+		_, payload, err := readMessage(conn)
+		if err != nil {
+			return
+		}
+		f.received = strings.TrimSuffix(string(payload), "\x00")
+		inner := md5.Sum([]byte(f.password + user))
+		outer := md5.Sum(append([]byte(hex.EncodeToString(inner[:])), salt...))
+		ok = f.received == "md5"+hex.EncodeToString(outer[:])
+	case "scram":
+		if ok, err = f.scram(conn); err != nil {
+			return
+		}
+	}
+	if !ok {
+		writeMessage(conn, 'E', []byte("SFATAL\x00C28P01\x00Mpassword authentication failed for user \""+user+"\"\x00\x00"))
+		return
+	}
+
+	writeMessage(conn, 'R', authRequest(0, nil))
+	writeMessage(conn, 'S', []byte("server_version\x0016.2\x00"))
+	writeMessage(conn, 'Z', []byte("I"))
+
+	msgType, payload, err := readMessage(conn)
+	if err != nil || msgType != 'Q' {
+		return
+	}
+	if query := strings.TrimSuffix(string(payload), "\x00"); query == "SELECT 1" {
+		writeMessage(conn, 'D', []byte{0, 1, 0, 0, 0, 1, '1'})
+		writeMessage(conn, 'C', []byte("SELECT 1\x00"))
+	} else {
+		writeMessage(conn, 'E', []byte("SERROR\x00C42601\x00Msyntax error\x00\x00"))
+	}
+	writeMessage(conn, 'Z', []byte("I"))
+	readMessage(conn) // Terminate
+}
+
+// scram runs the server side of SCRAM-SHA-256 and reports whether the client proof is valid
+func (f *fakePostgres) scram(conn net.Conn) (bool, error) {
+	writeMessage(conn, 'R', authRequest(10, []byte("SCRAM-SHA-256-PLUS\x00SCRAM-SHA-256\x00\x00")))
+	_, payload, err := readMessage(conn)
+	if err != nil {
+		return false, err
+	}
+	mechanism, rest, _ := bytes.Cut(payload, []byte{0})
+	if string(mechanism) != "SCRAM-SHA-256" || len(rest) < 4 {
+		return false, fmt.Errorf("unexpected SASLInitialResponse %q", payload)
+	}
+	clientFirstBare := strings.TrimPrefix(string(rest[4:]), "n,,")
+	_, clientNonce, _ := strings.Cut(clientFirstBare, "r=")
+
+	salt := []byte("fake-postgres-salt")
+	serverFirst := fmt.Sprintf("r=%sserver-nonce,s=%s,i=4096", clientNonce, base64.StdEncoding.EncodeToString(salt))
+	writeMessage(conn, 'R', authRequest(11, []byte(serverFirst)))
+
+	_, payload, err = readMessage(conn)
+	if err != nil {
+		return false, err
+	}
+	withoutProof, encodedProof, _ := strings.Cut(string(payload), ",p=")
+	proof, _ := base64.StdEncoding.DecodeString(encodedProof)
+	authMessage := clientFirstBare + "," + serverFirst + "," + withoutProof
+
+	salted := pbkdf2.Key([]byte(f.password), salt, 4096, sha256.Size, sha256.New)
+	storedKey := sha256.Sum256(hmacSHA256(salted, "Client Key"))
+	signature := hmacSHA256(storedKey[:], authMessage)
+	if len(proof) != len(signature) {
+		return false, nil
+	}
+	for i := range proof {
+		proof[i] ^= signature[i]
+	}
+	if sha256.Sum256(proof) != storedKey {
+		return false, nil
+	}
+
+	if f.skipFinal {
+		return true, nil
+	}
+	serverSignature := hmacSHA256(hmacSHA256(salted, "Server Key"), authMessage)
+	if f.badSignature {
+		serverSignature[0] ^= 0xff
+	}
+	writeMessage(conn, 'R', authRequest(12, []byte("v="+base64.StdEncoding.EncodeToString(serverSignature))))
+	return true, nil
+}
+
+func readStartup(r io.Reader) ([]byte, error) {
+	var length int32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	payload := make([]byte, length-4)
+	_, err := io.ReadFull(r, payload)
+	return payload, err
+}
+
+func startupParams(startup []byte) map[string]string {
+	params := make(map[string]string)
+	fields := strings.Split(string(startup[4:]), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		params[fields[i]] = fields[i+1]
+	}
+	return params
+}
+
+func readMessage(r io.Reader) (byte, []byte, error) {
+	msgType := make([]byte, 1)
+	if _, err := io.ReadFull(r, msgType); err != nil {
+		return 0, nil, err
+	}
+	payload, err := readStartup(r)
+	return msgType[0], payload, err
+}
+
+func writeMessage(w io.Writer, msgType byte, payload []byte) {
+	message := []byte{msgType, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(message[1:], uint32(len(payload)+4))
+	w.Write(append(message, payload...))
+}
+
+func authRequest(code uint32, data []byte) []byte {
+	payload := binary.BigEndian.AppendUint32(nil, code)
+	return append(payload, data...)
+}
+
+func TestPostgresProbe(t *testing.T) {
+	serverTLS, caFile := testTLS(t)
+
+	tests := []struct {
+		name   string
+		server fakePostgres
+		config ProbeConfig
+		want   string // Error substring, empty when healthy
+		tls    bool
+	}{
+		{name: "trust", server: fakePostgres{auth: "trust"}, config: ProbeConfig{TLS: "prefer"}},
+		{name: "md5", server: fakePostgres{auth: "md5", password: "secret"}, config: ProbeConfig{Password: "secret", TLS: "prefer"}},
+		{name: "scram", server: fakePostgres{auth: "scram", password: "secret"}, config: ProbeConfig{Password: "secret", TLS: "prefer"}},
+		{name: "scram wrong password", server: fakePostgres{auth: "scram", password: "secret"}, config: ProbeConfig{Password: "guess", TLS: "prefer"}, want: "FATAL 28P01"},
+		{name: "scram forged server signature", server: fakePostgres{auth: "scram", password: "secret", badSignature: true}, config: ProbeConfig{Password: "secret", TLS: "prefer"}, want: "signature mismatch"},
+		{name: "scram without SASL final", server: fakePostgres{auth: "scram", password: "secret", skipFinal: true}, config: ProbeConfig{Password: "secret", TLS: "prefer"}, want: "without proving its identity"},
+		{name: "cleartext refused without TLS", server: fakePostgres{auth: "password", password: "secret"}, config: ProbeConfig{Password: "secret", TLS: "prefer"}, want: "cleartext password over an unencrypted connection"},
+		{name: "cleartext over TLS", server: fakePostgres{tls: serverTLS, auth: "password", password: "secret"}, config: ProbeConfig{Password: "secret", CAFile: caFile}, tls: true},
+		{name: "scram over TLS", server: fakePostgres{tls: serverTLS, auth: "scram", password: "secret"}, config: ProbeConfig{Password: "secret", CAFile: caFile}, tls: true},
+		{name: "scram over preferred TLS", server: fakePostgres{tls: serverTLS, auth: "scram", password: "secret"}, config: ProbeConfig{Password: "secret", TLS: "prefer", CAFile: caFile}, tls: true},
+		{name: "TLS required by default", server: fakePostgres{auth: "trust"}, want: "does not support TLS"},
+		{name: "TLS required but not offered", server: fakePostgres{auth: "trust"}, config: ProbeConfig{TLS: "require"}, want: "does not support TLS"},
+		{name: "untrusted certificate", server: fakePostgres{tls: serverTLS, auth: "trust"}, want: "TLS handshake failed"},
+		{name: "untrusted certificate with TLS preferred", server: fakePostgres{tls: serverTLS, auth: "trust"}, config: ProbeConfig{TLS: "prefer"}, want: "TLS handshake failed"},
+		{name: "insecure", server: fakePostgres{tls: serverTLS, auth: "trust"}, config: ProbeConfig{Insecure: true}, tls: true},
+		{name: "query error", server: fakePostgres{auth: "trust"}, config: ProbeConfig{Query: "SELEC 1", TLS: "prefer"}, want: "42601"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := tt.server
+			config := tt.config
+			config.Type = "postgres"
+			address, done := serveOnce(t, server.serve)
+			config.Address = address
+			config.Username = "health"
+
+			probe, err := newPostgresProbe(config, Dependencies{})
+			if err != nil {
+				t.Fatalf("newPostgresProbe: %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			outcome := probe.Check(ctx)
+			<-done
+
+			if tt.want == "" {
+				if outcome.Status != StatusHealthy {
+					t.Fatalf("outcome = %s: %v", outcome.Status, outcome.Err)
+				}
+				if outcome.Metrics["rows"] != "1" || outcome.Metrics["tls"] != fmt.Sprint(tt.tls) {
+					t.Errorf("metrics = %v", outcome.Metrics)
+				}
+			} else if outcome.Err == nil || !strings.Contains(outcome.Err.Error(), tt.want) {
+				t.Fatalf("outcome = %s: %v, want %q", outcome.Status, outcome.Err, tt.want)
+			}
+			if !server.sslRequested {
+				t.Error("the probe did not send an SSLRequest")
+			}
+		})
+	}
+}
+
+func TestPostgresProbeNeverSendsCleartextPasswordUnencrypted(t *testing.T) {
+	server := fakePostgres{auth: "password", password: "secret"}
+	address, done := serveOnce(t, server.serve)
+
+	probe, err := newPostgresProbe(ProbeConfig{Address: address, Username: "health", Password: "secret", TLS: "disable"}, Dependencies{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome := probe.Check(context.Background()); outcome.Err == nil {
+		t.Fatal("expected the cleartext request to be refused")
+	}
+	<-done
+	if server.sslRequested || server.received != "" {
+		t.Errorf("SSLRequest sent %t, password received %q", server.sslRequested, server.received)
+	}
+}
+
+func TestPostgresProbeConfig(t *testing.T) {
+	tests := []struct {
+		config ProbeConfig
+		want   string
+	}{
+		{ProbeConfig{Username: "u"}, "address is required"},
+		{ProbeConfig{Address: "db:5432"}, "username is required"},
+		{ProbeConfig{Address: "db:5432", Username: "u", TLS: "verify"}, "tls must be one of disable, prefer, require"},
+		{ProbeConfig{Address: "db:5432", Username: "u", CAFile: "/nonexistent/ca.pem"}, "failed to read ca_file"},
+	}
+	for _, tt := range tests {
+		if _, err := newPostgresProbe(tt.config, Dependencies{}); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("newPostgresProbe(%+v) error = %v, want %q", tt.config, err, tt.want)
+		}
+	}
+
+	// ca_file is not read when TLS is disabled
+	if _, err := newPostgresProbe(ProbeConfig{Address: "db:5432", Username: "u", TLS: "disable", CAFile: "/nonexistent/ca.pem"}, Dependencies{}); err != nil {
+		t.Errorf("newPostgresProbe without TLS: %v", err)
+	}
+}
+
+// TestSCRAMClient checks the client messages against the RFC 7677 example
+func TestSCRAMClient(t *testing.T) {
+	scram := &scramClient{password: "pencil", nonce: "rOprNGfwEbeRWgbNEkqO"}
+	scram.clientFirst()
+	scram.clientFirstBare = "n=user,r=rOprNGfwEbeRWgbNEkqO" // The RFC example names the user
+
+	final, err := scram.clientFinal("r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096")
+	if err != nil {
+		t.Fatalf("clientFinal: %v", err)
+	}
+	if want := "c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ="; final != want {
+		t.Errorf("client final = %s, want %s", final, want)
+	}
+	if !scram.verifyServer("v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=") {
+		t.Error("the RFC server signature was rejected")
+	}
+	if scram.verifyServer("v=AAAA") {
+		t.Error("a wrong server signature was accepted")
+	}
+	if early := newSCRAMClient("pencil"); early.verifyServer("v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=") {
+		t.Error("a server signature was accepted before the client proof was sent")
+	}
+
+	if _, err := scram.clientFinal("r=someone-else,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096"); err == nil {
+		t.Error("expected a nonce that does not extend the client nonce to be rejected")
+	}
+}
@@ -0,0 +1,249 @@
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxProbeBody caps how much of an HTTP response is read for expect_body
+const maxProbeBody = 64 * 1024
+
+// TLS modes of the redis and postgres probes
+const (
+	tlsDisable = "disable"
+	tlsPrefer  = "prefer" // Use TLS when the server offers it
+	tlsRequire = "require"
+)
+
+type httpProbe struct {
+	config ProbeConfig
+	client *http.Client
+}
+
+func newHTTPProbe(config ProbeConfig, deps Dependencies) (Probe, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	if config.Method == "" {
+		config.Method = "GET"
+	}
+	tlsConfig, err := config.tlsConfig("")
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpProbe{
+		config: config,
+		client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig:   tlsConfig,
+				DisableKeepAlives: true, // Every check measures a fresh connection
+			},
+			// Redirects are reported as they are, like the status a load balancer would see
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}, nil
+}
+
+func (p *httpProbe) Check(ctx context.Context) Outcome {
+	req, err := http.NewRequestWithContext(ctx, p.config.Method, p.config.URL, nil)
+	if err != nil {
+		return Failed(fmt.Errorf("failed to create request: %w", err))
+	}
+	for name, value := range p.config.Headers {
+		req.Header.Set(name, value)
+	}
+	if p.config.Username != "" {
+		req.SetBasicAuth(p.config.Username, p.config.password())
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Failed(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxProbeBody))
+	metrics := map[string]string{"status_code": strconv.Itoa(resp.StatusCode)}
+
+	if !p.statusExpected(resp.StatusCode) {
+		err := fmt.Errorf("%s %s returned status %d", p.config.Method, p.config.URL, resp.StatusCode)
+		if text := strings.TrimSpace(string(body)); text != "" {
+			err = fmt.Errorf("%w: %s", err, truncate(text, 200))
+		}
+		return Outcome{Status: StatusCritical, Metrics: metrics, Err: err}
+	}
+	if p.config.ExpectBody != "" && !strings.Contains(string(body), p.config.ExpectBody) {
+		return Outcome{Status: StatusCritical, Metrics: metrics,
+			Err: fmt.Errorf("response body does not contain %q", p.config.ExpectBody)}
+	}
+
+	outcome := Healthy("%s %s returned %d", p.config.Method, p.config.URL, resp.StatusCode)
+	outcome.Metrics = metrics
+	return outcome
+}
+
+func (p *httpProbe) statusExpected(status int) bool {
+	if len(p.config.ExpectStatus) == 0 {
+		return status >= 200 && status < 400
+	}
+	for _, expected := range p.config.ExpectStatus {
+		if status == expected {
+			return true
+		}
+	}
+	return false
+}
+
+type tcpProbe struct {
+	address string
+}
+
+func newTCPProbe(config ProbeConfig, deps Dependencies) (Probe, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+	return &tcpProbe{address: config.Address}, nil
+}
+
+func (p *tcpProbe) Check(ctx context.Context) Outcome {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return Failed(err)
+	}
+	conn.Close()
+	return Healthy("connected to %s", p.address)
+}
+
+type dnsProbe struct {
+	config   ProbeConfig
+	resolver *net.Resolver
+}
+
+func newDNSProbe(config ProbeConfig, deps Dependencies) (Probe, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if config.MinAddresses == 0 {
+		config.MinAddresses = 1
+	}
+
+	resolver := net.DefaultResolver
+	if config.Resolver != "" {
+		server := config.Resolver
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, "53")
+		}
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, server)
+			},
+		}
+	}
+
+	return &dnsProbe{config: config, resolver: resolver}, nil
+}
+
+func (p *dnsProbe) Check(ctx context.Context) Outcome {
+	addresses, err := p.resolver.LookupHost(ctx, p.config.Host)
+	if err != nil {
+		return Failed(err)
+	}
+
+	metrics := map[string]string{"addresses": strconv.Itoa(len(addresses))}
+	if len(addresses) < p.config.MinAddresses {
+		return Outcome{Status: StatusCritical, Metrics: metrics,
+			Err: fmt.Errorf("%s resolved to %d addresses, expected at least %d", p.config.Host, len(addresses), p.config.MinAddresses)}
+	}
+	if p.config.ExpectAddress != "" {
+		found := false
+		for _, address := range addresses {
+			found = found || address == p.config.ExpectAddress
+		}
+		if !found {
+			return Outcome{Status: StatusCritical, Metrics: metrics,
+				Err: fmt.Errorf("%s resolved to %s, expected %s", p.config.Host, strings.Join(addresses, ", "), p.config.ExpectAddress)}
+		}
+	}
+
+	outcome := Healthy("%s resolved to %s", p.config.Host, strings.Join(addresses, ", "))
+	outcome.Metrics = metrics
+	return outcome
+}
+
+// setDeadline bounds raw protocol exchanges by the probe timeout
+func setDeadline(ctx context.Context, conn net.Conn) {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(defaultProbeTimeout))
+	}
+}
+
+// tlsMode validates the tls field against the modes a probe supports
+func (p ProbeConfig) tlsMode(fallback string, supported ...string) (string, error) {
+	if p.TLS == "" {
+		return fallback, nil
+	}
+	for _, mode := range supported {
+		if p.TLS == mode {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("tls must be one of %s", strings.Join(supported, ", "))
+}
+
+// tlsConfig verifies the server certificate against ca_file (or the system
+// roots) and serverName, unless insecure is set
+func (p ProbeConfig) tlsConfig(serverName string) (*tls.Config, error) {
+	config := &tls.Config{ServerName: serverName, InsecureSkipVerify: p.Insecure}
+	if p.CAFile != "" {
+		data, err := os.ReadFile(p.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("ca_file %s contains no PEM certificates", p.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// addressTLSConfig is tlsConfig for a probe that dials host:port
+func (p ProbeConfig) addressTLSConfig() (*tls.Config, error) {
+	host, _, err := net.SplitHostPort(p.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	return p.tlsConfig(host)
+}
+
+// startTLS runs the client handshake over an established connection
+func startTLS(ctx context.Context, conn net.Conn, config *tls.Config) (net.Conn, error) {
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return nil, fmt.Errorf("TLS handshake failed: %w", err)
+	}
+	return tlsConn, nil
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
@@ -0,0 +1,158 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultRedisKey = "autosphere:health:probe"
+
+// redisProbe runs a PING and a SET/GET/DEL round trip over the Redis protocol,
+// over TLS with tls: require. Passwords are only sent over TLS unless
+// allow_plaintext_auth is set.
+type redisProbe struct {
+	config    ProbeConfig
+	tlsConfig *tls.Config // Nil without TLS
+}
+
+func newRedisProbe(config ProbeConfig, deps Dependencies) (Probe, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+	if config.Key == "" {
+		config.Key = defaultRedisKey
+	}
+
+	probe := &redisProbe{config: config}
+	mode, err := config.tlsMode(tlsDisable, tlsDisable, tlsRequire)
+	if err != nil {
+		return nil, err
+	}
+	if mode != tlsRequire && (config.Password != "" || config.PasswordEnv != "") && !config.AllowPlaintextAuth {
+		return nil, fmt.Errorf("AUTH would send the password without TLS; set tls: require, or allow_plaintext_auth: true to accept that")
+	}
+	if mode == tlsRequire {
+		if probe.tlsConfig, err = config.addressTLSConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return probe, nil
+}
+
+func (p *redisProbe) Check(ctx context.Context) Outcome {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.config.Address)
+	if err != nil {
+		return Failed(err)
+	}
+	defer func() { conn.Close() }()
+	setDeadline(ctx, conn)
+
+	if p.tlsConfig != nil {
+		tlsConn, err := startTLS(ctx, conn, p.tlsConfig)
+		if err != nil {
+			return Failed(err)
+		}
+		conn = tlsConn
+	}
+
+	r := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	if password := p.config.password(); password != "" {
+		args := []string{"AUTH", password}
+		if p.config.Username != "" {
+			args = []string{"AUTH", p.config.Username, password}
+		}
+		if _, err := r.do(args...); err != nil {
+			return Failed(fmt.Errorf("AUTH failed: %w", err))
+		}
+	}
+
+	if reply, err := r.do("PING"); err != nil {
+		return Failed(fmt.Errorf("PING failed: %w", err))
+	} else if reply != "PONG" {
+		return Failed(fmt.Errorf("unexpected PING reply %q", reply))
+	}
+
+	value := strconv.FormatInt(time.Now().UnixNano(), 10)
+	start := time.Now()
+	if _, err := r.do("SET", p.config.Key, value, "PX", "60000"); err != nil {
+		return Failed(fmt.Errorf("SET failed: %w", err))
+	}
+	got, err := r.do("GET", p.config.Key)
+	if err != nil {
+		return Failed(fmt.Errorf("GET failed: %w", err))
+	}
+	roundTrip := time.Since(start)
+	r.do("DEL", p.config.Key)
+
+	if got != value {
+		return Failed(fmt.Errorf("GET returned %q, expected the value just set (%q)", got, value))
+	}
+
+	outcome := Healthy("PING and SET/GET round trip on %s succeeded", p.config.Address)
+	outcome.Metrics = map[string]string{"set_get_round_trip": roundTrip.Round(time.Microsecond).String()}
+	return outcome
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// do sends a command and returns its reply as a string. Error replies are returned as errors.
+func (r *redisConn) do(args ...string) (string, error) {
+	var command strings.Builder
+	command.WriteString(fmt.Sprintf("*%d\r\n", len(args)))
+	for _, arg := range args {
+		command.WriteString(fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg))
+	}
+	if _, err := r.conn.Write([]byte(command.String())); err != nil {
+		return "", err
+	}
+
+	line, err := r.readLine()
+	if err != nil {
+		return "", err
+	}
+	if line == "" {
+		return "", fmt.Errorf("empty reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", fmt.Errorf("%s", line[1:])
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid bulk reply %q", line)
+		}
+		if length < 0 {
+			return "", nil
+		}
+		data := make([]byte, length+2)
+		if _, err := io.ReadFull(r.reader, data); err != nil {
+			return "", err
+		}
+		return string(data[:length]), nil
+	default:
+		return "", fmt.Errorf("unsupported reply %q", line)
+	}
+}
+
+func (r *redisConn) readLine() (string, error) {
+	line, err := r.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,163 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRedis answers AUTH, PING, SET, GET and DEL from an in-memory map
+type fakeRedis struct {
+	tls      *tls.Config // Serve TLS when set
+	username string      // ACL user; empty for the default user
+	password string      // Required by every command but AUTH when set
+	staleGet bool        // GET returns an old value
+
+	commands []string
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	if f.tls != nil {
+		tlsConn := tls.Server(conn, f.tls)
+		if tlsConn.Handshake() != nil {
+			return
+		}
+		conn = tlsConn
+	}
+
+	reader := bufio.NewReader(conn)
+	values := make(map[string]string)
+	authenticated := f.password == ""
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		f.commands = append(f.commands, args[0])
+
+		reply := "+OK"
+		switch {
+		case args[0] == "AUTH":
+			username, password := "default", args[len(args)-1]
+			if len(args) == 3 {
+				username = args[1]
+			}
+			authenticated = password == f.password && (f.username == "" || username == f.username)
+			if !authenticated {
+				reply = "-WRONGPASS invalid username-password pair or user is disabled."
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required."
+		case args[0] == "PING":
+			reply = "+PONG"
+		case args[0] == "SET":
+			values[args[1]] = args[2]
+		case args[0] == "GET":
+			value, ok := values[args[1]]
+			if f.staleGet {
+				value = "1"
+			}
+			reply = "$-1"
+			if ok {
+				reply = fmt.Sprintf("$%d\r\n%s", len(value), value)
+			}
+		case args[0] == "DEL":
+			delete(values, args[1])
+			reply = ":1"
+		default:
+			reply = "-ERR unknown command"
+		}
+		fmt.Fprintf(conn, "%s\r\n", reply)
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, count)
+	for i := range args {
+		if _, err := reader.ReadString('\n'); err != nil { // $<length>
+			return nil, err
+		}
+		arg, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func TestRedisProbe(t *testing.T) {
+	serverTLS, caFile := testTLS(t)
+
+	tests := []struct {
+		name     string
+		server   fakeRedis
+		config   ProbeConfig
+		want     string // Error substring, empty when healthy
+		commands string
+	}{
+		{name: "no auth", commands: "PING,SET,GET,DEL"},
+		{name: "password", server: fakeRedis{password: "secret"}, config: ProbeConfig{Password: "secret", AllowPlaintextAuth: true}, commands: "AUTH,PING,SET,GET,DEL"},
+		{name: "ACL user", server: fakeRedis{username: "health", password: "secret"}, config: ProbeConfig{Username: "health", Password: "secret", AllowPlaintextAuth: true}, commands: "AUTH,PING,SET,GET,DEL"},
+		{name: "wrong password", server: fakeRedis{password: "secret"}, config: ProbeConfig{Password: "guess", AllowPlaintextAuth: true}, want: "AUTH failed: WRONGPASS", commands: "AUTH"},
+		{name: "missing password", server: fakeRedis{password: "secret"}, want: "PING failed: NOAUTH", commands: "PING"},
+		{name: "TLS", server: fakeRedis{tls: serverTLS, password: "secret"}, config: ProbeConfig{Password: "secret", TLS: "require", CAFile: caFile}, commands: "AUTH,PING,SET,GET,DEL"},
+		{name: "untrusted certificate", server: fakeRedis{tls: serverTLS}, config: ProbeConfig{TLS: "require"}, want: "TLS handshake failed"},
+		{name: "stale value", server: fakeRedis{staleGet: true}, want: "expected the value just set", commands: "PING,SET,GET,DEL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := tt.server
+			config := tt.config
+			config.Type = "redis"
+			address, done := serveOnce(t, server.serve)
+			config.Address = address
+
+			probe, err := newRedisProbe(config, Dependencies{})
+			if err != nil {
+				t.Fatalf("newRedisProbe: %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			outcome := probe.Check(ctx)
+			<-done
+
+			if tt.want == "" {
+				if outcome.Status != StatusHealthy {
+					t.Fatalf("outcome = %s: %v", outcome.Status, outcome.Err)
+				}
+			} else if outcome.Err == nil || !strings.Contains(outcome.Err.Error(), tt.want) {
+				t.Fatalf("outcome = %s: %v, want %q", outcome.Status, outcome.Err, tt.want)
+			}
+			if got := strings.Join(server.commands, ","); got != tt.commands {
+				t.Errorf("commands = %s, want %s", got, tt.commands)
+			}
+		})
+	}
+
+	if _, err := newRedisProbe(ProbeConfig{Address: "cache:6379", TLS: "prefer"}, Dependencies{}); err == nil {
+		t.Error("expected tls: prefer to be rejected for redis")
+	}
+	for _, config := range []ProbeConfig{
+		{Address: "cache:6379", Password: "secret"},
+		{Address: "cache:6379", PasswordEnv: "REDIS_PASSWORD", TLS: "disable"},
+	} {
+		if _, err := newRedisProbe(config, Dependencies{}); err == nil || !strings.Contains(err.Error(), "without TLS") {
+			t.Errorf("newRedisProbe(%+v) error = %v, want AUTH without TLS refused", config, err)
+		}
+	}
+}
@@ -2,51 +2,157 @@ package interfaces
 
 import (
 	"context"
+	"time"
+
 	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
 type AutomationService interface {
 	LaunchJob(ctx context.Context, args models.AWXJobArgs) (models.AWXJobOutput, error)
+	ListVarSets(ctx context.Context, args models.ListVarSetsArgs) (models.ListVarSetsOutput, error)
 	CheckJobStatus(ctx context.Context, args models.AWXStatusArgs) (models.AWXStatusOutput, error)
+	WaitForJob(ctx context.Context, args models.WaitForJobArgs, onProgress func(models.JobProgress)) (models.WaitForJobOutput, error)
 	CheckHealth(ctx context.Context, args models.HealthCheckArgs) (models.HealthCheckOutput, error)
+	GetHealthHistory(ctx context.Context, args models.GetHealthHistoryArgs) (models.GetHealthHistoryOutput, error)
 	Autoscale(ctx context.Context, args models.AutoscaleArgs) (models.AutoscaleOutput, error)
 
 	// New methods for enhanced AWX functionality
 	ListJobs(ctx context.Context, args models.ListJobsArgs) (models.ListJobsOutput, error)
 	GetJobOutput(ctx context.Context, args models.GetJobOutputArgs) (models.GetJobOutputOutput, error)
+	GetJobEvents(ctx context.Context, args models.GetJobEventsArgs) (models.GetJobEventsOutput, error)
 	CancelJob(ctx context.Context, args models.CancelJobArgs) (models.CancelJobOutput, error)
 	ListResources(ctx context.Context, args models.ListResourcesArgs) (models.ListResourcesOutput, error)
 
 	// Job Template management
 	ListJobTemplates(ctx context.Context, args models.ListJobTemplatesArgs) (models.ListJobTemplatesOutput, error)
+	DescribeJobTemplate(ctx context.Context, args models.DescribeJobTemplateArgs) (models.DescribeJobTemplateOutput, error)
 	CreateJobTemplate(ctx context.Context, args models.CreateJobTemplateArgs) (models.CreateJobTemplateOutput, error)
+	UpdateJobTemplate(ctx context.Context, args models.UpdateJobTemplateArgs) (models.UpdateJobTemplateOutput, error)
+	CopyJobTemplate(ctx context.Context, args models.CopyJobTemplateArgs) (models.CopyJobTemplateOutput, error)
+	DeleteJobTemplate(ctx context.Context, args models.DeleteJobTemplateArgs) (models.DeleteJobTemplateOutput, error)
+
+	// Inventory management
+	ListInventoryHosts(ctx context.Context, args models.ListInventoryHostsArgs) (models.ListInventoryHostsOutput, error)
+	ListInventoryGroups(ctx context.Context, args models.ListInventoryGroupsArgs) (models.ListInventoryGroupsOutput, error)
+	GetInventoryHost(ctx context.Context, args models.GetInventoryHostArgs) (models.GetInventoryHostOutput, error)
+	AddInventoryHost(ctx context.Context, args models.AddInventoryHostArgs) (models.InventoryHostChangeOutput, error)
+	UpdateInventoryHost(ctx context.Context, args models.UpdateInventoryHostArgs) (models.InventoryHostChangeOutput, error)
+	RemoveInventoryHost(ctx context.Context, args models.RemoveInventoryHostArgs) (models.RemoveInventoryHostOutput, error)
+	UpdateInventoryGroup(ctx context.Context, args models.UpdateInventoryGroupArgs) (models.UpdateInventoryGroupOutput, error)
+	ListInventorySources(ctx context.Context, args models.ListInventorySourcesArgs) (models.ListInventorySourcesOutput, error)
+	SyncInventorySource(ctx context.Context, args models.SyncInventorySourceArgs, onProgress func(models.JobProgress)) (models.InventorySyncOutput, error)
+	CheckInventorySync(ctx context.Context, args models.CheckInventorySyncArgs) (models.InventorySyncOutput, error)
+
+	// Job relaunch
+	RelaunchJob(ctx context.Context, args models.RelaunchJobArgs) (models.RelaunchJobOutput, error)
+
+	// Job analytics
+	AnalyzeJobHistory(ctx context.Context, args models.AnalyzeJobHistoryArgs) (models.JobHistoryAnalyticsOutput, error)
+
+	// Ad hoc commands
+	RunAdHocCommand(ctx context.Context, args models.RunAdHocCommandArgs, onProgress func(models.JobProgress)) (models.RunAdHocCommandOutput, error)
+	ListAdHocModules(ctx context.Context, args models.ListAdHocModulesArgs) (models.ListAdHocModulesOutput, error)
+
+	// Project management
+	ListProjectPlaybooks(ctx context.Context, args models.ListProjectPlaybooksArgs) (models.ListProjectPlaybooksOutput, error)
+	SyncProject(ctx context.Context, args models.SyncProjectArgs, onProgress func(models.JobProgress)) (models.ProjectSyncOutput, error)
+	CheckProjectSync(ctx context.Context, args models.CheckProjectSyncArgs) (models.ProjectSyncOutput, error)
+	CreateProject(ctx context.Context, args models.CreateProjectArgs) (models.ProjectChangeOutput, error)
+	UpdateProject(ctx context.Context, args models.UpdateProjectArgs) (models.ProjectChangeOutput, error)
+
+	// Workflow management
+	ListWorkflowTemplates(ctx context.Context, args models.ListWorkflowTemplatesArgs) (models.ListWorkflowTemplatesOutput, error)
+	LaunchWorkflow(ctx context.Context, args models.LaunchWorkflowArgs) (models.LaunchWorkflowOutput, error)
+	GetWorkflowStatus(ctx context.Context, args models.WorkflowStatusArgs) (models.WorkflowStatusOutput, error)
+	DecideWorkflowApproval(ctx context.Context, args models.WorkflowApprovalArgs) (models.WorkflowApprovalOutput, error)
+
+	// Schedule management
+	ListSchedules(ctx context.Context, args models.ListSchedulesArgs) (models.ListSchedulesOutput, error)
+	CreateSchedule(ctx context.Context, args models.CreateScheduleArgs) (models.ScheduleChangeOutput, error)
+	UpdateSchedule(ctx context.Context, args models.UpdateScheduleArgs) (models.ScheduleChangeOutput, error)
+	SetScheduleEnabled(ctx context.Context, args models.SetScheduleEnabledArgs) (models.ScheduleChangeOutput, error)
+	DeleteSchedule(ctx context.Context, args models.DeleteScheduleArgs) (models.DeleteScheduleOutput, error)
+	PreviewSchedule(ctx context.Context, args models.PreviewScheduleArgs) (models.PreviewScheduleOutput, error)
+	GetUpcomingScheduleRuns(ctx context.Context, args models.UpcomingScheduleRunsArgs) (models.UpcomingScheduleRunsOutput, error)
 
 	// Cache management
 	GetCacheStats(ctx context.Context, args models.GetCacheStatsArgs) (models.GetCacheStatsOutput, error)
 }
 
 type HealthService interface {
-	CheckComponent(component string, deep bool) models.ComponentHealth
-	GetSystemMetrics() map[string]float64
-	AnalyzeLoad(threshold string) string
+	Components() []string
+	CheckComponent(ctx context.Context, component string, deep bool) models.ComponentHealth
+	History(component string, window time.Duration, samples int) (models.GetHealthHistoryOutput, error)
 }
 
 type AutomationHandler interface {
 	LaunchAWXJob(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	ListVarSets(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 	CheckAWXJobStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	WaitForAWXJob(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 	CheckAutosphereHealth(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	GetHealthHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 	AutoscaleAutosphere(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 
 	// New handler methods for enhanced AWX functionality
 	ListAWXJobs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 	GetAWXJobOutput(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	GetAWXJobEvents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 	CancelAWXJob(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 	ListAWXResources(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 
 	// Job Template management handlers
 	ListJobTemplates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	DescribeJobTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 	CreateJobTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	UpdateJobTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	CopyJobTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	DeleteJobTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// Inventory management handlers
+	ListInventoryHosts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	ListInventoryGroups(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	GetInventoryHost(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	AddInventoryHost(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	UpdateInventoryHost(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	RemoveInventoryHost(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	UpdateInventoryGroup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	ListInventorySources(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	SyncInventorySource(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	CheckInventorySync(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// Job relaunch handlers
+	RelaunchAWXJob(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// Job analytics handlers
+	AnalyzeJobHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// Ad hoc command handlers
+	RunAdHocCommand(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	ListAdHocModules(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// Project management handlers
+	ListProjectPlaybooks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	SyncProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	CheckProjectSync(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	CreateProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	UpdateProject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// Workflow management handlers
+	ListWorkflowTemplates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	LaunchAWXWorkflow(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	CheckAWXWorkflowStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	DecideWorkflowApproval(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// Schedule management handlers
+	ListSchedules(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	CreateSchedule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	UpdateSchedule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	SetScheduleEnabled(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	DeleteSchedule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	PreviewSchedule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	GetUpcomingScheduleRuns(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 
 	// Cache management handlers
 	GetCacheStats(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
@@ -58,10 +164,16 @@ type ObservabilityService interface {
 	QueryPrometheus(ctx context.Context, args models.QueryPrometheusArgs) (models.QueryPrometheusOutput, error)
 	GetSystemMetrics(ctx context.Context, args models.GetSystemMetricsArgs) (models.GetSystemMetricsOutput, error)
 	GetAlerts(ctx context.Context, args models.GetAlertsArgs) (models.GetAlertsOutput, error)
+	ListSilences(ctx context.Context, args models.ListSilencesArgs) (models.ListSilencesOutput, error)
+	CreateSilence(ctx context.Context, args models.CreateSilenceArgs) (models.CreateSilenceOutput, error)
+	ExpireSilence(ctx context.Context, args models.ExpireSilenceArgs) (models.ExpireSilenceOutput, error)
 }
 
 type ObservabilityHandler interface {
 	QueryPrometheus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 	GetSystemMetrics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 	GetAlerts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	ListSilences(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	CreateSilence(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	ExpireSilence(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
 }
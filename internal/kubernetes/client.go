@@ -0,0 +1,395 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	inClusterTokenFile     = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	inClusterCAFile        = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	inClusterNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// Client is a minimal Kubernetes API client covering the deployment and
+// HorizontalPodAutoscaler calls needed for scaling
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	tokenFile  string
+	namespace  string
+	debug      bool
+}
+
+// KubernetesConfig contains configuration for the Kubernetes client. When
+// APIServer is empty the client falls back to Kubeconfig and then to the
+// in-cluster service account.
+type KubernetesConfig struct {
+	APIServer  string
+	Token      string
+	CAFile     string
+	Insecure   bool
+	Kubeconfig string
+	Namespace  string
+	Timeout    time.Duration
+	Debug      bool
+}
+
+// IsInCluster reports whether the process runs inside a Kubernetes pod
+func IsInCluster() bool {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return false
+	}
+	_, err := os.Stat(inClusterTokenFile)
+	return err == nil
+}
+
+// NewClient creates a Kubernetes client from explicit settings, a kubeconfig
+// file or the in-cluster service account, in that order
+func NewClient(config KubernetesConfig) (*Client, error) {
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.Insecure}
+	client := &Client{
+		baseURL:   strings.TrimRight(config.APIServer, "/"),
+		token:     config.Token,
+		namespace: config.Namespace,
+		debug:     config.Debug,
+	}
+
+	switch {
+	case config.APIServer != "":
+		if config.CAFile != "" {
+			if err := appendCAFile(tlsConfig, config.CAFile); err != nil {
+				return nil, err
+			}
+		}
+
+	case config.Kubeconfig != "":
+		if err := client.loadKubeconfig(config.Kubeconfig, tlsConfig); err != nil {
+			return nil, err
+		}
+
+	case IsInCluster():
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if port == "" {
+			port = "443"
+		}
+		client.baseURL = "https://" + net.JoinHostPort(host, port)
+		// Projected service account tokens rotate, so the file is re-read per request
+		client.tokenFile = inClusterTokenFile
+		if err := appendCAFile(tlsConfig, inClusterCAFile); err != nil {
+			return nil, err
+		}
+		if client.namespace == "" {
+			if namespace, err := os.ReadFile(inClusterNamespaceFile); err == nil {
+				client.namespace = strings.TrimSpace(string(namespace))
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("no Kubernetes API server configured: set an API server URL, a kubeconfig or run in-cluster")
+	}
+
+	if client.namespace == "" {
+		client.namespace = "default"
+	}
+
+	client.httpClient = &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			TLSClientConfig:     tlsConfig,
+			MaxIdleConns:        20,
+			MaxIdleConnsPerHost: 5,
+			IdleConnTimeout:     90 * time.Second,
+			ForceAttemptHTTP2:   true,
+		},
+	}
+
+	return client, nil
+}
+
+// Namespace returns the namespace used when a call does not specify one
+func (c *Client) Namespace() string {
+	return c.namespace
+}
+
+// kubeconfig holds the subset of the kubeconfig format the client understands
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string `yaml:"token"`
+			TokenFile             string `yaml:"tokenFile"`
+			ClientCertificate     string `yaml:"client-certificate"`
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKey             string `yaml:"client-key"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			// Recognised only to refuse them: the client cannot run exec plugins, auth providers or basic auth
+			Exec *struct {
+				Command string `yaml:"command"`
+			} `yaml:"exec"`
+			AuthProvider *struct {
+				Name string `yaml:"name"`
+			} `yaml:"auth-provider"`
+			Username string `yaml:"username"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// loadKubeconfig configures the client from the current context of a kubeconfig file
+func (c *Client) loadKubeconfig(path string, tlsConfig *tls.Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+
+	var config kubeconfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	// Relative file references are resolved against the kubeconfig directory
+	resolve := func(file string) string {
+		if file == "" || filepath.IsAbs(file) {
+			return file
+		}
+		return filepath.Join(filepath.Dir(path), file)
+	}
+
+	var clusterName, userName, namespace string
+	for _, context := range config.Contexts {
+		if context.Name == config.CurrentContext {
+			clusterName, userName, namespace = context.Context.Cluster, context.Context.User, context.Context.Namespace
+		}
+	}
+	if clusterName == "" {
+		return fmt.Errorf("kubeconfig current context %q not found", config.CurrentContext)
+	}
+	if c.namespace == "" {
+		c.namespace = namespace
+	}
+
+	clusterFound := false
+	for _, cluster := range config.Clusters {
+		if cluster.Name != clusterName {
+			continue
+		}
+		clusterFound = true
+		c.baseURL = strings.TrimRight(cluster.Cluster.Server, "/")
+		tlsConfig.InsecureSkipVerify = tlsConfig.InsecureSkipVerify || cluster.Cluster.InsecureSkipTLSVerify
+
+		switch {
+		case cluster.Cluster.CertificateAuthorityData != "":
+			pem, err := base64.StdEncoding.DecodeString(cluster.Cluster.CertificateAuthorityData)
+			if err != nil {
+				return fmt.Errorf("invalid certificate-authority-data: %w", err)
+			}
+			if err := appendCA(tlsConfig, pem); err != nil {
+				return err
+			}
+		case cluster.Cluster.CertificateAuthority != "":
+			if err := appendCAFile(tlsConfig, resolve(cluster.Cluster.CertificateAuthority)); err != nil {
+				return err
+			}
+		}
+	}
+	if !clusterFound {
+		return fmt.Errorf("kubeconfig cluster %q not found", clusterName)
+	}
+
+	userFound := false
+	for _, user := range config.Users {
+		if user.Name != userName {
+			continue
+		}
+		userFound = true
+		if c.token == "" {
+			c.token = user.User.Token
+		}
+		if c.token == "" && user.User.TokenFile != "" {
+			c.tokenFile = resolve(user.User.TokenFile)
+		}
+
+		certPEM, err := readDataOrFile(user.User.ClientCertificateData, resolve(user.User.ClientCertificate))
+		if err != nil {
+			return fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		keyPEM, err := readDataOrFile(user.User.ClientKeyData, resolve(user.User.ClientKey))
+		if err != nil {
+			return fmt.Errorf("failed to read client key: %w", err)
+		}
+		if certPEM != nil && keyPEM != nil {
+			certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+			if err != nil {
+				return fmt.Errorf("invalid client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+
+		// Without a usable credential requests would silently go out unauthenticated
+		if c.token == "" && c.tokenFile == "" && len(tlsConfig.Certificates) == 0 {
+			switch {
+			case user.User.Exec != nil:
+				return fmt.Errorf("kubeconfig user %q authenticates with the exec plugin %q, which is not supported; set a token or a client certificate", userName, user.User.Exec.Command)
+			case user.User.AuthProvider != nil:
+				return fmt.Errorf("kubeconfig user %q authenticates with the auth provider %q, which is not supported; set a token or a client certificate", userName, user.User.AuthProvider.Name)
+			case user.User.Username != "":
+				return fmt.Errorf("kubeconfig user %q uses basic authentication, which is not supported; set a token or a client certificate", userName)
+			}
+		}
+	}
+	if userName != "" && !userFound {
+		return fmt.Errorf("kubeconfig user %q not found", userName)
+	}
+
+	return nil
+}
+
+func readDataOrFile(data, file string) ([]byte, error) {
+	if data != "" {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	if file != "" {
+		return os.ReadFile(file)
+	}
+	return nil, nil
+}
+
+func appendCAFile(tlsConfig *tls.Config, file string) error {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	return appendCA(tlsConfig, pem)
+}
+
+func appendCA(tlsConfig *tls.Config, pem []byte) error {
+	if tlsConfig.RootCAs == nil {
+		tlsConfig.RootCAs = x509.NewCertPool()
+	}
+	if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("no valid CA certificate found")
+	}
+	return nil
+}
+
+// StatusError is returned when the API server answers with an error status
+type StatusError struct {
+	StatusCode int
+	Reason     string
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("Kubernetes API error: status %d (%s) - %s", e.StatusCode, e.Reason, e.Message)
+}
+
+// IsNotFound reports whether err is a Kubernetes 404 error
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// makeRequest makes a HTTP request to the Kubernetes API
+func (c *Client) makeRequest(ctx context.Context, method, endpoint, contentType string, body interface{}, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewBuffer(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+endpoint, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	token := c.token
+	if token == "" && c.tokenFile != "" {
+		if data, err := os.ReadFile(c.tokenFile); err == nil {
+			token = strings.TrimSpace(string(data))
+		}
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if c.debug {
+		log.Printf("Kubernetes request: %s %s", method, endpoint)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		statusErr := &StatusError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+
+		// The API server returns a Status object describing the failure
+		var status struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &status) == nil && status.Message != "" {
+			statusErr.Reason = status.Reason
+			statusErr.Message = status.Message
+		}
+		return statusErr
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return nil
+}
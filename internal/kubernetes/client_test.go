@@ -0,0 +1,230 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// deploymentHandler serves deployment "web" in namespace "shop" and records
+// the bearer tokens it sees
+func deploymentHandler(t *testing.T, tokens *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*tokens = append(*tokens, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		if r.URL.Path != "/apis/apps/v1/namespaces/shop/deployments/web" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"kind": "Status", "reason": "NotFound", "message": `deployments.apps "x" not found`})
+			return
+		}
+		fmt.Fprint(w, `{"metadata":{"name":"web","namespace":"shop"},"spec":{"replicas":3},"status":{"readyReplicas":2}}`)
+	})
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestKubeconfigWithCAData(t *testing.T) {
+	var tokens []string
+	server := httptest.NewTLSServer(deploymentHandler(t, &tokens))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	path := writeFile(t, t.TempDir(), "config", fmt.Sprintf(`
+apiVersion: v1
+kind: Config
+current-context: shop
+clusters:
+- name: other
+  cluster:
+    server: https://wrong.example.com
+- name: prod
+  cluster:
+    server: %s/
+    certificate-authority-data: %s
+contexts:
+- name: other
+  context: {cluster: other, user: other}
+- name: shop
+  context: {cluster: prod, user: deployer, namespace: shop}
+users:
+- name: other
+  user: {token: wrong}
+- name: deployer
+  user: {token: kubeconfig-token}
+`, server.URL, base64.StdEncoding.EncodeToString(caPEM)))
+
+	client, err := NewClient(KubernetesConfig{Kubeconfig: path})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.Namespace() != "shop" {
+		t.Errorf("namespace = %q, want the context namespace", client.Namespace())
+	}
+
+	deployment, err := client.GetDeployment(context.Background(), "", "web")
+	if err != nil {
+		t.Fatalf("GetDeployment over TLS: %v", err)
+	}
+	if deployment.DesiredReplicas() != 3 || deployment.Status.ReadyReplicas != 2 {
+		t.Errorf("deployment = %+v", deployment)
+	}
+	if strings.Join(tokens, ",") != "kubeconfig-token" {
+		t.Errorf("tokens = %v", tokens)
+	}
+}
+
+func TestKubeconfigRelativeTokenFileIsReread(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(deploymentHandler(t, &tokens))
+	defer server.Close()
+
+	dir := t.TempDir()
+	writeFile(t, dir, "token", "first\n")
+	path := writeFile(t, dir, "config", fmt.Sprintf(`
+current-context: ctx
+clusters:
+- name: c
+  cluster: {server: %s}
+contexts:
+- name: ctx
+  context: {cluster: c, user: u}
+users:
+- name: u
+  user: {tokenFile: token}
+`, server.URL))
+
+	client, err := NewClient(KubernetesConfig{Kubeconfig: path, Namespace: "shop"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := client.GetDeployment(ctx, "", "web"); err != nil {
+		t.Fatalf("GetDeployment: %v", err)
+	}
+	writeFile(t, dir, "token", "rotated")
+	if _, err := client.GetDeployment(ctx, "", "web"); err != nil {
+		t.Fatalf("GetDeployment: %v", err)
+	}
+	if strings.Join(tokens, ",") != "first,rotated" {
+		t.Errorf("tokens = %v, want the file re-read per request", tokens)
+	}
+}
+
+func TestKubeconfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	context := "current-context: a\ncontexts:\n- name: a\n  context: {cluster: c, user: u}\nclusters:\n- name: c\n  cluster: {server: https://k}\n"
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{"missing context", "current-context: nope\n", `current context "nope" not found`},
+		{"missing cluster", "current-context: a\ncontexts:\n- name: a\n  context: {cluster: gone}\n", `cluster "gone" not found`},
+		{"bad CA data", "current-context: a\ncontexts:\n- name: a\n  context: {cluster: c}\nclusters:\n- name: c\n  cluster: {server: https://k, certificate-authority-data: '!!'}\n", "certificate-authority-data"},
+		{"not yaml", "{", "failed to parse kubeconfig"},
+		{"missing user", context + "users: []\n", `user "u" not found`},
+		{"exec plugin", context + "users:\n- name: u\n  user:\n    exec: {command: aws, args: [eks, get-token]}\n", `exec plugin "aws", which is not supported`},
+		{"auth provider", context + "users:\n- name: u\n  user:\n    auth-provider: {name: oidc}\n", `auth provider "oidc", which is not supported`},
+		{"basic auth", context + "users:\n- name: u\n  user: {username: admin, password: secret}\n", "basic authentication, which is not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, strings.ReplaceAll(tt.name, " ", "-"), tt.config)
+			_, err := NewClient(KubernetesConfig{Kubeconfig: path})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	// A static token next to the exec plugin, or given explicitly, is used as is
+	withToken := writeFile(t, dir, "exec-with-token", context+"users:\n- name: u\n  user:\n    token: abc\n    exec: {command: aws}\n")
+	if _, err := NewClient(KubernetesConfig{Kubeconfig: withToken}); err != nil {
+		t.Errorf("exec plugin with a token: %v", err)
+	}
+	execOnly := writeFile(t, dir, "exec-only", context+"users:\n- name: u\n  user:\n    exec: {command: aws}\n")
+	if _, err := NewClient(KubernetesConfig{Kubeconfig: execOnly, Token: "explicit"}); err != nil {
+		t.Errorf("exec plugin with an explicit token: %v", err)
+	}
+
+	if _, err := NewClient(KubernetesConfig{}); err == nil && !IsInCluster() {
+		t.Error("expected an error without any API server configuration")
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(deploymentHandler(t, &tokens))
+	defer server.Close()
+
+	client, err := NewClient(KubernetesConfig{APIServer: server.URL, Namespace: "shop"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	_, err = client.GetDeployment(context.Background(), "other", "web")
+	if !IsNotFound(err) {
+		t.Fatalf("IsNotFound(%v) = false", err)
+	}
+	if !strings.Contains(err.Error(), `(NotFound) - deployments.apps "x" not found`) {
+		t.Errorf("error = %v, want the Status reason and message", err)
+	}
+}
+
+func TestDeploymentHPAAndScale(t *testing.T) {
+	var patched string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/apis/autoscaling/v2/namespaces/default/horizontalpodautoscalers":
+			fmt.Fprint(w, `{"items":[
+				{"metadata":{"name":"rs-hpa"},"spec":{"scaleTargetRef":{"kind":"ReplicaSet","name":"web"},"maxReplicas":4}},
+				{"metadata":{"name":"web-hpa"},"spec":{"scaleTargetRef":{"kind":"Deployment","name":"web"},"maxReplicas":8}}]}`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/apis/apps/v1/namespaces/default/deployments/web/scale":
+			body := new(strings.Builder)
+			fmt.Fprint(body, r.Header.Get("Content-Type"), " ")
+			var patch map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&patch)
+			encoded, _ := json.Marshal(patch)
+			body.Write(encoded)
+			patched = body.String()
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(KubernetesConfig{APIServer: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+
+	hpa, err := client.GetDeploymentHPA(ctx, "", "web")
+	if err != nil || hpa == nil || hpa.Metadata.Name != "web-hpa" || hpa.MinReplicasOrDefault() != 1 {
+		t.Fatalf("GetDeploymentHPA = %+v, %v", hpa, err)
+	}
+	if hpa, err := client.GetDeploymentHPA(ctx, "", "api"); err != nil || hpa != nil {
+		t.Errorf("GetDeploymentHPA for an unmanaged deployment = %+v, %v", hpa, err)
+	}
+
+	if err := client.ScaleDeployment(ctx, "", "web", 5); err != nil {
+		t.Fatalf("ScaleDeployment: %v", err)
+	}
+	if patched != `application/merge-patch+json {"spec":{"replicas":5}}` {
+		t.Errorf("patch = %s", patched)
+	}
+}
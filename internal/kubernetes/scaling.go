@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Deployment holds the replica-related fields of an apps/v1 Deployment
+type Deployment struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Replicas *int `json:"replicas"`
+	} `json:"spec"`
+	Status struct {
+		Replicas            int `json:"replicas"`
+		ReadyReplicas       int `json:"readyReplicas"`
+		AvailableReplicas   int `json:"availableReplicas"`
+		UpdatedReplicas     int `json:"updatedReplicas"`
+		UnavailableReplicas int `json:"unavailableReplicas"`
+	} `json:"status"`
+}
+
+// DesiredReplicas returns spec.replicas, which defaults to 1 when unset
+func (d Deployment) DesiredReplicas() int {
+	if d.Spec.Replicas == nil {
+		return 1
+	}
+	return *d.Spec.Replicas
+}
+
+// ObjectMeta holds the metadata fields used by the client
+type ObjectMeta struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// HorizontalPodAutoscaler holds the fields of an autoscaling/v2 HPA used for scaling decisions
+type HorizontalPodAutoscaler struct {
+	Metadata ObjectMeta `json:"metadata"`
+	Spec     struct {
+		ScaleTargetRef struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+			Name       string `json:"name"`
+		} `json:"scaleTargetRef"`
+		MinReplicas *int        `json:"minReplicas"`
+		MaxReplicas int         `json:"maxReplicas"`
+		Metrics     []HPAMetric `json:"metrics"`
+	} `json:"spec"`
+	Status struct {
+		CurrentReplicas int               `json:"currentReplicas"`
+		DesiredReplicas int               `json:"desiredReplicas"`
+		CurrentMetrics  []HPAMetricStatus `json:"currentMetrics"`
+	} `json:"status"`
+}
+
+// MinReplicasOrDefault returns spec.minReplicas, which defaults to 1 when unset
+func (h HorizontalPodAutoscaler) MinReplicasOrDefault() int {
+	if h.Spec.MinReplicas == nil {
+		return 1
+	}
+	return *h.Spec.MinReplicas
+}
+
+// HPAMetric is a metric target of an HPA. Only resource metrics are decoded in detail.
+type HPAMetric struct {
+	Type     string `json:"type"`
+	Resource *struct {
+		Name   string `json:"name"`
+		Target struct {
+			Type               string `json:"type"`
+			AverageUtilization *int   `json:"averageUtilization"`
+		} `json:"target"`
+	} `json:"resource,omitempty"`
+}
+
+// HPAMetricStatus is the last observed value of an HPA metric
+type HPAMetricStatus struct {
+	Type     string `json:"type"`
+	Resource *struct {
+		Name    string `json:"name"`
+		Current struct {
+			AverageUtilization *int `json:"averageUtilization"`
+		} `json:"current"`
+	} `json:"resource,omitempty"`
+}
+
+// GetDeployment returns a deployment by name. An empty namespace uses the client default.
+func (c *Client) GetDeployment(ctx context.Context, namespace, name string) (*Deployment, error) {
+	var deployment Deployment
+	endpoint := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s", url.PathEscape(c.ns(namespace)), url.PathEscape(name))
+	if err := c.makeRequest(ctx, "GET", endpoint, "", nil, &deployment); err != nil {
+		return nil, fmt.Errorf("failed to get deployment %s/%s: %w", c.ns(namespace), name, err)
+	}
+	return &deployment, nil
+}
+
+// GetDeploymentHPA returns the HPA targeting the named deployment, or nil if there is none
+func (c *Client) GetDeploymentHPA(ctx context.Context, namespace, deployment string) (*HorizontalPodAutoscaler, error) {
+	var list struct {
+		Items []HorizontalPodAutoscaler `json:"items"`
+	}
+	endpoint := fmt.Sprintf("/apis/autoscaling/v2/namespaces/%s/horizontalpodautoscalers", url.PathEscape(c.ns(namespace)))
+	if err := c.makeRequest(ctx, "GET", endpoint, "", nil, &list); err != nil {
+		return nil, fmt.Errorf("failed to list horizontal pod autoscalers in %s: %w", c.ns(namespace), err)
+	}
+
+	for _, hpa := range list.Items {
+		target := hpa.Spec.ScaleTargetRef
+		if target.Kind == "Deployment" && target.Name == deployment {
+			return &hpa, nil
+		}
+	}
+	return nil, nil
+}
+
+// ScaleDeployment sets the replica count of a deployment through its scale subresource
+func (c *Client) ScaleDeployment(ctx context.Context, namespace, name string, replicas int) error {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": replicas},
+	}
+	endpoint := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s/scale", url.PathEscape(c.ns(namespace)), url.PathEscape(name))
+	if err := c.makeRequest(ctx, "PATCH", endpoint, "application/merge-patch+json", patch, nil); err != nil {
+		return fmt.Errorf("failed to scale deployment %s/%s to %d replicas: %w", c.ns(namespace), name, replicas, err)
+	}
+	return nil
+}
+
+func (c *Client) ns(namespace string) string {
+	if namespace == "" {
+		return c.namespace
+	}
+	return namespace
+}
@@ -1,19 +1,36 @@
 package models
 
+import "time"
+
 type AWXJobArgs struct {
-	JobTemplate   string            `json:"job_template" jsonschema:"the name or ID of the AWX job template"`
-	ExtraVars     map[string]string `json:"extra_vars,omitempty" jsonschema:"extra variables to pass to the job"`
-	Inventory     string            `json:"inventory,omitempty" jsonschema:"inventory name or ID (optional)"`
-	Limit         string            `json:"limit,omitempty" jsonschema:"limit the job to specific hosts (optional)"`
-	Tags          string            `json:"tags,omitempty" jsonschema:"ansible tags to run (optional)"`
-	SkipTags      string            `json:"skip_tags,omitempty" jsonschema:"ansible tags to skip (optional)"`
+	JobTemplate          string                 `json:"job_template" jsonschema:"the name or ID of the AWX job template"`
+	ExtraVars            map[string]interface{} `json:"extra_vars,omitempty" jsonschema:"extra variables to pass to the job, checked against the template survey"`
+	VarSets              []string               `json:"var_sets,omitempty" jsonschema:"server-side var-sets merged under extra_vars, in order (optional)"`
+	Inventory            string                 `json:"inventory,omitempty" jsonschema:"inventory name or ID (optional)"`
+	Credentials          []string               `json:"credentials,omitempty" jsonschema:"credential names or IDs replacing the template credentials (optional)"`
+	Limit                string                 `json:"limit,omitempty" jsonschema:"limit the job to specific hosts (optional)"`
+	Tags                 string                 `json:"tags,omitempty" jsonschema:"ansible tags to run (optional)"`
+	SkipTags             string                 `json:"skip_tags,omitempty" jsonschema:"ansible tags to skip (optional)"`
+	JobType              string                 `json:"job_type,omitempty" jsonschema:"run or check (optional)"`
+	Verbosity            *int                   `json:"verbosity,omitempty" jsonschema:"ansible verbosity 0-5 (optional)"`
+	DiffMode             *bool                  `json:"diff_mode,omitempty" jsonschema:"show file changes (optional)"`
+	Forks                *int                   `json:"forks,omitempty" jsonschema:"parallel processes (optional)"`
+	JobTimeout           *int                   `json:"timeout,omitempty" jsonschema:"seconds the job may run, 0 for no limit (optional)"`
+	ExecutionEnvironment string                 `json:"execution_environment,omitempty" jsonschema:"execution environment name or ID (optional)"`
+	Labels               []string               `json:"labels,omitempty" jsonschema:"label names or IDs (optional)"`
+	InstanceGroups       []string               `json:"instance_groups,omitempty" jsonschema:"instance group names or IDs (optional)"`
+	ScmBranch            string                 `json:"scm_branch,omitempty" jsonschema:"project branch, tag or commit (optional)"`
+	JobSliceCount        *int                   `json:"job_slice_count,omitempty" jsonschema:"number of job slices (optional)"`
 }
 
 type AWXJobOutput struct {
-	JobID     int    `json:"job_id" jsonschema:"the AWX job ID"`
-	Status    string `json:"status" jsonschema:"the job status"`
-	URL       string `json:"url" jsonschema:"the AWX job URL"`
-	Message   string `json:"message" jsonschema:"human-readable status message"`
+	JobID         int      `json:"job_id" jsonschema:"the AWX job ID"`
+	Status        string   `json:"status" jsonschema:"the job status"`
+	URL           string   `json:"url" jsonschema:"the AWX job URL"`
+	Message       string   `json:"message" jsonschema:"human-readable status message"`
+	Template      string   `json:"template,omitempty" jsonschema:"the launched job template"`
+	VarSets       []string `json:"var_sets,omitempty" jsonschema:"var-sets merged into the extra variables"`
+	IgnoredFields []string `json:"ignored_fields,omitempty" jsonschema:"launch fields AWX accepted but did not apply"`
 }
 
 type AWXStatusArgs struct {
@@ -27,6 +44,55 @@ type AWXStatusOutput struct {
 	FinishedAt      string                 `json:"finished_at,omitempty" jsonschema:"when the job finished (if completed)"`
 	ElapsedTime     string                 `json:"elapsed_time" jsonschema:"how long the job has been running"`
 	PlaybookResults map[string]interface{} `json:"playbook_results,omitempty" jsonschema:"results from the playbook execution"`
+	HostResults     []HostResult           `json:"host_results,omitempty" jsonschema:"per-host play recap counts"`
+	Failures        []HostFailure          `json:"failures,omitempty" jsonschema:"failing task and module message for each failed host"`
+}
+
+type HostResult struct {
+	Host        string `json:"host" jsonschema:"host name"`
+	OK          int    `json:"ok" jsonschema:"tasks that ran without changes"`
+	Changed     int    `json:"changed" jsonschema:"tasks that reported changes"`
+	Failed      int    `json:"failed" jsonschema:"tasks that failed"`
+	Unreachable int    `json:"unreachable" jsonschema:"times the host was unreachable"`
+	Skipped     int    `json:"skipped" jsonschema:"tasks that were skipped"`
+	Rescued     int    `json:"rescued,omitempty" jsonschema:"failed tasks rescued by a rescue block"`
+	Ignored     int    `json:"ignored,omitempty" jsonschema:"failed tasks ignored via ignore_errors"`
+}
+
+type HostFailure struct {
+	Host    string `json:"host" jsonschema:"host name"`
+	Task    string `json:"task" jsonschema:"name of the failing task"`
+	Module  string `json:"module,omitempty" jsonschema:"ansible module used by the task"`
+	Message string `json:"message,omitempty" jsonschema:"module error message"`
+	Event   string `json:"event" jsonschema:"AWX event type (runner_on_failed, runner_on_unreachable, ...)"`
+}
+
+type GetJobEventsArgs struct {
+	JobID      int    `json:"job_id" jsonschema:"the AWX job ID to get events for"`
+	Host       string `json:"host,omitempty" jsonschema:"only events for this host"`
+	EventType  string `json:"event_type,omitempty" jsonschema:"only events of this type (e.g., runner_on_failed, runner_on_ok)"`
+	FailedOnly bool   `json:"failed_only,omitempty" jsonschema:"only failed events"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"maximum number of events to return (default: 50)"`
+}
+
+type GetJobEventsOutput struct {
+	JobID  int            `json:"job_id" jsonschema:"the job ID"`
+	Events []JobEventInfo `json:"events" jsonschema:"matching job events in execution order"`
+	Total  int            `json:"total" jsonschema:"total number of matching events"`
+}
+
+type JobEventInfo struct {
+	ID      int    `json:"id" jsonschema:"event ID"`
+	Counter int    `json:"counter" jsonschema:"event position in the job"`
+	Event   string `json:"event" jsonschema:"event type"`
+	Host    string `json:"host,omitempty" jsonschema:"host name"`
+	Play    string `json:"play,omitempty" jsonschema:"play name"`
+	Task    string `json:"task,omitempty" jsonschema:"task name"`
+	Module  string `json:"module,omitempty" jsonschema:"ansible module"`
+	Changed bool   `json:"changed,omitempty" jsonschema:"whether the task reported changes"`
+	Failed  bool   `json:"failed,omitempty" jsonschema:"whether the task failed"`
+	Message string `json:"message,omitempty" jsonschema:"module result message"`
+	Created string `json:"created,omitempty" jsonschema:"when the event was emitted"`
 }
 
 type HealthCheckArgs struct {
@@ -46,23 +112,120 @@ type ComponentHealth struct {
 	Details     string            `json:"details" jsonschema:"detailed status information"`
 	Metrics     map[string]string `json:"metrics,omitempty" jsonschema:"relevant metrics for this component"`
 	LastChecked string            `json:"last_checked" jsonschema:"when this component was last checked"`
+	Probes      []ProbeResult     `json:"probes,omitempty" jsonschema:"results of the individual probes"`
+}
+
+type ProbeResult struct {
+	Name      string            `json:"name" jsonschema:"probe name"`
+	Type      string            `json:"type" jsonschema:"probe type (http, tcp, dns, prometheus, awx_job, redis, postgres)"`
+	Deep      bool              `json:"deep,omitempty" jsonschema:"whether the probe only runs for deep checks"`
+	Status    string            `json:"status" jsonschema:"probe status (healthy, warning, critical)"`
+	Latency   string            `json:"latency" jsonschema:"measured probe duration"`
+	LatencyMs float64           `json:"latency_ms" jsonschema:"measured probe duration in milliseconds"`
+	Message   string            `json:"message,omitempty" jsonschema:"what the probe observed"`
+	Error     string            `json:"error,omitempty" jsonschema:"why the probe is not healthy"`
+	Metrics   map[string]string `json:"metrics,omitempty" jsonschema:"values measured by the probe"`
+}
+
+type GetHealthHistoryArgs struct {
+	Component string `json:"component,omitempty" jsonschema:"component to report on (default: all)"`
+	Window    string `json:"window,omitempty" jsonschema:"how far back to look, e.g. 1h or 7d (default: 24h)"`
+	Samples   int    `json:"samples,omitempty" jsonschema:"number of most recent samples to include per component (default: 10)"`
+}
+
+type GetHealthHistoryOutput struct {
+	OverallStatus string             `json:"overall_status" jsonschema:"worst latest status across components"`
+	Window        string             `json:"window" jsonschema:"window the statistics cover"`
+	Interval      string             `json:"interval" jsonschema:"background probing interval"`
+	FlapWindow    string             `json:"flap_window" jsonschema:"window state changes are counted in for flap detection"`
+	FlapThreshold int                `json:"flap_threshold" jsonschema:"state changes within the flap window that count as flapping"`
+	Components    []ComponentHistory `json:"components" jsonschema:"history per component"`
+	Timestamp     string             `json:"timestamp" jsonschema:"when the report was generated"`
+}
+
+type ComponentHistory struct {
+	Component      string         `json:"component" jsonschema:"component name"`
+	Status         string         `json:"status" jsonschema:"latest status"`
+	StatusSince    string         `json:"status_since" jsonschema:"when the latest status began"`
+	Samples        int            `json:"samples" jsonschema:"samples within the window"`
+	UptimePercent  float64        `json:"uptime_percent" jsonschema:"percentage of samples that were healthy or warning"`
+	HealthyPercent float64        `json:"healthy_percent" jsonschema:"percentage of samples that were healthy"`
+	Transitions    int            `json:"transitions" jsonschema:"status changes within the window"`
+	Flapping       bool           `json:"flapping" jsonschema:"whether the component changes state too often"`
+	FlapChanges    int            `json:"flap_changes" jsonschema:"status changes within the flap window"`
+	AvgLatency     string         `json:"avg_latency" jsonschema:"average of the slowest probe latency per sample"`
+	MaxLatency     string         `json:"max_latency" jsonschema:"slowest probe latency within the window"`
+	LastError      string         `json:"last_error,omitempty" jsonschema:"most recent probe problem within the window"`
+	LastErrorAt    string         `json:"last_error_at,omitempty" jsonschema:"when the most recent problem was seen"`
+	Recent         []HealthSample `json:"recent,omitempty" jsonschema:"most recent samples, oldest first"`
+}
+
+type HealthSample struct {
+	Time    string `json:"time" jsonschema:"when the sample was taken"`
+	Status  string `json:"status" jsonschema:"component status"`
+	Latency string `json:"latency" jsonschema:"slowest probe latency"`
+	Error   string `json:"error,omitempty" jsonschema:"first probe problem"`
 }
 
 type AutoscaleArgs struct {
 	Action    string `json:"action" jsonschema:"autoscaling action (scale_up, scale_down, analyze, auto)"`
-	Service   string `json:"service,omitempty" jsonschema:"specific service to scale (optional)"`
+	Service   string `json:"service,omitempty" jsonschema:"deployment to scale (default: api)"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace of the deployment"`
 	Replicas  int    `json:"replicas,omitempty" jsonschema:"target number of replicas (for manual scaling)"`
 	Threshold string `json:"threshold,omitempty" jsonschema:"scaling threshold (cpu_high, memory_high, load_high)"`
 }
 
 type AutoscaleOutput struct {
-	Action      string `json:"action" jsonschema:"action taken"`
-	Service     string `json:"service" jsonschema:"service affected"`
-	OldReplicas int    `json:"old_replicas" jsonschema:"previous number of replicas"`
-	NewReplicas int    `json:"new_replicas" jsonschema:"new number of replicas"`
-	Reason      string `json:"reason" jsonschema:"reason for scaling decision"`
-	JobID       int    `json:"job_id,omitempty" jsonschema:"AWX job ID if automation was triggered"`
-	Status      string `json:"status" jsonschema:"operation status"`
+	Action        string      `json:"action" jsonschema:"action taken"`
+	Service       string      `json:"service" jsonschema:"service affected"`
+	Namespace     string      `json:"namespace,omitempty" jsonschema:"Kubernetes namespace of the deployment"`
+	OldReplicas   int         `json:"old_replicas" jsonschema:"previous number of replicas"`
+	NewReplicas   int         `json:"new_replicas" jsonschema:"new number of replicas"`
+	ReadyReplicas int         `json:"ready_replicas" jsonschema:"replicas currently ready"`
+	HPA           *HPAStatus  `json:"hpa,omitempty" jsonschema:"HorizontalPodAutoscaler managing the deployment"`
+	Reason        string      `json:"reason" jsonschema:"reason for scaling decision"`
+	Mode          string      `json:"mode,omitempty" jsonschema:"how the change was applied (awx, patch)"`
+	JobID         int         `json:"job_id,omitempty" jsonschema:"AWX job ID if automation was triggered"`
+	JobURL        string      `json:"job_url,omitempty" jsonschema:"AWX job URL if automation was triggered"`
+	Status        string      `json:"status" jsonschema:"operation status"`
+	Warnings      []string    `json:"warnings,omitempty" jsonschema:"caveats about the scaling decision"`
+	Policy        *ScalingPolicyExplanation `json:"policy,omitempty" jsonschema:"scaling policy rule the decision was made against"`
+}
+
+type ScalingPolicyExplanation struct {
+	Rule              string                    `json:"rule" jsonschema:"policy rule applied (services.<name>, defaults or built-in)"`
+	Deployment        string                    `json:"deployment" jsonschema:"deployment the rule maps the service to"`
+	MinReplicas       int                       `json:"min_replicas" jsonschema:"minimum replicas allowed by the rule"`
+	MaxReplicas       int                       `json:"max_replicas" jsonschema:"maximum replicas allowed by the rule"`
+	ScaleUpStep       int                       `json:"scale_up_step" jsonschema:"replicas added per scale up"`
+	ScaleDownStep     int                       `json:"scale_down_step" jsonschema:"replicas removed per scale down"`
+	ScaleUpCooldown   string                    `json:"scale_up_cooldown" jsonschema:"minimum time between a scaling event and a scale up"`
+	ScaleDownCooldown string                    `json:"scale_down_cooldown" jsonschema:"minimum time between a scaling event and a scale down"`
+	EvaluationWindow  string                    `json:"evaluation_window" jsonschema:"window metric queries are evaluated over"`
+	LastScaled        string                    `json:"last_scaled,omitempty" jsonschema:"when this server last scaled the deployment"`
+	CooldownRemaining string                    `json:"cooldown_remaining,omitempty" jsonschema:"time left before the requested scaling is allowed"`
+	Metrics           []ScalingMetricEvaluation `json:"metrics,omitempty" jsonschema:"metric readings evaluated against the rule"`
+}
+
+type ScalingMetricEvaluation struct {
+	Name           string   `json:"name" jsonschema:"metric name"`
+	Source         string   `json:"source" jsonschema:"metric source (promql, hpa:cpu, hpa:memory)"`
+	Query          string   `json:"query,omitempty" jsonschema:"PromQL query evaluated"`
+	Value          *float64 `json:"value,omitempty" jsonschema:"observed value"`
+	ScaleUpAbove   *float64 `json:"scale_up_above,omitempty" jsonschema:"scale up when the value is above"`
+	ScaleDownBelow *float64 `json:"scale_down_below,omitempty" jsonschema:"scale down when the value is below"`
+	Verdict        string   `json:"verdict" jsonschema:"what the metric suggests (up, down, hold, unavailable)"`
+	Error          string   `json:"error,omitempty" jsonschema:"why the value is unavailable"`
+}
+
+type HPAStatus struct {
+	Name            string         `json:"name" jsonschema:"HPA name"`
+	MinReplicas     int            `json:"min_replicas" jsonschema:"HPA minimum replicas"`
+	MaxReplicas     int            `json:"max_replicas" jsonschema:"HPA maximum replicas"`
+	CurrentReplicas int            `json:"current_replicas" jsonschema:"replicas observed by the HPA"`
+	DesiredReplicas int            `json:"desired_replicas" jsonschema:"replicas the HPA wants"`
+	Utilization     map[string]int `json:"utilization,omitempty" jsonschema:"current average utilization percent per resource"`
+	Targets         map[string]int `json:"targets,omitempty" jsonschema:"target average utilization percent per resource"`
 }
 
 // New models for additional tools
@@ -88,12 +251,28 @@ type JobSummary struct {
 }
 
 type GetJobOutputArgs struct {
-	JobID int `json:"job_id" jsonschema:"the AWX job ID to get output for"`
+	JobID        int    `json:"job_id" jsonschema:"the AWX job ID to get output for"`
+	Format       string `json:"format,omitempty" jsonschema:"output format requested from AWX (txt, ansi, json; default: txt)"`
+	StartLine    int    `json:"start_line,omitempty" jsonschema:"first line to return (0-based)"`
+	EndLine      int    `json:"end_line,omitempty" jsonschema:"line to stop at (exclusive)"`
+	Tail         int    `json:"tail,omitempty" jsonschema:"only return the last N lines"`
+	Grep         string `json:"grep,omitempty" jsonschema:"only return lines matching this regular expression"`
+	ContextLines int    `json:"context_lines,omitempty" jsonschema:"lines of context around each grep match"`
+	StripANSI    bool   `json:"strip_ansi,omitempty" jsonschema:"remove ANSI color codes"`
+	MaxBytes     int    `json:"max_bytes,omitempty" jsonschema:"maximum bytes of output to return (default: 65536)"`
+	Cursor       string `json:"cursor,omitempty" jsonschema:"continuation cursor returned by a previous truncated call"`
 }
 
 type GetJobOutputOutput struct {
-	JobID  int    `json:"job_id" jsonschema:"the job ID"`
-	Output string `json:"output" jsonschema:"the job output/logs"`
+	JobID      int    `json:"job_id" jsonschema:"the job ID"`
+	Output     string `json:"output" jsonschema:"the job output/logs"`
+	Format     string `json:"format" jsonschema:"format of the output"`
+	StartLine  int    `json:"start_line" jsonschema:"first line included in the output"`
+	EndLine    int    `json:"end_line" jsonschema:"line after the last one included in the output"`
+	TotalLines int    `json:"total_lines,omitempty" jsonschema:"total number of stdout lines (when known)"`
+	Matches    int    `json:"matches,omitempty" jsonschema:"number of lines matching grep"`
+	Truncated  bool   `json:"truncated" jsonschema:"whether the output was cut at max_bytes"`
+	NextCursor string `json:"next_cursor,omitempty" jsonschema:"pass as cursor to continue reading after truncation"`
 }
 
 type CancelJobArgs struct {
@@ -127,9 +306,10 @@ type ResourceSummary struct {
 
 type QueryPrometheusArgs struct {
 	Query     string `json:"query" jsonschema:"PromQL query to execute"`
-	StartTime string `json:"start_time,omitempty" jsonschema:"start time for range queries (RFC3339 format)"`
-	EndTime   string `json:"end_time,omitempty" jsonschema:"end time for range queries (RFC3339 format)"`
+	StartTime string `json:"start_time,omitempty" jsonschema:"start time for range queries (RFC3339, unix seconds, 'now' or relative such as '-1h')"`
+	EndTime   string `json:"end_time,omitempty" jsonschema:"end time for range queries (same formats as start_time, default: now)"`
 	Step      string `json:"step,omitempty" jsonschema:"step size for range queries (e.g., '5m', '1h')"`
+	MaxPoints int    `json:"max_points,omitempty" jsonschema:"maximum samples returned per series, larger series are downsampled"`
 }
 
 type QueryPrometheusOutput struct {
@@ -138,12 +318,32 @@ type QueryPrometheusOutput struct {
 	Metrics     []PrometheusMetric     `json:"metrics" jsonschema:"list of metric results"`
 	Summary     string                 `json:"summary" jsonschema:"human-readable summary of results"`
 	QueryTime   string                 `json:"query_time" jsonschema:"when the query was executed"`
+	Start       string                 `json:"start,omitempty" jsonschema:"resolved start of the range query"`
+	End         string                 `json:"end,omitempty" jsonschema:"resolved end of the range query"`
+	Step        string                 `json:"step,omitempty" jsonschema:"step of the range query"`
+	Downsampled bool                   `json:"downsampled,omitempty" jsonschema:"whether any series was downsampled to max_points"`
 }
 
 type PrometheusMetric struct {
-	Labels map[string]string `json:"labels" jsonschema:"metric labels"`
-	Value  string            `json:"value" jsonschema:"metric value"`
-	Time   string            `json:"time" jsonschema:"timestamp of the value"`
+	Labels  map[string]string  `json:"labels" jsonschema:"metric labels"`
+	Value   string             `json:"value" jsonschema:"metric value (latest sample for range queries)"`
+	Time    string             `json:"time" jsonschema:"timestamp of the value"`
+	Samples []PrometheusSample `json:"samples,omitempty" jsonschema:"samples of a range query series, oldest first"`
+	Stats   *SeriesStats       `json:"stats,omitempty" jsonschema:"statistics over all samples of a range query series"`
+}
+
+type PrometheusSample struct {
+	Timestamp string `json:"timestamp" jsonschema:"sample time (RFC3339)"`
+	Value     string `json:"value" jsonschema:"sample value"`
+}
+
+type SeriesStats struct {
+	Samples      int     `json:"samples" jsonschema:"number of numeric samples in the series"`
+	Min          float64 `json:"min" jsonschema:"minimum value"`
+	Max          float64 `json:"max" jsonschema:"maximum value"`
+	Avg          float64 `json:"avg" jsonschema:"average value"`
+	Last         float64 `json:"last" jsonschema:"most recent value"`
+	RateOfChange float64 `json:"rate_of_change" jsonschema:"change per second between the first and last sample"`
 }
 
 type GetSystemMetricsArgs struct {
@@ -153,8 +353,10 @@ type GetSystemMetricsArgs struct {
 
 type GetSystemMetricsOutput struct {
 	OverallHealth string                    `json:"overall_health" jsonschema:"overall system health status"`
+	TimeRange     string                    `json:"time_range" jsonschema:"window the metrics are averaged over"`
 	Metrics       map[string]float64        `json:"metrics" jsonschema:"key system metrics"`
 	NodeMetrics   map[string]map[string]float64 `json:"node_metrics,omitempty" jsonschema:"per-node metrics"`
+	Errors        map[string]string         `json:"errors,omitempty" jsonschema:"metrics whose query failed"`
 	Alerts        []string                  `json:"alerts,omitempty" jsonschema:"active alerts"`
 	Timestamp     string                    `json:"timestamp" jsonschema:"when metrics were collected"`
 	Recommendations []string                `json:"recommendations,omitempty" jsonschema:"optimization recommendations"`
@@ -171,6 +373,8 @@ type GetAlertsOutput struct {
 	TotalAlerts  int           `json:"total_alerts" jsonschema:"total number of alerts"`
 	Critical     int           `json:"critical_count" jsonschema:"number of critical alerts"`
 	Warning      int           `json:"warning_count" jsonschema:"number of warning alerts"`
+	Silenced     int           `json:"silenced_count" jsonschema:"number of silenced alerts"`
+	Inhibited    int           `json:"inhibited_count" jsonschema:"number of inhibited alerts"`
 	Summary      string        `json:"summary" jsonschema:"overall alert status summary"`
 	Timestamp    string        `json:"timestamp" jsonschema:"when alerts were retrieved"`
 }
@@ -178,11 +382,60 @@ type GetAlertsOutput struct {
 type AlertSummary struct {
 	Name        string            `json:"name" jsonschema:"alert name"`
 	Severity    string            `json:"severity" jsonschema:"alert severity"`
-	Status      string            `json:"status" jsonschema:"alert status"`
+	Status      string            `json:"status" jsonschema:"alert status (active, suppressed, unprocessed)"`
 	Labels      map[string]string `json:"labels" jsonschema:"alert labels"`
 	Annotations map[string]string `json:"annotations" jsonschema:"alert annotations"`
 	ActiveSince string            `json:"active_since,omitempty" jsonschema:"how long the alert has been active"`
 	Value       string            `json:"value,omitempty" jsonschema:"current metric value"`
+	Fingerprint string            `json:"fingerprint,omitempty" jsonschema:"Alertmanager alert fingerprint"`
+	StartsAt    string            `json:"starts_at,omitempty" jsonschema:"when the alert started firing"`
+	SilencedBy  []string          `json:"silenced_by,omitempty" jsonschema:"IDs of the silences muting this alert"`
+	InhibitedBy []string          `json:"inhibited_by,omitempty" jsonschema:"fingerprints of the alerts inhibiting this alert"`
+	Receivers   []string          `json:"receivers,omitempty" jsonschema:"receivers the alert is routed to"`
+}
+
+// Alertmanager silence models
+
+type ListSilencesArgs struct {
+	State    string   `json:"state,omitempty" jsonschema:"filter by silence state (active, pending, expired)"`
+	Matchers []string `json:"matchers,omitempty" jsonschema:"only silences with these label matchers, e.g. alertname=HighCPU"`
+}
+
+type ListSilencesOutput struct {
+	Silences []SilenceSummary `json:"silences" jsonschema:"list of silences"`
+	Total    int              `json:"total" jsonschema:"number of silences returned"`
+}
+
+type SilenceSummary struct {
+	ID        string   `json:"id" jsonschema:"silence ID"`
+	State     string   `json:"state" jsonschema:"silence state (active, pending, expired)"`
+	Matchers  []string `json:"matchers" jsonschema:"label matchers of the silence"`
+	StartsAt  string   `json:"starts_at" jsonschema:"when the silence starts"`
+	EndsAt    string   `json:"ends_at" jsonschema:"when the silence ends"`
+	CreatedBy string   `json:"created_by" jsonschema:"author of the silence"`
+	Comment   string   `json:"comment" jsonschema:"reason for the silence"`
+}
+
+type CreateSilenceArgs struct {
+	Matchers  []string `json:"matchers" jsonschema:"label matchers selecting the alerts to silence, e.g. alertname=HighCPU, instance=~node-.*"`
+	Duration  string   `json:"duration" jsonschema:"how long the silence lasts (e.g., 30m, 2h, 1d)"`
+	Comment   string   `json:"comment" jsonschema:"reason for the silence"`
+	CreatedBy string   `json:"created_by,omitempty" jsonschema:"author of the silence"`
+}
+
+type CreateSilenceOutput struct {
+	Silence        SilenceSummary `json:"silence" jsonschema:"the created silence"`
+	MatchingAlerts int            `json:"matching_alerts" jsonschema:"number of current alerts matched by the silence"`
+	Message        string         `json:"message" jsonschema:"result message"`
+}
+
+type ExpireSilenceArgs struct {
+	SilenceID string `json:"silence_id" jsonschema:"ID of the silence to expire"`
+}
+
+type ExpireSilenceOutput struct {
+	SilenceID string `json:"silence_id" jsonschema:"ID of the expired silence"`
+	Message   string `json:"message" jsonschema:"result message"`
 }
 
 // Job Template models
@@ -205,12 +458,49 @@ type JobTemplateSummary struct {
 	Project     int    `json:"project" jsonschema:"project ID"`
 }
 
+type DescribeJobTemplateArgs struct {
+	JobTemplate string `json:"job_template" jsonschema:"required,the name or ID of the AWX job template"`
+}
+
+type DescribeJobTemplateOutput struct {
+	ID                       int                     `json:"id" jsonschema:"template ID"`
+	Name                     string                  `json:"name" jsonschema:"template name"`
+	Description              string                  `json:"description" jsonschema:"template description"`
+	Playbook                 string                  `json:"playbook" jsonschema:"playbook path"`
+	Prompts                  []LaunchPrompt          `json:"prompts" jsonschema:"launch_awx_job fields the template prompts for, with their defaults"`
+	SurveyEnabled            bool                    `json:"survey_enabled" jsonschema:"whether the survey is asked on launch"`
+	Survey                   []SurveyQuestionSummary `json:"survey,omitempty" jsonschema:"survey questions answered through extra_vars"`
+	ExtraVarsSchema          map[string]interface{}  `json:"extra_vars_schema,omitempty" jsonschema:"JSON schema the extra_vars must match"`
+	CanStartWithoutUserInput bool                    `json:"can_start_without_user_input" jsonschema:"whether the template launches with no fields set"`
+	VariablesNeededToStart   []string                `json:"variables_needed_to_start,omitempty" jsonschema:"survey variables without a default that must be answered"`
+	PasswordsNeededToStart   []string                `json:"passwords_needed_to_start,omitempty" jsonschema:"credential passwords the template prompts for"`
+	InventoryNeededToStart   bool                    `json:"inventory_needed_to_start" jsonschema:"whether an inventory must be given"`
+	CredentialNeededToStart  bool                    `json:"credential_needed_to_start" jsonschema:"whether a credential must be given"`
+}
+
+type LaunchPrompt struct {
+	Field   string      `json:"field" jsonschema:"launch_awx_job parameter"`
+	Default interface{} `json:"default,omitempty" jsonschema:"value used when the field is not given"`
+}
+
+type SurveyQuestionSummary struct {
+	Variable    string      `json:"variable" jsonschema:"extra_vars key"`
+	Question    string      `json:"question" jsonschema:"question text"`
+	Description string      `json:"description,omitempty" jsonschema:"question description"`
+	Type        string      `json:"type" jsonschema:"text, textarea, password, integer, float, multiplechoice or multiselect"`
+	Required    bool        `json:"required" jsonschema:"whether an answer is required"`
+	Default     interface{} `json:"default,omitempty" jsonschema:"default answer, never shown for passwords"`
+	Choices     []string    `json:"choices,omitempty" jsonschema:"allowed answers"`
+	Min         *float64    `json:"min,omitempty" jsonschema:"minimum value or length"`
+	Max         *float64    `json:"max,omitempty" jsonschema:"maximum value or length"`
+}
+
 type CreateJobTemplateArgs struct {
 	Name        string `json:"name" jsonschema:"required,template name"`
 	Description string `json:"description,omitempty" jsonschema:"template description"`
 	JobType     string `json:"job_type,omitempty" jsonschema:"job type (default: run)"`
-	Inventory   int    `json:"inventory" jsonschema:"required,inventory ID"`
-	Project     int    `json:"project" jsonschema:"required,project ID"`
+	Inventory   string `json:"inventory" jsonschema:"required,inventory name or ID"`
+	Project     string `json:"project" jsonschema:"required,project name or ID"`
 	Playbook    string `json:"playbook" jsonschema:"required,playbook path (e.g., site.yml)"`
 	Verbosity   int    `json:"verbosity,omitempty" jsonschema:"playbook verbosity (0-5)"`
 }
@@ -223,6 +513,92 @@ type CreateJobTemplateOutput struct {
 	Message     string `json:"message" jsonschema:"status message"`
 }
 
+type ListVarSetsArgs struct {
+	// No arguments needed
+}
+
+type ListVarSetsOutput struct {
+	VarSets []VarSetSummary `json:"var_sets" jsonschema:"configured var-sets"`
+	Total   int             `json:"total" jsonschema:"number of var-sets"`
+}
+
+type VarSetSummary struct {
+	Name        string                 `json:"name" jsonschema:"var-set name used in var_sets"`
+	Description string                 `json:"description,omitempty" jsonschema:"what the var-set is for"`
+	Vars        map[string]interface{} `json:"vars" jsonschema:"the extra variables the var-set adds"`
+}
+
+type UpdateJobTemplateArgs struct {
+	JobTemplate          string                 `json:"job_template" jsonschema:"required,the name or ID of the job template to update"`
+	Name                 *string                `json:"name,omitempty" jsonschema:"new template name"`
+	Description          *string                `json:"description,omitempty" jsonschema:"template description"`
+	JobType              *string                `json:"job_type,omitempty" jsonschema:"run or check"`
+	Inventory            *string                `json:"inventory,omitempty" jsonschema:"inventory name or ID"`
+	Project              *string                `json:"project,omitempty" jsonschema:"project name or ID"`
+	Playbook             *string                `json:"playbook,omitempty" jsonschema:"playbook path"`
+	ScmBranch            *string                `json:"scm_branch,omitempty" jsonschema:"project branch, tag or commit"`
+	Limit                *string                `json:"limit,omitempty" jsonschema:"host pattern"`
+	Tags                 *string                `json:"tags,omitempty" jsonschema:"ansible tags to run"`
+	SkipTags             *string                `json:"skip_tags,omitempty" jsonschema:"ansible tags to skip"`
+	ExtraVars            map[string]interface{} `json:"extra_vars,omitempty" jsonschema:"template extra variables, replacing the current ones"`
+	ExecutionEnvironment *string                `json:"execution_environment,omitempty" jsonschema:"execution environment name or ID"`
+	Forks                *int                   `json:"forks,omitempty" jsonschema:"parallel processes"`
+	Verbosity            *int                   `json:"verbosity,omitempty" jsonschema:"ansible verbosity 0-5"`
+	Timeout              *int                   `json:"timeout,omitempty" jsonschema:"seconds a job may run, 0 for no limit"`
+	JobSliceCount        *int                   `json:"job_slice_count,omitempty" jsonschema:"number of job slices"`
+	DiffMode             *bool                  `json:"diff_mode,omitempty" jsonschema:"show file changes"`
+	AllowSimultaneous    *bool                  `json:"allow_simultaneous,omitempty" jsonschema:"allow jobs of the template to run at the same time"`
+	SurveyEnabled        *bool                  `json:"survey_enabled,omitempty" jsonschema:"ask the survey on launch"`
+	AskOnLaunch          map[string]bool        `json:"ask_on_launch,omitempty" jsonschema:"prompt-on-launch flags by AWX field name, e.g. ask_inventory_on_launch"`
+	AddCredentials       []string               `json:"add_credentials,omitempty" jsonschema:"credential names or IDs to attach"`
+	RemoveCredentials    []string               `json:"remove_credentials,omitempty" jsonschema:"credential names or IDs to detach"`
+	AddLabels            []string               `json:"add_labels,omitempty" jsonschema:"labels to attach, created in the template organization when missing"`
+	RemoveLabels         []string               `json:"remove_labels,omitempty" jsonschema:"label names or IDs to detach"`
+}
+
+type UpdateJobTemplateOutput struct {
+	ID            int      `json:"id" jsonschema:"template ID"`
+	Name          string   `json:"name" jsonschema:"template name"`
+	UpdatedFields []string `json:"updated_fields,omitempty" jsonschema:"template fields changed"`
+	Associations  []string `json:"associations,omitempty" jsonschema:"credentials and labels attached or detached"`
+	Message       string   `json:"message" jsonschema:"status message"`
+}
+
+type CopyJobTemplateArgs struct {
+	JobTemplate string `json:"job_template" jsonschema:"required,the name or ID of the job template to copy"`
+	Name        string `json:"name,omitempty" jsonschema:"name of the copy (default: '<template> (copy)')"`
+}
+
+type CopyJobTemplateOutput struct {
+	ID         int      `json:"id" jsonschema:"ID of the copy"`
+	Name       string   `json:"name" jsonschema:"name of the copy"`
+	SourceID   int      `json:"source_id" jsonschema:"ID of the copied template"`
+	SourceName string   `json:"source_name" jsonschema:"name of the copied template"`
+	Warnings   []string `json:"warnings,omitempty" jsonschema:"related resources the copy could not keep"`
+	Message    string   `json:"message" jsonschema:"status message"`
+}
+
+type DeleteJobTemplateArgs struct {
+	JobTemplate       string `json:"job_template" jsonschema:"required,the name or ID of the job template to delete"`
+	ConfirmationToken string `json:"confirmation_token,omitempty" jsonschema:"token returned by a first call without it"`
+}
+
+type DeleteJobTemplateOutput struct {
+	ID                int        `json:"id" jsonschema:"template ID"`
+	Name              string     `json:"name" jsonschema:"template name"`
+	Deleted           bool       `json:"deleted" jsonschema:"whether the template was deleted"`
+	ConfirmationToken string     `json:"confirmation_token,omitempty" jsonschema:"token to pass on a second call to delete the template"`
+	ExpiresAt         string     `json:"expires_at,omitempty" jsonschema:"when the confirmation token expires"`
+	RecentJobs        []JobBrief `json:"recent_jobs,omitempty" jsonschema:"latest jobs of the template"`
+	Warnings          []string   `json:"warnings,omitempty" jsonschema:"reasons to reconsider"`
+	Message           string     `json:"message" jsonschema:"status message"`
+}
+
+type JobBrief struct {
+	ID     int    `json:"id" jsonschema:"job ID"`
+	Status string `json:"status" jsonschema:"job status"`
+}
+
 // Cache statistics models
 
 type GetCacheStatsArgs struct {
@@ -244,3 +620,605 @@ type CacheStatsDetail struct {
 	CurrentSize int     `json:"current_size" jsonschema:"current number of cached items"`
 	HitRate     float64 `json:"hit_rate" jsonschema:"cache hit rate percentage"`
 }
+
+// Workflow models
+
+type ListWorkflowTemplatesArgs struct {
+	// No arguments needed for simple list
+}
+
+type ListWorkflowTemplatesOutput struct {
+	Templates []WorkflowTemplateSummary `json:"templates" jsonschema:"list of workflow job templates"`
+	Total     int                       `json:"total" jsonschema:"total number of workflow templates"`
+}
+
+type WorkflowTemplateSummary struct {
+	ID          int    `json:"id" jsonschema:"workflow template ID"`
+	Name        string `json:"name" jsonschema:"workflow template name"`
+	Description string `json:"description" jsonschema:"workflow template description"`
+	Status      string `json:"status,omitempty" jsonschema:"status of the last run"`
+}
+
+type LaunchWorkflowArgs struct {
+	WorkflowTemplate string                 `json:"workflow_template" jsonschema:"the name or ID of the AWX workflow job template"`
+	ExtraVars        map[string]interface{} `json:"extra_vars,omitempty" jsonschema:"extra variables to pass to the workflow"`
+	VarSets          []string               `json:"var_sets,omitempty" jsonschema:"server-side var-sets merged under extra_vars, in order (optional)"`
+	Inventory        string                 `json:"inventory,omitempty" jsonschema:"inventory name or ID (optional)"`
+	Limit            string                 `json:"limit,omitempty" jsonschema:"limit the workflow to specific hosts (optional)"`
+}
+
+type LaunchWorkflowOutput struct {
+	WorkflowJobID int    `json:"workflow_job_id" jsonschema:"the AWX workflow job ID"`
+	Status        string `json:"status" jsonschema:"the workflow job status"`
+	URL           string `json:"url" jsonschema:"the AWX workflow job URL"`
+	Message       string `json:"message" jsonschema:"human-readable status message"`
+}
+
+type WorkflowStatusArgs struct {
+	WorkflowJobID int `json:"workflow_job_id" jsonschema:"the AWX workflow job ID to check"`
+}
+
+type WorkflowStatusOutput struct {
+	WorkflowJobID    int                   `json:"workflow_job_id" jsonschema:"the AWX workflow job ID"`
+	Name             string                `json:"name" jsonschema:"the workflow job name"`
+	Status           string                `json:"status" jsonschema:"the current workflow job status"`
+	StartedAt        string                `json:"started_at,omitempty" jsonschema:"when the workflow started"`
+	FinishedAt       string                `json:"finished_at,omitempty" jsonschema:"when the workflow finished (if completed)"`
+	ElapsedTime      string                `json:"elapsed_time,omitempty" jsonschema:"how long the workflow has been running"`
+	URL              string                `json:"url" jsonschema:"the AWX workflow job URL"`
+	Nodes            []WorkflowNodeSummary `json:"nodes" jsonschema:"workflow graph nodes with per-node job status"`
+	PendingApprovals []int                 `json:"pending_approvals,omitempty" jsonschema:"IDs of approval steps waiting for a decision"`
+}
+
+type WorkflowNodeSummary struct {
+	NodeID       int    `json:"node_id" jsonschema:"workflow node ID"`
+	Identifier   string `json:"identifier,omitempty" jsonschema:"workflow node identifier"`
+	Template     string `json:"template" jsonschema:"name of the template run by this node"`
+	TemplateType string `json:"template_type" jsonschema:"type of template (job, workflow_approval, project_update, ...)"`
+	JobID        int    `json:"job_id,omitempty" jsonschema:"ID of the job spawned by this node"`
+	Status       string `json:"status" jsonschema:"status of the spawned job (not_started if none yet)"`
+	ElapsedTime  string `json:"elapsed_time,omitempty" jsonschema:"duration of the spawned job"`
+	OnSuccess    []int  `json:"on_success,omitempty" jsonschema:"nodes run when this node succeeds"`
+	OnFailure    []int  `json:"on_failure,omitempty" jsonschema:"nodes run when this node fails"`
+	Always       []int  `json:"always,omitempty" jsonschema:"nodes always run after this node"`
+}
+
+type WorkflowApprovalArgs struct {
+	ApprovalID int    `json:"approval_id" jsonschema:"the workflow approval ID (job ID of the approval node)"`
+	Action     string `json:"action" jsonschema:"decision to take (approve, deny)"`
+}
+
+type WorkflowApprovalOutput struct {
+	ApprovalID int    `json:"approval_id" jsonschema:"the workflow approval ID"`
+	Name       string `json:"name" jsonschema:"the approval step name"`
+	Action     string `json:"action" jsonschema:"decision taken"`
+	Status     string `json:"status" jsonschema:"approval status after the decision"`
+	Message    string `json:"message" jsonschema:"status message"`
+}
+
+type WaitForJobArgs struct {
+	JobID        int `json:"job_id" jsonschema:"the AWX job ID to wait for"`
+	Timeout      int `json:"timeout,omitempty" jsonschema:"maximum time to wait in seconds (default: 600)"`
+	PollInterval int `json:"poll_interval,omitempty" jsonschema:"seconds between status checks (default: 10)"`
+}
+
+type WaitForJobOutput struct {
+	Job      AWXStatusOutput `json:"job" jsonschema:"final job status summary"`
+	TimedOut bool            `json:"timed_out" jsonschema:"whether the wait ended before the job finished"`
+	Waited   string          `json:"waited" jsonschema:"how long the tool waited"`
+	LastTask string          `json:"last_task,omitempty" jsonschema:"name of the last task seen while waiting"`
+}
+
+// JobProgress is reported periodically while waiting for a job
+type JobProgress struct {
+	JobID   int
+	Status  string
+	Elapsed time.Duration
+	Timeout time.Duration
+	Task    string
+}
+
+// Inventory models
+
+type ListInventoryHostsArgs struct {
+	Inventory string `json:"inventory" jsonschema:"required,inventory name or ID"`
+	Group     string `json:"group,omitempty" jsonschema:"only hosts of this group, by name or ID"`
+	Search    string `json:"search,omitempty" jsonschema:"text to find in host names and descriptions"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"maximum number of hosts to return (default: 200)"`
+}
+
+type ListInventoryHostsOutput struct {
+	InventoryID int                    `json:"inventory_id" jsonschema:"inventory ID"`
+	Group       string                 `json:"group,omitempty" jsonschema:"group the hosts were listed from"`
+	Hosts       []InventoryHostSummary `json:"hosts" jsonschema:"the hosts"`
+	Total       int                    `json:"total" jsonschema:"number of matching hosts"`
+	Truncated   bool                   `json:"truncated,omitempty" jsonschema:"whether hosts were left out because of the limit"`
+}
+
+type InventoryHostSummary struct {
+	ID                int      `json:"id" jsonschema:"host ID"`
+	Name              string   `json:"name" jsonschema:"host name"`
+	Description       string   `json:"description,omitempty" jsonschema:"host description"`
+	Enabled           bool     `json:"enabled" jsonschema:"whether jobs run against the host"`
+	Groups            []string `json:"groups,omitempty" jsonschema:"groups the host belongs to"`
+	LastJobStatus     string   `json:"last_job_status,omitempty" jsonschema:"status of the last job that ran against the host"`
+	HasActiveFailures bool     `json:"has_active_failures,omitempty" jsonschema:"whether the last job failed on the host"`
+}
+
+type ListInventoryGroupsArgs struct {
+	Inventory string `json:"inventory" jsonschema:"required,inventory name or ID"`
+}
+
+type ListInventoryGroupsOutput struct {
+	InventoryID int                     `json:"inventory_id" jsonschema:"inventory ID"`
+	Groups      []InventoryGroupSummary `json:"groups" jsonschema:"the groups"`
+	Total       int                     `json:"total" jsonschema:"number of groups"`
+}
+
+type InventoryGroupSummary struct {
+	ID          int                    `json:"id" jsonschema:"group ID"`
+	Name        string                 `json:"name" jsonschema:"group name"`
+	Description string                 `json:"description,omitempty" jsonschema:"group description"`
+	Variables   map[string]interface{} `json:"variables,omitempty" jsonschema:"group variables"`
+}
+
+type GetInventoryHostArgs struct {
+	Inventory string   `json:"inventory" jsonschema:"required,inventory name or ID"`
+	Host      string   `json:"host" jsonschema:"required,host name or ID"`
+	Facts     bool     `json:"facts,omitempty" jsonschema:"also return the gathered Ansible facts"`
+	FactNames []string `json:"fact_names,omitempty" jsonschema:"only these facts; a trailing * matches a prefix"`
+}
+
+type GetInventoryHostOutput struct {
+	InventoryHostSummary
+	InventoryID int                    `json:"inventory_id" jsonschema:"inventory ID"`
+	InstanceID  string                 `json:"instance_id,omitempty" jsonschema:"cloud instance ID from the inventory source"`
+	Variables   map[string]interface{} `json:"variables" jsonschema:"host variables"`
+	Facts       map[string]interface{} `json:"facts,omitempty" jsonschema:"gathered Ansible facts"`
+	FactCount   int                    `json:"fact_count,omitempty" jsonschema:"number of facts gathered for the host"`
+	Warnings    []string               `json:"warnings,omitempty" jsonschema:"problems reading the host"`
+}
+
+type AddInventoryHostArgs struct {
+	Inventory   string                 `json:"inventory" jsonschema:"required,inventory name or ID"`
+	Name        string                 `json:"name" jsonschema:"required,host name or address"`
+	Description string                 `json:"description,omitempty" jsonschema:"host description"`
+	Variables   map[string]interface{} `json:"variables,omitempty" jsonschema:"host variables"`
+	Groups      []string               `json:"groups,omitempty" jsonschema:"group names or IDs to add the host to"`
+	Enabled     *bool                  `json:"enabled,omitempty" jsonschema:"whether jobs run against the host (default: true)"`
+}
+
+type UpdateInventoryHostArgs struct {
+	Inventory         string                 `json:"inventory" jsonschema:"required,inventory name or ID"`
+	Host              string                 `json:"host" jsonschema:"required,host name or ID"`
+	Name              *string                `json:"name,omitempty" jsonschema:"new host name"`
+	Description       *string                `json:"description,omitempty" jsonschema:"host description"`
+	Enabled           *bool                  `json:"enabled,omitempty" jsonschema:"whether jobs run against the host"`
+	Variables         map[string]interface{} `json:"variables,omitempty" jsonschema:"variables merged into the host variables"`
+	RemoveVariables   []string               `json:"remove_variables,omitempty" jsonschema:"top-level variables to drop"`
+	ReplaceVariables  bool                   `json:"replace_variables,omitempty" jsonschema:"replace the host variables instead of merging"`
+	AddGroups         []string               `json:"add_groups,omitempty" jsonschema:"group names or IDs to add the host to"`
+	RemoveGroups      []string               `json:"remove_groups,omitempty" jsonschema:"group names or IDs to take the host out of"`
+	ConfirmationToken string                 `json:"confirmation_token,omitempty" jsonschema:"token returned by a first call replacing the variables with none"`
+}
+
+type InventoryHostChangeOutput struct {
+	ID                int                    `json:"id" jsonschema:"host ID"`
+	Name              string                 `json:"name" jsonschema:"host name"`
+	InventoryID       int                    `json:"inventory_id" jsonschema:"inventory ID"`
+	Enabled           bool                   `json:"enabled" jsonschema:"whether jobs run against the host"`
+	Changes           []string               `json:"changes,omitempty" jsonschema:"what was changed"`
+	Variables         map[string]interface{} `json:"variables,omitempty" jsonschema:"host variables after the change, or the variables a confirmation would clear"`
+	ConfirmationToken string                 `json:"confirmation_token,omitempty" jsonschema:"token to pass on a second call to clear the variables"`
+	ExpiresAt         string                 `json:"expires_at,omitempty" jsonschema:"when the confirmation token expires"`
+	Message           string                 `json:"message" jsonschema:"status message"`
+}
+
+type RemoveInventoryHostArgs struct {
+	Inventory         string `json:"inventory" jsonschema:"required,inventory name or ID"`
+	Host              string `json:"host" jsonschema:"required,host name or ID"`
+	ConfirmationToken string `json:"confirmation_token,omitempty" jsonschema:"token returned by a first call without it"`
+}
+
+type RemoveInventoryHostOutput struct {
+	ID                int      `json:"id" jsonschema:"host ID"`
+	Name              string   `json:"name" jsonschema:"host name"`
+	InventoryID       int      `json:"inventory_id" jsonschema:"inventory ID"`
+	Groups            []string `json:"groups,omitempty" jsonschema:"groups the host belongs to"`
+	Removed           bool     `json:"removed" jsonschema:"whether the host was deleted"`
+	ConfirmationToken string   `json:"confirmation_token,omitempty" jsonschema:"token to pass on a second call to delete the host"`
+	ExpiresAt         string   `json:"expires_at,omitempty" jsonschema:"when the confirmation token expires"`
+	Message           string   `json:"message" jsonschema:"status message"`
+}
+
+type UpdateInventoryGroupArgs struct {
+	Inventory         string                 `json:"inventory" jsonschema:"required,inventory name or ID"`
+	Group             string                 `json:"group" jsonschema:"required,group name or ID"`
+	Name              *string                `json:"name,omitempty" jsonschema:"new group name"`
+	Description       *string                `json:"description,omitempty" jsonschema:"group description"`
+	Variables         map[string]interface{} `json:"variables,omitempty" jsonschema:"variables merged into the group variables"`
+	RemoveVariables   []string               `json:"remove_variables,omitempty" jsonschema:"top-level variables to drop"`
+	ReplaceVariables  bool                   `json:"replace_variables,omitempty" jsonschema:"replace the group variables instead of merging"`
+	ConfirmationToken string                 `json:"confirmation_token,omitempty" jsonschema:"token returned by a first call replacing the variables with none"`
+}
+
+type UpdateInventoryGroupOutput struct {
+	ID                int                    `json:"id" jsonschema:"group ID"`
+	Name              string                 `json:"name" jsonschema:"group name"`
+	InventoryID       int                    `json:"inventory_id" jsonschema:"inventory ID"`
+	Changes           []string               `json:"changes,omitempty" jsonschema:"what was changed"`
+	Variables         map[string]interface{} `json:"variables,omitempty" jsonschema:"group variables after the change, or the variables a confirmation would clear"`
+	ConfirmationToken string                 `json:"confirmation_token,omitempty" jsonschema:"token to pass on a second call to clear the variables"`
+	ExpiresAt         string                 `json:"expires_at,omitempty" jsonschema:"when the confirmation token expires"`
+	Message           string                 `json:"message" jsonschema:"status message"`
+}
+
+type ListInventorySourcesArgs struct {
+	Inventory string `json:"inventory" jsonschema:"required,inventory name or ID"`
+}
+
+type ListInventorySourcesOutput struct {
+	InventoryID int                      `json:"inventory_id" jsonschema:"inventory ID"`
+	Sources     []InventorySourceSummary `json:"sources" jsonschema:"the inventory sources"`
+	Total       int                      `json:"total" jsonschema:"number of sources"`
+}
+
+type InventorySourceSummary struct {
+	ID          int    `json:"id" jsonschema:"source ID"`
+	Name        string `json:"name" jsonschema:"source name"`
+	Source      string `json:"source" jsonschema:"source type, e.g. scm, ec2, openstack"`
+	Status      string `json:"status" jsonschema:"status of the last sync"`
+	LastUpdated string `json:"last_updated,omitempty" jsonschema:"when the last successful sync finished"`
+}
+
+type SyncInventorySourceArgs struct {
+	Inventory    string `json:"inventory" jsonschema:"required,inventory name or ID"`
+	Source       string `json:"source,omitempty" jsonschema:"source name or ID (default: every source of the inventory)"`
+	Wait         bool   `json:"wait,omitempty" jsonschema:"wait for the syncs to finish"`
+	Timeout      int    `json:"timeout,omitempty" jsonschema:"maximum time to wait in seconds (default: 600)"`
+	PollInterval int    `json:"poll_interval,omitempty" jsonschema:"seconds between status checks (default: 10)"`
+}
+
+type CheckInventorySyncArgs struct {
+	UpdateIDs []int `json:"update_ids" jsonschema:"required,inventory update IDs returned by sync_inventory_source"`
+}
+
+type InventorySyncOutput struct {
+	InventoryID int                     `json:"inventory_id,omitempty" jsonschema:"inventory ID"`
+	Updates     []InventoryUpdateStatus `json:"updates" jsonschema:"one sync per source"`
+	TimedOut    bool                    `json:"timed_out,omitempty" jsonschema:"whether the wait ended before every sync finished"`
+	Waited      string                  `json:"waited,omitempty" jsonschema:"how long the tool waited"`
+	Message     string                  `json:"message" jsonschema:"status message"`
+}
+
+type InventoryUpdateStatus struct {
+	UpdateID    int     `json:"update_id" jsonschema:"inventory update ID"`
+	SourceID    int     `json:"source_id" jsonschema:"inventory source ID"`
+	SourceName  string  `json:"source_name,omitempty" jsonschema:"inventory source name"`
+	Status      string  `json:"status" jsonschema:"sync status"`
+	Elapsed     float64 `json:"elapsed,omitempty" jsonschema:"seconds the sync ran"`
+	Explanation string  `json:"explanation,omitempty" jsonschema:"why the sync failed or was canceled"`
+}
+
+// Project models
+
+type ListProjectPlaybooksArgs struct {
+	Project string `json:"project" jsonschema:"required,project name or ID"`
+}
+
+type ListProjectPlaybooksOutput struct {
+	ProjectID   int      `json:"project_id" jsonschema:"project ID"`
+	Name        string   `json:"name" jsonschema:"project name"`
+	Status      string   `json:"status" jsonschema:"status of the last project sync"`
+	ScmRevision string   `json:"scm_revision,omitempty" jsonschema:"commit the playbooks were read from"`
+	Playbooks   []string `json:"playbooks" jsonschema:"playbook paths job templates can use"`
+}
+
+type SyncProjectArgs struct {
+	Project      string `json:"project" jsonschema:"required,project name or ID"`
+	Wait         bool   `json:"wait,omitempty" jsonschema:"wait for the sync to finish"`
+	Timeout      int    `json:"timeout,omitempty" jsonschema:"maximum time to wait in seconds (default: 600)"`
+	PollInterval int    `json:"poll_interval,omitempty" jsonschema:"seconds between status checks (default: 10)"`
+}
+
+type CheckProjectSyncArgs struct {
+	SyncID int `json:"sync_id" jsonschema:"required,project update ID returned by sync_project"`
+}
+
+type ProjectSyncOutput struct {
+	SyncID      int      `json:"sync_id" jsonschema:"project update ID"`
+	ProjectID   int      `json:"project_id" jsonschema:"project ID"`
+	Name        string   `json:"name,omitempty" jsonschema:"project name"`
+	Status      string   `json:"status" jsonschema:"sync status"`
+	ScmRevision string   `json:"scm_revision,omitempty" jsonschema:"commit the sync checked out"`
+	Elapsed     float64  `json:"elapsed,omitempty" jsonschema:"seconds the sync ran"`
+	Explanation string   `json:"explanation,omitempty" jsonschema:"why the sync failed or was canceled"`
+	Playbooks   []string `json:"playbooks,omitempty" jsonschema:"playbooks found by a successful sync"`
+	TimedOut    bool     `json:"timed_out,omitempty" jsonschema:"whether the wait ended before the sync finished"`
+	Waited      string   `json:"waited,omitempty" jsonschema:"how long the tool waited"`
+	Message     string   `json:"message" jsonschema:"status message"`
+}
+
+type CreateProjectArgs struct {
+	Name           string `json:"name" jsonschema:"required,project name"`
+	Description    string `json:"description,omitempty" jsonschema:"project description"`
+	Organization   string `json:"organization" jsonschema:"required,organization name or ID"`
+	ScmType        string `json:"scm_type,omitempty" jsonschema:"git, svn, insights, archive or manual (default: git)"`
+	ScmURL         string `json:"scm_url,omitempty" jsonschema:"repository URL"`
+	ScmBranch      string `json:"scm_branch,omitempty" jsonschema:"branch, tag or commit to check out"`
+	Credential     string `json:"credential,omitempty" jsonschema:"source control credential name or ID"`
+	UpdateOnLaunch bool   `json:"update_on_launch,omitempty" jsonschema:"sync the project before every job"`
+	AllowOverride  bool   `json:"allow_override,omitempty" jsonschema:"let job templates pick another branch"`
+}
+
+type UpdateProjectArgs struct {
+	Project        string  `json:"project" jsonschema:"required,project name or ID"`
+	Name           *string `json:"name,omitempty" jsonschema:"new project name"`
+	Description    *string `json:"description,omitempty" jsonschema:"project description"`
+	ScmType        *string `json:"scm_type,omitempty" jsonschema:"git, svn, insights, archive or manual"`
+	ScmURL         *string `json:"scm_url,omitempty" jsonschema:"repository URL"`
+	ScmBranch      *string `json:"scm_branch,omitempty" jsonschema:"branch, tag or commit to check out"`
+	Credential     *string `json:"credential,omitempty" jsonschema:"source control credential name or ID"`
+	UpdateOnLaunch *bool   `json:"update_on_launch,omitempty" jsonschema:"sync the project before every job"`
+	AllowOverride  *bool   `json:"allow_override,omitempty" jsonschema:"let job templates pick another branch"`
+}
+
+type ProjectChangeOutput struct {
+	ID            int      `json:"id" jsonschema:"project ID"`
+	Name          string   `json:"name" jsonschema:"project name"`
+	Status        string   `json:"status" jsonschema:"project status"`
+	ScmType       string   `json:"scm_type,omitempty" jsonschema:"source control type"`
+	ScmURL        string   `json:"scm_url,omitempty" jsonschema:"repository URL"`
+	ScmBranch     string   `json:"scm_branch,omitempty" jsonschema:"branch, tag or commit"`
+	UpdatedFields []string `json:"updated_fields,omitempty" jsonschema:"project fields changed"`
+	Message       string   `json:"message" jsonschema:"status message"`
+}
+
+// Ad hoc command models
+
+type RunAdHocCommandArgs struct {
+	Inventory    string `json:"inventory" jsonschema:"required,inventory name or ID"`
+	Limit        string `json:"limit,omitempty" jsonschema:"hosts or groups to run against"`
+	ModuleName   string `json:"module_name" jsonschema:"required,Ansible module to run"`
+	ModuleArgs   string `json:"module_args,omitempty" jsonschema:"module arguments"`
+	Credential   string `json:"credential,omitempty" jsonschema:"machine credential name or ID"`
+	Become       bool   `json:"become,omitempty" jsonschema:"run with privilege escalation"`
+	Forks        int    `json:"forks,omitempty" jsonschema:"number of hosts to run on in parallel"`
+	Wait         bool   `json:"wait,omitempty" jsonschema:"wait for the command to finish"`
+	Timeout      int    `json:"timeout,omitempty" jsonschema:"maximum time to wait in seconds (default: 600)"`
+	PollInterval int    `json:"poll_interval,omitempty" jsonschema:"seconds between status checks (default: 10)"`
+}
+
+type RunAdHocCommandOutput struct {
+	JobID       int               `json:"job_id" jsonschema:"ad hoc command ID, accepted by the job tools"`
+	Name        string            `json:"name" jsonschema:"ad hoc command name"`
+	Status      string            `json:"status" jsonschema:"command status"`
+	InventoryID int               `json:"inventory_id" jsonschema:"inventory the command ran against"`
+	Limit       string            `json:"limit,omitempty" jsonschema:"hosts or groups the command ran against"`
+	ModuleName  string            `json:"module_name" jsonschema:"Ansible module"`
+	ModuleArgs  string            `json:"module_args,omitempty" jsonschema:"module arguments"`
+	Become      bool              `json:"become,omitempty" jsonschema:"whether privilege escalation was used"`
+	Result      *WaitForJobOutput `json:"result,omitempty" jsonschema:"final status and host recap when waiting"`
+	Output      string            `json:"output,omitempty" jsonschema:"last lines of the command output when waiting"`
+	Message     string            `json:"message" jsonschema:"status message"`
+}
+
+type ListAdHocModulesArgs struct{}
+
+type AdHocModuleSummary struct {
+	Name                string   `json:"name" jsonschema:"module name"`
+	Description         string   `json:"description,omitempty" jsonschema:"what the module is allowed for"`
+	Args                []string `json:"args,omitempty" jsonschema:"patterns module_args must match in full; none means no arguments"`
+	AllowShellOperators bool     `json:"allow_shell_operators,omitempty" jsonschema:"whether module_args may chain or redirect commands"`
+}
+
+type ListAdHocModulesOutput struct {
+	Modules      []AdHocModuleSummary `json:"modules" jsonschema:"allowed modules"`
+	MaxForks     int                  `json:"max_forks" jsonschema:"highest forks value allowed"`
+	AllowBecome  bool                 `json:"allow_become" jsonschema:"whether privilege escalation is allowed"`
+	AllowNoLimit bool                 `json:"allow_no_limit" jsonschema:"whether commands may run against the whole inventory"`
+}
+
+// Relaunch models
+
+type RelaunchJobArgs struct {
+	JobID               int                    `json:"job_id" jsonschema:"required,the AWX job ID to relaunch"`
+	Hosts               string                 `json:"hosts,omitempty" jsonschema:"all or failed (default: all)"`
+	ExtraVars           map[string]interface{} `json:"extra_vars,omitempty" jsonschema:"extra_vars overriding those the job ran with"`
+	CredentialPasswords map[string]string      `json:"credential_passwords,omitempty" jsonschema:"answers to credential password prompts, such as ssh_password"`
+}
+
+type RelaunchJobOutput struct {
+	JobID          int      `json:"job_id" jsonschema:"the new job ID"`
+	RelaunchOf     int      `json:"relaunch_of" jsonschema:"the job that was relaunched"`
+	RetryChain     []int    `json:"retry_chain" jsonschema:"job IDs from the first launch to the new job"`
+	Hosts          string   `json:"hosts" jsonschema:"all or failed"`
+	HostCount      int      `json:"host_count,omitempty" jsonschema:"number of hosts the new job runs on"`
+	FailedHosts    []string `json:"failed_hosts,omitempty" jsonschema:"failed hosts the new job is limited to"`
+	Method         string   `json:"method" jsonschema:"relaunch, or launch when extra_vars were changed"`
+	TemplateID     int      `json:"template_id,omitempty" jsonschema:"template launched again"`
+	TemplateName   string   `json:"template_name,omitempty" jsonschema:"template launched again"`
+	Limit          string   `json:"limit,omitempty" jsonschema:"limit of the new job"`
+	OverriddenVars []string `json:"overridden_vars,omitempty" jsonschema:"extra_vars that were changed"`
+	DroppedVars    []string `json:"dropped_vars,omitempty" jsonschema:"secret extra_vars AWX masks, which were not sent again"`
+	Status         string   `json:"status" jsonschema:"status of the new job"`
+	URL            string   `json:"url" jsonschema:"AWX URL of the new job"`
+	Message        string   `json:"message" jsonschema:"status message"`
+}
+
+// Schedule models
+
+type ListSchedulesArgs struct {
+	JobTemplate      string `json:"job_template,omitempty" jsonschema:"only schedules of this job template (name or ID)"`
+	WorkflowTemplate string `json:"workflow_template,omitempty" jsonschema:"only schedules of this workflow job template (name or ID)"`
+	Enabled          *bool  `json:"enabled,omitempty" jsonschema:"only enabled or only disabled schedules"`
+}
+
+type ScheduleSummary struct {
+	ID           int                    `json:"id" jsonschema:"schedule ID"`
+	Name         string                 `json:"name" jsonschema:"schedule name"`
+	Description  string                 `json:"description,omitempty" jsonschema:"schedule description"`
+	TemplateID   int                    `json:"template_id" jsonschema:"ID of the scheduled template"`
+	TemplateName string                 `json:"template_name" jsonschema:"name of the scheduled template"`
+	TemplateType string                 `json:"template_type" jsonschema:"job, workflow_job, project_update, inventory_update or system_job"`
+	Enabled      bool                   `json:"enabled" jsonschema:"whether the schedule runs"`
+	Rrule        string                 `json:"rrule" jsonschema:"the schedule rule"`
+	Timezone     string                 `json:"timezone,omitempty" jsonschema:"time zone of the rule"`
+	NextRun      string                 `json:"next_run,omitempty" jsonschema:"next run as AWX reports it, in UTC"`
+	Until        string                 `json:"until,omitempty" jsonschema:"when the rule ends"`
+	ExtraData    map[string]interface{} `json:"extra_data,omitempty" jsonschema:"extra_vars the scheduled jobs get"`
+}
+
+type ListSchedulesOutput struct {
+	Schedules []ScheduleSummary `json:"schedules" jsonschema:"schedules ordered by next run"`
+	Total     int               `json:"total" jsonschema:"total number of matching schedules"`
+}
+
+type CreateScheduleArgs struct {
+	JobTemplate      string                 `json:"job_template,omitempty" jsonschema:"job template to schedule (name or ID)"`
+	WorkflowTemplate string                 `json:"workflow_template,omitempty" jsonschema:"workflow job template to schedule (name or ID)"`
+	Name             string                 `json:"name" jsonschema:"required,schedule name"`
+	Description      string                 `json:"description,omitempty" jsonschema:"schedule description"`
+	Rrule            string                 `json:"rrule" jsonschema:"required,schedule rule such as DTSTART;TZID=Europe/Paris:20250101T020000 RRULE:FREQ=DAILY;INTERVAL=1"`
+	Enabled          *bool                  `json:"enabled,omitempty" jsonschema:"whether the schedule runs (default: true)"`
+	ExtraData        map[string]interface{} `json:"extra_data,omitempty" jsonschema:"extra_vars for the scheduled jobs; the template must prompt for variables"`
+}
+
+type UpdateScheduleArgs struct {
+	Schedule    string                 `json:"schedule" jsonschema:"required,schedule name or ID"`
+	Name        *string                `json:"name,omitempty" jsonschema:"new schedule name"`
+	Description *string                `json:"description,omitempty" jsonschema:"schedule description"`
+	Rrule       *string                `json:"rrule,omitempty" jsonschema:"new schedule rule"`
+	Enabled     *bool                  `json:"enabled,omitempty" jsonschema:"whether the schedule runs"`
+	ExtraData   map[string]interface{} `json:"extra_data,omitempty" jsonschema:"extra_vars for the scheduled jobs"`
+}
+
+type SetScheduleEnabledArgs struct {
+	Schedule string `json:"schedule" jsonschema:"required,schedule name or ID"`
+	Enabled  bool   `json:"enabled" jsonschema:"required,true to enable, false to disable"`
+}
+
+type ScheduleChangeOutput struct {
+	Schedule      ScheduleSummary `json:"schedule" jsonschema:"the schedule after the change"`
+	UpdatedFields []string        `json:"updated_fields,omitempty" jsonschema:"schedule fields changed"`
+	NextRuns      []string        `json:"next_runs,omitempty" jsonschema:"next runs in the time zone of the rule"`
+	Message       string          `json:"message" jsonschema:"status message"`
+}
+
+type DeleteScheduleArgs struct {
+	Schedule          string `json:"schedule" jsonschema:"required,schedule name or ID"`
+	ConfirmationToken string `json:"confirmation_token,omitempty" jsonschema:"token returned by a first call without it"`
+}
+
+type DeleteScheduleOutput struct {
+	ID                int    `json:"id" jsonschema:"schedule ID"`
+	Name              string `json:"name" jsonschema:"schedule name"`
+	TemplateName      string `json:"template_name" jsonschema:"name of the scheduled template"`
+	Enabled           bool   `json:"enabled" jsonschema:"whether the schedule was running"`
+	NextRun           string `json:"next_run,omitempty" jsonschema:"next run that deleting cancels, in UTC"`
+	Deleted           bool   `json:"deleted" jsonschema:"whether the schedule was deleted"`
+	ConfirmationToken string `json:"confirmation_token,omitempty" jsonschema:"token to pass on a second call to delete the schedule"`
+	ExpiresAt         string `json:"expires_at,omitempty" jsonschema:"when the confirmation token expires"`
+	Message           string `json:"message" jsonschema:"status message"`
+}
+
+type PreviewScheduleArgs struct {
+	Rrule    string `json:"rrule,omitempty" jsonschema:"schedule rule to preview"`
+	Schedule string `json:"schedule,omitempty" jsonschema:"existing schedule to preview (name or ID), instead of rrule"`
+	Count    int    `json:"count,omitempty" jsonschema:"number of runs to show (default: 10, max: 100)"`
+	TimeZone string `json:"time_zone,omitempty" jsonschema:"IANA time zone to show the runs in (default: the zone of the rule)"`
+}
+
+type ScheduleOccurrence struct {
+	Local string `json:"local" jsonschema:"run time in the chosen time zone"`
+	UTC   string `json:"utc" jsonschema:"run time in UTC"`
+}
+
+type PreviewScheduleOutput struct {
+	Rrule       string               `json:"rrule" jsonschema:"the previewed rule"`
+	TimeZone    string               `json:"time_zone" jsonschema:"time zone of the local run times"`
+	Occurrences []ScheduleOccurrence `json:"occurrences" jsonschema:"next runs after now"`
+	Message     string               `json:"message" jsonschema:"status message"`
+}
+
+type UpcomingScheduleRunsArgs struct {
+	Hours    int    `json:"hours,omitempty" jsonschema:"how far ahead to look (default: 24, max: 168)"`
+	TimeZone string `json:"time_zone,omitempty" jsonschema:"IANA time zone to show the runs in (default: UTC)"`
+}
+
+type UpcomingScheduleRun struct {
+	Time         string `json:"time" jsonschema:"run time in the chosen time zone"`
+	UTC          string `json:"utc" jsonschema:"run time in UTC"`
+	ScheduleID   int    `json:"schedule_id" jsonschema:"schedule ID"`
+	ScheduleName string `json:"schedule_name" jsonschema:"schedule name"`
+	TemplateID   int    `json:"template_id" jsonschema:"ID of the scheduled template"`
+	TemplateName string `json:"template_name" jsonschema:"name of the scheduled template"`
+	TemplateType string `json:"template_type" jsonschema:"job, workflow_job, project_update, inventory_update or system_job"`
+}
+
+type UpcomingScheduleRunsOutput struct {
+	From      string                `json:"from" jsonschema:"start of the window"`
+	To        string                `json:"to" jsonschema:"end of the window"`
+	TimeZone  string                `json:"time_zone" jsonschema:"time zone of the run times"`
+	Runs      []UpcomingScheduleRun `json:"runs" jsonschema:"runs in the window, earliest first"`
+	TotalRuns int                   `json:"total_runs" jsonschema:"number of runs in the window, more than listed when truncated"`
+	Truncated bool                  `json:"truncated,omitempty" jsonschema:"whether runs were left out of the list"`
+	Schedules int                   `json:"schedules" jsonschema:"number of enabled schedules checked"`
+	Warnings  []string              `json:"warnings,omitempty" jsonschema:"schedules whose runs could not be worked out"`
+	Message   string                `json:"message" jsonschema:"status message"`
+}
+
+// Job analytics models
+
+type AnalyzeJobHistoryArgs struct {
+	Days        int    `json:"days,omitempty" jsonschema:"how many days of job history to analyze (default: 7, max: 90)"`
+	JobTemplate string `json:"job_template,omitempty" jsonschema:"only jobs of this job template (name or ID)"`
+}
+
+type TemplateJobStats struct {
+	TemplateID       int     `json:"template_id,omitempty" jsonschema:"job template ID, 0 once the template is deleted"`
+	TemplateName     string  `json:"template_name" jsonschema:"job template name"`
+	Runs             int     `json:"runs" jsonschema:"finished jobs in the window"`
+	Successful       int     `json:"successful" jsonschema:"jobs that succeeded"`
+	Failed           int     `json:"failed" jsonschema:"jobs that failed or errored"`
+	Canceled         int     `json:"canceled" jsonschema:"jobs that were canceled, left out of the rates"`
+	SuccessRate      float64 `json:"success_rate" jsonschema:"percentage of successful jobs among the successful and failed ones"`
+	FailureRate      float64 `json:"failure_rate" jsonschema:"percentage of failed jobs among the successful and failed ones"`
+	P50Seconds       float64 `json:"p50_seconds" jsonschema:"median job duration in seconds"`
+	P95Seconds       float64 `json:"p95_seconds" jsonschema:"95th percentile job duration in seconds"`
+	Recoveries       int     `json:"recoveries" jsonschema:"failures followed by a success"`
+	MTTRSeconds      float64 `json:"mttr_seconds,omitempty" jsonschema:"mean time from the first failure to the next success, in seconds"`
+	CurrentlyFailing bool    `json:"currently_failing" jsonschema:"whether the latest outcome is a failure"`
+	FailingSince     string  `json:"failing_since,omitempty" jsonschema:"when the current run of failures started"`
+	OutcomeFlips     int     `json:"outcome_flips" jsonschema:"times the outcome changed between consecutive jobs"`
+	Flaky            bool    `json:"flaky" jsonschema:"whether outcomes alternate often enough to call the template flaky"`
+	LastStatus       string  `json:"last_status" jsonschema:"status of the latest job"`
+	LastJobID        int     `json:"last_job_id" jsonschema:"ID of the latest job"`
+}
+
+type FailingHost struct {
+	Host          string   `json:"host" jsonschema:"host name"`
+	FailedJobs    int      `json:"failed_jobs" jsonschema:"failed jobs the host failed or was unreachable in"`
+	Unreachable   int      `json:"unreachable" jsonschema:"failed jobs the host was unreachable in"`
+	Templates     []string `json:"templates" jsonschema:"templates of those jobs"`
+	LastFailedJob int      `json:"last_failed_job" jsonschema:"latest job the host failed in"`
+	LastFailure   string   `json:"last_failure" jsonschema:"when that job finished"`
+}
+
+type JobHistoryAnalyticsOutput struct {
+	From             string             `json:"from" jsonschema:"start of the window"`
+	To               string             `json:"to" jsonschema:"end of the window"`
+	JobsAnalyzed     int                `json:"jobs_analyzed" jsonschema:"finished jobs analyzed"`
+	TotalJobs        int                `json:"total_jobs" jsonschema:"finished jobs in the window, more than analyzed when truncated"`
+	Truncated        bool               `json:"truncated,omitempty" jsonschema:"whether the oldest jobs were left out"`
+	Successful       int                `json:"successful" jsonschema:"jobs that succeeded"`
+	Failed           int                `json:"failed" jsonschema:"jobs that failed or errored"`
+	Canceled         int                `json:"canceled" jsonschema:"jobs that were canceled"`
+	SuccessRate      float64            `json:"success_rate" jsonschema:"overall percentage of successful jobs among the successful and failed ones"`
+	Templates        []TemplateJobStats `json:"templates" jsonschema:"per-template statistics, most failures first"`
+	FlakyTemplates   []string           `json:"flaky_templates,omitempty" jsonschema:"templates whose outcomes alternate"`
+	FailingHosts     []FailingHost      `json:"failing_hosts,omitempty" jsonschema:"hosts that failed in more than one job, most failures first"`
+	FailedJobsProbed int                `json:"failed_jobs_probed" jsonschema:"failed jobs whose host results were read"`
+	Warnings         []string           `json:"warnings,omitempty" jsonschema:"gaps in the analysis"`
+	Message          string             `json:"message" jsonschema:"status message"`
+}
@@ -10,7 +10,9 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NacerKH/autosphere-mcp-golang/internal/cache"
@@ -66,13 +68,47 @@ func NewPrometheusClient(config PrometheusConfig) *PrometheusClient {
 
 // QueryResponse represents a Prometheus query response
 type QueryResponse struct {
-	Status string `json:"status"`
-	Data   struct {
-		ResultType string   `json:"resultType"`
-		Result     []Result `json:"result"`
-	} `json:"data"`
-	Error     string `json:"error,omitempty"`
-	ErrorType string `json:"errorType,omitempty"`
+	Status    string    `json:"status"`
+	Data      QueryData `json:"data"`
+	Error     string    `json:"error,omitempty"`
+	ErrorType string    `json:"errorType,omitempty"`
+}
+
+// QueryData holds the result of a query. Scalar and string results, which
+// Prometheus returns as a bare [timestamp, value] pair, are exposed as a
+// single Result without labels.
+type QueryData struct {
+	ResultType string   `json:"resultType"`
+	Result     []Result `json:"result"`
+}
+
+// UnmarshalJSON decodes vector, matrix, scalar and string results
+func (d *QueryData) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	d.ResultType = raw.ResultType
+	d.Result = nil
+	if len(raw.Result) == 0 {
+		return nil
+	}
+
+	switch raw.ResultType {
+	case "scalar", "string":
+		var pair []interface{}
+		if err := json.Unmarshal(raw.Result, &pair); err != nil {
+			return fmt.Errorf("failed to decode %s result: %w", raw.ResultType, err)
+		}
+		d.Result = []Result{{Metric: map[string]string{}, Value: pair}}
+		return nil
+	default:
+		return json.Unmarshal(raw.Result, &d.Result)
+	}
 }
 
 // Result represents a single metric result
@@ -128,7 +164,7 @@ func (c *PrometheusClient) QueryRange(ctx context.Context, query string, start,
 	params.Set("query", query)
 	params.Set("start", strconv.FormatInt(start.Unix(), 10))
 	params.Set("end", strconv.FormatInt(end.Unix(), 10))
-	params.Set("step", step.String())
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
 
 	return c.makeRequest(ctx, "/api/v1/query_range", params)
 }
@@ -172,18 +208,66 @@ func (c *PrometheusClient) makeRequest(ctx context.Context, endpoint string, par
 	return &queryResp, nil
 }
 
-// GetSystemMetrics retrieves common system metrics with caching
-func (c *PrometheusClient) GetSystemMetrics(ctx context.Context) (map[string]float64, error) {
+// systemMetricQueries builds the per-node PromQL of each system metric from
+// the averaging window and an optional instance matcher
+var systemMetricQueries = map[string]func(window, instance string) string{
+	"cpu_usage_percent": func(window, instance string) string {
+		return fmt.Sprintf(`100 - (avg by (instance) (rate(node_cpu_seconds_total%s[%s])) * 100)`,
+			selector(`mode="idle"`, instance), window)
+	},
+	"memory_usage_percent": func(window, instance string) string {
+		return fmt.Sprintf(`(1 - (avg_over_time(node_memory_MemAvailable_bytes%[1]s[%[2]s]) / avg_over_time(node_memory_MemTotal_bytes%[1]s[%[2]s]))) * 100`,
+			selector(instance), window)
+	},
+	"disk_usage_percent": func(window, instance string) string {
+		return fmt.Sprintf(`max by (instance) (100 - ((avg_over_time(node_filesystem_avail_bytes%[1]s[%[2]s]) / avg_over_time(node_filesystem_size_bytes%[1]s[%[2]s])) * 100))`,
+			selector(`mountpoint="/"`, instance), window)
+	},
+}
+
+// selector joins the non-empty label matchers into a PromQL selector
+func selector(matchers ...string) string {
+	var parts []string
+	for _, matcher := range matchers {
+		if matcher != "" {
+			parts = append(parts, matcher)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// SystemMetrics holds system metrics averaged over a window, overall and per node
+type SystemMetrics struct {
+	Overall map[string]float64            // Mean across the nodes that reported the metric
+	Nodes   map[string]map[string]float64 // Metrics by instance
+	Errors  map[string]string             // Metrics whose query failed, with the error
+}
+
+// GetSystemMetrics retrieves CPU, memory and disk usage averaged over window
+// for the given node instances (all nodes when empty). Results are cached
+// only when every query succeeded; an error is returned when all failed.
+func (c *PrometheusClient) GetSystemMetrics(ctx context.Context, window time.Duration, nodes []string) (*SystemMetrics, error) {
+	if window < time.Second {
+		return nil, fmt.Errorf("window must be at least 1s, got %s", window)
+	}
+	instance, err := instanceMatcher(nodes)
+	if err != nil {
+		return nil, err
+	}
+
 	// Cache key for system metrics
-	cacheKey := "prom:system_metrics"
+	cacheKey := c.getCacheKey(fmt.Sprintf("system_metrics|%s|%s", window, instance))
 
 	// Try cache first
 	if cached, ok := c.cache.Get(cacheKey); ok {
-		if metrics, ok := cached.(map[string]float64); ok {
+		if metrics, ok := cached.(*SystemMetrics); ok {
 			if c.debug {
 				log.Printf("Cache HIT: system metrics")
 			}
-			return metrics, nil
+			return metrics.clone(), nil
 		}
 	}
 
@@ -191,39 +275,96 @@ func (c *PrometheusClient) GetSystemMetrics(ctx context.Context) (map[string]flo
 		log.Printf("Cache MISS: system metrics - fetching from Prometheus")
 	}
 
-	metrics := make(map[string]float64)
+	metrics := &SystemMetrics{
+		Overall: make(map[string]float64),
+		Nodes:   make(map[string]map[string]float64),
+		Errors:  make(map[string]string),
+	}
+	windowText := strconv.FormatInt(int64(window/time.Second), 10) + "s"
+	for name, build := range systemMetricQueries {
+		resp, err := c.Query(ctx, build(windowText, instance))
+		if err != nil {
+			log.Printf("System metric %s query failed: %v", name, err)
+			metrics.Errors[name] = err.Error()
+			continue
+		}
 
-	// CPU usage
-	cpuQuery := `100 - (avg(rate(node_cpu_seconds_total{mode="idle"}[5m])) * 100)`
-	cpuResp, err := c.Query(ctx, cpuQuery)
-	if err == nil && len(cpuResp.Data.Result) > 0 {
-		if val, err := strconv.ParseFloat(cpuResp.Data.Result[0].Value[1].(string), 64); err == nil {
-			metrics["cpu_usage_percent"] = val
+		var sum float64
+		var count int
+		for _, result := range resp.Data.Result {
+			sample, err := result.InstantSample()
+			if err != nil || !sample.IsNumber() {
+				continue
+			}
+			node := result.Metric["instance"]
+			if node == "" {
+				node = "unknown"
+			}
+			if metrics.Nodes[node] == nil {
+				metrics.Nodes[node] = make(map[string]float64)
+			}
+			metrics.Nodes[node][name] = sample.Value
+			sum += sample.Value
+			count++
+		}
+		if count > 0 {
+			metrics.Overall[name] = sum / float64(count)
 		}
 	}
 
-	// Memory usage
-	memQuery := `(1 - (node_memory_MemAvailable_bytes / node_memory_MemTotal_bytes)) * 100`
-	memResp, err := c.Query(ctx, memQuery)
-	if err == nil && len(memResp.Data.Result) > 0 {
-		if val, err := strconv.ParseFloat(memResp.Data.Result[0].Value[1].(string), 64); err == nil {
-			metrics["memory_usage_percent"] = val
+	if len(metrics.Errors) == len(systemMetricQueries) {
+		for _, message := range metrics.Errors {
+			return nil, fmt.Errorf("all system metric queries failed: %s", message)
 		}
 	}
+	if len(metrics.Errors) == 0 {
+		// Cache for 30 seconds
+		c.cache.Set(cacheKey, metrics.clone(), 30*time.Second)
+	}
+
+	return metrics, nil
+}
 
-	// Disk usage
-	diskQuery := `100 - ((node_filesystem_avail_bytes{mountpoint="/"} / node_filesystem_size_bytes{mountpoint="/"}) * 100)`
-	diskResp, err := c.Query(ctx, diskQuery)
-	if err == nil && len(diskResp.Data.Result) > 0 {
-		if val, err := strconv.ParseFloat(diskResp.Data.Result[0].Value[1].(string), 64); err == nil {
-			metrics["disk_usage_percent"] = val
+// instanceMatcher returns a label matcher limiting a query to the given nodes,
+// or "" for all nodes. Nodes match an instance label with or without a port.
+func instanceMatcher(nodes []string) (string, error) {
+	var patterns []string
+	for _, node := range nodes {
+		node = strings.TrimSpace(node)
+		if node == "" {
+			continue
+		}
+		if strings.Contains(node, "`") {
+			return "", fmt.Errorf("invalid node name %q", node)
 		}
+		patterns = append(patterns, regexp.QuoteMeta(node))
 	}
+	if len(patterns) == 0 {
+		return "", nil
+	}
+	// A raw string keeps the regexp escapes as they are
+	return "instance=~`(" + strings.Join(patterns, "|") + ")(:[0-9]+)?`", nil
+}
 
-	// Cache for 30 seconds
-	c.cache.Set(cacheKey, metrics, 30*time.Second)
-
-	return metrics, nil
+func (m *SystemMetrics) clone() *SystemMetrics {
+	copied := &SystemMetrics{
+		Overall: make(map[string]float64, len(m.Overall)),
+		Nodes:   make(map[string]map[string]float64, len(m.Nodes)),
+		Errors:  make(map[string]string, len(m.Errors)),
+	}
+	for name, value := range m.Overall {
+		copied.Overall[name] = value
+	}
+	for node, values := range m.Nodes {
+		copied.Nodes[node] = make(map[string]float64, len(values))
+		for name, value := range values {
+			copied.Nodes[node][name] = value
+		}
+	}
+	for name, message := range m.Errors {
+		copied.Errors[name] = message
+	}
+	return copied
 }
 
 // ClearCache clears all cached data
@@ -0,0 +1,237 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stub is an httptest Prometheus answering queries through respond
+type stub struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	respond  func(r *http.Request) (int, interface{})
+}
+
+func newStub(t *testing.T, respond func(r *http.Request) (int, interface{})) (*stub, *PrometheusClient) {
+	t.Helper()
+	s := &stub{respond: respond}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.mu.Unlock()
+
+		status, body := s.respond(r)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return s, NewPrometheusClient(PrometheusConfig{BaseURL: server.URL, Username: "prom", Password: "secret"})
+}
+
+func (s *stub) queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var queries []string
+	for _, r := range s.requests {
+		queries = append(queries, r.URL.Query().Get("query"))
+	}
+	return queries
+}
+
+func vector(results ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"status": "success",
+		"data":   map[string]interface{}{"resultType": "vector", "result": results},
+	}
+}
+
+func sample(labels map[string]string, value string) map[string]interface{} {
+	return map[string]interface{}{"metric": labels, "value": []interface{}{1700000000.0, value}}
+}
+
+func TestQuery(t *testing.T) {
+	s, client := newStub(t, func(r *http.Request) (int, interface{}) {
+		if r.URL.Path != "/api/v1/query" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if user, password, ok := r.BasicAuth(); !ok || user != "prom" || password != "secret" {
+			t.Errorf("basic auth = %q %q %t", user, password, ok)
+		}
+		return http.StatusOK, vector(sample(map[string]string{"job": "node"}, "1"))
+	})
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Query(context.Background(), "up")
+		if err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+		if len(resp.Data.Result) != 1 || resp.Data.Result[0].Metric["job"] != "node" {
+			t.Fatalf("unexpected result %+v", resp.Data)
+		}
+	}
+	if got := len(s.queries()); got != 1 {
+		t.Errorf("made %d requests, want 1 (second query cached)", got)
+	}
+}
+
+func TestQueryScalar(t *testing.T) {
+	_, client := newStub(t, func(r *http.Request) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"data":   map[string]interface{}{"resultType": "scalar", "result": []interface{}{1700000000.5, "42"}},
+		}
+	})
+
+	resp, err := client.Query(context.Background(), "scalar(1)")
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	got, err := resp.Data.Result[0].InstantSample()
+	if err != nil || got.Value != 42 {
+		t.Errorf("sample = %+v, %v; want 42", got, err)
+	}
+}
+
+func TestQueryRangeParameters(t *testing.T) {
+	s, client := newStub(t, func(r *http.Request) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"status": "success",
+			"data": map[string]interface{}{"resultType": "matrix", "result": []interface{}{
+				map[string]interface{}{"metric": map[string]string{}, "values": [][]interface{}{{1700000000.0, "1"}, {1700000060.0, "2"}}},
+			}},
+		}
+	})
+
+	start := time.Unix(1700000000, 0)
+	resp, err := client.QueryRange(context.Background(), "rate(x[5m])", start, start.Add(time.Hour), 90*time.Second)
+	if err != nil {
+		t.Fatalf("QueryRange: %v", err)
+	}
+	samples, err := resp.Data.Result[0].RangeSamples()
+	if err != nil || len(samples) != 2 {
+		t.Fatalf("samples = %+v, %v", samples, err)
+	}
+
+	query := s.requests[0].URL.Query()
+	if s.requests[0].URL.Path != "/api/v1/query_range" || query.Get("start") != "1700000000" ||
+		query.Get("end") != "1700003600" || query.Get("step") != "90" {
+		t.Errorf("unexpected request %s", s.requests[0].URL)
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   interface{}
+		want   string
+	}{
+		{"http error", http.StatusBadRequest, map[string]string{"status": "error", "error": "parse error"}, "status 400"},
+		{"query error", http.StatusOK, map[string]string{"status": "error", "errorType": "execution", "error": "timeout"}, "execution - timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client := newStub(t, func(r *http.Request) (int, interface{}) { return tt.status, tt.body })
+
+			_, err := client.Query(context.Background(), "up")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSystemMetricsPerNode(t *testing.T) {
+	s, client := newStub(t, func(r *http.Request) (int, interface{}) {
+		query := r.URL.Query().Get("query")
+		switch {
+		case strings.Contains(query, "node_cpu_seconds_total"):
+			return http.StatusOK, vector(
+				sample(map[string]string{"instance": "web1:9100"}, "20"),
+				sample(map[string]string{"instance": "web2:9100"}, "60"),
+			)
+		case strings.Contains(query, "node_memory"):
+			return http.StatusOK, vector(sample(map[string]string{"instance": "web1:9100"}, "50"))
+		default:
+			return http.StatusOK, vector(sample(map[string]string{"instance": "web2:9100"}, "NaN"))
+		}
+	})
+
+	metrics, err := client.GetSystemMetrics(context.Background(), 10*time.Minute, []string{"web1", "web2.example"})
+	if err != nil {
+		t.Fatalf("GetSystemMetrics: %v", err)
+	}
+	if metrics.Overall["cpu_usage_percent"] != 40 || metrics.Overall["memory_usage_percent"] != 50 {
+		t.Errorf("overall = %v", metrics.Overall)
+	}
+	if _, ok := metrics.Overall["disk_usage_percent"]; ok {
+		t.Errorf("NaN disk usage should be left out: %v", metrics.Overall)
+	}
+	if metrics.Nodes["web2:9100"]["cpu_usage_percent"] != 60 || len(metrics.Errors) != 0 {
+		t.Errorf("nodes = %v, errors = %v", metrics.Nodes, metrics.Errors)
+	}
+
+	for _, query := range s.queries() {
+		if !strings.Contains(query, "[600s]") || !strings.Contains(query, "instance=~`(web1|web2\\.example)(:[0-9]+)?`") {
+			t.Errorf("query does not use the window and node filter: %s", query)
+		}
+	}
+}
+
+func TestGetSystemMetricsFailures(t *testing.T) {
+	var failMemory bool
+	s, client := newStub(t, func(r *http.Request) (int, interface{}) {
+		if failMemory && strings.Contains(r.URL.Query().Get("query"), "node_memory") {
+			return http.StatusServiceUnavailable, map[string]string{"status": "error"}
+		}
+		return http.StatusOK, vector(sample(map[string]string{"instance": "web1:9100"}, "10"))
+	})
+	ctx := context.Background()
+
+	failMemory = true
+	metrics, err := client.GetSystemMetrics(ctx, 5*time.Minute, nil)
+	if err != nil {
+		t.Fatalf("GetSystemMetrics: %v", err)
+	}
+	if _, ok := metrics.Errors["memory_usage_percent"]; !ok || len(metrics.Errors) != 1 {
+		t.Errorf("errors = %v, want the memory query", metrics.Errors)
+	}
+
+	// A partial result is not cached
+	failMemory = false
+	before := len(s.queries())
+	metrics, err = client.GetSystemMetrics(ctx, 5*time.Minute, nil)
+	if err != nil || len(metrics.Errors) != 0 {
+		t.Fatalf("GetSystemMetrics = %v, %v; want a complete result", metrics, err)
+	}
+	if len(s.queries()) == before {
+		t.Errorf("partial result was served from the cache")
+	}
+}
+
+func TestGetSystemMetricsPrometheusDown(t *testing.T) {
+	_, client := newStub(t, func(r *http.Request) (int, interface{}) {
+		return http.StatusBadGateway, map[string]string{"status": "error"}
+	})
+
+	if _, err := client.GetSystemMetrics(context.Background(), 5*time.Minute, nil); err == nil {
+		t.Fatal("expected an error when every query fails")
+	}
+}
+
+func TestInstanceMatcher(t *testing.T) {
+	if got, _ := instanceMatcher([]string{" ", ""}); got != "" {
+		t.Errorf("empty nodes gave %q", got)
+	}
+	if _, err := instanceMatcher([]string{"web`1"}); err == nil {
+		t.Error("expected a backtick in a node name to be rejected")
+	}
+}
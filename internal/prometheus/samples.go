@@ -0,0 +1,67 @@
+package prometheus
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Sample is a single timestamped value of a series
+type Sample struct {
+	Time  time.Time
+	Value float64
+	Raw   string // Value as returned by Prometheus, e.g. "NaN" or "+Inf"
+}
+
+// IsNumber reports whether the value can be used in arithmetic
+func (s Sample) IsNumber() bool {
+	return !math.IsNaN(s.Value) && !math.IsInf(s.Value, 0)
+}
+
+// InstantSample returns the sample of a vector or scalar result
+func (r Result) InstantSample() (Sample, error) {
+	return parseSample(r.Value)
+}
+
+// RangeSamples returns the samples of a matrix result in time order
+func (r Result) RangeSamples() ([]Sample, error) {
+	samples := make([]Sample, 0, len(r.Values))
+	for _, pair := range r.Values {
+		sample, err := parseSample(pair)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// parseSample decodes a [<unix seconds>, "<value>"] pair
+func parseSample(pair []interface{}) (Sample, error) {
+	if len(pair) != 2 {
+		return Sample{}, fmt.Errorf("malformed sample: expected [timestamp, value], got %d elements", len(pair))
+	}
+
+	timestamp, ok := pair[0].(float64)
+	if !ok {
+		return Sample{}, fmt.Errorf("malformed sample timestamp: %v", pair[0])
+	}
+
+	raw, ok := pair[1].(string)
+	if !ok {
+		return Sample{}, fmt.Errorf("malformed sample value: %v", pair[1])
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("malformed sample value %q: %w", raw, err)
+	}
+
+	seconds, fraction := math.Modf(timestamp)
+	return Sample{
+		Time:  time.Unix(int64(seconds), int64(fraction*1e9)),
+		Value: value,
+		Raw:   raw,
+	}, nil
+}
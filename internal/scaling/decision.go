@@ -0,0 +1,199 @@
+package scaling
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scaling directions
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+	DirectionHold = "hold"
+)
+
+// Reading is the observed value of a policy metric
+type Reading struct {
+	Metric    MetricRule
+	Value     float64
+	Available bool
+	Err       error // Why the value is unavailable, if known
+}
+
+// Verdict tells what the reading suggests on its own
+func (r Reading) Verdict() string {
+	switch {
+	case !r.Available:
+		return "unavailable"
+	case r.Metric.ScaleUpAbove != nil && r.Value > *r.Metric.ScaleUpAbove:
+		return DirectionUp
+	case r.Metric.ScaleDownBelow != nil && r.Value < *r.Metric.ScaleDownBelow:
+		return DirectionDown
+	default:
+		return DirectionHold
+	}
+}
+
+// Describe renders the reading against its thresholds, e.g. "cpu 91 > scale_up_above 80"
+func (r Reading) Describe() string {
+	if !r.Available {
+		if r.Err != nil {
+			return fmt.Sprintf("%s unavailable (%v)", r.Metric.Name, r.Err)
+		}
+		return fmt.Sprintf("%s unavailable", r.Metric.Name)
+	}
+
+	switch r.Verdict() {
+	case DirectionUp:
+		return fmt.Sprintf("%s %g > scale_up_above %g", r.Metric.Name, r.Value, *r.Metric.ScaleUpAbove)
+	case DirectionDown:
+		return fmt.Sprintf("%s %g < scale_down_below %g", r.Metric.Name, r.Value, *r.Metric.ScaleDownBelow)
+	default:
+		return fmt.Sprintf("%s %g within thresholds", r.Metric.Name, r.Value)
+	}
+}
+
+// Decision is the outcome of evaluating a service rule
+type Decision struct {
+	Direction string
+	Target    int
+	Reason    string
+}
+
+// Decide proposes a replica count from metric readings. Any metric above its
+// scale-up threshold scales up; scaling down requires every available metric
+// with a scale-down threshold to be below it. The target stays within
+// min/max replicas.
+func (r ServiceRule) Decide(current int, readings []Reading) Decision {
+	// Bring deployments scaled outside the rule, e.g. by hand, back within bounds first
+	if current > r.MaxReplicas {
+		return Decision{Direction: DirectionDown, Target: r.MaxReplicas,
+			Reason: fmt.Sprintf("Rule %s: %d replicas exceed max_replicas %d", r.Origin, current, r.MaxReplicas)}
+	}
+	if current < r.MinReplicas {
+		return Decision{Direction: DirectionUp, Target: r.MinReplicas,
+			Reason: fmt.Sprintf("Rule %s: %d replicas are below min_replicas %d", r.Origin, current, r.MinReplicas)}
+	}
+
+	var available, up, down []Reading
+	for _, reading := range readings {
+		if !reading.Available {
+			continue
+		}
+		available = append(available, reading)
+		switch reading.Verdict() {
+		case DirectionUp:
+			up = append(up, reading)
+		case DirectionDown:
+			down = append(down, reading)
+		}
+	}
+
+	if len(available) == 0 {
+		return Decision{Direction: DirectionHold, Target: current,
+			Reason: fmt.Sprintf("No metric of rule %s is available - no scaling decision made", r.Origin)}
+	}
+
+	if len(up) > 0 {
+		target := current + r.ScaleUpStep
+		if target > r.MaxReplicas {
+			target = r.MaxReplicas
+		}
+		reason := fmt.Sprintf("Rule %s: %s", r.Origin, describeAll(up))
+		if target <= current {
+			return Decision{Direction: DirectionHold, Target: current,
+				Reason: fmt.Sprintf("%s, but already at max_replicas %d", reason, r.MaxReplicas)}
+		}
+		return Decision{Direction: DirectionUp, Target: target,
+			Reason: fmt.Sprintf("%s → scale up by %d (scale_up_step, max_replicas %d)", reason, target-current, r.MaxReplicas)}
+	}
+
+	// Metrics without a scale-down threshold cannot veto scaling down
+	downCandidates := 0
+	for _, reading := range available {
+		if reading.Metric.ScaleDownBelow != nil {
+			downCandidates++
+		}
+	}
+	if downCandidates > 0 && len(down) == downCandidates {
+		target := current - r.ScaleDownStep
+		if target < r.MinReplicas {
+			target = r.MinReplicas
+		}
+		reason := fmt.Sprintf("Rule %s: %s", r.Origin, describeAll(down))
+		if target >= current {
+			return Decision{Direction: DirectionHold, Target: current,
+				Reason: fmt.Sprintf("%s, but already at min_replicas %d", reason, r.MinReplicas)}
+		}
+		return Decision{Direction: DirectionDown, Target: target,
+			Reason: fmt.Sprintf("%s → scale down by %d (scale_down_step, min_replicas %d)", reason, current-target, r.MinReplicas)}
+	}
+
+	return Decision{Direction: DirectionHold, Target: current,
+		Reason: fmt.Sprintf("Rule %s: no scaling needed (%s)", r.Origin, describeAll(available))}
+}
+
+// CheckTarget refuses replica counts outside min/max replicas
+func (r ServiceRule) CheckTarget(target int) error {
+	if target < r.MinReplicas {
+		return fmt.Errorf("target of %d replicas is below min_replicas %d of rule %s", target, r.MinReplicas, r.Origin)
+	}
+	if target > r.MaxReplicas {
+		return fmt.Errorf("target of %d replicas is above max_replicas %d of rule %s", target, r.MaxReplicas, r.Origin)
+	}
+	return nil
+}
+
+// CooldownRemaining returns how long scaling in the given direction is still
+// blocked after the last scaling event, or zero
+func (r ServiceRule) CooldownRemaining(direction string, lastScaled, now time.Time) time.Duration {
+	if lastScaled.IsZero() {
+		return 0
+	}
+
+	cooldown := r.ScaleUpCooldown
+	if direction == DirectionDown {
+		cooldown = r.ScaleDownCooldown
+	}
+
+	remaining := cooldown - now.Sub(lastScaled)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func describeAll(readings []Reading) string {
+	parts := make([]string, len(readings))
+	for i, reading := range readings {
+		parts[i] = reading.Describe()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CooldownTracker remembers when each deployment was last scaled
+type CooldownTracker struct {
+	mu         sync.Mutex
+	lastScaled map[string]time.Time
+}
+
+// NewCooldownTracker creates an empty tracker
+func NewCooldownTracker() *CooldownTracker {
+	return &CooldownTracker{lastScaled: make(map[string]time.Time)}
+}
+
+// LastScaled returns when the deployment was last scaled, or the zero time
+func (t *CooldownTracker) LastScaled(namespace, deployment string) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.lastScaled[namespace+"/"+deployment]
+}
+
+// Record marks the deployment as scaled at the given time
+func (t *CooldownTracker) Record(namespace, deployment string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastScaled[namespace+"/"+deployment] = at
+}
@@ -0,0 +1,163 @@
+package scaling
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testRule allows 2 to 8 replicas, scaling up by 3 and down by 2
+func testRule() ServiceRule {
+	return (&Policy{Services: map[string]Rule{"api": {
+		MinReplicas: 2, MaxReplicas: 8, ScaleUpStep: 3, ScaleDownStep: 2,
+		ScaleUpCooldown: time.Minute, ScaleDownCooldown: 10 * time.Minute,
+	}}}).RuleFor("api")
+}
+
+func reading(metric MetricRule, value float64) Reading {
+	return Reading{Metric: metric, Value: value, Available: true}
+}
+
+func TestDecide(t *testing.T) {
+	cpu := MetricRule{Name: "cpu", Resource: "cpu", ScaleUpAbove: floatPtr(80), ScaleDownBelow: floatPtr(20)}
+	memory := MetricRule{Name: "memory", Resource: "memory", ScaleUpAbove: floatPtr(85), ScaleDownBelow: floatPtr(30)}
+	queue := MetricRule{Name: "queue", Query: "sum(queue_depth)", ScaleUpAbove: floatPtr(100)} // Scale-down disabled
+	unavailable := Reading{Metric: memory, Err: errors.New("no HPA")}
+
+	tests := []struct {
+		name      string
+		current   int
+		readings  []Reading
+		direction string
+		target    int
+		reason    string
+	}{
+		{name: "scale up by the step", current: 3, readings: []Reading{reading(cpu, 91)},
+			direction: DirectionUp, target: 6, reason: "cpu 91 > scale_up_above 80 → scale up by 3"},
+		{name: "scale up clamped to max", current: 7, readings: []Reading{reading(cpu, 91)},
+			direction: DirectionUp, target: 8, reason: "scale up by 1 (scale_up_step, max_replicas 8)"},
+		{name: "already at max", current: 8, readings: []Reading{reading(cpu, 91)},
+			direction: DirectionHold, target: 8, reason: "but already at max_replicas 8"},
+		{name: "one metric above is enough", current: 3, readings: []Reading{reading(cpu, 10), reading(memory, 90)},
+			direction: DirectionUp, target: 6, reason: "memory 90 > scale_up_above 85"},
+		{name: "scale down by the step", current: 6, readings: []Reading{reading(cpu, 5), reading(memory, 10)},
+			direction: DirectionDown, target: 4, reason: "cpu 5 < scale_down_below 20, memory 10 < scale_down_below 30 → scale down by 2"},
+		{name: "scale down clamped to min", current: 3, readings: []Reading{reading(cpu, 5)},
+			direction: DirectionDown, target: 2, reason: "scale down by 1 (scale_down_step, min_replicas 2)"},
+		{name: "already at min", current: 2, readings: []Reading{reading(cpu, 5)},
+			direction: DirectionHold, target: 2, reason: "but already at min_replicas 2"},
+		{name: "scale down needs every metric below", current: 6, readings: []Reading{reading(cpu, 5), reading(memory, 50)},
+			direction: DirectionHold, target: 6, reason: "no scaling needed"},
+		{name: "unavailable metrics do not veto", current: 6, readings: []Reading{reading(cpu, 5), unavailable},
+			direction: DirectionDown, target: 4},
+		{name: "metrics without scale-down threshold do not veto", current: 6, readings: []Reading{reading(cpu, 5), reading(queue, 3)},
+			direction: DirectionDown, target: 4},
+		{name: "scale-down disabled", current: 6, readings: []Reading{reading(queue, 3)},
+			direction: DirectionHold, target: 6, reason: "queue 3 within thresholds"},
+		{name: "no metric available", current: 4, readings: []Reading{unavailable},
+			direction: DirectionHold, target: 4, reason: "No metric of rule services.api is available"},
+		{name: "no readings", current: 4,
+			direction: DirectionHold, target: 4, reason: "No metric of rule services.api is available"},
+		{name: "above max is brought back", current: 12, readings: []Reading{reading(cpu, 95)},
+			direction: DirectionDown, target: 8, reason: "12 replicas exceed max_replicas 8"},
+		{name: "below min is brought back", current: 0, readings: []Reading{reading(cpu, 5)},
+			direction: DirectionUp, target: 2, reason: "0 replicas are below min_replicas 2"},
+	}
+	rule := testRule()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := rule.Decide(tt.current, tt.readings)
+			if decision.Direction != tt.direction || decision.Target != tt.target {
+				t.Errorf("decision = %s to %d, want %s to %d (%s)", decision.Direction, decision.Target, tt.direction, tt.target, decision.Reason)
+			}
+			if !strings.Contains(decision.Reason, tt.reason) {
+				t.Errorf("reason = %q, want %q", decision.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestReadingDescribe(t *testing.T) {
+	cpu := MetricRule{Name: "cpu", ScaleUpAbove: floatPtr(80), ScaleDownBelow: floatPtr(20)}
+	tests := []struct {
+		reading Reading
+		verdict string
+		want    string
+	}{
+		{reading(cpu, 91), DirectionUp, "cpu 91 > scale_up_above 80"},
+		{reading(cpu, 80), DirectionHold, "cpu 80 within thresholds"},
+		{reading(cpu, 19.5), DirectionDown, "cpu 19.5 < scale_down_below 20"},
+		{Reading{Metric: cpu}, "unavailable", "cpu unavailable"},
+		{Reading{Metric: cpu, Err: errors.New("no data")}, "unavailable", "cpu unavailable (no data)"},
+	}
+	for _, tt := range tests {
+		if verdict, description := tt.reading.Verdict(), tt.reading.Describe(); verdict != tt.verdict || description != tt.want {
+			t.Errorf("reading %+v: %s %q, want %s %q", tt.reading, verdict, description, tt.verdict, tt.want)
+		}
+	}
+}
+
+func TestCheckTarget(t *testing.T) {
+	rule := testRule()
+	tests := []struct {
+		target int
+		want   string // Error substring, empty when allowed
+	}{
+		{target: 2},
+		{target: 5},
+		{target: 8},
+		{target: 1, want: "target of 1 replicas is below min_replicas 2 of rule services.api"},
+		{target: 0, want: "below min_replicas"},
+		{target: 9, want: "target of 9 replicas is above max_replicas 8 of rule services.api"},
+	}
+	for _, tt := range tests {
+		err := rule.CheckTarget(tt.target)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("CheckTarget(%d): %v", tt.target, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("CheckTarget(%d) error = %v, want %q", tt.target, err, tt.want)
+		}
+	}
+}
+
+func TestCooldownRemaining(t *testing.T) {
+	rule := testRule() // 1m up, 10m down
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		direction  string
+		lastScaled time.Time
+		want       time.Duration
+	}{
+		{name: "never scaled", direction: DirectionUp, want: 0},
+		{name: "scale up within cooldown", direction: DirectionUp, lastScaled: now.Add(-20 * time.Second), want: 40 * time.Second},
+		{name: "scale up after cooldown", direction: DirectionUp, lastScaled: now.Add(-2 * time.Minute), want: 0},
+		{name: "scale up at the end of the cooldown", direction: DirectionUp, lastScaled: now.Add(-time.Minute), want: 0},
+		{name: "scale down within cooldown", direction: DirectionDown, lastScaled: now.Add(-2 * time.Minute), want: 8 * time.Minute},
+		{name: "scale down after cooldown", direction: DirectionDown, lastScaled: now.Add(-11 * time.Minute), want: 0},
+		{name: "hold uses the scale-up cooldown", direction: DirectionHold, lastScaled: now.Add(-30 * time.Second), want: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := rule.CooldownRemaining(tt.direction, tt.lastScaled, now); got != tt.want {
+			t.Errorf("%s: CooldownRemaining = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCooldownTracker(t *testing.T) {
+	tracker := NewCooldownTracker()
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	if !tracker.LastScaled("prod", "api").IsZero() {
+		t.Error("expected no scaling event for a new tracker")
+	}
+	tracker.Record("prod", "api", at)
+	if got := tracker.LastScaled("prod", "api"); !got.Equal(at) {
+		t.Errorf("LastScaled = %s, want %s", got, at)
+	}
+	if !tracker.LastScaled("staging", "api").IsZero() {
+		t.Error("scaling events leaked across namespaces")
+	}
+}
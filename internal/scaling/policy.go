@@ -0,0 +1,253 @@
+package scaling
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WindowPlaceholder is replaced by the evaluation window (e.g. "5m") in PromQL metric queries
+const WindowPlaceholder = "{{window}}"
+
+// Policy is the declarative scaling policy. Rules under Services override
+// Defaults field by field; unset fields fall back to the built-in defaults.
+type Policy struct {
+	Defaults Rule            `yaml:"defaults" json:"defaults"`
+	Services map[string]Rule `yaml:"services" json:"services"`
+}
+
+// Rule holds the scaling guardrails of a service. Zero values inherit.
+type Rule struct {
+	Deployment        string        `yaml:"deployment,omitempty" json:"deployment,omitempty"`
+	Namespace         string        `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	MinReplicas       int           `yaml:"min_replicas,omitempty" json:"min_replicas,omitempty"`
+	MaxReplicas       int           `yaml:"max_replicas,omitempty" json:"max_replicas,omitempty"`
+	ScaleUpStep       int           `yaml:"scale_up_step,omitempty" json:"scale_up_step,omitempty"`
+	ScaleDownStep     int           `yaml:"scale_down_step,omitempty" json:"scale_down_step,omitempty"`
+	ScaleUpCooldown   time.Duration `yaml:"scale_up_cooldown,omitempty" json:"scale_up_cooldown,omitempty"`
+	ScaleDownCooldown time.Duration `yaml:"scale_down_cooldown,omitempty" json:"scale_down_cooldown,omitempty"`
+	EvaluationWindow  time.Duration `yaml:"evaluation_window,omitempty" json:"evaluation_window,omitempty"`
+	Metrics           []MetricRule  `yaml:"metrics,omitempty" json:"metrics,omitempty"`
+}
+
+// MetricRule is a metric source with the thresholds that trigger scaling.
+// Exactly one of Query (PromQL) and Resource (HPA-observed cpu or memory
+// utilization percent) is set.
+type MetricRule struct {
+	Name           string   `yaml:"name" json:"name"`
+	Query          string   `yaml:"query,omitempty" json:"query,omitempty"`
+	Resource       string   `yaml:"resource,omitempty" json:"resource,omitempty"`
+	ScaleUpAbove   *float64 `yaml:"scale_up_above,omitempty" json:"scale_up_above,omitempty"`
+	ScaleDownBelow *float64 `yaml:"scale_down_below,omitempty" json:"scale_down_below,omitempty"`
+}
+
+// Source describes where the metric value comes from
+func (m MetricRule) Source() string {
+	if m.Query != "" {
+		return "promql"
+	}
+	return "hpa:" + m.Resource
+}
+
+// ServiceRule is the fully resolved rule for one service
+type ServiceRule struct {
+	Rule
+	Service string // Service name the rule applies to
+	Origin  string // "services.<name>", "defaults" or "built-in"
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+// builtinRule is used for every field neither the service rule nor the policy defaults set
+var builtinRule = Rule{
+	MinReplicas:       1,
+	MaxReplicas:       10,
+	ScaleUpStep:       2,
+	ScaleDownStep:     1,
+	ScaleUpCooldown:   3 * time.Minute,
+	ScaleDownCooldown: 5 * time.Minute,
+	EvaluationWindow:  5 * time.Minute,
+	Metrics: []MetricRule{
+		{Name: "cpu", Resource: "cpu", ScaleUpAbove: floatPtr(80), ScaleDownBelow: floatPtr(20)},
+		{Name: "memory", Resource: "memory", ScaleUpAbove: floatPtr(85), ScaleDownBelow: floatPtr(30)},
+	},
+}
+
+// DefaultPolicy returns the policy used when no policy file is configured
+func DefaultPolicy() *Policy {
+	return &Policy{Services: map[string]Rule{}}
+}
+
+// LoadPolicy reads and validates a policy file. YAML and JSON are accepted.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scaling policy: %w", err)
+	}
+
+	var policy Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to parse scaling policy %s: %w", path, err)
+	}
+	if policy.Services == nil {
+		policy.Services = map[string]Rule{}
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scaling policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
+
+// Validate checks the resolved rule of every service, and the defaults
+func (p *Policy) Validate() error {
+	names := make([]string, 0, len(p.Services))
+	for name := range p.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := p.RuleFor("").validate(); err != nil {
+		return fmt.Errorf("defaults: %w", err)
+	}
+	for _, name := range names {
+		if err := p.RuleFor(name).validate(); err != nil {
+			return fmt.Errorf("services.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// RuleFor resolves the rule of a service: service rule, then policy defaults, then built-in defaults
+func (p *Policy) RuleFor(service string) ServiceRule {
+	resolved := ServiceRule{Rule: builtinRule, Service: service, Origin: "built-in"}
+	if !isZeroRule(p.Defaults) {
+		resolved.Rule = mergeRule(resolved.Rule, p.Defaults)
+		resolved.Origin = "defaults"
+	}
+	if rule, ok := p.Services[service]; ok {
+		resolved.Rule = mergeRule(resolved.Rule, rule)
+		resolved.Origin = "services." + service
+	}
+	if resolved.Deployment == "" {
+		resolved.Deployment = service
+	}
+	return resolved
+}
+
+// ServiceNames returns the names of the services with an explicit rule
+func (p *Policy) ServiceNames() []string {
+	names := make([]string, 0, len(p.Services))
+	for name := range p.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func mergeRule(base, override Rule) Rule {
+	if override.Deployment != "" {
+		base.Deployment = override.Deployment
+	}
+	if override.Namespace != "" {
+		base.Namespace = override.Namespace
+	}
+	if override.MinReplicas != 0 {
+		base.MinReplicas = override.MinReplicas
+	}
+	if override.MaxReplicas != 0 {
+		base.MaxReplicas = override.MaxReplicas
+	}
+	if override.ScaleUpStep != 0 {
+		base.ScaleUpStep = override.ScaleUpStep
+	}
+	if override.ScaleDownStep != 0 {
+		base.ScaleDownStep = override.ScaleDownStep
+	}
+	if override.ScaleUpCooldown != 0 {
+		base.ScaleUpCooldown = override.ScaleUpCooldown
+	}
+	if override.ScaleDownCooldown != 0 {
+		base.ScaleDownCooldown = override.ScaleDownCooldown
+	}
+	if override.EvaluationWindow != 0 {
+		base.EvaluationWindow = override.EvaluationWindow
+	}
+	// Metric lists are replaced as a whole, merging them entry by entry would be ambiguous
+	if len(override.Metrics) > 0 {
+		base.Metrics = override.Metrics
+	}
+	return base
+}
+
+func isZeroRule(rule Rule) bool {
+	return rule.Deployment == "" && rule.Namespace == "" && rule.MinReplicas == 0 && rule.MaxReplicas == 0 &&
+		rule.ScaleUpStep == 0 && rule.ScaleDownStep == 0 && rule.ScaleUpCooldown == 0 &&
+		rule.ScaleDownCooldown == 0 && rule.EvaluationWindow == 0 && len(rule.Metrics) == 0
+}
+
+func (r ServiceRule) validate() error {
+	switch {
+	case r.MinReplicas < 1:
+		return fmt.Errorf("min_replicas must be at least 1")
+	case r.MaxReplicas < r.MinReplicas:
+		return fmt.Errorf("max_replicas (%d) must not be below min_replicas (%d)", r.MaxReplicas, r.MinReplicas)
+	case r.ScaleUpStep < 1 || r.ScaleDownStep < 1:
+		return fmt.Errorf("scale_up_step and scale_down_step must be at least 1")
+	case r.ScaleUpCooldown < 0 || r.ScaleDownCooldown < 0:
+		return fmt.Errorf("cooldowns must not be negative")
+	case r.EvaluationWindow <= 0:
+		return fmt.Errorf("evaluation_window must be positive")
+	}
+
+	seen := make(map[string]bool)
+	for i, metric := range r.Metrics {
+		if metric.Name == "" {
+			return fmt.Errorf("metrics[%d]: name is required", i)
+		}
+		if seen[metric.Name] {
+			return fmt.Errorf("metrics[%d]: duplicate metric name %q", i, metric.Name)
+		}
+		seen[metric.Name] = true
+
+		if (metric.Query == "") == (metric.Resource == "") {
+			return fmt.Errorf("metric %s: exactly one of query and resource must be set", metric.Name)
+		}
+		if metric.Resource != "" && metric.Resource != "cpu" && metric.Resource != "memory" {
+			return fmt.Errorf("metric %s: unsupported resource %q (cpu, memory)", metric.Name, metric.Resource)
+		}
+		if metric.ScaleUpAbove == nil && metric.ScaleDownBelow == nil {
+			return fmt.Errorf("metric %s: at least one of scale_up_above and scale_down_below is required", metric.Name)
+		}
+		if metric.ScaleUpAbove != nil && metric.ScaleDownBelow != nil && *metric.ScaleDownBelow >= *metric.ScaleUpAbove {
+			return fmt.Errorf("metric %s: scale_down_below (%g) must be below scale_up_above (%g)", metric.Name, *metric.ScaleDownBelow, *metric.ScaleUpAbove)
+		}
+	}
+	return nil
+}
+
+// QueryFor returns the PromQL query of a metric with the evaluation window filled in
+func (r ServiceRule) QueryFor(metric MetricRule) string {
+	return strings.ReplaceAll(metric.Query, WindowPlaceholder, formatWindow(r.EvaluationWindow))
+}
+
+// formatWindow renders a duration in PromQL syntax, e.g. 5m or 90s
+func formatWindow(window time.Duration) string {
+	switch {
+	case window%time.Hour == 0:
+		return fmt.Sprintf("%dh", window/time.Hour)
+	case window%time.Minute == 0:
+		return fmt.Sprintf("%dm", window/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", int(window.Seconds()))
+	}
+}
@@ -0,0 +1,168 @@
+package scaling
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeRule(t *testing.T) {
+	base := builtinRule
+	queue := []MetricRule{{Name: "queue", Query: "sum(queue_depth)", ScaleUpAbove: floatPtr(100)}}
+
+	tests := []struct {
+		name     string
+		override Rule
+		want     func(rule *Rule)
+	}{
+		{name: "zero override keeps everything", want: func(rule *Rule) {}},
+		{name: "scalar fields", override: Rule{Deployment: "api-v2", Namespace: "prod", MaxReplicas: 20, ScaleDownCooldown: time.Hour},
+			want: func(rule *Rule) {
+				rule.Deployment, rule.Namespace, rule.MaxReplicas, rule.ScaleDownCooldown = "api-v2", "prod", 20, time.Hour
+			}},
+		{name: "every field", override: Rule{MinReplicas: 3, MaxReplicas: 6, ScaleUpStep: 4, ScaleDownStep: 2,
+			ScaleUpCooldown: time.Second, ScaleDownCooldown: 2 * time.Second, EvaluationWindow: 90 * time.Second},
+			want: func(rule *Rule) {
+				rule.MinReplicas, rule.MaxReplicas, rule.ScaleUpStep, rule.ScaleDownStep = 3, 6, 4, 2
+				rule.ScaleUpCooldown, rule.ScaleDownCooldown, rule.EvaluationWindow = time.Second, 2*time.Second, 90*time.Second
+			}},
+		{name: "metrics are replaced as a whole", override: Rule{Metrics: queue},
+			want: func(rule *Rule) { rule.Metrics = queue }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := base
+			tt.want(&want)
+			if got := mergeRule(base, tt.override); !reflect.DeepEqual(got, want) {
+				t.Errorf("mergeRule =\n%+v\nwant\n%+v", got, want)
+			}
+		})
+	}
+	if len(builtinRule.Metrics) != 2 || builtinRule.Metrics[0].Name != "cpu" {
+		t.Errorf("merging modified the built-in rule: %+v", builtinRule.Metrics)
+	}
+}
+
+func TestRuleFor(t *testing.T) {
+	queue := []MetricRule{{Name: "queue", Query: "sum(queue_depth)", ScaleUpAbove: floatPtr(100)}}
+	policy := &Policy{
+		Defaults: Rule{Namespace: "prod", MaxReplicas: 6, ScaleUpCooldown: time.Minute},
+		Services: map[string]Rule{
+			"api":    {MinReplicas: 2},
+			"worker": {Deployment: "autosphere-worker", MaxReplicas: 30, Namespace: "jobs", Metrics: queue},
+		},
+	}
+
+	tests := []struct {
+		service    string
+		origin     string
+		deployment string
+		namespace  string
+		min, max   int
+		upCooldown time.Duration
+		metrics    string
+	}{
+		{service: "api", origin: "services.api", deployment: "api", namespace: "prod", min: 2, max: 6, upCooldown: time.Minute, metrics: "cpu,memory"},
+		{service: "worker", origin: "services.worker", deployment: "autosphere-worker", namespace: "jobs", min: 1, max: 30, upCooldown: time.Minute, metrics: "queue"},
+		{service: "unknown", origin: "defaults", deployment: "unknown", namespace: "prod", min: 1, max: 6, upCooldown: time.Minute, metrics: "cpu,memory"},
+	}
+	for _, tt := range tests {
+		rule := policy.RuleFor(tt.service)
+		var metrics []string
+		for _, metric := range rule.Metrics {
+			metrics = append(metrics, metric.Name)
+		}
+		got := []interface{}{rule.Origin, rule.Deployment, rule.Namespace, rule.MinReplicas, rule.MaxReplicas, rule.ScaleUpCooldown, strings.Join(metrics, ",")}
+		want := []interface{}{tt.origin, tt.deployment, tt.namespace, tt.min, tt.max, tt.upCooldown, tt.metrics}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("RuleFor(%s) = %v, want %v", tt.service, got, want)
+		}
+		if rule.Service != tt.service || rule.ScaleDownCooldown != builtinRule.ScaleDownCooldown {
+			t.Errorf("RuleFor(%s) = %+v", tt.service, rule)
+		}
+	}
+
+	builtin := DefaultPolicy().RuleFor("api")
+	if builtin.Origin != "built-in" || !reflect.DeepEqual(builtin.Rule, mergeRule(builtinRule, Rule{Deployment: "api"})) {
+		t.Errorf("default policy rule = %+v, want the built-in rule", builtin)
+	}
+	if names := strings.Join(policy.ServiceNames(), ","); names != "api,worker" {
+		t.Errorf("service names = %s", names)
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults Rule
+		service  Rule
+		want     string // Error substring, empty when valid
+	}{
+		{name: "built-in defaults"},
+		{name: "valid override", service: Rule{MinReplicas: 2, MaxReplicas: 4}},
+		{name: "max below min", service: Rule{MinReplicas: 5, MaxReplicas: 3}, want: "services.api: max_replicas (3) must not be below min_replicas (5)"},
+		{name: "max below inherited min", defaults: Rule{MinReplicas: 4}, service: Rule{MaxReplicas: 3}, want: "services.api: max_replicas (3) must not be below min_replicas (4)"},
+		{name: "invalid defaults", defaults: Rule{MinReplicas: 11}, want: "defaults: max_replicas (10) must not be below min_replicas (11)"},
+		{name: "negative min", service: Rule{MinReplicas: -1}, want: "min_replicas must be at least 1"},
+		{name: "negative step", service: Rule{ScaleDownStep: -1}, want: "scale_up_step and scale_down_step must be at least 1"},
+		{name: "negative cooldown", service: Rule{ScaleUpCooldown: -time.Second}, want: "cooldowns must not be negative"},
+		{name: "negative window", service: Rule{EvaluationWindow: -time.Minute}, want: "evaluation_window must be positive"},
+		{name: "unnamed metric", service: Rule{Metrics: []MetricRule{{Resource: "cpu", ScaleUpAbove: floatPtr(1)}}}, want: "metrics[0]: name is required"},
+		{name: "duplicate metric", service: Rule{Metrics: []MetricRule{
+			{Name: "cpu", Resource: "cpu", ScaleUpAbove: floatPtr(80)}, {Name: "cpu", Resource: "memory", ScaleUpAbove: floatPtr(80)},
+		}}, want: `metrics[1]: duplicate metric name "cpu"`},
+		{name: "query and resource", service: Rule{Metrics: []MetricRule{{Name: "cpu", Resource: "cpu", Query: "up", ScaleUpAbove: floatPtr(1)}}}, want: "exactly one of query and resource"},
+		{name: "neither query nor resource", service: Rule{Metrics: []MetricRule{{Name: "cpu", ScaleUpAbove: floatPtr(1)}}}, want: "exactly one of query and resource"},
+		{name: "unknown resource", service: Rule{Metrics: []MetricRule{{Name: "gpu", Resource: "gpu", ScaleUpAbove: floatPtr(1)}}}, want: `unsupported resource "gpu"`},
+		{name: "no threshold", service: Rule{Metrics: []MetricRule{{Name: "cpu", Resource: "cpu"}}}, want: "at least one of scale_up_above and scale_down_below"},
+		{name: "inverted thresholds", service: Rule{Metrics: []MetricRule{{Name: "cpu", Resource: "cpu", ScaleUpAbove: floatPtr(20), ScaleDownBelow: floatPtr(80)}}}, want: "scale_down_below (80) must be below scale_up_above (20)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &Policy{Defaults: tt.defaults, Services: map[string]Rule{"api": tt.service}}
+			err := policy.Validate()
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("Validate: %v", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("Validate error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	if _, err := LoadPolicy("../../scaling-policy.example.yaml"); err != nil {
+		t.Fatalf("example policy: %v", err)
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"unknown field": "defaults:\n  max_replica: 3\n",
+		"invalid rule":  "services:\n  api:\n    min_replicas: 4\n    max_replicas: 2\n",
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".yaml")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadPolicy(path); err == nil {
+			t.Errorf("%s: expected LoadPolicy to fail", name)
+		}
+	}
+}
+
+func TestQueryFor(t *testing.T) {
+	for window, want := range map[time.Duration]string{
+		5 * time.Minute:  "rate(http_requests_total[5m])",
+		2 * time.Hour:    "rate(http_requests_total[2h])",
+		90 * time.Second: "rate(http_requests_total[90s])",
+	} {
+		rule := ServiceRule{Rule: Rule{EvaluationWindow: window}}
+		if got := rule.QueryFor(MetricRule{Query: "rate(http_requests_total[{{window}}])"}); got != want {
+			t.Errorf("QueryFor with a %s window = %s, want %s", window, got, want)
+		}
+	}
+}
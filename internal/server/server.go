@@ -2,14 +2,24 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"strings"
 	"time"
 	
+	"github.com/NacerKH/autosphere-mcp-golang/internal/alertmanager"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/config"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/handlers"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/handlers/prompts"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/handlers/resources"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/health"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/kubernetes"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/prometheus"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/scaling"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/services"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -22,6 +32,8 @@ type MCPServer struct {
 	observabilityHandler *handlers.ObservabilityHandler
 	resourceHandler     *resources.ResourceHandler
 	promptsHandler      *prompts.PromptsHandler
+	healthMonitor       *health.Monitor
+	awxClient           *awx.Client
 }
 
 func NewMCPServer(cfg *config.Config) *MCPServer {
@@ -33,6 +45,9 @@ func NewMCPServer(cfg *config.Config) *MCPServer {
 		Token:    cfg.AWXToken,
 		Timeout:  120 * time.Second, // Increased to 2 minutes
 		Debug:    cfg.EnableDebug,   // Pass debug flag for conditional logging
+
+		PageSize:     cfg.AWXPageSize,
+		MaxListItems: cfg.AWXMaxListItems,
 	})
 	
 	// Test AWX connection if credentials are provided
@@ -47,11 +62,130 @@ func NewMCPServer(cfg *config.Config) *MCPServer {
 		log.Printf("⚠️  No AWX credentials provided. Use -awx-username/-awx-password or -awx-token flags")
 	}
 	
-	healthService := services.NewHealthService()
-	automationService := services.NewAutomationService(healthService, awxClient, cfg.AWXBaseURL)
+	// Autoscaling reads deployment and HPA state from Kubernetes
+	var kubeClient *kubernetes.Client
+	if cfg.KubeAPIServer != "" || cfg.Kubeconfig != "" || kubernetes.IsInCluster() {
+		client, err := kubernetes.NewClient(kubernetes.KubernetesConfig{
+			APIServer:  cfg.KubeAPIServer,
+			Token:      cfg.KubeToken,
+			CAFile:     cfg.KubeCAFile,
+			Insecure:   cfg.KubeInsecure,
+			Kubeconfig: cfg.Kubeconfig,
+			Namespace:  cfg.KubeNamespace,
+			Timeout:    30 * time.Second,
+			Debug:      cfg.EnableDebug,
+		})
+		if err != nil {
+			log.Printf("⚠️  Failed to configure Kubernetes client: %v", err)
+		} else {
+			kubeClient = client
+		}
+	} else {
+		log.Printf("⚠️  No Kubernetes access configured. Use -kubeconfig or -k8s-api-url to enable autoscaling")
+	}
+
+	// Observability tools are always registered; they report a clear error
+	// when no Prometheus or Alertmanager URL is configured
+	var prometheusClient *prometheus.PrometheusClient
+	if cfg.PrometheusURL != "" {
+		prometheusClient = prometheus.NewPrometheusClient(prometheus.PrometheusConfig{
+			BaseURL:  cfg.PrometheusURL,
+			Username: cfg.PrometheusUsername,
+			Password: cfg.PrometheusPassword,
+			Timeout:  30 * time.Second,
+			Debug:    cfg.EnableDebug,
+		})
+	} else {
+		log.Printf("⚠️  No Prometheus URL provided. Use -prometheus-url to enable metrics queries")
+	}
+
+	scalingPolicy := scaling.DefaultPolicy()
+	if cfg.ScalingPolicyFile != "" {
+		policy, err := scaling.LoadPolicy(cfg.ScalingPolicyFile)
+		if err != nil {
+			// Scaling without the intended guardrails is worse than not starting
+			log.Fatalf("Failed to load scaling policy: %v", err)
+		}
+		scalingPolicy = policy
+		log.Printf("✅ Loaded scaling policy %s (%d service rules)", cfg.ScalingPolicyFile, len(policy.Services))
+	}
+
+	// Component health is measured by the configured probes, or by probes of
+	// the backends above when no health configuration is given
+	healthConfig := health.DefaultConfig(health.Endpoints{
+		AWX:                  cfg.AWXBaseURL,
+		Prometheus:           cfg.PrometheusURL,
+		PrometheusUsername:   cfg.PrometheusUsername,
+		PrometheusPassword:   cfg.PrometheusPassword,
+		Alertmanager:         cfg.AlertmanagerURL,
+		AlertmanagerUsername: cfg.AlertmanagerUsername,
+		AlertmanagerPassword: cfg.AlertmanagerPassword,
+	})
+	if cfg.HealthConfigFile != "" {
+		loaded, err := health.LoadConfig(cfg.HealthConfigFile)
+		if err != nil {
+			log.Fatalf("Failed to load health config: %v", err)
+		}
+		healthConfig = loaded
+	}
+	healthChecker, err := health.NewChecker(healthConfig, health.NewRegistry(), health.Dependencies{
+		Prometheus: prometheusClient,
+		AWX:        awxClient,
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure health probes: %v", err)
+	}
+	log.Printf("✅ Health probes configured for components: %v", healthChecker.Components())
+	healthMonitor := health.NewMonitor(healthChecker, healthConfig.Monitor)
+	healthService := services.NewHealthService(healthChecker, healthMonitor)
+
+	var varSets awx.VarSets
+	if cfg.VarSetsFile != "" {
+		loaded, err := awx.LoadVarSets(cfg.VarSetsFile)
+		if err != nil {
+			log.Fatalf("Failed to load var-sets: %v", err)
+		}
+		varSets = loaded
+		log.Printf("✅ Loaded var-sets %s: %v", cfg.VarSetsFile, varSets.Names())
+	}
+
+	adHocPolicy := awx.DefaultAdHocPolicy()
+	if cfg.AdHocPolicyFile != "" {
+		loaded, err := awx.LoadAdHocPolicy(cfg.AdHocPolicyFile)
+		if err != nil {
+			// Falling back to another allowlist than the one configured is worse than not starting
+			log.Fatalf("Failed to load ad hoc policy: %v", err)
+		}
+		adHocPolicy = loaded
+		log.Printf("✅ Loaded ad hoc policy %s: %v", cfg.AdHocPolicyFile, adHocPolicy.ModuleNames())
+	}
+
+	automationService := services.NewAutomationService(healthService, awxClient, cfg.AWXBaseURL, varSets, adHocPolicy, kubeClient, services.AutoscaleSettings{
+		Mode:       cfg.AutoscaleMode,
+		Template:   cfg.AutoscaleTemplate,
+		Namespace:  cfg.KubeNamespace,
+		Policy:     scalingPolicy,
+		Prometheus: prometheusClient,
+	})
 	
 	automationHandler := handlers.NewAutomationHandler(automationService)
-	resourceHandler := resources.NewResourceHandler()
+
+	var alertmanagerClient *alertmanager.AlertmanagerClient
+	if cfg.AlertmanagerURL != "" {
+		alertmanagerClient = alertmanager.NewAlertmanagerClient(alertmanager.AlertmanagerConfig{
+			BaseURL:  cfg.AlertmanagerURL,
+			Username: cfg.AlertmanagerUsername,
+			Password: cfg.AlertmanagerPassword,
+			Timeout:  30 * time.Second,
+			Debug:    cfg.EnableDebug,
+		})
+	} else {
+		log.Printf("⚠️  No Alertmanager URL provided. Use -alertmanager-url to enable alert and silence tools")
+	}
+
+	observabilityService := services.NewObservabilityService(prometheusClient, alertmanagerClient)
+	observabilityHandler := handlers.NewObservabilityHandler(observabilityService)
+	resourceHandler := resources.NewResourceHandler(healthService)
 	promptsHandler := prompts.NewPromptsHandler()
 
 	
@@ -63,11 +197,14 @@ func NewMCPServer(cfg *config.Config) *MCPServer {
 	)
 	
 	mcpServer := &MCPServer{
-		server:            server,
-		config:            cfg,
-		automationHandler: automationHandler,
-		resourceHandler:   resourceHandler,
-		promptsHandler:    promptsHandler,
+		server:               server,
+		config:               cfg,
+		automationHandler:    automationHandler,
+		observabilityHandler: observabilityHandler,
+		resourceHandler:      resourceHandler,
+		promptsHandler:       promptsHandler,
+		healthMonitor:        healthMonitor,
+		awxClient:            awxClient,
 	}
 	
 	mcpServer.registerTools()
@@ -80,38 +217,75 @@ func NewMCPServer(cfg *config.Config) *MCPServer {
 func (s *MCPServer) registerTools() {
 	// Launch AWX Job Tool
 	launchAWXTool := mcp.NewTool("launch_awx_job",
-		mcp.WithDescription("Launch an AWX job template for Autosphere automation (deployment, scaling, health checks, backups)"),
+		mcp.WithDescription("Launch an AWX job template for Autosphere automation (deployment, scaling, health checks, backups). Fields other than job_template must be prompted on launch by the template"),
 		mcp.WithString("job_template", mcp.Required(), mcp.Description("The name or ID of the AWX job template")),
-		mcp.WithString("extra_vars", mcp.Description("Extra variables to pass to the job (JSON string)")),
+		mcp.WithString("extra_vars", mcp.Description("Extra variables to pass to the job as a JSON or YAML object; values keep their types (numbers, booleans, lists, nested objects). Survey answers are checked and converted to the survey types, see describe_job_template")),
+		mcp.WithString("var_sets", mcp.Description("Comma-separated server-side var-sets merged in order under extra_vars, see list_var_sets (optional)")),
 		mcp.WithString("inventory", mcp.Description("Inventory name or ID (optional)")),
 		mcp.WithString("limit", mcp.Description("Limit the job to specific hosts (optional)")),
 		mcp.WithString("tags", mcp.Description("Ansible tags to run (optional)")),
 		mcp.WithString("skip_tags", mcp.Description("Ansible tags to skip (optional)")),
+		mcp.WithString("credentials", mcp.Description("Comma-separated credential names or IDs replacing the template credentials (optional)")),
+		mcp.WithString("job_type", mcp.Description("run or check (optional)")),
+		mcp.WithString("verbosity", mcp.Description("Ansible verbosity from 0 (normal) to 5 (WinRM debug) (optional)")),
+		mcp.WithString("diff_mode", mcp.Description("true to show the changes made to files (optional)")),
+		mcp.WithString("forks", mcp.Description("Number of parallel processes (optional)")),
+		mcp.WithString("timeout", mcp.Description("Seconds the job may run, 0 for no limit (optional)")),
+		mcp.WithString("execution_environment", mcp.Description("Execution environment name or ID (optional)")),
+		mcp.WithString("labels", mcp.Description("Comma-separated label names or IDs (optional)")),
+		mcp.WithString("instance_groups", mcp.Description("Comma-separated instance group names or IDs (optional)")),
+		mcp.WithString("scm_branch", mcp.Description("Project branch, tag or commit to run (optional)")),
+		mcp.WithString("job_slice_count", mcp.Description("Number of slices to split the job into (optional)")),
 	)
 	s.server.AddTool(launchAWXTool, s.automationHandler.LaunchAWXJob)
 
+	// List Var-Sets Tool
+	listVarSetsTool := mcp.NewTool("list_var_sets",
+		mcp.WithDescription("List the server-side var-sets (named extra_vars such as per-environment defaults) that launch_awx_job and launch_awx_workflow can merge with var_sets"),
+	)
+	s.server.AddTool(listVarSetsTool, s.automationHandler.ListVarSets)
+
 	// Check AWX Job Status Tool
 	checkAWXTool := mcp.NewTool("check_awx_job",
-		mcp.WithDescription("Check the status of a running or completed AWX job"),
+		mcp.WithDescription("Check the status of a running or completed AWX job, including the per-host play recap and failing tasks"),
 		mcp.WithString("job_id", mcp.Required(), mcp.Description("The AWX job ID to check")),
 	)
 	s.server.AddTool(checkAWXTool, s.automationHandler.CheckAWXJobStatus)
 
+	// Wait For AWX Job Tool
+	waitAWXTool := mcp.NewTool("wait_for_awx_job",
+		mcp.WithDescription("Wait until an AWX job finishes (or a timeout expires), sending progress notifications, and return the final summary"),
+		mcp.WithString("job_id", mcp.Required(), mcp.Description("The AWX job ID to wait for")),
+		mcp.WithString("timeout", mcp.Description("Maximum time to wait in seconds (default: 600, max: 3600)")),
+		mcp.WithString("poll_interval", mcp.Description("Seconds between status checks (default: 10, min: 2)")),
+	)
+	s.server.AddTool(waitAWXTool, s.automationHandler.WaitForAWXJob)
+
 	// Health Check Tool
 	healthCheckTool := mcp.NewTool("health_check",
-		mcp.WithDescription("Perform comprehensive health checks on Autosphere components (API, database, cache, web, workers, monitoring)"),
-		mcp.WithString("component", mcp.Description("Specific component to check (api, database, cache, all)")),
-		mcp.WithString("deep", mcp.Description("Perform deep health checks (true/false)")),
+		mcp.WithDescription("Probe Autosphere components (HTTP, TCP, DNS, Prometheus, AWX job, Redis and PostgreSQL probes) and report measured latencies and errors"),
+		mcp.WithString("component", mcp.Description("Specific component to check (e.g., api, database, cache, monitoring, awx; default: all configured)")),
+		mcp.WithString("deep", mcp.Description("Also run deep probes such as database queries and cache set/get round trips (true/false)")),
 	)
 	s.server.AddTool(healthCheckTool, s.automationHandler.CheckAutosphereHealth)
 
+	// Health History Tool
+	healthHistoryTool := mcp.NewTool("get_health_history",
+		mcp.WithDescription("Report uptime percentages, state changes, flapping and recent samples from the background component health probes"),
+		mcp.WithString("component", mcp.Description("Component to report on (default: all)")),
+		mcp.WithString("window", mcp.Description("How far back to look, e.g. 1h, 24h or 7d (default: 24h, limited by the retained history)")),
+		mcp.WithString("samples", mcp.Description("Number of most recent samples to include per component (default: 10)")),
+	)
+	s.server.AddTool(healthHistoryTool, s.automationHandler.GetHealthHistory)
+
 	// Autoscale Tool
 	autoscaleTool := mcp.NewTool("autoscale",
-		mcp.WithDescription("Manage autoscaling of Autosphere services based on metrics and thresholds"),
+		mcp.WithDescription("Scale an Autosphere Kubernetes deployment within the scaling policy (min/max replicas, steps, cooldowns, metric thresholds), via the autoscale AWX template or a direct patch. Each decision is explained against the matching policy rule"),
 		mcp.WithString("action", mcp.Required(), mcp.Description("Autoscaling action (scale_up, scale_down, analyze, auto)")),
-		mcp.WithString("service", mcp.Description("Specific service to scale (optional)")),
+		mcp.WithString("service", mcp.Description("Service to scale, as named in the scaling policy (default: api)")),
+		mcp.WithString("namespace", mcp.Description("Kubernetes namespace of the deployment (default: configured namespace)")),
 		mcp.WithString("replicas", mcp.Description("Target number of replicas (for manual scaling)")),
-		mcp.WithString("threshold", mcp.Description("Scaling threshold (cpu_high, memory_high, load_high)")),
+		mcp.WithString("threshold", mcp.Description("Only evaluate this policy metric for analyze/auto (e.g., cpu_high, memory_high)")),
 	)
 	s.server.AddTool(autoscaleTool, s.automationHandler.AutoscaleAutosphere)
 
@@ -125,11 +299,31 @@ func (s *MCPServer) registerTools() {
 
 	// Get AWX Job Output Tool
 	getJobOutputTool := mcp.NewTool("get_job_output",
-		mcp.WithDescription("Get the output/logs of a specific AWX job"),
+		mcp.WithDescription("Get the output/logs of a specific AWX job, with line ranges, tail, regex grep and a byte cap with continuation cursor"),
 		mcp.WithString("job_id", mcp.Required(), mcp.Description("The AWX job ID to get output for")),
+		mcp.WithString("format", mcp.Description("Output format requested from AWX: txt, ansi or json (default: txt)")),
+		mcp.WithString("start_line", mcp.Description("First line to return, 0-based (optional)")),
+		mcp.WithString("end_line", mcp.Description("Line to stop at, exclusive (optional)")),
+		mcp.WithString("tail", mcp.Description("Only return the last N lines (optional)")),
+		mcp.WithString("grep", mcp.Description("Only return lines matching this regular expression (optional)")),
+		mcp.WithString("context_lines", mcp.Description("Lines of context around each grep match (default: 0)")),
+		mcp.WithString("strip_ansi", mcp.Description("Remove ANSI color codes (true/false, default: true)")),
+		mcp.WithString("max_bytes", mcp.Description("Maximum bytes of output to return (default: 65536)")),
+		mcp.WithString("cursor", mcp.Description("Continuation cursor returned by a previous truncated call (optional)")),
 	)
 	s.server.AddTool(getJobOutputTool, s.automationHandler.GetAWXJobOutput)
 
+	// Get AWX Job Events Tool
+	getJobEventsTool := mcp.NewTool("get_job_events",
+		mcp.WithDescription("Get the Ansible events of an AWX job (task results per host), optionally filtered by host, event type or failures"),
+		mcp.WithString("job_id", mcp.Required(), mcp.Description("The AWX job ID to get events for")),
+		mcp.WithString("host", mcp.Description("Only return events for this host (optional)")),
+		mcp.WithString("event_type", mcp.Description("Only return events of this type, e.g. runner_on_failed, runner_on_ok, runner_on_unreachable (optional)")),
+		mcp.WithString("failed_only", mcp.Description("Only return failed events (true/false)")),
+		mcp.WithString("limit", mcp.Description("Maximum number of events to return (default: 50)")),
+	)
+	s.server.AddTool(getJobEventsTool, s.automationHandler.GetAWXJobEvents)
+
 	// Cancel AWX Job Tool
 	cancelJobTool := mcp.NewTool("cancel_awx_job",
 		mcp.WithDescription("Cancel a running AWX job"),
@@ -137,6 +331,47 @@ func (s *MCPServer) registerTools() {
 	)
 	s.server.AddTool(cancelJobTool, s.automationHandler.CancelAWXJob)
 
+	// Relaunch AWX Job Tool
+	relaunchJobTool := mcp.NewTool("relaunch_awx_job",
+		mcp.WithDescription("Run an AWX job again, on all its hosts or only on those that failed, optionally with changed extra_vars. The output links the new job to the original with the retry chain"),
+		mcp.WithString("job_id", mcp.Required(), mcp.Description("The AWX job ID to relaunch")),
+		mcp.WithString("hosts", mcp.Description("all or failed (default: all)")),
+		mcp.WithString("extra_vars", mcp.Description("Extra variables overriding those the job ran with, as a JSON or YAML object (optional; the template must prompt for variables)")),
+		mcp.WithString("credential_passwords", mcp.Description("Answers to credential password prompts as a JSON object, e.g. {\"ssh_password\": \"...\"} (optional)")),
+	)
+	s.server.AddTool(relaunchJobTool, s.automationHandler.RelaunchAWXJob)
+
+	// Analyze Job History Tool
+	analyzeJobHistoryTool := mcp.NewTool("analyze_job_history",
+		mcp.WithDescription("Analyze AWX job history over a time window: per-template success and failure rates, p50/p95 duration and MTTR, flaky templates with alternating outcomes and hosts that fail repeatedly"),
+		mcp.WithString("days", mcp.Description("How many days of history to analyze (default: 7, max: 90)")),
+		mcp.WithString("job_template", mcp.Description("Only jobs of this job template, by name or ID (optional)")),
+		mcp.WithString("format", mcp.Description("markdown for a summary followed by the full JSON, or json for the JSON alone (default: markdown)")),
+	)
+	s.server.AddTool(analyzeJobHistoryTool, s.automationHandler.AnalyzeJobHistory)
+
+	// Run Ad Hoc Command Tool
+	runAdHocCommand := mcp.NewTool("run_ad_hoc_command",
+		mcp.WithDescription("Run a single Ansible module against inventory hosts for quick triage, like ansible -m shell -a 'docker ps'. Only modules and arguments in the server allowlist run (list_ad_hoc_modules); the returned job ID works with check_awx_job, wait_for_awx_job, get_job_output, get_job_events and cancel_awx_job"),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+		mcp.WithString("module_name", mcp.Required(), mcp.Description("Ansible module to run (e.g., ping, setup, command, shell)")),
+		mcp.WithString("module_args", mcp.Description("Module arguments, e.g. the command line for command and shell (optional)")),
+		mcp.WithString("limit", mcp.Description("Hosts or groups to run against, Ansible pattern syntax. Required unless the policy allows whole-inventory runs, which also covers all, wildcard and ~regex patterns")),
+		mcp.WithString("credential", mcp.Description("Machine credential name or ID (optional)")),
+		mcp.WithString("become", mcp.Description("true to run with privilege escalation, if the policy allows it (default: false)")),
+		mcp.WithString("forks", mcp.Description("Number of hosts to run on in parallel (optional, capped by the policy)")),
+		mcp.WithString("wait", mcp.Description("true to wait for the command and return its recap and output (default: false)")),
+		mcp.WithString("timeout", mcp.Description("Maximum time to wait in seconds (default: 600, max: 3600)")),
+		mcp.WithString("poll_interval", mcp.Description("Seconds between status checks (default: 10, min: 2)")),
+	)
+	s.server.AddTool(runAdHocCommand, s.automationHandler.RunAdHocCommand)
+
+	// List Ad Hoc Modules Tool
+	listAdHocModules := mcp.NewTool("list_ad_hoc_modules",
+		mcp.WithDescription("List the modules and argument patterns run_ad_hoc_command allows on this server"),
+	)
+	s.server.AddTool(listAdHocModules, s.automationHandler.ListAdHocModules)
+
 	// List AWX Resources Tool
 	listResourcesTool := mcp.NewTool("list_awx_resources",
 		mcp.WithDescription("List AWX resources (job templates, inventories, projects)"),
@@ -150,24 +385,398 @@ func (s *MCPServer) registerTools() {
 	)
 	s.server.AddTool(listJobTemplates, s.automationHandler.ListJobTemplates)
 
+	// Describe Job Template Tool
+	describeJobTemplate := mcp.NewTool("describe_job_template",
+		mcp.WithDescription("Show what launching a job template takes: the fields it prompts for with their defaults, its survey and the JSON schema extra_vars must match"),
+		mcp.WithString("job_template", mcp.Required(), mcp.Description("The name or ID of the AWX job template")),
+	)
+	s.server.AddTool(describeJobTemplate, s.automationHandler.DescribeJobTemplate)
+
 	// Create Job Template Tool
 	createJobTemplate := mcp.NewTool("create_job_template",
 		mcp.WithDescription("Create a new AWX job template"),
 		mcp.WithString("name", mcp.Required(), mcp.Description("Template name")),
-		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory ID (integer)")),
-		mcp.WithString("project", mcp.Required(), mcp.Description("Project ID (integer)")),
-		mcp.WithString("playbook", mcp.Required(), mcp.Description("Playbook path (e.g., site.yml, deploy.yml)")),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+		mcp.WithString("project", mcp.Required(), mcp.Description("Project name or ID")),
+		mcp.WithString("playbook", mcp.Required(), mcp.Description("Playbook path (e.g., site.yml, deploy.yml); must be listed by list_project_playbooks")),
 		mcp.WithString("description", mcp.Description("Template description (optional)")),
 		mcp.WithString("job_type", mcp.Description("Job type: run or check (default: run)")),
 		mcp.WithString("verbosity", mcp.Description("Playbook verbosity level 0-5 (default: 0)")),
 	)
 	s.server.AddTool(createJobTemplate, s.automationHandler.CreateJobTemplate)
 
+	// Update Job Template Tool
+	updateOptions := []mcp.ToolOption{
+		mcp.WithDescription("Change the given fields of an AWX job template; fields left out keep their values. Also attaches or detaches credentials and labels"),
+		mcp.WithString("job_template", mcp.Required(), mcp.Description("The name or ID of the AWX job template")),
+		mcp.WithString("name", mcp.Description("New template name (optional)")),
+		mcp.WithString("description", mcp.Description("New description, empty to clear (optional)")),
+		mcp.WithString("job_type", mcp.Description("run or check (optional)")),
+		mcp.WithString("inventory", mcp.Description("Inventory name or ID (optional)")),
+		mcp.WithString("project", mcp.Description("Project name or ID (optional)")),
+		mcp.WithString("playbook", mcp.Description("Playbook path in the project (optional)")),
+		mcp.WithString("scm_branch", mcp.Description("Project branch, tag or commit (optional)")),
+		mcp.WithString("limit", mcp.Description("Host pattern, empty to clear (optional)")),
+		mcp.WithString("tags", mcp.Description("Ansible tags to run, empty to clear (optional)")),
+		mcp.WithString("skip_tags", mcp.Description("Ansible tags to skip, empty to clear (optional)")),
+		mcp.WithString("extra_vars", mcp.Description("Template variables as a JSON or YAML object, replacing the current ones; {} clears them (optional)")),
+		mcp.WithString("forks", mcp.Description("Number of parallel processes, 0 for the AWX default (optional)")),
+		mcp.WithString("verbosity", mcp.Description("Ansible verbosity from 0 (normal) to 5 (WinRM debug) (optional)")),
+		mcp.WithString("timeout", mcp.Description("Seconds the job may run, 0 for no limit (optional)")),
+		mcp.WithString("job_slice_count", mcp.Description("Number of slices to split the job into (optional)")),
+		mcp.WithString("diff_mode", mcp.Description("true to show the changes made to files (optional)")),
+		mcp.WithString("allow_simultaneous", mcp.Description("true to allow concurrent jobs of the template (optional)")),
+		mcp.WithString("survey_enabled", mcp.Description("true to enable the template survey (optional)")),
+		mcp.WithString("execution_environment", mcp.Description("Execution environment name or ID (optional)")),
+		mcp.WithString("add_credentials", mcp.Description("Comma-separated credential names or IDs to attach (optional)")),
+		mcp.WithString("remove_credentials", mcp.Description("Comma-separated credential names or IDs to detach (optional)")),
+		mcp.WithString("add_labels", mcp.Description("Comma-separated label names or IDs to attach; unknown names are created (optional)")),
+		mcp.WithString("remove_labels", mcp.Description("Comma-separated label names or IDs to detach (optional)")),
+	}
+	for _, flag := range awx.AskOnLaunchFlags() {
+		prompt := strings.TrimSuffix(strings.TrimPrefix(flag, "ask_"), "_on_launch")
+		updateOptions = append(updateOptions, mcp.WithString(flag,
+			mcp.Description(fmt.Sprintf("true to prompt for %s on launch, false to stop prompting (optional)", prompt))))
+	}
+	updateJobTemplate := mcp.NewTool("update_job_template", updateOptions...)
+	s.server.AddTool(updateJobTemplate, s.automationHandler.UpdateJobTemplate)
+
+	// Copy Job Template Tool
+	copyJobTemplate := mcp.NewTool("copy_job_template",
+		mcp.WithDescription("Copy an AWX job template, survey and credentials included, under a new name"),
+		mcp.WithString("job_template", mcp.Required(), mcp.Description("The name or ID of the AWX job template to copy")),
+		mcp.WithString("name", mcp.Description("Name of the copy (default: '<name> (copy)')")),
+	)
+	s.server.AddTool(copyJobTemplate, s.automationHandler.CopyJobTemplate)
+
+	// Delete Job Template Tool
+	deleteJobTemplate := mcp.NewTool("delete_job_template",
+		mcp.WithDescription("Delete an AWX job template. The first call shows the template and its recent jobs and returns a confirmation token; call again with the token to delete"),
+		mcp.WithString("job_template", mcp.Required(), mcp.Description("The name or ID of the AWX job template")),
+		mcp.WithString("confirmation_token", mcp.Description("Token from the first call, valid for 5 minutes (optional)")),
+	)
+	s.server.AddTool(deleteJobTemplate, s.automationHandler.DeleteJobTemplate)
+
+	// List Inventory Hosts Tool
+	listInventoryHosts := mcp.NewTool("list_inventory_hosts",
+		mcp.WithDescription("List the hosts of an AWX inventory, or of one of its groups, with their enabled state, groups and last job status"),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+		mcp.WithString("group", mcp.Description("Only hosts of this group, by name or ID (optional)")),
+		mcp.WithString("search", mcp.Description("Text to find in host names and descriptions (optional)")),
+		mcp.WithString("limit", mcp.Description("Maximum number of hosts to return (default: 200)")),
+	)
+	s.server.AddTool(listInventoryHosts, s.automationHandler.ListInventoryHosts)
+
+	// List Inventory Groups Tool
+	listInventoryGroups := mcp.NewTool("list_inventory_groups",
+		mcp.WithDescription("List the groups of an AWX inventory with their variables"),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+	)
+	s.server.AddTool(listInventoryGroups, s.automationHandler.ListInventoryGroups)
+
+	// Get Inventory Host Tool
+	getInventoryHost := mcp.NewTool("get_inventory_host",
+		mcp.WithDescription("Show an inventory host with all its groups and variables and, when asked, the Ansible facts gathered for it"),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+		mcp.WithString("host", mcp.Required(), mcp.Description("Host name or ID")),
+		mcp.WithString("facts", mcp.Description("true to include every gathered fact (optional)")),
+		mcp.WithString("fact_names", mcp.Description("Comma-separated facts to include; a trailing * matches a prefix, e.g. ansible_default_ipv4,ansible_distribution* (optional)")),
+	)
+	s.server.AddTool(getInventoryHost, s.automationHandler.GetInventoryHost)
+
+	// Add Inventory Host Tool
+	addInventoryHost := mcp.NewTool("add_inventory_host",
+		mcp.WithDescription("Add a host to an AWX inventory, optionally with variables and group memberships"),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Host name or address")),
+		mcp.WithString("description", mcp.Description("Host description (optional)")),
+		mcp.WithString("variables", mcp.Description("Host variables as a JSON or YAML object (optional)")),
+		mcp.WithString("groups", mcp.Description("Comma-separated group names or IDs to add the host to (optional)")),
+		mcp.WithString("enabled", mcp.Description("false to add the host disabled (default: true)")),
+	)
+	s.server.AddTool(addInventoryHost, s.automationHandler.AddInventoryHost)
+
+	// Update Inventory Host Tool
+	updateInventoryHost := mcp.NewTool("update_inventory_host",
+		mcp.WithDescription("Enable or disable an inventory host, edit its variables, or move it in and out of groups (e.g. take a broken node out of a group before re-running a playbook)"),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+		mcp.WithString("host", mcp.Required(), mcp.Description("Host name or ID")),
+		mcp.WithString("name", mcp.Description("New host name (optional)")),
+		mcp.WithString("description", mcp.Description("Host description, empty to clear (optional)")),
+		mcp.WithString("enabled", mcp.Description("false to disable the host so jobs skip it, true to enable it (optional)")),
+		mcp.WithString("variables", mcp.Description("Variables as a JSON or YAML object, merged into the host variables (optional)")),
+		mcp.WithString("remove_variables", mcp.Description("Comma-separated top-level variables to drop (optional)")),
+		mcp.WithString("replace_variables", mcp.Description("true to replace the host variables with variables instead of merging; without variables this clears them and needs a confirmation token (optional)")),
+		mcp.WithString("add_groups", mcp.Description("Comma-separated group names or IDs to add the host to (optional)")),
+		mcp.WithString("remove_groups", mcp.Description("Comma-separated group names or IDs to take the host out of; the host stays in the inventory (optional)")),
+		mcp.WithString("confirmation_token", mcp.Description("Token from a first call clearing the variables, valid for 5 minutes (optional)")),
+	)
+	s.server.AddTool(updateInventoryHost, s.automationHandler.UpdateInventoryHost)
+
+	// Remove Inventory Host Tool
+	removeInventoryHost := mcp.NewTool("remove_inventory_host",
+		mcp.WithDescription("Delete a host from an AWX inventory. The first call shows the host and returns a confirmation token; call again with the token to delete"),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+		mcp.WithString("host", mcp.Required(), mcp.Description("Host name or ID")),
+		mcp.WithString("confirmation_token", mcp.Description("Token from the first call, valid for 5 minutes (optional)")),
+	)
+	s.server.AddTool(removeInventoryHost, s.automationHandler.RemoveInventoryHost)
+
+	// Update Inventory Group Tool
+	updateInventoryGroup := mcp.NewTool("update_inventory_group",
+		mcp.WithDescription("Rename or describe an inventory group and edit its variables"),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+		mcp.WithString("group", mcp.Required(), mcp.Description("Group name or ID")),
+		mcp.WithString("name", mcp.Description("New group name (optional)")),
+		mcp.WithString("description", mcp.Description("Group description, empty to clear (optional)")),
+		mcp.WithString("variables", mcp.Description("Variables as a JSON or YAML object, merged into the group variables (optional)")),
+		mcp.WithString("remove_variables", mcp.Description("Comma-separated top-level variables to drop (optional)")),
+		mcp.WithString("replace_variables", mcp.Description("true to replace the group variables with variables instead of merging; without variables this clears them and needs a confirmation token (optional)")),
+		mcp.WithString("confirmation_token", mcp.Description("Token from a first call clearing the variables, valid for 5 minutes (optional)")),
+	)
+	s.server.AddTool(updateInventoryGroup, s.automationHandler.UpdateInventoryGroup)
+
+	// List Inventory Sources Tool
+	listInventorySources := mcp.NewTool("list_inventory_sources",
+		mcp.WithDescription("List the sources an AWX inventory syncs from, with the status of their last sync"),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+	)
+	s.server.AddTool(listInventorySources, s.automationHandler.ListInventorySources)
+
+	// Sync Inventory Source Tool
+	syncInventorySource := mcp.NewTool("sync_inventory_source",
+		mcp.WithDescription("Sync an inventory source, or every source of an inventory, and optionally wait for the syncs with progress notifications"),
+		mcp.WithString("inventory", mcp.Required(), mcp.Description("Inventory name or ID")),
+		mcp.WithString("source", mcp.Description("Source name or ID (default: every source of the inventory)")),
+		mcp.WithString("wait", mcp.Description("true to wait until the syncs finish (optional)")),
+		mcp.WithString("timeout", mcp.Description("Maximum time to wait in seconds (default: 600, max: 3600)")),
+		mcp.WithString("poll_interval", mcp.Description("Seconds between status checks (default: 10, min: 2)")),
+	)
+	s.server.AddTool(syncInventorySource, s.automationHandler.SyncInventorySource)
+
+	// Check Inventory Sync Tool
+	checkInventorySync := mcp.NewTool("check_inventory_sync",
+		mcp.WithDescription("Check the status of inventory syncs started by sync_inventory_source"),
+		mcp.WithString("update_ids", mcp.Required(), mcp.Description("Comma-separated inventory update IDs")),
+	)
+	s.server.AddTool(checkInventorySync, s.automationHandler.CheckInventorySync)
+
+	// List Project Playbooks Tool
+	listProjectPlaybooks := mcp.NewTool("list_project_playbooks",
+		mcp.WithDescription("List the playbooks an AWX project exposes to job templates, as found by its last SCM sync"),
+		mcp.WithString("project", mcp.Required(), mcp.Description("Project name or ID")),
+	)
+	s.server.AddTool(listProjectPlaybooks, s.automationHandler.ListProjectPlaybooks)
+
+	// Sync Project Tool
+	syncProject := mcp.NewTool("sync_project",
+		mcp.WithDescription("Run an SCM update of an AWX project so it picks up pushed playbook changes, optionally waiting for it with progress notifications"),
+		mcp.WithString("project", mcp.Required(), mcp.Description("Project name or ID")),
+		mcp.WithString("wait", mcp.Description("true to wait until the sync finishes and list the playbooks it found (optional)")),
+		mcp.WithString("timeout", mcp.Description("Maximum time to wait in seconds (default: 600, max: 3600)")),
+		mcp.WithString("poll_interval", mcp.Description("Seconds between status checks (default: 10, min: 2)")),
+	)
+	s.server.AddTool(syncProject, s.automationHandler.SyncProject)
+
+	// Check Project Sync Tool
+	checkProjectSync := mcp.NewTool("check_project_sync",
+		mcp.WithDescription("Check the status of a project sync started by sync_project"),
+		mcp.WithString("sync_id", mcp.Required(), mcp.Description("Project update ID returned by sync_project")),
+	)
+	s.server.AddTool(checkProjectSync, s.automationHandler.CheckProjectSync)
+
+	// Create Project Tool
+	createProject := mcp.NewTool("create_project",
+		mcp.WithDescription("Create an AWX project from a source control repository; AWX syncs it right away"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Project name")),
+		mcp.WithString("organization", mcp.Required(), mcp.Description("Organization name or ID")),
+		mcp.WithString("scm_url", mcp.Description("Repository URL (required unless scm_type is manual)")),
+		mcp.WithString("scm_type", mcp.Description("git, svn, insights, archive or manual (default: git)")),
+		mcp.WithString("scm_branch", mcp.Description("Branch, tag or commit to check out (optional)")),
+		mcp.WithString("credential", mcp.Description("Source control credential name or ID (optional)")),
+		mcp.WithString("update_on_launch", mcp.Description("true to sync the project before every job (optional)")),
+		mcp.WithString("allow_override", mcp.Description("true to let job templates pick another branch (optional)")),
+		mcp.WithString("description", mcp.Description("Project description (optional)")),
+	)
+	s.server.AddTool(createProject, s.automationHandler.CreateProject)
+
+	// Update Project Tool
+	updateProject := mcp.NewTool("update_project",
+		mcp.WithDescription("Change the given fields of an AWX project; fields left out keep their values"),
+		mcp.WithString("project", mcp.Required(), mcp.Description("Project name or ID")),
+		mcp.WithString("name", mcp.Description("New project name (optional)")),
+		mcp.WithString("description", mcp.Description("Project description, empty to clear (optional)")),
+		mcp.WithString("scm_type", mcp.Description("git, svn, insights, archive or manual (optional)")),
+		mcp.WithString("scm_url", mcp.Description("Repository URL (optional)")),
+		mcp.WithString("scm_branch", mcp.Description("Branch, tag or commit to check out, empty for the default branch (optional)")),
+		mcp.WithString("credential", mcp.Description("Source control credential name or ID (optional)")),
+		mcp.WithString("update_on_launch", mcp.Description("true to sync the project before every job (optional)")),
+		mcp.WithString("allow_override", mcp.Description("true to let job templates pick another branch (optional)")),
+	)
+	s.server.AddTool(updateProject, s.automationHandler.UpdateProject)
+
+	// List Workflow Templates Tool
+	listWorkflowTemplates := mcp.NewTool("list_workflow_templates",
+		mcp.WithDescription("List all AWX workflow job templates"),
+	)
+	s.server.AddTool(listWorkflowTemplates, s.automationHandler.ListWorkflowTemplates)
+
+	// Launch AWX Workflow Tool
+	launchWorkflowTool := mcp.NewTool("launch_awx_workflow",
+		mcp.WithDescription("Launch an AWX workflow job template (multi-step remediation flows such as the RabbitMQ cascade fix)"),
+		mcp.WithString("workflow_template", mcp.Required(), mcp.Description("The name or ID of the AWX workflow job template")),
+		mcp.WithString("extra_vars", mcp.Description("Extra variables to pass to the workflow as a JSON or YAML object")),
+		mcp.WithString("var_sets", mcp.Description("Comma-separated server-side var-sets merged in order under extra_vars, see list_var_sets (optional)")),
+		mcp.WithString("inventory", mcp.Description("Inventory name or ID (optional)")),
+		mcp.WithString("limit", mcp.Description("Limit the workflow to specific hosts (optional)")),
+	)
+	s.server.AddTool(launchWorkflowTool, s.automationHandler.LaunchAWXWorkflow)
+
+	// Check AWX Workflow Status Tool
+	checkWorkflowTool := mcp.NewTool("check_awx_workflow",
+		mcp.WithDescription("Show the node graph of an AWX workflow job with per-node job status and pending approvals"),
+		mcp.WithString("workflow_job_id", mcp.Required(), mcp.Description("The AWX workflow job ID to check")),
+	)
+	s.server.AddTool(checkWorkflowTool, s.automationHandler.CheckAWXWorkflowStatus)
+
+	// Workflow Approval Tool
+	workflowApprovalTool := mcp.NewTool("approve_workflow_step",
+		mcp.WithDescription("Approve or deny a pending AWX workflow approval node"),
+		mcp.WithString("approval_id", mcp.Required(), mcp.Description("The workflow approval ID (listed under pending approvals by check_awx_workflow)")),
+		mcp.WithString("action", mcp.Required(), mcp.Description("Decision to take (approve, deny)")),
+	)
+	s.server.AddTool(workflowApprovalTool, s.automationHandler.DecideWorkflowApproval)
+
+	// List Schedules Tool
+	listSchedulesTool := mcp.NewTool("list_schedules",
+		mcp.WithDescription("List AWX schedules with their rule, template and next run, ordered by next run"),
+		mcp.WithString("job_template", mcp.Description("Only schedules of this job template, by name or ID (optional)")),
+		mcp.WithString("workflow_template", mcp.Description("Only schedules of this workflow job template, by name or ID (optional)")),
+		mcp.WithString("enabled", mcp.Description("true for enabled schedules only, false for disabled ones only (optional)")),
+	)
+	s.server.AddTool(listSchedulesTool, s.automationHandler.ListSchedules)
+
+	// Create Schedule Tool
+	createScheduleTool := mcp.NewTool("create_schedule",
+		mcp.WithDescription("Schedule a job template or workflow on an RRULE. The rule is validated before AWX is called and the next runs are shown"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Schedule name")),
+		mcp.WithString("rrule", mcp.Required(), mcp.Description("Schedule rule with DTSTART and RRULE, e.g. DTSTART;TZID=Europe/Paris:20250101T020000 RRULE:FREQ=DAILY;INTERVAL=1")),
+		mcp.WithString("job_template", mcp.Description("Job template to schedule, by name or ID (give this or workflow_template)")),
+		mcp.WithString("workflow_template", mcp.Description("Workflow job template to schedule, by name or ID (give this or job_template)")),
+		mcp.WithString("description", mcp.Description("Schedule description (optional)")),
+		mcp.WithString("enabled", mcp.Description("Whether the schedule runs (default: true)")),
+		mcp.WithString("extra_data", mcp.Description("Extra variables for the scheduled jobs as a JSON or YAML object; the template must prompt for variables (optional)")),
+	)
+	s.server.AddTool(createScheduleTool, s.automationHandler.CreateSchedule)
+
+	// Update Schedule Tool
+	updateScheduleTool := mcp.NewTool("update_schedule",
+		mcp.WithDescription("Change the name, description, rule, enabled state or extra variables of an AWX schedule; only the given fields change"),
+		mcp.WithString("schedule", mcp.Required(), mcp.Description("Schedule name or ID")),
+		mcp.WithString("name", mcp.Description("New schedule name")),
+		mcp.WithString("description", mcp.Description("Schedule description")),
+		mcp.WithString("rrule", mcp.Description("New schedule rule, e.g. DTSTART;TZID=Europe/Paris:20250101T020000 RRULE:FREQ=DAILY;INTERVAL=1")),
+		mcp.WithString("enabled", mcp.Description("Whether the schedule runs (true/false)")),
+		mcp.WithString("extra_data", mcp.Description("Extra variables for the scheduled jobs as a JSON or YAML object")),
+	)
+	s.server.AddTool(updateScheduleTool, s.automationHandler.UpdateSchedule)
+
+	// Set Schedule Enabled Tool
+	setScheduleEnabledTool := mcp.NewTool("set_schedule_enabled",
+		mcp.WithDescription("Enable or disable an AWX schedule, e.g. to pause a nightly run during maintenance"),
+		mcp.WithString("schedule", mcp.Required(), mcp.Description("Schedule name or ID")),
+		mcp.WithString("enabled", mcp.Required(), mcp.Description("true to enable the schedule, false to disable it")),
+	)
+	s.server.AddTool(setScheduleEnabledTool, s.automationHandler.SetScheduleEnabled)
+
+	// Delete Schedule Tool
+	deleteScheduleTool := mcp.NewTool("delete_schedule",
+		mcp.WithDescription("Delete an AWX schedule. The first call returns a confirmation token; call again with it to delete"),
+		mcp.WithString("schedule", mcp.Required(), mcp.Description("Schedule name or ID")),
+		mcp.WithString("confirmation_token", mcp.Description("Token returned by a first call without it")),
+	)
+	s.server.AddTool(deleteScheduleTool, s.automationHandler.DeleteSchedule)
+
+	// Preview Schedule Tool
+	previewScheduleTool := mcp.NewTool("preview_schedule",
+		mcp.WithDescription("Validate a schedule rule and show its next runs in a chosen time zone, or preview an existing schedule"),
+		mcp.WithString("rrule", mcp.Description("Schedule rule to preview, e.g. DTSTART;TZID=Europe/Paris:20250101T020000 RRULE:FREQ=DAILY;INTERVAL=1")),
+		mcp.WithString("schedule", mcp.Description("Existing schedule to preview, by name or ID, instead of rrule")),
+		mcp.WithString("count", mcp.Description("Number of runs to show (default: 10, max: 100)")),
+		mcp.WithString("time_zone", mcp.Description("IANA time zone to show the runs in, e.g. America/New_York (default: the zone of the rule)")),
+	)
+	s.server.AddTool(previewScheduleTool, s.automationHandler.PreviewSchedule)
+
+	// Upcoming Schedule Runs Tool
+	upcomingRunsTool := mcp.NewTool("get_upcoming_schedule_runs",
+		mcp.WithDescription("List the runs of every enabled AWX schedule in the coming hours, earliest first, to plan maintenance that does not clash with them"),
+		mcp.WithString("hours", mcp.Description("How far ahead to look (default: 24, max: 168)")),
+		mcp.WithString("time_zone", mcp.Description("IANA time zone to show the runs in (default: UTC)")),
+	)
+	s.server.AddTool(upcomingRunsTool, s.automationHandler.GetUpcomingScheduleRuns)
+
 	// Get Cache Statistics Tool
 	getCacheStats := mcp.NewTool("get_cache_stats",
 		mcp.WithDescription("Get cache performance statistics and hit rates"),
 	)
 	s.server.AddTool(getCacheStats, s.automationHandler.GetCacheStats)
+
+	s.registerObservabilityTools()
+}
+
+func (s *MCPServer) registerObservabilityTools() {
+	// Query Prometheus Tool
+	queryPrometheusTool := mcp.NewTool("query_prometheus",
+		mcp.WithDescription("Execute a PromQL query against Prometheus. Instant query by default; range query (with per-series min/max/avg/last/rate statistics) when start_time, end_time or step is given"),
+		mcp.WithString("query", mcp.Required(), mcp.Description("PromQL query to execute")),
+		mcp.WithString("start_time", mcp.Description("Start time for range queries: RFC3339, unix seconds, or relative such as -1h, -7d or -3600 seconds (default: 1h before end_time)")),
+		mcp.WithString("end_time", mcp.Description("End time for range queries: same formats as start_time (default: now)")),
+		mcp.WithString("step", mcp.Description("Step size for range queries (e.g., 5m, 1h, or 60 for seconds; default: chosen to fit max_points)")),
+		mcp.WithString("max_points", mcp.Description("Maximum samples returned per series; longer series are downsampled (default: 120, max: 1000)")),
+	)
+	s.server.AddTool(queryPrometheusTool, s.observabilityHandler.QueryPrometheus)
+
+	// System Metrics Tool
+	systemMetricsTool := mcp.NewTool("get_system_metrics",
+		mcp.WithDescription("Get key system metrics (CPU, memory, disk) from Prometheus, overall and per node, with health assessment"),
+		mcp.WithString("time_range", mcp.Description("Window the metrics are averaged over (e.g., 5m, 1h, 24h; default: 5m)")),
+		mcp.WithString("nodes", mcp.Description("Only these nodes, comma-separated, matching the instance label with or without port (default: all)")),
+	)
+	s.server.AddTool(systemMetricsTool, s.observabilityHandler.GetSystemMetrics)
+
+	// Alerts Tool
+	alertsTool := mcp.NewTool("get_alerts",
+		mcp.WithDescription("Get alerts from Alertmanager, including silenced and inhibited state, with optional filtering by severity, service and active status"),
+		mcp.WithString("severity", mcp.Description("Filter by alert severity (critical, warning, info)")),
+		mcp.WithString("service", mcp.Description("Filter by service name (matches the service, job or app label)")),
+		mcp.WithString("active", mcp.Description("true: only firing, unmuted alerts; false: only silenced or inhibited alerts (default: all)")),
+	)
+	s.server.AddTool(alertsTool, s.observabilityHandler.GetAlerts)
+
+	// List Silences Tool
+	listSilencesTool := mcp.NewTool("list_silences",
+		mcp.WithDescription("List Alertmanager silences"),
+		mcp.WithString("state", mcp.Description("Filter by silence state (active, pending, expired)")),
+		mcp.WithString("matchers", mcp.Description("Only silences with these label matchers, comma-separated or JSON array (e.g., alertname=HighCPU)")),
+	)
+	s.server.AddTool(listSilencesTool, s.observabilityHandler.ListSilences)
+
+	// Create Silence Tool
+	createSilenceTool := mcp.NewTool("create_silence",
+		mcp.WithDescription("Silence the Alertmanager alerts selected by label matchers for a given duration"),
+		mcp.WithString("matchers", mcp.Required(), mcp.Description("Label matchers, comma-separated or JSON array (e.g., alertname=HighCPU, instance=~node-.*)")),
+		mcp.WithString("duration", mcp.Required(), mcp.Description("How long the silence lasts (e.g., 30m, 2h, 1d; max 30d)")),
+		mcp.WithString("comment", mcp.Required(), mcp.Description("Reason for the silence")),
+		mcp.WithString("created_by", mcp.Description("Author of the silence (default: autosphere-mcp)")),
+	)
+	s.server.AddTool(createSilenceTool, s.observabilityHandler.CreateSilence)
+
+	// Expire Silence Tool
+	expireSilenceTool := mcp.NewTool("expire_silence",
+		mcp.WithDescription("Expire an Alertmanager silence immediately"),
+		mcp.WithString("silence_id", mcp.Required(), mcp.Description("ID of the silence to expire")),
+	)
+	s.server.AddTool(expireSilenceTool, s.observabilityHandler.ExpireSilence)
 }
 
 func (s *MCPServer) registerResources() {
@@ -193,7 +802,7 @@ func (s *MCPServer) registerResources() {
 	healthResource := mcp.NewResource(
 		"autosphere://health-report",
 		"Health Check Report",
-		mcp.WithResourceDescription("Real-time system health status and metrics"),
+		mcp.WithResourceDescription("Component health over the last 24 hours from the background probes: uptime, flapping and recent samples"),
 		mcp.WithMIMEType("application/json"),
 	)
 	s.server.AddResource(healthResource, s.resourceHandler.GetHealthCheckReport)
@@ -235,13 +844,21 @@ func (s *MCPServer) registerPrompts() {
 func (s *MCPServer) Run(ctx context.Context) error {
 	s.logServerInfo()
 	
+	// Background probing feeds get_health_history and the health report resource
+	s.healthMonitor.Start(ctx)
+	
 	if s.config.IsHTTPMode() {
-		return s.runHTTP()
+		return s.runHTTP(ctx)
 	}
 	return s.runSTDIO(ctx)
 }
 
-func (s *MCPServer) runHTTP() error {
+// Shutdown releases backend credentials the server created, such as the AWX token
+func (s *MCPServer) Shutdown(ctx context.Context) {
+	s.awxClient.Close(ctx)
+}
+
+func (s *MCPServer) runHTTP(ctx context.Context) error {
 	// For HTTP mode, we'll use StreamableHTTP server from mcp-go
 	log.Printf("🌐 StreamableHTTP server starting at %s", s.config.HTTPAddr)
 	log.Printf("📡 MCP endpoint: http://%s/mcp", s.config.HTTPAddr)
@@ -249,23 +866,43 @@ func (s *MCPServer) runHTTP() error {
 	// Create StreamableHTTP server
 	streamableServer := server.NewStreamableHTTPServer(s.server)
 	
-	return streamableServer.Start(s.config.HTTPAddr)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		streamableServer.Shutdown(shutdownCtx)
+	}()
+	
+	if err := streamableServer.Start(s.config.HTTPAddr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
 
 func (s *MCPServer) runSTDIO(ctx context.Context) error {
 	log.Printf("📺 STDIO transport active")
 	log.Printf("💡 Use -http flag to enable HTTP transport")
 	
-	return server.ServeStdio(s.server)
+	// Listen returns when stdin closes or ctx is canceled by a signal
+	err := server.NewStdioServer(s.server).Listen(ctx, os.Stdin, os.Stdout)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
 }
 
 func (s *MCPServer) logServerInfo() {
 	log.Printf("Starting Autosphere MCP server...")
 	log.Printf("Server: %s v%s", s.config.ServerName, s.config.Version)
-	log.Printf("Core AWX tools: launch_awx_job, check_awx_job, health_check, autoscale")
-	log.Printf("Enhanced AWX tools: list_awx_jobs, get_job_output, cancel_awx_job, list_awx_resources")
-	log.Printf("Template management: list_job_templates, create_job_template")
+	log.Printf("Core AWX tools: launch_awx_job, list_var_sets, check_awx_job, wait_for_awx_job, health_check, get_health_history, autoscale")
+	log.Printf("Enhanced AWX tools: list_awx_jobs, get_job_output, get_job_events, cancel_awx_job, relaunch_awx_job, analyze_job_history, run_ad_hoc_command, list_ad_hoc_modules, list_awx_resources")
+	log.Printf("Template management: list_job_templates, describe_job_template, create_job_template, update_job_template, copy_job_template, delete_job_template")
+	log.Printf("Inventory tools: list_inventory_hosts, list_inventory_groups, get_inventory_host, add_inventory_host, update_inventory_host, remove_inventory_host, update_inventory_group, list_inventory_sources, sync_inventory_source, check_inventory_sync")
+	log.Printf("Project tools: list_project_playbooks, sync_project, check_project_sync, create_project, update_project")
+	log.Printf("Workflow tools: list_workflow_templates, launch_awx_workflow, check_awx_workflow, approve_workflow_step")
+	log.Printf("Schedule tools: list_schedules, create_schedule, update_schedule, set_schedule_enabled, delete_schedule, preview_schedule, get_upcoming_schedule_runs")
 	log.Printf("Cache management: get_cache_stats")
+	log.Printf("Observability tools: query_prometheus, get_system_metrics, get_alerts, list_silences, create_silence, expire_silence")
 	log.Printf("Resources: autosphere://config, autosphere://deployment-manifest, autosphere://health-report, autosphere://awx-templates")
 	log.Printf("Prompts: deployment_planning, troubleshooting, scaling_decision, incident_response")
 	log.Printf("⚡ Performance: HTTP/2 connection pooling enabled, intelligent caching (TTL-based)")
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// adHocOutputTail is how many output lines a waited ad hoc command returns
+const adHocOutputTail = 50
+
+// RunAdHocCommand runs an allowlisted module against inventory hosts and
+// optionally waits for it. onProgress (optional) is called after every poll.
+func (s *AutomationService) RunAdHocCommand(ctx context.Context, args models.RunAdHocCommandArgs, onProgress func(models.JobProgress)) (models.RunAdHocCommandOutput, error) {
+	if args.Inventory == "" {
+		return models.RunAdHocCommandOutput{}, fmt.Errorf("inventory is required")
+	}
+	if args.ModuleName == "" {
+		return models.RunAdHocCommandOutput{}, fmt.Errorf("module_name is required")
+	}
+	if args.Forks < 0 {
+		return models.RunAdHocCommandOutput{}, fmt.Errorf("forks must be a positive number")
+	}
+
+	request := awx.AdHocCommandRequest{
+		Limit:         strings.TrimSpace(args.Limit),
+		ModuleName:    strings.TrimSpace(args.ModuleName),
+		ModuleArgs:    strings.TrimSpace(args.ModuleArgs),
+		BecomeEnabled: args.Become,
+		Forks:         args.Forks,
+	}
+	// The allowlist is checked before any name is resolved, so a refused
+	// command never reaches AWX
+	if err := s.adHocPolicy.Check(request); err != nil {
+		return models.RunAdHocCommandOutput{}, err
+	}
+
+	inventory, err := s.awxClient.ResolveID(ctx, awx.ResourceInventory, args.Inventory)
+	if err != nil {
+		return models.RunAdHocCommandOutput{}, err
+	}
+	request.Inventory = inventory
+	if args.Credential != "" {
+		credential, err := s.awxClient.ResolveID(ctx, awx.ResourceCredential, args.Credential)
+		if err != nil {
+			return models.RunAdHocCommandOutput{}, err
+		}
+		request.Credential = &credential
+	}
+
+	log.Printf("Running AWX ad hoc command on inventory %d (limit: %s): %s %s", inventory, request.Limit, request.ModuleName, request.ModuleArgs)
+
+	command, err := s.awxClient.RunAdHocCommand(ctx, request)
+	if err != nil {
+		return models.RunAdHocCommandOutput{}, fmt.Errorf("failed to run ad hoc command: %w", err)
+	}
+
+	output := models.RunAdHocCommandOutput{
+		JobID:       command.ID,
+		Name:        command.Name,
+		Status:      command.Status,
+		InventoryID: inventory,
+		Limit:       request.Limit,
+		ModuleName:  request.ModuleName,
+		ModuleArgs:  request.ModuleArgs,
+		Become:      request.BecomeEnabled,
+	}
+
+	if !args.Wait {
+		output.Message = fmt.Sprintf("Ad hoc command %d started; follow it with wait_for_awx_job, check_awx_job and get_job_output", command.ID)
+		return output, nil
+	}
+
+	result, err := s.WaitForJob(ctx, models.WaitForJobArgs{
+		JobID:        command.ID,
+		Timeout:      args.Timeout,
+		PollInterval: args.PollInterval,
+	}, onProgress)
+	if err != nil {
+		return models.RunAdHocCommandOutput{}, err
+	}
+	output.Result = &result
+	output.Status = result.Job.Status
+
+	stdout, err := s.GetJobOutput(ctx, models.GetJobOutputArgs{
+		JobID:     command.ID,
+		Tail:      adHocOutputTail,
+		StripANSI: true,
+	})
+	if err != nil {
+		log.Printf("Failed to get output of ad hoc command %d: %v", command.ID, err)
+	} else {
+		output.Output = stdout.Output
+	}
+
+	switch {
+	case result.TimedOut:
+		output.Message = fmt.Sprintf("Ad hoc command %d still %s after %s; follow it with wait_for_awx_job", command.ID, output.Status, result.Waited)
+	case output.Status == "successful":
+		output.Message = fmt.Sprintf("Ad hoc command %d succeeded on %d hosts", command.ID, len(result.Job.HostResults))
+	default:
+		output.Message = fmt.Sprintf("Ad hoc command %d ended %s; get_job_output shows the full output", command.ID, output.Status)
+	}
+	return output, nil
+}
+
+// ListAdHocModules describes the ad hoc command allowlist
+func (s *AutomationService) ListAdHocModules(ctx context.Context, args models.ListAdHocModulesArgs) (models.ListAdHocModulesOutput, error) {
+	policy := s.adHocPolicy
+	output := models.ListAdHocModulesOutput{
+		Modules:      make([]models.AdHocModuleSummary, 0, len(policy.Modules)),
+		MaxForks:     policy.MaxForks,
+		AllowBecome:  policy.AllowBecome,
+		AllowNoLimit: policy.AllowNoLimit,
+	}
+	for _, name := range policy.ModuleNames() {
+		rule := policy.Modules[name]
+		output.Modules = append(output.Modules, models.AdHocModuleSummary{
+			Name:                name,
+			Description:         rule.Description,
+			Args:                rule.Args,
+			AllowShellOperators: rule.AllowShellOperators,
+		})
+	}
+	return output, nil
+}
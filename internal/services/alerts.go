@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/alertmanager"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+const (
+	defaultSilenceAuthor = "autosphere-mcp"
+	maxSilenceDuration   = 30 * 24 * time.Hour
+)
+
+// serviceLabels are the alert labels checked, in order, when filtering by service
+var serviceLabels = []string{"service", "job", "app", "app_kubernetes_io_name"}
+
+func (s *ObservabilityService) GetAlerts(ctx context.Context, args models.GetAlertsArgs) (models.GetAlertsOutput, error) {
+	if s.alertmanagerClient == nil {
+		return models.GetAlertsOutput{}, fmt.Errorf("Alertmanager client not configured")
+	}
+
+	log.Printf("Retrieving alerts from Alertmanager (severity: %q, service: %q)", args.Severity, args.Service)
+
+	filter := alertmanager.AlertFilter{}
+	if args.Severity != "" {
+		filter.Matchers = append(filter.Matchers, alertmanager.Matcher{Name: "severity", Value: args.Severity, IsEqual: true})
+	}
+	if args.Active != nil {
+		// active=true: firing and not muted; active=false: only silenced or inhibited alerts
+		active := *args.Active
+		suppressed := !active
+		filter.Active = &active
+		filter.Silenced = &suppressed
+		filter.Inhibited = &suppressed
+	}
+
+	alerts, err := s.alertmanagerClient.GetAlerts(ctx, filter)
+	if err != nil {
+		log.Printf("Failed to get alerts: %v", err)
+		return models.GetAlertsOutput{}, fmt.Errorf("failed to get alerts: %w", err)
+	}
+
+	output := models.GetAlertsOutput{
+		ActiveAlerts: make([]models.AlertSummary, 0, len(alerts)),
+		Timestamp:    time.Now().Format(time.RFC3339),
+	}
+
+	for _, alert := range alerts {
+		if args.Service != "" && !alertMatchesService(alert, args.Service) {
+			continue
+		}
+
+		summary := summarizeAlert(alert)
+		output.ActiveAlerts = append(output.ActiveAlerts, summary)
+
+		switch summary.Severity {
+		case "critical":
+			output.Critical++
+		case "warning":
+			output.Warning++
+		}
+		if alert.IsSilenced() {
+			output.Silenced++
+		}
+		if alert.IsInhibited() {
+			output.Inhibited++
+		}
+	}
+
+	sort.SliceStable(output.ActiveAlerts, func(i, j int) bool {
+		a, b := output.ActiveAlerts[i], output.ActiveAlerts[j]
+		if severityRank(a.Severity) != severityRank(b.Severity) {
+			return severityRank(a.Severity) < severityRank(b.Severity)
+		}
+		return a.StartsAt < b.StartsAt
+	})
+
+	output.TotalAlerts = len(output.ActiveAlerts)
+	output.Summary = fmt.Sprintf("%d total alerts (%d critical, %d warning, %d silenced, %d inhibited)",
+		output.TotalAlerts, output.Critical, output.Warning, output.Silenced, output.Inhibited)
+
+	log.Printf("Alertmanager returned %s", output.Summary)
+
+	return output, nil
+}
+
+func (s *ObservabilityService) ListSilences(ctx context.Context, args models.ListSilencesArgs) (models.ListSilencesOutput, error) {
+	if s.alertmanagerClient == nil {
+		return models.ListSilencesOutput{}, fmt.Errorf("Alertmanager client not configured")
+	}
+
+	matchers, err := parseMatchers(args.Matchers)
+	if err != nil {
+		return models.ListSilencesOutput{}, err
+	}
+
+	silences, err := s.alertmanagerClient.GetSilences(ctx, matchers)
+	if err != nil {
+		log.Printf("Failed to get silences: %v", err)
+		return models.ListSilencesOutput{}, fmt.Errorf("failed to get silences: %w", err)
+	}
+
+	summaries := make([]models.SilenceSummary, 0, len(silences))
+	for _, silence := range silences {
+		if args.State != "" && !strings.EqualFold(silence.Status.State, args.State) {
+			continue
+		}
+		summaries = append(summaries, summarizeSilence(silence))
+	}
+
+	// Active silences first, then pending, then expired; most recent end first within a state
+	stateOrder := map[string]int{"active": 0, "pending": 1, "expired": 2}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if stateOrder[summaries[i].State] != stateOrder[summaries[j].State] {
+			return stateOrder[summaries[i].State] < stateOrder[summaries[j].State]
+		}
+		return summaries[i].EndsAt > summaries[j].EndsAt
+	})
+
+	return models.ListSilencesOutput{
+		Silences: summaries,
+		Total:    len(summaries),
+	}, nil
+}
+
+func (s *ObservabilityService) CreateSilence(ctx context.Context, args models.CreateSilenceArgs) (models.CreateSilenceOutput, error) {
+	if s.alertmanagerClient == nil {
+		return models.CreateSilenceOutput{}, fmt.Errorf("Alertmanager client not configured")
+	}
+
+	if strings.TrimSpace(args.Comment) == "" {
+		return models.CreateSilenceOutput{}, fmt.Errorf("comment is required")
+	}
+
+	duration, err := parsePromDuration(args.Duration)
+	if err != nil || duration <= 0 {
+		return models.CreateSilenceOutput{}, fmt.Errorf("invalid duration %q: expected a positive duration such as '30m', '2h' or '1d'", args.Duration)
+	}
+	if duration > maxSilenceDuration {
+		return models.CreateSilenceOutput{}, fmt.Errorf("duration %s exceeds the maximum of %s", duration, maxSilenceDuration)
+	}
+
+	matchers, err := parseMatchers(args.Matchers)
+	if err != nil {
+		return models.CreateSilenceOutput{}, err
+	}
+	if len(matchers) == 0 {
+		return models.CreateSilenceOutput{}, fmt.Errorf("at least one matcher is required")
+	}
+
+	createdBy := args.CreatedBy
+	if createdBy == "" {
+		createdBy = defaultSilenceAuthor
+	}
+
+	now := time.Now().UTC()
+	silence := alertmanager.PostableSilence{
+		Matchers:  matchers,
+		StartsAt:  now,
+		EndsAt:    now.Add(duration),
+		CreatedBy: createdBy,
+		Comment:   args.Comment,
+	}
+
+	log.Printf("Creating Alertmanager silence for %s (%s): %s", formatMatchers(matchers), duration, args.Comment)
+
+	silenceID, err := s.alertmanagerClient.CreateSilence(ctx, silence)
+	if err != nil {
+		log.Printf("Failed to create silence: %v", err)
+		return models.CreateSilenceOutput{}, err
+	}
+
+	output := models.CreateSilenceOutput{
+		Silence: models.SilenceSummary{
+			ID:        silenceID,
+			State:     "active",
+			Matchers:  formatMatcherList(matchers),
+			StartsAt:  silence.StartsAt.Format(time.RFC3339),
+			EndsAt:    silence.EndsAt.Format(time.RFC3339),
+			CreatedBy: createdBy,
+			Comment:   args.Comment,
+		},
+	}
+
+	// Report how many current alerts the silence covers; a silence matching
+	// nothing usually means a typo in the matchers
+	if alerts, err := s.alertmanagerClient.GetAlerts(ctx, alertmanager.AlertFilter{Matchers: matchers}); err == nil {
+		output.MatchingAlerts = len(alerts)
+	} else {
+		log.Printf("Failed to count alerts matched by silence %s: %v", silenceID, err)
+	}
+
+	output.Message = fmt.Sprintf("Silence %s created until %s, matching %d current alerts",
+		silenceID, output.Silence.EndsAt, output.MatchingAlerts)
+
+	return output, nil
+}
+
+func (s *ObservabilityService) ExpireSilence(ctx context.Context, args models.ExpireSilenceArgs) (models.ExpireSilenceOutput, error) {
+	if s.alertmanagerClient == nil {
+		return models.ExpireSilenceOutput{}, fmt.Errorf("Alertmanager client not configured")
+	}
+
+	if args.SilenceID == "" {
+		return models.ExpireSilenceOutput{}, fmt.Errorf("silence_id is required")
+	}
+
+	silence, err := s.alertmanagerClient.GetSilence(ctx, args.SilenceID)
+	if err != nil {
+		return models.ExpireSilenceOutput{}, err
+	}
+	if silence.Status.State == "expired" {
+		return models.ExpireSilenceOutput{}, fmt.Errorf("silence %s has already expired", args.SilenceID)
+	}
+
+	log.Printf("Expiring Alertmanager silence %s (%s)", silence.ID, formatMatchers(silence.Matchers))
+
+	if err := s.alertmanagerClient.ExpireSilence(ctx, args.SilenceID); err != nil {
+		return models.ExpireSilenceOutput{}, err
+	}
+
+	return models.ExpireSilenceOutput{
+		SilenceID: silence.ID,
+		Message:   fmt.Sprintf("Silence %s (%s) expired", silence.ID, formatMatchers(silence.Matchers)),
+	}, nil
+}
+
+func summarizeAlert(alert alertmanager.Alert) models.AlertSummary {
+	summary := models.AlertSummary{
+		Name:        alert.Labels["alertname"],
+		Severity:    alert.Labels["severity"],
+		Status:      alert.Status.State,
+		Labels:      alert.Labels,
+		Annotations: alert.Annotations,
+		Value:       alert.Annotations["value"],
+		Fingerprint: alert.Fingerprint,
+		SilencedBy:  alert.Status.SilencedBy,
+		InhibitedBy: alert.Status.InhibitedBy,
+	}
+
+	if !alert.StartsAt.IsZero() {
+		summary.StartsAt = alert.StartsAt.Format(time.RFC3339)
+		summary.ActiveSince = time.Since(alert.StartsAt).Round(time.Minute).String()
+	}
+
+	for _, receiver := range alert.Receivers {
+		summary.Receivers = append(summary.Receivers, receiver.Name)
+	}
+
+	return summary
+}
+
+func summarizeSilence(silence alertmanager.Silence) models.SilenceSummary {
+	return models.SilenceSummary{
+		ID:        silence.ID,
+		State:     silence.Status.State,
+		Matchers:  formatMatcherList(silence.Matchers),
+		StartsAt:  silence.StartsAt.Format(time.RFC3339),
+		EndsAt:    silence.EndsAt.Format(time.RFC3339),
+		CreatedBy: silence.CreatedBy,
+		Comment:   silence.Comment,
+	}
+}
+
+func alertMatchesService(alert alertmanager.Alert, service string) bool {
+	for _, label := range serviceLabels {
+		if value, ok := alert.Labels[label]; ok && strings.EqualFold(value, service) {
+			return true
+		}
+	}
+	return false
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 0
+	case "warning":
+		return 1
+	case "info":
+		return 2
+	default:
+		return 3
+	}
+}
+
+func parseMatchers(exprs []string) ([]alertmanager.Matcher, error) {
+	matchers := make([]alertmanager.Matcher, 0, len(exprs))
+	for _, expr := range exprs {
+		if strings.TrimSpace(expr) == "" {
+			continue
+		}
+		matcher, err := alertmanager.ParseMatcher(expr)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers, nil
+}
+
+func formatMatcherList(matchers []alertmanager.Matcher) []string {
+	formatted := make([]string, len(matchers))
+	for i, matcher := range matchers {
+		formatted[i] = matcher.String()
+	}
+	return formatted
+}
+
+func formatMatchers(matchers []alertmanager.Matcher) string {
+	return strings.Join(formatMatcherList(matchers), ", ")
+}
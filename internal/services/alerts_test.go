@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/alertmanager"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// fakeAlertmanager implements the parts of the Alertmanager v2 API the
+// service uses: alerts with silence and inhibition state, and silences
+type fakeAlertmanager struct {
+	mu       sync.Mutex
+	alerts   []alertmanager.Alert
+	silences map[string]*alertmanager.Silence
+}
+
+func newFakeAlertmanager(t *testing.T, alerts ...alertmanager.Alert) (*fakeAlertmanager, *ObservabilityService) {
+	t.Helper()
+	fake := &fakeAlertmanager{alerts: alerts, silences: make(map[string]*alertmanager.Silence)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := alertmanager.NewAlertmanagerClient(alertmanager.AlertmanagerConfig{BaseURL: server.URL})
+	return fake, NewObservabilityService(nil, client)
+}
+
+func (f *fakeAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v2/silence/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/alerts":
+		json.NewEncoder(w).Encode(f.listAlerts(r))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silences":
+		var silences []alertmanager.Silence
+		for _, silence := range f.silences {
+			silences = append(silences, *silence)
+		}
+		json.NewEncoder(w).Encode(silences)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+		var postable alertmanager.PostableSilence
+		if err := json.NewDecoder(r.Body).Decode(&postable); err != nil || postable.Comment == "" || !postable.EndsAt.After(postable.StartsAt) {
+			http.Error(w, "invalid silence", http.StatusBadRequest)
+			return
+		}
+		silenceID := fmt.Sprintf("silence-%d", len(f.silences)+1)
+		f.silences[silenceID] = &alertmanager.Silence{
+			ID:        silenceID,
+			Matchers:  postable.Matchers,
+			StartsAt:  postable.StartsAt,
+			EndsAt:    postable.EndsAt,
+			CreatedBy: postable.CreatedBy,
+			Comment:   postable.Comment,
+			Status:    alertmanager.SilenceStatus{State: "active"},
+		}
+		json.NewEncoder(w).Encode(map[string]string{"silenceID": silenceID})
+	case r.Method == http.MethodGet && f.silences[id] != nil:
+		json.NewEncoder(w).Encode(f.silences[id])
+	case r.Method == http.MethodDelete && f.silences[id] != nil:
+		f.silences[id].Status.State = "expired"
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+// listAlerts applies the active, silenced, inhibited and filter parameters the
+// way Alertmanager does, with equality matchers only
+func (f *fakeAlertmanager) listAlerts(r *http.Request) []alertmanager.Alert {
+	query := r.URL.Query()
+	excluded := func(key string, state bool) bool {
+		return query.Get(key) == "false" && state
+	}
+
+	alerts := []alertmanager.Alert{}
+	for _, alert := range f.alerts {
+		alert.Status.SilencedBy = append([]string(nil), alert.Status.SilencedBy...)
+		for _, silence := range f.silences {
+			if silence.Status.State == "active" && matchesAll(alert.Labels, silence.Matchers) {
+				alert.Status.SilencedBy = append(alert.Status.SilencedBy, silence.ID)
+			}
+		}
+		alert.Status.State = "active"
+		if alert.IsSilenced() || alert.IsInhibited() {
+			alert.Status.State = "suppressed"
+		}
+
+		if excluded("active", alert.Status.State == "active") || excluded("silenced", alert.IsSilenced()) ||
+			excluded("inhibited", alert.IsInhibited()) {
+			continue
+		}
+		var matchers []alertmanager.Matcher
+		for _, expr := range query["filter"] {
+			matcher, _ := alertmanager.ParseMatcher(expr)
+			matchers = append(matchers, matcher)
+		}
+		if matchesAll(alert.Labels, matchers) {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+func matchesAll(labels map[string]string, matchers []alertmanager.Matcher) bool {
+	for _, matcher := range matchers {
+		if (labels[matcher.Name] == matcher.Value) != matcher.IsEqual {
+			return false
+		}
+	}
+	return true
+}
+
+func testAlert(name, severity, job string, status alertmanager.AlertStatus) alertmanager.Alert {
+	return alertmanager.Alert{
+		Fingerprint: name,
+		Labels:      map[string]string{"alertname": name, "severity": severity, "job": job},
+		StartsAt:    time.Now().Add(-time.Hour),
+		Status:      status,
+	}
+}
+
+func defaultAlerts() []alertmanager.Alert {
+	return []alertmanager.Alert{
+		testAlert("DiskFull", "warning", "node", alertmanager.AlertStatus{}),
+		testAlert("NodeDown", "critical", "node", alertmanager.AlertStatus{}),
+		testAlert("AWXQueueLong", "warning", "awx", alertmanager.AlertStatus{InhibitedBy: []string{"NodeDown"}}),
+		testAlert("BackupLate", "info", "backup", alertmanager.AlertStatus{SilencedBy: []string{"preexisting"}}),
+	}
+}
+
+func alertNames(output models.GetAlertsOutput) string {
+	var names []string
+	for _, alert := range output.ActiveAlerts {
+		names = append(names, alert.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestGetAlertsFilters(t *testing.T) {
+	_, service := newFakeAlertmanager(t, defaultAlerts()...)
+	active, inactive := true, false
+
+	tests := []struct {
+		name string
+		args models.GetAlertsArgs
+		want string
+	}{
+		{"all, most severe first", models.GetAlertsArgs{}, "NodeDown,DiskFull,AWXQueueLong,BackupLate"},
+		{"severity", models.GetAlertsArgs{Severity: "warning"}, "DiskFull,AWXQueueLong"},
+		{"service", models.GetAlertsArgs{Service: "NODE"}, "NodeDown,DiskFull"},
+		{"active", models.GetAlertsArgs{Active: &active}, "NodeDown,DiskFull"},
+		{"muted", models.GetAlertsArgs{Active: &inactive}, "AWXQueueLong,BackupLate"},
+		{"combined", models.GetAlertsArgs{Severity: "warning", Service: "awx", Active: &inactive}, "AWXQueueLong"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := service.GetAlerts(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("GetAlerts: %v", err)
+			}
+			if got := alertNames(output); got != tt.want {
+				t.Errorf("alerts = %s, want %s", got, tt.want)
+			}
+			if output.TotalAlerts != len(output.ActiveAlerts) {
+				t.Errorf("total = %d for %d alerts", output.TotalAlerts, len(output.ActiveAlerts))
+			}
+		})
+	}
+}
+
+func TestGetAlertsSuppressionState(t *testing.T) {
+	_, service := newFakeAlertmanager(t, defaultAlerts()...)
+
+	output, err := service.GetAlerts(context.Background(), models.GetAlertsArgs{})
+	if err != nil {
+		t.Fatalf("GetAlerts: %v", err)
+	}
+	if output.Critical != 1 || output.Warning != 2 || output.Silenced != 1 || output.Inhibited != 1 {
+		t.Errorf("counts = %+v", output)
+	}
+	for _, alert := range output.ActiveAlerts {
+		switch alert.Name {
+		case "AWXQueueLong":
+			if alert.Status != "suppressed" || strings.Join(alert.InhibitedBy, ",") != "NodeDown" {
+				t.Errorf("inhibited alert = %+v", alert)
+			}
+		case "BackupLate":
+			if alert.Status != "suppressed" || strings.Join(alert.SilencedBy, ",") != "preexisting" {
+				t.Errorf("silenced alert = %+v", alert)
+			}
+		case "NodeDown":
+			if alert.Status != "active" || alert.ActiveSince != "1h0m0s" {
+				t.Errorf("active alert = %+v", alert)
+			}
+		}
+	}
+}
+
+func TestCreateSilenceValidation(t *testing.T) {
+	fake, service := newFakeAlertmanager(t, defaultAlerts()...)
+
+	tests := []struct {
+		name string
+		args models.CreateSilenceArgs
+		want string
+	}{
+		{"missing comment", models.CreateSilenceArgs{Matchers: []string{"alertname=DiskFull"}, Duration: "1h", Comment: " "}, "comment is required"},
+		{"missing duration", models.CreateSilenceArgs{Matchers: []string{"alertname=DiskFull"}, Comment: "disk swap"}, "invalid duration"},
+		{"negative duration", models.CreateSilenceArgs{Matchers: []string{"alertname=DiskFull"}, Duration: "-1h", Comment: "disk swap"}, "invalid duration"},
+		{"too long", models.CreateSilenceArgs{Matchers: []string{"alertname=DiskFull"}, Duration: "31d", Comment: "disk swap"}, "exceeds the maximum"},
+		{"no matchers", models.CreateSilenceArgs{Duration: "1h", Comment: "disk swap"}, "at least one matcher"},
+		{"bad matcher", models.CreateSilenceArgs{Matchers: []string{"DiskFull"}, Duration: "1h", Comment: "disk swap"}, "invalid matcher"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateSilence(context.Background(), tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+	if len(fake.silences) != 0 {
+		t.Errorf("invalid requests created %d silences", len(fake.silences))
+	}
+}
+
+func TestSilenceLifecycle(t *testing.T) {
+	_, service := newFakeAlertmanager(t, defaultAlerts()...)
+	ctx := context.Background()
+
+	created, err := service.CreateSilence(ctx, models.CreateSilenceArgs{
+		Matchers: []string{"alertname=DiskFull"},
+		Duration: "2h",
+		Comment:  "disk replacement",
+	})
+	if err != nil {
+		t.Fatalf("CreateSilence: %v", err)
+	}
+	silence := created.Silence
+	if created.MatchingAlerts != 1 || silence.CreatedBy != defaultSilenceAuthor || silence.Comment != "disk replacement" {
+		t.Errorf("created = %+v", created)
+	}
+	starts, _ := time.Parse(time.RFC3339, silence.StartsAt)
+	ends, _ := time.Parse(time.RFC3339, silence.EndsAt)
+	if ends.Sub(starts) != 2*time.Hour {
+		t.Errorf("silence lasts %s, want 2h", ends.Sub(starts))
+	}
+
+	active := true
+	alerts, _ := service.GetAlerts(ctx, models.GetAlertsArgs{Active: &active})
+	if got := alertNames(alerts); got != "NodeDown" {
+		t.Errorf("active alerts after silencing = %s, want NodeDown", got)
+	}
+
+	listed, err := service.ListSilences(ctx, models.ListSilencesArgs{State: "active"})
+	if err != nil || listed.Total != 1 || listed.Silences[0].ID != silence.ID {
+		t.Fatalf("ListSilences = %+v, %v", listed, err)
+	}
+
+	if _, err := service.ExpireSilence(ctx, models.ExpireSilenceArgs{SilenceID: silence.ID}); err != nil {
+		t.Fatalf("ExpireSilence: %v", err)
+	}
+	alerts, _ = service.GetAlerts(ctx, models.GetAlertsArgs{Active: &active})
+	if got := alertNames(alerts); got != "NodeDown,DiskFull" {
+		t.Errorf("active alerts after expiry = %s", got)
+	}
+	if _, err := service.ExpireSilence(ctx, models.ExpireSilenceArgs{SilenceID: silence.ID}); err == nil || !strings.Contains(err.Error(), "already expired") {
+		t.Errorf("second expiry error = %v", err)
+	}
+	if _, err := service.ExpireSilence(ctx, models.ExpireSilenceArgs{SilenceID: "missing"}); err == nil {
+		t.Error("expected an error for an unknown silence")
+	}
+}
+
+func TestAlertsWithoutAlertmanager(t *testing.T) {
+	service := NewObservabilityService(nil, nil)
+	if _, err := service.GetAlerts(context.Background(), models.GetAlertsArgs{}); err == nil {
+		t.Error("expected an error without an Alertmanager client")
+	}
+}
@@ -5,23 +5,47 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 	
 	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/kubernetes"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/scaling"
 )
 
 type AutomationService struct {
 	healthService *HealthService
 	awxClient     *awx.Client
 	awxBaseURL    string
+	varSets       awx.VarSets
+	adHocPolicy   *awx.AdHocPolicy
+	kubeClient    *kubernetes.Client
+	autoscale     AutoscaleSettings
+	cooldowns     *scaling.CooldownTracker
+	confirmations *confirmations
+	relaunches    *relaunchChains
 }
 
-func NewAutomationService(healthService *HealthService, awxClient *awx.Client, awxBaseURL string) *AutomationService {
+// NewAutomationService creates the automation service. kubeClient may be nil,
+// in which case the autoscale tool reports that Kubernetes is not configured.
+// varSets are the extra_vars sets launches may merge, nil when none are configured.
+// adHocPolicy is the ad hoc command allowlist, the built-in one when nil.
+func NewAutomationService(healthService *HealthService, awxClient *awx.Client, awxBaseURL string, varSets awx.VarSets, adHocPolicy *awx.AdHocPolicy, kubeClient *kubernetes.Client, autoscale AutoscaleSettings) *AutomationService {
+	if adHocPolicy == nil {
+		adHocPolicy = awx.DefaultAdHocPolicy()
+	}
 	return &AutomationService{
 		healthService: healthService,
 		awxClient:     awxClient,
 		awxBaseURL:    awxBaseURL,
+		varSets:       varSets,
+		adHocPolicy:   adHocPolicy,
+		kubeClient:    kubeClient,
+		autoscale:     autoscale,
+		cooldowns:     scaling.NewCooldownTracker(),
+		confirmations: newConfirmations(),
+		relaunches:    newRelaunchChains(),
 	}
 }
 
@@ -32,23 +56,35 @@ func (s *AutomationService) LaunchJob(ctx context.Context, args models.AWXJobArg
 	
 	log.Printf("Launching AWX job with template: %s", args.JobTemplate)
 	
+	// Var-sets are layered first so the given extra_vars override them
+	extraVars, err := s.varSets.Merge(args.VarSets, args.ExtraVars)
+	if err != nil {
+		return models.AWXJobOutput{}, err
+	}
+	
 	// Create job launcher with professional configuration
 	launcher := awx.NewJobLauncher(s.awxClient)
 	
 	// Prepare launch options
 	options := awx.LaunchJobOptions{
-		TemplateNameOrID: args.JobTemplate,
-		ExtraVars:        make(map[string]interface{}),
-		Inventory:        args.Inventory,
-		Limit:            args.Limit,
-		Tags:             args.Tags,
-		SkipTags:         args.SkipTags,
-		Timeout:          60 * time.Second,
-	}
-	
-	// Convert extra vars
-	for k, v := range args.ExtraVars {
-		options.ExtraVars[k] = v
+		TemplateNameOrID:     args.JobTemplate,
+		ExtraVars:            extraVars,
+		Inventory:            args.Inventory,
+		Credentials:          args.Credentials,
+		Limit:                args.Limit,
+		Tags:                 args.Tags,
+		SkipTags:             args.SkipTags,
+		JobType:              args.JobType,
+		Verbosity:            args.Verbosity,
+		DiffMode:             args.DiffMode,
+		Forks:                args.Forks,
+		JobTimeout:           args.JobTimeout,
+		ExecutionEnvironment: args.ExecutionEnvironment,
+		Labels:               args.Labels,
+		InstanceGroups:       args.InstanceGroups,
+		ScmBranch:            args.ScmBranch,
+		JobSliceCount:        args.JobSliceCount,
+		Timeout:              60 * time.Second,
 	}
 	
 	// Launch the job using professional launcher
@@ -61,10 +97,31 @@ func (s *AutomationService) LaunchJob(ctx context.Context, args models.AWXJobArg
 	log.Printf("AWX job launched successfully: ID %d", result.JobID)
 	
 	return models.AWXJobOutput{
-		JobID:   result.JobID,
-		Status:  result.Status,
-		URL:     result.URL,
-		Message: result.Message,
+		JobID:         result.JobID,
+		Status:        result.Status,
+		URL:           result.URL,
+		Message:       result.Message,
+		Template:      result.TemplateName,
+		VarSets:       args.VarSets,
+		IgnoredFields: result.IgnoredFields,
+	}, nil
+}
+
+// ListVarSets lists the var-sets launches can merge into their extra_vars
+func (s *AutomationService) ListVarSets(ctx context.Context, args models.ListVarSetsArgs) (models.ListVarSetsOutput, error) {
+	summaries := make([]models.VarSetSummary, 0, len(s.varSets))
+	for _, name := range s.varSets.Names() {
+		set := s.varSets[name]
+		summaries = append(summaries, models.VarSetSummary{
+			Name:        name,
+			Description: set.Description,
+			Vars:        set.Vars,
+		})
+	}
+
+	return models.ListVarSetsOutput{
+		VarSets: summaries,
+		Total:   len(summaries),
 	}, nil
 }
 
@@ -99,26 +156,24 @@ func (s *AutomationService) CheckJobStatus(ctx context.Context, args models.AWXS
 		}
 	}
 	
-	// Create mock results for now (can be enhanced to get real job events)
-	results := map[string]interface{}{
-		"status": job.Status,
-	}
-	
-	if job.Status == "successful" {
-		results["changed"] = 2
-		results["ok"] = 8
-		results["failed"] = 0
-		results["skipped"] = 1
-	}
-	
-	return models.AWXStatusOutput{
+	output := models.AWXStatusOutput{
 		JobID:           args.JobID,
 		Status:          job.Status,
 		StartedAt:       startedAt,
 		FinishedAt:      finishedAt,
 		ElapsedTime:     elapsedTime,
-		PlaybookResults: results,
-	}, nil
+		PlaybookResults: map[string]interface{}{"status": job.Status},
+	}
+	
+	// Host summaries are only written once the playbook has finished
+	if job.IsFinished() {
+		if err := s.collectPlaybookResults(ctx, &output); err != nil {
+			log.Printf("Failed to collect playbook results for job %d: %v", args.JobID, err)
+			output.PlaybookResults["error"] = err.Error()
+		}
+	}
+	
+	return output, nil
 }
 
 func (s *AutomationService) CheckHealth(ctx context.Context, args models.HealthCheckArgs) (models.HealthCheckOutput, error) {
@@ -126,16 +181,31 @@ func (s *AutomationService) CheckHealth(ctx context.Context, args models.HealthC
 	components := make(map[string]models.ComponentHealth)
 	var recommendations []string
 	
-	componentsToCheck := []string{"api", "database", "cache", "web", "workers", "monitoring"}
+	componentsToCheck := s.healthService.Components()
 	
 	if args.Component != "" && args.Component != "all" {
 		componentsToCheck = []string{args.Component}
 	}
+	if len(componentsToCheck) == 0 {
+		return models.HealthCheckOutput{}, fmt.Errorf("no components have health probes configured: use -health-config")
+	}
+	
+	// Components are probed concurrently so one slow probe does not delay the others
+	results := make([]models.ComponentHealth, len(componentsToCheck))
+	var wg sync.WaitGroup
+	for i, comp := range componentsToCheck {
+		wg.Add(1)
+		go func(i int, comp string) {
+			defer wg.Done()
+			results[i] = s.healthService.CheckComponent(ctx, comp, args.Deep)
+		}(i, comp)
+	}
+	wg.Wait()
 	
 	overallHealthy := true
 	
-	for _, comp := range componentsToCheck {
-		health := s.healthService.CheckComponent(comp, args.Deep)
+	for i, comp := range componentsToCheck {
+		health := results[i]
 		components[comp] = health
 		
 		if health.Status == "critical" || health.Status == "warning" {
@@ -150,9 +220,11 @@ func (s *AutomationService) CheckHealth(ctx context.Context, args models.HealthC
 				recommendations = append(recommendations, "Check cache hit ratio and consider increasing cache size")
 			case "api":
 				recommendations = append(recommendations, "Monitor API response times and consider horizontal scaling")
+			default:
+				recommendations = append(recommendations, fmt.Sprintf("Investigate degraded %s component: %s", comp, health.Details))
 			}
 		} else if health.Status == "critical" {
-			recommendations = append(recommendations, fmt.Sprintf("URGENT: %s component requires immediate attention", comp))
+			recommendations = append(recommendations, fmt.Sprintf("URGENT: %s component requires immediate attention: %s", comp, health.Details))
 		}
 	}
 	
@@ -180,92 +252,33 @@ func (s *AutomationService) CheckHealth(ctx context.Context, args models.HealthC
 	}, nil
 }
 
-func (s *AutomationService) Autoscale(ctx context.Context, args models.AutoscaleArgs) (models.AutoscaleOutput, error) {
-	if args.Action == "" {
-		return models.AutoscaleOutput{}, fmt.Errorf("action is required")
-	}
-	
-	service := args.Service
-	if service == "" {
-		service = "api"
+const (
+	defaultHealthHistoryWindow  = 24 * time.Hour
+	defaultHealthHistorySamples = 10
+)
+
+// GetHealthHistory reports uptime, flapping and recent samples from the background health probes
+func (s *AutomationService) GetHealthHistory(ctx context.Context, args models.GetHealthHistoryArgs) (models.GetHealthHistoryOutput, error) {
+	window := defaultHealthHistoryWindow
+	if args.Window != "" {
+		parsed, err := parsePromDuration(args.Window)
+		if err != nil || parsed <= 0 {
+			return models.GetHealthHistoryOutput{}, fmt.Errorf("invalid window %q: use a duration such as 30m, 6h or 7d", args.Window)
+		}
+		window = parsed
 	}
 	
-	oldReplicas := 3
-	newReplicas := oldReplicas
-	reason := ""
-	awxJobID := 0
-	
-	switch args.Action {
-	case "scale_up":
-		if args.Replicas > 0 {
-			newReplicas = args.Replicas
-		} else {
-			newReplicas = oldReplicas + 2
-		}
-		reason = "Manual scale up requested"
-		awxJobID = int(time.Now().Unix())
-		
-	case "scale_down":
-		if args.Replicas > 0 {
-			newReplicas = args.Replicas
-		} else {
-			if oldReplicas-1 > 1 {
-				newReplicas = oldReplicas - 1
-			} else {
-				newReplicas = 1
-			}
-		}
-		reason = "Manual scale down requested"
-		awxJobID = int(time.Now().Unix())
-		
-	case "analyze":
-		reason = s.healthService.AnalyzeLoad(args.Threshold)
-		if strings.Contains(reason, "high") {
-			newReplicas = oldReplicas + 1
-		} else if strings.Contains(reason, "low") {
-			if oldReplicas-1 > 1 {
-				newReplicas = oldReplicas - 1
-			} else {
-				newReplicas = 1
-			}
-		}
-		
-	case "auto":
-		metrics := s.healthService.GetSystemMetrics()
-		if metrics["cpu"] > 80 || metrics["memory"] > 85 {
-			newReplicas = oldReplicas + 2
-			reason = "Auto-scaling up due to high resource usage"
-			awxJobID = int(time.Now().Unix())
-		} else if metrics["cpu"] < 20 && metrics["memory"] < 30 && oldReplicas > 1 {
-			if oldReplicas-1 > 1 {
-				newReplicas = oldReplicas - 1
-			} else {
-				newReplicas = 1
-			}
-			reason = "Auto-scaling down due to low resource usage"
-			awxJobID = int(time.Now().Unix())
-		} else {
-			reason = "No scaling needed - metrics within normal range"
-		}
-		
-	default:
-		return models.AutoscaleOutput{}, fmt.Errorf("unknown action: %s", args.Action)
+	samples := args.Samples
+	if samples <= 0 {
+		samples = defaultHealthHistorySamples
 	}
 	
-	status := "completed"
-	if awxJobID > 0 {
-		status = "job_launched"
+	component := args.Component
+	if component == "all" {
+		component = ""
 	}
 	
-	return models.AutoscaleOutput{
-		Action:      args.Action,
-		Service:     service,
-		OldReplicas: oldReplicas,
-		NewReplicas: newReplicas,
-		Reason:      reason,
-		JobID:       awxJobID,
-		Status:      status,
-	}, nil
+	return s.healthService.History(component, window, samples)
 }
 
 func (s *AutomationService) ListJobs(ctx context.Context, args models.ListJobsArgs) (models.ListJobsOutput, error) {
@@ -276,15 +289,15 @@ func (s *AutomationService) ListJobs(ctx context.Context, args models.ListJobsAr
 	
 	log.Printf("Listing AWX jobs (limit: %d, status: %s)", limit, args.Status)
 	
-	jobs, err := s.awxClient.GetJobs(ctx, limit, args.Status)
+	jobList, err := s.awxClient.GetJobList(ctx, limit, args.Status)
 	if err != nil {
 		log.Printf("Failed to get AWX jobs: %v", err)
 		return models.ListJobsOutput{}, fmt.Errorf("failed to get jobs: %w", err)
 	}
 	
 	// Convert to job summaries
-	jobSummaries := make([]models.JobSummary, len(jobs))
-	for i, job := range jobs {
+	jobSummaries := make([]models.JobSummary, len(jobList.Results))
+	for i, job := range jobList.Results {
 		startedAt := ""
 		finishedAt := ""
 		elapsedTime := ""
@@ -303,39 +316,18 @@ func (s *AutomationService) ListJobs(ctx context.Context, args models.ListJobsAr
 			ID:          job.ID,
 			Name:        job.Name,
 			Status:      job.Status,
-			Template:    fmt.Sprintf("Template ID: %d", job.JobTemplate),
+			Template:    job.TemplateName(),
 			StartedAt:   startedAt,
 			FinishedAt:  finishedAt,
 			ElapsedTime: elapsedTime,
 		}
 	}
 	
-	log.Printf("Retrieved %d jobs", len(jobSummaries))
+	log.Printf("Retrieved %d of %d jobs", len(jobSummaries), jobList.Count)
 	
 	return models.ListJobsOutput{
 		Jobs:  jobSummaries,
-		Total: len(jobSummaries),
-	}, nil
-}
-
-func (s *AutomationService) GetJobOutput(ctx context.Context, args models.GetJobOutputArgs) (models.GetJobOutputOutput, error) {
-	if args.JobID <= 0 {
-		return models.GetJobOutputOutput{}, fmt.Errorf("valid job_id is required")
-	}
-	
-	log.Printf("Getting output for AWX job: %d", args.JobID)
-	
-	output, err := s.awxClient.GetJobOutput(ctx, args.JobID)
-	if err != nil {
-		log.Printf("Failed to get job output: %v", err)
-		return models.GetJobOutputOutput{}, fmt.Errorf("failed to get job output: %w", err)
-	}
-	
-	log.Printf("Retrieved output for job %d (%d characters)", args.JobID, len(output))
-	
-	return models.GetJobOutputOutput{
-		JobID:  args.JobID,
-		Output: output,
+		Total: jobList.Count,
 	}, nil
 }
 
@@ -369,15 +361,16 @@ func (s *AutomationService) ListResources(ctx context.Context, args models.ListR
 	log.Printf("Listing AWX resources: %s", args.ResourceType)
 	
 	var resources []interface{}
-	var err error
+	total := 0
 	
 	switch strings.ToLower(args.ResourceType) {
 	case "templates", "job_templates":
-		templates, err := s.awxClient.GetJobTemplates(ctx)
+		templates, err := s.awxClient.GetJobTemplateList(ctx)
 		if err != nil {
 			return models.ListResourcesOutput{}, fmt.Errorf("failed to get job templates: %w", err)
 		}
-		for _, template := range templates {
+		total = templates.Count
+		for _, template := range templates.Results {
 			resources = append(resources, models.ResourceSummary{
 				ID:          template.ID,
 				Name:        template.Name,
@@ -386,11 +379,12 @@ func (s *AutomationService) ListResources(ctx context.Context, args models.ListR
 		}
 		
 	case "inventories":
-		inventories, err := s.awxClient.GetInventories(ctx)
+		inventories, err := s.awxClient.GetInventoryList(ctx)
 		if err != nil {
 			return models.ListResourcesOutput{}, fmt.Errorf("failed to get inventories: %w", err)
 		}
-		for _, inventory := range inventories {
+		total = inventories.Count
+		for _, inventory := range inventories.Results {
 			resources = append(resources, models.ResourceSummary{
 				ID:          inventory.ID,
 				Name:        inventory.Name,
@@ -399,11 +393,12 @@ func (s *AutomationService) ListResources(ctx context.Context, args models.ListR
 		}
 		
 	case "projects":
-		projects, err := s.awxClient.GetProjects(ctx)
+		projects, err := s.awxClient.GetProjectList(ctx)
 		if err != nil {
 			return models.ListResourcesOutput{}, fmt.Errorf("failed to get projects: %w", err)
 		}
-		for _, project := range projects {
+		total = projects.Count
+		for _, project := range projects.Results {
 			resources = append(resources, models.ResourceSummary{
 				ID:          project.ID,
 				Name:        project.Name,
@@ -416,31 +411,27 @@ func (s *AutomationService) ListResources(ctx context.Context, args models.ListR
 		return models.ListResourcesOutput{}, fmt.Errorf("unsupported resource type: %s. Supported types: templates, inventories, projects", args.ResourceType)
 	}
 	
-	if err != nil {
-		return models.ListResourcesOutput{}, err
-	}
-	
-	log.Printf("Retrieved %d %s", len(resources), args.ResourceType)
+	log.Printf("Retrieved %d of %d %s", len(resources), total, args.ResourceType)
 	
 	return models.ListResourcesOutput{
 		ResourceType: args.ResourceType,
 		Resources:    resources,
-		Total:        len(resources),
+		Total:        total,
 	}, nil
 }
 
 func (s *AutomationService) ListJobTemplates(ctx context.Context, args models.ListJobTemplatesArgs) (models.ListJobTemplatesOutput, error) {
 	log.Printf("Listing AWX job templates")
 
-	templates, err := s.awxClient.GetJobTemplates(ctx)
+	templates, err := s.awxClient.GetJobTemplateList(ctx)
 	if err != nil {
 		log.Printf("Failed to get job templates: %v", err)
 		return models.ListJobTemplatesOutput{}, fmt.Errorf("failed to get job templates: %w", err)
 	}
 
 	// Convert to template summaries
-	templateSummaries := make([]models.JobTemplateSummary, len(templates))
-	for i, template := range templates {
+	templateSummaries := make([]models.JobTemplateSummary, len(templates.Results))
+	for i, template := range templates.Results {
 		templateSummaries[i] = models.JobTemplateSummary{
 			ID:          template.ID,
 			Name:        template.Name,
@@ -451,11 +442,11 @@ func (s *AutomationService) ListJobTemplates(ctx context.Context, args models.Li
 		}
 	}
 
-	log.Printf("Retrieved %d job templates", len(templateSummaries))
+	log.Printf("Retrieved %d of %d job templates", len(templateSummaries), templates.Count)
 
 	return models.ListJobTemplatesOutput{
 		Templates: templateSummaries,
-		Total:     len(templateSummaries),
+		Total:     templates.Count,
 	}, nil
 }
 
@@ -464,11 +455,11 @@ func (s *AutomationService) CreateJobTemplate(ctx context.Context, args models.C
 	if args.Name == "" {
 		return models.CreateJobTemplateOutput{}, fmt.Errorf("template name is required")
 	}
-	if args.Inventory == 0 {
-		return models.CreateJobTemplateOutput{}, fmt.Errorf("inventory ID is required")
+	if args.Inventory == "" {
+		return models.CreateJobTemplateOutput{}, fmt.Errorf("inventory is required")
 	}
-	if args.Project == 0 {
-		return models.CreateJobTemplateOutput{}, fmt.Errorf("project ID is required")
+	if args.Project == "" {
+		return models.CreateJobTemplateOutput{}, fmt.Errorf("project is required")
 	}
 	if args.Playbook == "" {
 		return models.CreateJobTemplateOutput{}, fmt.Errorf("playbook path is required")
@@ -482,13 +473,25 @@ func (s *AutomationService) CreateJobTemplate(ctx context.Context, args models.C
 
 	log.Printf("Creating AWX job template: %s", args.Name)
 
+	inventory, err := s.awxClient.ResolveID(ctx, awx.ResourceInventory, args.Inventory)
+	if err != nil {
+		return models.CreateJobTemplateOutput{}, err
+	}
+	project, err := s.awxClient.ResolveID(ctx, awx.ResourceProject, args.Project)
+	if err != nil {
+		return models.CreateJobTemplateOutput{}, err
+	}
+	if err := s.checkPlaybook(ctx, project, args.Playbook); err != nil {
+		return models.CreateJobTemplateOutput{}, err
+	}
+
 	// Create the template using AWX client
 	request := awx.CreateJobTemplateRequest{
 		Name:        args.Name,
 		Description: args.Description,
 		JobType:     jobType,
-		Inventory:   args.Inventory,
-		Project:     args.Project,
+		Inventory:   inventory,
+		Project:     project,
 		Playbook:    args.Playbook,
 		Verbosity:   args.Verbosity,
 	}
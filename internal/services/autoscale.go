@@ -0,0 +1,358 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/kubernetes"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/prometheus"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/scaling"
+)
+
+const (
+	// AutoscaleModeAWX launches an AWX job template that performs the scaling
+	AutoscaleModeAWX = "awx"
+	// AutoscaleModePatch patches the deployment scale subresource directly
+	AutoscaleModePatch = "patch"
+
+	defaultAutoscaleService = "api"
+)
+
+// AutoscaleSettings controls how scaling decisions are made and applied
+type AutoscaleSettings struct {
+	Mode       string                       // AutoscaleModeAWX or AutoscaleModePatch
+	Template   string                       // AWX job template launched in AWX mode
+	Namespace  string                       // Namespace used when neither the request nor the policy names one
+	Policy     *scaling.Policy              // Scaling guardrails, scaling.DefaultPolicy() when nil
+	Prometheus *prometheus.PrometheusClient // Source of PromQL policy metrics, may be nil
+}
+
+func (s *AutomationService) Autoscale(ctx context.Context, args models.AutoscaleArgs) (models.AutoscaleOutput, error) {
+	if args.Action == "" {
+		return models.AutoscaleOutput{}, fmt.Errorf("action is required")
+	}
+	switch args.Action {
+	case "scale_up", "scale_down", "analyze", "auto":
+	default:
+		return models.AutoscaleOutput{}, fmt.Errorf("unknown action: %s", args.Action)
+	}
+
+	if s.kubeClient == nil {
+		return models.AutoscaleOutput{}, fmt.Errorf("Kubernetes client not configured: use -kubeconfig or -k8s-api-url, or run in-cluster")
+	}
+
+	service := args.Service
+	if service == "" {
+		service = defaultAutoscaleService
+	}
+
+	policy := s.autoscale.Policy
+	if policy == nil {
+		policy = scaling.DefaultPolicy()
+	}
+	rule := policy.RuleFor(service)
+
+	namespace := args.Namespace
+	for _, candidate := range []string{rule.Namespace, s.autoscale.Namespace, s.kubeClient.Namespace()} {
+		if namespace == "" {
+			namespace = candidate
+		}
+	}
+
+	deployment, err := s.kubeClient.GetDeployment(ctx, namespace, rule.Deployment)
+	if err != nil {
+		if kubernetes.IsNotFound(err) {
+			return models.AutoscaleOutput{}, fmt.Errorf("deployment %s not found in namespace %s", rule.Deployment, namespace)
+		}
+		return models.AutoscaleOutput{}, err
+	}
+
+	output := models.AutoscaleOutput{
+		Action:        args.Action,
+		Service:       service,
+		Namespace:     namespace,
+		OldReplicas:   deployment.DesiredReplicas(),
+		ReadyReplicas: deployment.Status.ReadyReplicas,
+		Policy: &models.ScalingPolicyExplanation{
+			Rule:              rule.Origin,
+			Deployment:        rule.Deployment,
+			MinReplicas:       rule.MinReplicas,
+			MaxReplicas:       rule.MaxReplicas,
+			ScaleUpStep:       rule.ScaleUpStep,
+			ScaleDownStep:     rule.ScaleDownStep,
+			ScaleUpCooldown:   rule.ScaleUpCooldown.String(),
+			ScaleDownCooldown: rule.ScaleDownCooldown.String(),
+			EvaluationWindow:  rule.EvaluationWindow.String(),
+		},
+	}
+
+	hpa, err := s.kubeClient.GetDeploymentHPA(ctx, namespace, rule.Deployment)
+	if err != nil {
+		// The HPA only refines the decision, scaling can proceed without it
+		log.Printf("Failed to read HPA for %s/%s: %v", namespace, rule.Deployment, err)
+		output.Warnings = append(output.Warnings, fmt.Sprintf("Could not read HorizontalPodAutoscaler state: %v", err))
+	} else if hpa != nil {
+		output.HPA = summarizeHPA(hpa)
+	}
+
+	oldReplicas := output.OldReplicas
+	newReplicas := oldReplicas
+	direction := scaling.DirectionHold
+
+	switch args.Action {
+	case "scale_up":
+		direction = scaling.DirectionUp
+		newReplicas = oldReplicas + rule.ScaleUpStep
+		if args.Replicas > 0 {
+			newReplicas = args.Replicas
+		}
+		output.Reason = "Manual scale up requested"
+		if newReplicas <= oldReplicas {
+			return refuseScaling(output, fmt.Sprintf("scale_up target of %d replicas is not above the current %d", newReplicas, oldReplicas)), nil
+		}
+
+	case "scale_down":
+		direction = scaling.DirectionDown
+		newReplicas = oldReplicas - rule.ScaleDownStep
+		if args.Replicas > 0 {
+			newReplicas = args.Replicas
+		}
+		output.Reason = "Manual scale down requested"
+		if newReplicas >= oldReplicas {
+			return refuseScaling(output, fmt.Sprintf("scale_down target of %d replicas is not below the current %d", newReplicas, oldReplicas)), nil
+		}
+
+	case "analyze", "auto":
+		readings, err := s.readScalingMetrics(ctx, rule, output.HPA, args.Threshold)
+		if err != nil {
+			return models.AutoscaleOutput{}, err
+		}
+		output.Policy.Metrics = explainReadings(rule, readings)
+
+		decision := rule.Decide(oldReplicas, readings)
+		direction = decision.Direction
+		newReplicas = decision.Target
+		output.Reason = decision.Reason
+	}
+
+	// Manual targets outside the rule are refused rather than silently adjusted
+	if err := rule.CheckTarget(newReplicas); err != nil {
+		if args.Action == "analyze" {
+			output.Warnings = append(output.Warnings, err.Error())
+		} else {
+			return refuseScaling(output, err.Error()), nil
+		}
+	}
+
+	if lastScaled := s.cooldowns.LastScaled(namespace, rule.Deployment); !lastScaled.IsZero() {
+		output.Policy.LastScaled = lastScaled.Format(time.RFC3339)
+		if remaining := rule.CooldownRemaining(direction, lastScaled, time.Now()); remaining > 0 && direction != scaling.DirectionHold {
+			output.Policy.CooldownRemaining = remaining.Round(time.Second).String()
+			reason := fmt.Sprintf("scale %s cooldown of rule %s is active: last scaled %s ago, %s remaining",
+				direction, rule.Origin, time.Since(lastScaled).Round(time.Second), remaining.Round(time.Second))
+			if args.Action != "analyze" {
+				return refuseScaling(output, reason), nil
+			}
+			output.Warnings = append(output.Warnings, reason)
+		}
+	}
+
+	// Replica counts outside the HPA range would be reverted by the HPA controller
+	if output.HPA != nil && newReplicas != oldReplicas {
+		if newReplicas > output.HPA.MaxReplicas || newReplicas < output.HPA.MinReplicas {
+			output.Warnings = append(output.Warnings, fmt.Sprintf("Target of %d replicas is outside the %d-%d range of HPA %s, which will revert it",
+				newReplicas, output.HPA.MinReplicas, output.HPA.MaxReplicas, output.HPA.Name))
+		} else if args.Action != "analyze" {
+			output.Warnings = append(output.Warnings, fmt.Sprintf("HPA %s manages this deployment and may override the new replica count", output.HPA.Name))
+		}
+	}
+
+	output.NewReplicas = newReplicas
+
+	switch {
+	case args.Action == "analyze":
+		output.Status = "analyzed"
+		return output, nil
+	case newReplicas == oldReplicas:
+		output.Status = "no_change"
+		return output, nil
+	}
+
+	log.Printf("Scaling %s/%s from %d to %d replicas (%s, mode: %s)", namespace, rule.Deployment, oldReplicas, newReplicas, output.Reason, s.autoscale.Mode)
+
+	if err := s.applyScaling(ctx, rule.Deployment, &output); err != nil {
+		return models.AutoscaleOutput{}, err
+	}
+	s.cooldowns.Record(namespace, rule.Deployment, time.Now())
+
+	return output, nil
+}
+
+// refuseScaling turns output into a refusal that leaves the replica count unchanged
+func refuseScaling(output models.AutoscaleOutput, reason string) models.AutoscaleOutput {
+	log.Printf("Refused to scale %s: %s", output.Service, reason)
+	output.NewReplicas = output.OldReplicas
+	output.Status = "refused"
+	output.Reason = "Refused: " + reason
+	return output
+}
+
+// applyScaling carries out the scaling decision in output according to the configured mode
+func (s *AutomationService) applyScaling(ctx context.Context, deployment string, output *models.AutoscaleOutput) error {
+	switch s.autoscale.Mode {
+	case AutoscaleModePatch:
+		if err := s.kubeClient.ScaleDeployment(ctx, output.Namespace, deployment, output.NewReplicas); err != nil {
+			return err
+		}
+		output.Mode = AutoscaleModePatch
+		output.Status = "scaled"
+
+	case AutoscaleModeAWX, "":
+		launcher := awx.NewJobLauncher(s.awxClient)
+		result, err := launcher.Launch(ctx, awx.LaunchJobOptions{
+			TemplateNameOrID: s.autoscale.Template,
+			ExtraVars: map[string]interface{}{
+				"deployment":       deployment,
+				"namespace":        output.Namespace,
+				"current_replicas": output.OldReplicas,
+				"target_replicas":  output.NewReplicas,
+				"scaling_reason":   output.Reason,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to launch autoscale template %s: %w", s.autoscale.Template, err)
+		}
+		output.Mode = AutoscaleModeAWX
+		output.JobID = result.JobID
+		output.JobURL = result.URL
+		output.Status = "job_launched"
+
+	default:
+		return fmt.Errorf("unknown autoscale mode: %s. Supported modes: %s, %s", s.autoscale.Mode, AutoscaleModeAWX, AutoscaleModePatch)
+	}
+
+	return nil
+}
+
+// readScalingMetrics reads the metrics of a rule. threshold (e.g. cpu_high)
+// restricts the evaluation to the metric of that name.
+func (s *AutomationService) readScalingMetrics(ctx context.Context, rule scaling.ServiceRule, hpa *models.HPAStatus, threshold string) ([]scaling.Reading, error) {
+	metrics := rule.Metrics
+	if threshold != "" {
+		name := strings.TrimSuffix(threshold, "_high")
+		metrics = nil
+		var available []string
+		for _, metric := range rule.Metrics {
+			available = append(available, metric.Name)
+			if metric.Name == name {
+				metrics = append(metrics, metric)
+			}
+		}
+		if len(metrics) == 0 {
+			return nil, fmt.Errorf("threshold %s does not match any metric of rule %s (available: %s)", threshold, rule.Origin, strings.Join(available, ", "))
+		}
+	}
+
+	readings := make([]scaling.Reading, 0, len(metrics))
+	for _, metric := range metrics {
+		reading := scaling.Reading{Metric: metric}
+
+		if metric.Resource != "" {
+			if hpa == nil {
+				reading.Err = fmt.Errorf("no HorizontalPodAutoscaler reports %s utilization", metric.Resource)
+			} else if value, ok := hpa.Utilization[metric.Resource]; ok {
+				reading.Value, reading.Available = float64(value), true
+			} else {
+				reading.Err = fmt.Errorf("HPA %s does not report %s utilization", hpa.Name, metric.Resource)
+			}
+		} else {
+			reading.Value, reading.Err = s.queryScalingMetric(ctx, rule.QueryFor(metric))
+			reading.Available = reading.Err == nil
+		}
+
+		readings = append(readings, reading)
+	}
+	return readings, nil
+}
+
+// queryScalingMetric evaluates a PromQL metric. When the query returns
+// several series the highest value is used, so one hot pod is enough to scale up.
+func (s *AutomationService) queryScalingMetric(ctx context.Context, query string) (float64, error) {
+	if s.autoscale.Prometheus == nil {
+		return 0, fmt.Errorf("Prometheus client not configured")
+	}
+
+	result, err := s.autoscale.Prometheus.Query(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	value, found := math.Inf(-1), false
+	for _, series := range result.Data.Result {
+		sample, err := series.InstantSample()
+		if err != nil || !sample.IsNumber() {
+			continue
+		}
+		value, found = math.Max(value, sample.Value), true
+	}
+	if !found {
+		return 0, fmt.Errorf("query returned no numeric result")
+	}
+	return value, nil
+}
+
+func explainReadings(rule scaling.ServiceRule, readings []scaling.Reading) []models.ScalingMetricEvaluation {
+	evaluations := make([]models.ScalingMetricEvaluation, len(readings))
+	for i, reading := range readings {
+		evaluation := models.ScalingMetricEvaluation{
+			Name:           reading.Metric.Name,
+			Source:         reading.Metric.Source(),
+			ScaleUpAbove:   reading.Metric.ScaleUpAbove,
+			ScaleDownBelow: reading.Metric.ScaleDownBelow,
+			Verdict:        reading.Verdict(),
+		}
+		if reading.Metric.Query != "" {
+			evaluation.Query = rule.QueryFor(reading.Metric)
+		}
+		if reading.Available {
+			value := reading.Value
+			evaluation.Value = &value
+		}
+		if reading.Err != nil {
+			evaluation.Error = reading.Err.Error()
+		}
+		evaluations[i] = evaluation
+	}
+	return evaluations
+}
+
+func summarizeHPA(hpa *kubernetes.HorizontalPodAutoscaler) *models.HPAStatus {
+	status := &models.HPAStatus{
+		Name:            hpa.Metadata.Name,
+		MinReplicas:     hpa.MinReplicasOrDefault(),
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+		Utilization:     make(map[string]int),
+		Targets:         make(map[string]int),
+	}
+
+	for _, metric := range hpa.Spec.Metrics {
+		if metric.Resource != nil && metric.Resource.Target.AverageUtilization != nil {
+			status.Targets[metric.Resource.Name] = *metric.Resource.Target.AverageUtilization
+		}
+	}
+	for _, metric := range hpa.Status.CurrentMetrics {
+		if metric.Resource != nil && metric.Resource.Current.AverageUtilization != nil {
+			status.Utilization[metric.Resource.Name] = *metric.Resource.Current.AverageUtilization
+		}
+	}
+
+	return status
+}
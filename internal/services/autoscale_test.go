@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/kubernetes"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// fakeAPIServer serves one deployment, its scale subresource and an optional
+// HPA the way the Kubernetes API server does
+type fakeAPIServer struct {
+	mu          sync.Mutex
+	namespace   string
+	name        string
+	replicas    int
+	ready       int
+	hpa         map[string]interface{}
+	patches     []string
+	contentType string
+}
+
+func (f *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer kube-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"kind": "Status", "reason": "Unauthorized", "message": "Unauthorized"})
+		return
+	}
+
+	deploymentPath := "/apis/apps/v1/namespaces/" + f.namespace + "/deployments/" + f.name
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == deploymentPath:
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"metadata": map[string]interface{}{"name": f.name, "namespace": f.namespace},
+			"spec":     map[string]interface{}{"replicas": f.replicas},
+			"status":   map[string]interface{}{"replicas": f.replicas, "readyReplicas": f.ready},
+		})
+	case r.Method == http.MethodPatch && r.URL.Path == deploymentPath+"/scale":
+		var patch struct {
+			Spec struct {
+				Replicas int `json:"replicas"`
+			} `json:"spec"`
+		}
+		json.NewDecoder(r.Body).Decode(&patch)
+		f.contentType = r.Header.Get("Content-Type")
+		f.patches = append(f.patches, r.URL.Path)
+		f.replicas = patch.Spec.Replicas
+		json.NewEncoder(w).Encode(map[string]interface{}{"spec": map[string]interface{}{"replicas": f.replicas}})
+	case r.Method == http.MethodGet && r.URL.Path == "/apis/autoscaling/v2/namespaces/"+f.namespace+"/horizontalpodautoscalers":
+		items := []interface{}{map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "other"},
+			"spec":     map[string]interface{}{"scaleTargetRef": map[string]string{"kind": "Deployment", "name": "other"}, "maxReplicas": 3},
+		}}
+		if f.hpa != nil {
+			items = append(items, f.hpa)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"kind": "Status", "reason": "NotFound", "message": r.URL.Path + " not found"})
+	}
+}
+
+// cpuHPA returns an HPA on the fake deployment reporting the given CPU utilization
+func cpuHPA(name string, utilization int) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": name + "-hpa"},
+		"spec": map[string]interface{}{
+			"scaleTargetRef": map[string]string{"apiVersion": "apps/v1", "kind": "Deployment", "name": name},
+			"minReplicas":    2,
+			"maxReplicas":    10,
+			"metrics": []interface{}{map[string]interface{}{
+				"type":     "Resource",
+				"resource": map[string]interface{}{"name": "cpu", "target": map[string]interface{}{"type": "Utilization", "averageUtilization": 70}},
+			}},
+		},
+		"status": map[string]interface{}{
+			"currentReplicas": 4,
+			"desiredReplicas": 4,
+			"currentMetrics": []interface{}{map[string]interface{}{
+				"type":     "Resource",
+				"resource": map[string]interface{}{"name": "cpu", "current": map[string]interface{}{"averageUtilization": utilization}},
+			}},
+		},
+	}
+}
+
+// stubAWX accepts launches of the autoscale job template
+type stubAWX struct {
+	mu       sync.Mutex
+	launches []map[string]interface{}
+}
+
+func (s *stubAWX) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/job_templates/":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":   2,
+			"results": []awx.JobTemplate{{ID: 7, Name: "other"}, {ID: 42, Name: "autosphere-autoscale"}},
+		})
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v2/job_templates/42/launch/":
+		json.NewEncoder(w).Encode(map[string]interface{}{"ask_variables_on_launch": true, "can_start_without_user_input": true})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/job_templates/42/launch/":
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		s.launches = append(s.launches, body)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"job": 9001, "id": 9001})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newAutoscaleService(t *testing.T, mode string, kube *fakeAPIServer) (*AutomationService, *stubAWX) {
+	t.Helper()
+	kubeServer := httptest.NewServer(kube)
+	t.Cleanup(kubeServer.Close)
+	stub := &stubAWX{}
+	awxServer := httptest.NewServer(stub)
+	t.Cleanup(awxServer.Close)
+
+	kubeClient, err := kubernetes.NewClient(kubernetes.KubernetesConfig{APIServer: kubeServer.URL, Token: "kube-token", Namespace: "prod"})
+	if err != nil {
+		t.Fatalf("kubernetes.NewClient: %v", err)
+	}
+	awxClient := awx.NewClient(awx.ClientConfig{BaseURL: awxServer.URL, Token: "awx-token", MaxRetries: -1})
+
+	service := NewAutomationService(nil, awxClient, awxServer.URL, nil, nil, kubeClient, AutoscaleSettings{
+		Mode:     mode,
+		Template: "autosphere-autoscale",
+	})
+	return service, stub
+}
+
+func TestAutoscaleAWXMode(t *testing.T) {
+	kube := &fakeAPIServer{namespace: "prod", name: "api", replicas: 4, ready: 3}
+	service, stub := newAutoscaleService(t, AutoscaleModeAWX, kube)
+
+	output, err := service.Autoscale(context.Background(), models.AutoscaleArgs{Action: "scale_up"})
+	if err != nil {
+		t.Fatalf("Autoscale: %v", err)
+	}
+	if output.Status != "job_launched" || output.Mode != AutoscaleModeAWX || output.JobID != 9001 {
+		t.Errorf("status %s, mode %s, job %d; want job_launched, awx, 9001", output.Status, output.Mode, output.JobID)
+	}
+	if output.OldReplicas != 4 || output.NewReplicas != 6 || output.ReadyReplicas != 3 {
+		t.Errorf("replicas %d -> %d (ready %d), want 4 -> 6 (ready 3)", output.OldReplicas, output.NewReplicas, output.ReadyReplicas)
+	}
+
+	if len(stub.launches) != 1 {
+		t.Fatalf("got %d launches, want 1", len(stub.launches))
+	}
+	vars, _ := stub.launches[0]["extra_vars"].(map[string]interface{})
+	if vars["deployment"] != "api" || vars["namespace"] != "prod" || vars["current_replicas"] != 4.0 || vars["target_replicas"] != 6.0 {
+		t.Errorf("extra_vars = %v", vars)
+	}
+	if len(kube.patches) != 0 {
+		t.Errorf("AWX mode patched the deployment directly: %v", kube.patches)
+	}
+}
+
+func TestAutoscalePatchMode(t *testing.T) {
+	kube := &fakeAPIServer{namespace: "prod", name: "api", replicas: 5, ready: 5}
+	service, stub := newAutoscaleService(t, AutoscaleModePatch, kube)
+
+	output, err := service.Autoscale(context.Background(), models.AutoscaleArgs{Action: "scale_down", Replicas: 3})
+	if err != nil {
+		t.Fatalf("Autoscale: %v", err)
+	}
+	if output.Status != "scaled" || output.Mode != AutoscaleModePatch || output.JobID != 0 {
+		t.Errorf("status %s, mode %s, job %d; want scaled, patch, no job", output.Status, output.Mode, output.JobID)
+	}
+	if output.OldReplicas != 5 || output.NewReplicas != 3 || kube.replicas != 3 {
+		t.Errorf("replicas %d -> %d, deployment now at %d; want 5 -> 3", output.OldReplicas, output.NewReplicas, kube.replicas)
+	}
+	if kube.contentType != "application/merge-patch+json" {
+		t.Errorf("patch content type = %q", kube.contentType)
+	}
+	if len(stub.launches) != 0 {
+		t.Errorf("patch mode launched %d AWX jobs", len(stub.launches))
+	}
+}
+
+func TestAutoscaleAutoUsesHPAUtilization(t *testing.T) {
+	for _, mode := range []string{AutoscaleModeAWX, AutoscaleModePatch} {
+		t.Run(mode, func(t *testing.T) {
+			kube := &fakeAPIServer{namespace: "prod", name: "api", replicas: 4, ready: 4, hpa: cpuHPA("api", 93)}
+			service, stub := newAutoscaleService(t, mode, kube)
+
+			output, err := service.Autoscale(context.Background(), models.AutoscaleArgs{Action: "auto", Threshold: "cpu_high"})
+			if err != nil {
+				t.Fatalf("Autoscale: %v", err)
+			}
+			if output.HPA == nil || output.HPA.Name != "api-hpa" || output.HPA.Utilization["cpu"] != 93 || output.HPA.Targets["cpu"] != 70 {
+				t.Fatalf("hpa = %+v", output.HPA)
+			}
+			if output.OldReplicas != 4 || output.NewReplicas != 6 {
+				t.Errorf("replicas %d -> %d, want 4 -> 6", output.OldReplicas, output.NewReplicas)
+			}
+
+			switch mode {
+			case AutoscaleModeAWX:
+				if output.JobID != 9001 || len(stub.launches) != 1 {
+					t.Errorf("job %d after %d launches", output.JobID, len(stub.launches))
+				}
+			case AutoscaleModePatch:
+				if kube.replicas != 6 {
+					t.Errorf("deployment at %d replicas, want 6", kube.replicas)
+				}
+			}
+		})
+	}
+}
+
+func TestAutoscaleRefusals(t *testing.T) {
+	kube := &fakeAPIServer{namespace: "prod", name: "api", replicas: 4, ready: 4}
+	service, stub := newAutoscaleService(t, AutoscaleModePatch, kube)
+	ctx := context.Background()
+
+	output, err := service.Autoscale(ctx, models.AutoscaleArgs{Action: "scale_up", Replicas: 50})
+	if err != nil {
+		t.Fatalf("Autoscale: %v", err)
+	}
+	if output.Status != "refused" || output.NewReplicas != 4 || !strings.Contains(output.Reason, "max") {
+		t.Errorf("above max: status %s, target %d, reason %q", output.Status, output.NewReplicas, output.Reason)
+	}
+
+	if _, err := service.Autoscale(ctx, models.AutoscaleArgs{Action: "scale_up"}); err != nil {
+		t.Fatalf("Autoscale: %v", err)
+	}
+	output, err = service.Autoscale(ctx, models.AutoscaleArgs{Action: "scale_up"})
+	if err != nil {
+		t.Fatalf("Autoscale: %v", err)
+	}
+	if output.Status != "refused" || !strings.Contains(output.Reason, "cooldown") {
+		t.Errorf("inside cooldown: status %s, reason %q", output.Status, output.Reason)
+	}
+	if len(kube.patches) != 1 || len(stub.launches) != 0 {
+		t.Errorf("got %d patches and %d launches, want only the one allowed scale", len(kube.patches), len(stub.launches))
+	}
+}
+
+func TestAutoscaleErrors(t *testing.T) {
+	kube := &fakeAPIServer{namespace: "prod", name: "api", replicas: 4}
+	service, _ := newAutoscaleService(t, AutoscaleModePatch, kube)
+	ctx := context.Background()
+
+	if _, err := service.Autoscale(ctx, models.AutoscaleArgs{Action: "scale_up", Service: "missing"}); err == nil || !strings.Contains(err.Error(), "not found in namespace prod") {
+		t.Errorf("missing deployment error = %v", err)
+	}
+	if _, err := service.Autoscale(ctx, models.AutoscaleArgs{Action: "explode"}); err == nil {
+		t.Error("expected an unknown action to be rejected")
+	}
+
+	unconfigured := NewAutomationService(nil, nil, "", nil, nil, nil, AutoscaleSettings{})
+	if _, err := unconfigured.Autoscale(ctx, models.AutoscaleArgs{Action: "analyze"}); err == nil || !strings.Contains(err.Error(), "Kubernetes client not configured") {
+		t.Errorf("error without Kubernetes = %v", err)
+	}
+}
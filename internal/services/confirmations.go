@@ -0,0 +1,70 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// confirmationTTL is how long a confirmation token stays valid
+const confirmationTTL = 5 * time.Minute
+
+// confirmations hands out single-use tokens that destructive tools require on
+// a second call, so an agent sees what it is about to destroy before it does
+type confirmations struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+}
+
+type pendingConfirmation struct {
+	action  string
+	expires time.Time
+}
+
+func newConfirmations() *confirmations {
+	return &confirmations{pending: make(map[string]pendingConfirmation)}
+}
+
+// issue returns a token confirming action, such as "delete job template 7"
+func (c *confirmations) issue(action string) (string, time.Time) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand does not fail on supported platforms
+		panic(fmt.Sprintf("failed to generate confirmation token: %v", err))
+	}
+	token := hex.EncodeToString(buf)
+	expires := time.Now().Add(confirmationTTL)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for key, pending := range c.pending {
+		if now.After(pending.expires) {
+			delete(c.pending, key)
+		}
+	}
+	c.pending[token] = pendingConfirmation{action: action, expires: expires}
+	return token, expires
+}
+
+// redeem consumes a token, failing when it is unknown, expired or was issued for another action
+func (c *confirmations) redeem(token, action string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending, ok := c.pending[token]
+	if !ok {
+		return fmt.Errorf("confirmation token is unknown or already used, call again without it to get a new one")
+	}
+	if time.Now().After(pending.expires) {
+		delete(c.pending, token)
+		return fmt.Errorf("confirmation token expired, call again without it to get a new one")
+	}
+	if pending.action != action {
+		return fmt.Errorf("confirmation token was issued to %s, not to %s", pending.action, action)
+	}
+	delete(c.pending, token)
+	return nil
+}
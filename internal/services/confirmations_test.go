@@ -0,0 +1,50 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfirmations(t *testing.T) {
+	c := newConfirmations()
+
+	token, expires := c.issue("delete job template 7")
+	if len(token) != 16 || time.Until(expires) > confirmationTTL || time.Until(expires) < confirmationTTL-time.Minute {
+		t.Fatalf("issue = %q, expires in %v", token, time.Until(expires))
+	}
+	if other, _ := c.issue("delete job template 7"); other == token {
+		t.Fatal("two confirmations of the same action got the same token")
+	}
+
+	if err := c.redeem(token, "delete job template 8"); err == nil ||
+		err.Error() != "confirmation token was issued to delete job template 7, not to delete job template 8" {
+		t.Errorf("redeem for another action: %v", err)
+	}
+	if err := c.redeem(token, "delete job template 7"); err != nil {
+		t.Errorf("redeem: %v", err)
+	}
+	if err := c.redeem(token, "delete job template 7"); err == nil || !strings.Contains(err.Error(), "unknown or already used") {
+		t.Errorf("second redeem: %v", err)
+	}
+	if err := c.redeem("", "delete job template 7"); err == nil {
+		t.Error("redeemed an empty token")
+	}
+
+	expired, _ := c.issue("delete job template 9")
+	c.pending[expired] = pendingConfirmation{action: "delete job template 9", expires: time.Now().Add(-time.Second)}
+	if err := c.redeem(expired, "delete job template 9"); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("redeem of an expired token: %v", err)
+	}
+	if _, ok := c.pending[expired]; ok {
+		t.Error("expired token kept after redeem")
+	}
+
+	// Issuing sweeps expired tokens
+	stale, _ := c.issue("delete job template 10")
+	c.pending[stale] = pendingConfirmation{action: "delete job template 10", expires: time.Now().Add(-time.Second)}
+	c.issue("delete job template 11")
+	if _, ok := c.pending[stale]; ok {
+		t.Error("expired token kept after a new issue")
+	}
+}
@@ -1,93 +1,163 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 	
+	"github.com/NacerKH/autosphere-mcp-golang/internal/health"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
 )
 
 type HealthService struct {
+	checker *health.Checker
+	monitor *health.Monitor
 }
 
-func NewHealthService() *HealthService {
-	return &HealthService{}
+func NewHealthService(checker *health.Checker, monitor *health.Monitor) *HealthService {
+	return &HealthService{checker: checker, monitor: monitor}
 }
 
-func (s *HealthService) CheckComponent(component string, deep bool) models.ComponentHealth {
-	now := time.Now().Format("2006-01-02 15:04:05")
+// Components returns the components that have probes configured
+func (s *HealthService) Components() []string {
+	return s.checker.Components()
+}
+
+func (s *HealthService) CheckComponent(ctx context.Context, component string, deep bool) models.ComponentHealth {
+	if !s.checker.Has(component) {
+		return models.ComponentHealth{
+			Status:      health.StatusUnknown,
+			Details:     fmt.Sprintf("Component '%s' has no probes configured. Configured components: %s", component, strings.Join(s.checker.Components(), ", ")),
+			LastChecked: time.Now().Format("2006-01-02 15:04:05"),
+		}
+	}
 	
-	healthStatuses := map[string]models.ComponentHealth{
-		"api": {
-			Status:      "healthy",
-			Details:     "API endpoints responding normally, average response time: 120ms",
-			Metrics:     map[string]string{"response_time": "120ms", "error_rate": "0.1%", "requests_per_min": "450"},
-			LastChecked: now,
-		},
-		"database": {
-			Status:      "healthy",
-			Details:     "Database connections stable, query performance optimal",
-			Metrics:     map[string]string{"connections": "45/100", "query_time": "15ms", "cpu_usage": "35%"},
-			LastChecked: now,
-		},
-		"cache": {
-			Status:      "warning",
-			Details:     "Cache hit ratio below optimal, consider increasing cache size",
-			Metrics:     map[string]string{"hit_ratio": "75%", "memory_usage": "88%", "evictions": "12/min"},
-			LastChecked: now,
-		},
-		"web": {
-			Status:      "healthy",
-			Details:     "Web server handling requests efficiently",
-			Metrics:     map[string]string{"active_connections": "23", "cpu_usage": "25%", "memory_usage": "45%"},
-			LastChecked: now,
-		},
-		"workers": {
-			Status:      "healthy",
-			Details:     "Background workers processing queued jobs normally",
-			Metrics:     map[string]string{"queue_size": "5", "processed_jobs": "150/hr", "failed_jobs": "0"},
-			LastChecked: now,
-		},
-		"monitoring": {
-			Status:      "healthy",
-			Details:     "Monitoring systems operational, all alerts configured",
-			Metrics:     map[string]string{"uptime": "99.9%", "alerts": "0 active", "dashboards": "5 active"},
-			LastChecked: now,
-		},
+	result := s.checker.Check(ctx, component, deep)
+	return componentHealth(result, deep)
+}
+
+// componentHealth converts probe results into the component summary
+func componentHealth(result health.ComponentResult, deep bool) models.ComponentHealth {
+	output := models.ComponentHealth{
+		Status:      result.Status,
+		Metrics:     make(map[string]string),
+		LastChecked: result.CheckedAt.Format("2006-01-02 15:04:05"),
 	}
 	
-	if health, exists := healthStatuses[component]; exists {
-		if deep {
-			health.Details += " (deep check completed)"
-		}
-		return health
+	if len(result.Probes) == 0 {
+		output.Details = "All probes of this component are deep checks - run with deep=true"
+		return output
 	}
 	
-	return models.ComponentHealth{
-		Status:      "unknown",
-		Details:     fmt.Sprintf("Component '%s' not recognized", component),
-		LastChecked: now,
+	for _, probe := range result.Probes {
+		output.Probes = append(output.Probes, models.ProbeResult{
+			Name:      probe.Name,
+			Type:      probe.Type,
+			Deep:      probe.Deep,
+			Status:    probe.Status,
+			Latency:   probe.Latency.Round(time.Microsecond).String(),
+			LatencyMs: float64(probe.Latency.Microseconds()) / 1000,
+			Message:   probe.Message,
+			Error:     probe.Error,
+			Metrics:   probe.Metrics,
+		})
+		output.Metrics[probe.Name+"_latency"] = probe.Latency.Round(time.Millisecond).String()
 	}
+	
+	failures := result.Failures()
+	if len(failures) == 0 {
+		output.Details = fmt.Sprintf("%d/%d probes passed", len(result.Probes), len(result.Probes))
+	} else {
+		var problems []string
+		for _, probe := range failures {
+			problem := probe.Error
+			if problem == "" {
+				problem = probe.Message
+			}
+			problems = append(problems, fmt.Sprintf("%s: %s", probe.Name, problem))
+		}
+		output.Details = fmt.Sprintf("%d/%d probes passed - %s", len(result.Probes)-len(failures), len(result.Probes), strings.Join(problems, "; "))
+	}
+	if deep {
+		output.Details += " (deep check)"
+	}
+	
+	return output
 }
 
-func (s *HealthService) GetSystemMetrics() map[string]float64 {
-	return map[string]float64{
-		"cpu":    45.5,
-		"memory": 67.2,
-		"disk":   23.1,
-		"load":   1.8,
+// History summarizes the background probe history of one component, or of
+// all components when component is empty
+func (s *HealthService) History(component string, window time.Duration, samples int) (models.GetHealthHistoryOutput, error) {
+	settings := s.monitor.Settings()
+	output := models.GetHealthHistoryOutput{
+		OverallStatus: health.StatusHealthy,
+		Window:        window.String(),
+		Interval:      settings.Interval.String(),
+		FlapWindow:    settings.FlapWindow.String(),
+		FlapThreshold: settings.FlapThreshold,
+		Timestamp:     time.Now().Format(time.RFC3339),
+	}
+	if settings.Disabled {
+		return output, fmt.Errorf("background health probing is disabled in the health config")
 	}
+	
+	components := s.monitor.Components()
+	if component != "" {
+		if !s.checker.Has(component) {
+			return output, fmt.Errorf("component '%s' has no probes configured. Configured components: %s", component, strings.Join(s.checker.Components(), ", "))
+		}
+		if !s.checker.Monitored(component) {
+			return output, fmt.Errorf("component '%s' only has deep probes, which the background checks skip; use health_check with deep=true", component)
+		}
+		components = []string{component}
+	}
+	
+	for _, name := range components {
+		summary, ok := s.monitor.Summarize(name, window, samples)
+		if !ok {
+			return output, fmt.Errorf("no history for component '%s' yet: the first background check runs at startup, then every %s", name, settings.Interval)
+		}
+		output.OverallStatus = health.Worst(output.OverallStatus, summary.Status)
+		if summary.Flapping {
+			// A flapping component is unreliable even when its latest check passed
+			output.OverallStatus = health.Worst(output.OverallStatus, health.StatusWarning)
+		}
+		output.Components = append(output.Components, componentHistory(summary))
+	}
+	if len(output.Components) == 0 {
+		return output, fmt.Errorf("no health history recorded yet: the first background check runs at startup, then every %s", settings.Interval)
+	}
+	
+	return output, nil
 }
 
-func (s *HealthService) AnalyzeLoad(threshold string) string {
-	switch threshold {
-	case "cpu_high":
-		return "CPU usage is high (85%) - recommend scaling up"
-	case "memory_high":
-		return "Memory usage is high (90%) - recommend scaling up"
-	case "load_high":
-		return "System load is high - recommend scaling up"
-	default:
-		return "Current metrics show normal resource usage - no scaling needed"
+func componentHistory(summary health.Summary) models.ComponentHistory {
+	history := models.ComponentHistory{
+		Component:      summary.Component,
+		Status:         summary.Status,
+		StatusSince:    summary.StatusSince.Format(time.RFC3339),
+		Samples:        summary.Samples,
+		UptimePercent:  math.Round(summary.UptimePercent*100) / 100,
+		HealthyPercent: math.Round(summary.HealthyPercent*100) / 100,
+		Transitions:    summary.Transitions,
+		Flapping:       summary.Flapping,
+		FlapChanges:    summary.FlapChanges,
+		AvgLatency:     summary.AvgLatency.Round(time.Millisecond).String(),
+		MaxLatency:     summary.MaxLatency.Round(time.Millisecond).String(),
+		LastError:      summary.LastError,
+	}
+	if !summary.LastErrorAt.IsZero() {
+		history.LastErrorAt = summary.LastErrorAt.Format(time.RFC3339)
+	}
+	for _, sample := range summary.Recent {
+		history.Recent = append(history.Recent, models.HealthSample{
+			Time:    sample.Time.Format(time.RFC3339),
+			Status:  sample.Status,
+			Latency: sample.Latency.Round(time.Millisecond).String(),
+			Error:   sample.Error,
+		})
 	}
+	return history
 }
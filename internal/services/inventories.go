@@ -0,0 +1,693 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+const defaultHostListLimit = 200
+
+// resolveInventory turns an inventory name or ID into its ID
+func (s *AutomationService) resolveInventory(ctx context.Context, inventory string) (int, error) {
+	if strings.TrimSpace(inventory) == "" {
+		return 0, fmt.Errorf("inventory is required")
+	}
+	return s.awxClient.ResolveID(ctx, awx.ResourceInventory, inventory)
+}
+
+// parseVariables reads host or group variables as AWX stores them, JSON or YAML text
+func parseVariables(text string) (map[string]interface{}, error) {
+	vars, err := awx.ParseExtraVars(text)
+	if err != nil {
+		return nil, fmt.Errorf("stored variables are not a JSON or YAML object: %w", err)
+	}
+	return vars, nil
+}
+
+// appliedError reports a failure after some changes already went through, so
+// the caller knows the state AWX was left in
+func appliedError(err error, applied []string) error {
+	if len(applied) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w (already applied: %s)", err, strings.Join(applied, ", "))
+}
+
+func hostSummary(host awx.Host) models.InventoryHostSummary {
+	summary := models.InventoryHostSummary{
+		ID:                host.ID,
+		Name:              host.Name,
+		Description:       host.Description,
+		Enabled:           host.Enabled,
+		Groups:            host.GroupNames(),
+		HasActiveFailures: host.HasActiveFailures,
+	}
+	if host.SummaryFields.LastJob != nil {
+		summary.LastJobStatus = host.SummaryFields.LastJob.Status
+	}
+	return summary
+}
+
+// ListInventoryHosts lists the hosts of an inventory or of one of its groups
+func (s *AutomationService) ListInventoryHosts(ctx context.Context, args models.ListInventoryHostsArgs) (models.ListInventoryHostsOutput, error) {
+	inventoryID, err := s.resolveInventory(ctx, args.Inventory)
+	if err != nil {
+		return models.ListInventoryHostsOutput{}, err
+	}
+
+	output := models.ListInventoryHostsOutput{InventoryID: inventoryID}
+	groupID := 0
+	if args.Group != "" {
+		group, err := s.awxClient.GetGroup(ctx, inventoryID, args.Group)
+		if err != nil {
+			return models.ListInventoryHostsOutput{}, err
+		}
+		groupID = group.ID
+		output.Group = group.Name
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultHostListLimit
+	}
+
+	log.Printf("Listing hosts of AWX inventory %d (group: %q, search: %q)", inventoryID, output.Group, args.Search)
+
+	hosts, err := s.awxClient.GetInventoryHosts(ctx, inventoryID, groupID, args.Search, limit)
+	if err != nil {
+		return models.ListInventoryHostsOutput{}, fmt.Errorf("failed to list hosts of inventory %d: %w", inventoryID, err)
+	}
+
+	output.Hosts = make([]models.InventoryHostSummary, len(hosts.Results))
+	for i, host := range hosts.Results {
+		output.Hosts[i] = hostSummary(host)
+	}
+	output.Total = hosts.Count
+	output.Truncated = hosts.Truncated
+	return output, nil
+}
+
+// ListInventoryGroups lists the groups of an inventory with their variables
+func (s *AutomationService) ListInventoryGroups(ctx context.Context, args models.ListInventoryGroupsArgs) (models.ListInventoryGroupsOutput, error) {
+	inventoryID, err := s.resolveInventory(ctx, args.Inventory)
+	if err != nil {
+		return models.ListInventoryGroupsOutput{}, err
+	}
+
+	groups, err := s.awxClient.GetInventoryGroups(ctx, inventoryID)
+	if err != nil {
+		return models.ListInventoryGroupsOutput{}, fmt.Errorf("failed to list groups of inventory %d: %w", inventoryID, err)
+	}
+
+	output := models.ListInventoryGroupsOutput{InventoryID: inventoryID, Total: groups.Count}
+	output.Groups = make([]models.InventoryGroupSummary, len(groups.Results))
+	for i, group := range groups.Results {
+		summary := models.InventoryGroupSummary{ID: group.ID, Name: group.Name, Description: group.Description}
+		if vars, err := parseVariables(group.Variables); err == nil && len(vars) > 0 {
+			summary.Variables = vars
+		}
+		output.Groups[i] = summary
+	}
+	return output, nil
+}
+
+// GetInventoryHost shows a host with its groups, variables and, when asked, its gathered facts
+func (s *AutomationService) GetInventoryHost(ctx context.Context, args models.GetInventoryHostArgs) (models.GetInventoryHostOutput, error) {
+	inventoryID, err := s.resolveInventory(ctx, args.Inventory)
+	if err != nil {
+		return models.GetInventoryHostOutput{}, err
+	}
+
+	host, err := s.awxClient.GetHost(ctx, inventoryID, args.Host)
+	if err != nil {
+		return models.GetInventoryHostOutput{}, err
+	}
+
+	output := models.GetInventoryHostOutput{
+		InventoryHostSummary: hostSummary(*host),
+		InventoryID:          inventoryID,
+		InstanceID:           host.InstanceID,
+	}
+
+	// The host summary lists only the first few groups
+	if groups, err := s.awxClient.GetHostGroups(ctx, host.ID); err == nil {
+		output.Groups = make([]string, len(groups.Results))
+		for i, group := range groups.Results {
+			output.Groups[i] = group.Name
+		}
+	} else {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("could not list all groups: %v", err))
+	}
+
+	if vars, err := parseVariables(host.Variables); err == nil {
+		output.Variables = vars
+	} else {
+		output.Warnings = append(output.Warnings, err.Error())
+	}
+
+	if args.Facts || len(args.FactNames) > 0 {
+		facts, err := s.awxClient.GetHostFacts(ctx, host.ID)
+		if err != nil {
+			return models.GetInventoryHostOutput{}, err
+		}
+		output.FactCount = len(facts)
+		output.Facts = filterFacts(facts, args.FactNames)
+		if len(facts) == 0 {
+			output.Warnings = append(output.Warnings, "no facts gathered: run a job with fact caching enabled against the host")
+		}
+	}
+
+	return output, nil
+}
+
+// filterFacts keeps the facts named in patterns; a trailing * matches a prefix
+func filterFacts(facts map[string]interface{}, patterns []string) map[string]interface{} {
+	if len(patterns) == 0 {
+		return facts
+	}
+
+	filtered := make(map[string]interface{})
+	for name, value := range facts {
+		for _, pattern := range patterns {
+			prefix, isPrefix := strings.CutSuffix(pattern, "*")
+			if name == pattern || (isPrefix && strings.HasPrefix(name, prefix)) {
+				filtered[name] = value
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// resolveGroups turns group names or IDs of an inventory into groups
+func (s *AutomationService) resolveGroups(ctx context.Context, inventoryID int, references []string) ([]*awx.Group, error) {
+	groups := make([]*awx.Group, 0, len(references))
+	for _, reference := range references {
+		group, err := s.awxClient.GetGroup(ctx, inventoryID, reference)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// AddInventoryHost adds a host to an inventory and to the given groups
+func (s *AutomationService) AddInventoryHost(ctx context.Context, args models.AddInventoryHostArgs) (models.InventoryHostChangeOutput, error) {
+	if strings.TrimSpace(args.Name) == "" {
+		return models.InventoryHostChangeOutput{}, fmt.Errorf("name is required")
+	}
+
+	inventoryID, err := s.resolveInventory(ctx, args.Inventory)
+	if err != nil {
+		return models.InventoryHostChangeOutput{}, err
+	}
+	groups, err := s.resolveGroups(ctx, inventoryID, args.Groups)
+	if err != nil {
+		return models.InventoryHostChangeOutput{}, err
+	}
+
+	request := awx.HostRequest{Name: args.Name, Description: args.Description, Enabled: true}
+	if args.Enabled != nil {
+		request.Enabled = *args.Enabled
+	}
+	if len(args.Variables) > 0 {
+		// AWX stores variables as JSON or YAML text
+		encoded, err := json.Marshal(args.Variables)
+		if err != nil {
+			return models.InventoryHostChangeOutput{}, fmt.Errorf("failed to encode variables: %w", err)
+		}
+		request.Variables = string(encoded)
+	}
+
+	log.Printf("Adding host %s to AWX inventory %d", args.Name, inventoryID)
+
+	host, err := s.awxClient.CreateHost(ctx, inventoryID, request)
+	if err != nil {
+		return models.InventoryHostChangeOutput{}, err
+	}
+
+	changes := []string{fmt.Sprintf("host %s added to inventory %d", host.Name, inventoryID)}
+	for _, group := range groups {
+		if err := s.awxClient.AssociateGroupHost(ctx, group.ID, host.ID, false); err != nil {
+			return models.InventoryHostChangeOutput{}, appliedError(err, changes)
+		}
+		changes = append(changes, fmt.Sprintf("added to group %s", group.Name))
+	}
+
+	return models.InventoryHostChangeOutput{
+		ID:          host.ID,
+		Name:        host.Name,
+		InventoryID: inventoryID,
+		Enabled:     host.Enabled,
+		Changes:     changes,
+		Variables:   args.Variables,
+		Message:     fmt.Sprintf("Host '%s' (ID: %d) added to inventory %d", host.Name, host.ID, inventoryID),
+	}, nil
+}
+
+// UpdateInventoryHost renames, describes, enables or disables a host, edits
+// its variables and moves it between groups. Groups are resolved before
+// anything changes.
+func (s *AutomationService) UpdateInventoryHost(ctx context.Context, args models.UpdateInventoryHostArgs) (models.InventoryHostChangeOutput, error) {
+	inventoryID, err := s.resolveInventory(ctx, args.Inventory)
+	if err != nil {
+		return models.InventoryHostChangeOutput{}, err
+	}
+	host, err := s.awxClient.GetHost(ctx, inventoryID, args.Host)
+	if err != nil {
+		return models.InventoryHostChangeOutput{}, err
+	}
+	addGroups, err := s.resolveGroups(ctx, inventoryID, args.AddGroups)
+	if err != nil {
+		return models.InventoryHostChangeOutput{}, err
+	}
+	removeGroups, err := s.resolveGroups(ctx, inventoryID, args.RemoveGroups)
+	if err != nil {
+		return models.InventoryHostChangeOutput{}, err
+	}
+
+	update := awx.HostUpdate{Name: args.Name, Description: args.Description, Enabled: args.Enabled}
+	changeVariables := args.Variables != nil || len(args.RemoveVariables) > 0 || args.ReplaceVariables
+	if update == (awx.HostUpdate{}) && !changeVariables && len(addGroups)+len(removeGroups) == 0 {
+		return models.InventoryHostChangeOutput{}, fmt.Errorf("nothing to update: give a field, variables or groups to change")
+	}
+
+	output := models.InventoryHostChangeOutput{ID: host.ID, Name: host.Name, InventoryID: inventoryID, Enabled: host.Enabled}
+	if args.ReplaceVariables && len(args.Variables) == 0 {
+		pending, err := s.confirmClearVariables(ctx, awx.HostVariablesEndpoint(host.ID), fmt.Sprintf("clear the variables of host %d", host.ID), args.ConfirmationToken)
+		if err != nil {
+			return models.InventoryHostChangeOutput{}, err
+		}
+		if pending != nil {
+			output.Variables = pending.current
+			output.ConfirmationToken = pending.token
+			output.ExpiresAt = pending.expires.Format(time.RFC3339)
+			output.Message = fmt.Sprintf("Nothing changed: replace_variables without variables deletes every variable of host '%s' (%s). "+
+				"Pass the variables to keep, or call update_inventory_host again with the same arguments and confirmation_token %s within %v to clear them.",
+				host.Name, strings.Join(sortedVarNames(pending.current), ", "), pending.token, confirmationTTL)
+			return output, nil
+		}
+	}
+
+	log.Printf("Updating host %s (ID: %d) of AWX inventory %d", host.Name, host.ID, inventoryID)
+
+	if update != (awx.HostUpdate{}) {
+		updated, err := s.awxClient.UpdateHost(ctx, host.ID, update)
+		if err != nil {
+			return models.InventoryHostChangeOutput{}, err
+		}
+		output.Name, output.Enabled = updated.Name, updated.Enabled
+		if args.Name != nil {
+			output.Changes = append(output.Changes, fmt.Sprintf("renamed from %s", host.Name))
+		}
+		if args.Description != nil {
+			output.Changes = append(output.Changes, "description changed")
+		}
+		if args.Enabled != nil {
+			if updated.Enabled {
+				output.Changes = append(output.Changes, "enabled")
+			} else {
+				output.Changes = append(output.Changes, "disabled")
+			}
+		}
+	}
+
+	if changeVariables {
+		vars, err := s.awxClient.SetVariables(ctx, awx.HostVariablesEndpoint(host.ID), args.Variables, args.RemoveVariables, args.ReplaceVariables)
+		if err != nil {
+			return models.InventoryHostChangeOutput{}, appliedError(err, output.Changes)
+		}
+		output.Variables = vars
+		output.Changes = append(output.Changes, variablesChange(args.Variables, args.RemoveVariables, args.ReplaceVariables))
+	}
+
+	for _, group := range addGroups {
+		if err := s.awxClient.AssociateGroupHost(ctx, group.ID, host.ID, false); err != nil {
+			return models.InventoryHostChangeOutput{}, appliedError(err, output.Changes)
+		}
+		output.Changes = append(output.Changes, fmt.Sprintf("added to group %s", group.Name))
+	}
+	for _, group := range removeGroups {
+		if err := s.awxClient.AssociateGroupHost(ctx, group.ID, host.ID, true); err != nil {
+			return models.InventoryHostChangeOutput{}, appliedError(err, output.Changes)
+		}
+		output.Changes = append(output.Changes, fmt.Sprintf("removed from group %s", group.Name))
+	}
+
+	output.Message = fmt.Sprintf("Host '%s' (ID: %d) updated: %s", output.Name, output.ID, strings.Join(output.Changes, ", "))
+	return output, nil
+}
+
+// variablesChange describes a variables edit for the change list
+func variablesChange(vars map[string]interface{}, remove []string, replace bool) string {
+	if replace {
+		return fmt.Sprintf("variables replaced (%d keys)", len(vars))
+	}
+	keys := sortedVarNames(vars)
+	parts := make([]string, 0, 2)
+	if len(keys) > 0 {
+		parts = append(parts, "set "+strings.Join(keys, ", "))
+	}
+	if len(remove) > 0 {
+		parts = append(parts, "removed "+strings.Join(remove, ", "))
+	}
+	return "variables: " + strings.Join(parts, "; ")
+}
+
+func sortedVarNames(vars map[string]interface{}) []string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// pendingClear is a confirmation to ask for before variables are cleared
+type pendingClear struct {
+	token   string
+	expires time.Time
+	current map[string]interface{} // The variables that would be deleted
+}
+
+// confirmClearVariables guards replace_variables without variables, which
+// deletes every variable of a host or group. The first call returns a token
+// with the variables at stake; the second redeems it and returns nil, as does
+// a call on something without variables.
+func (s *AutomationService) confirmClearVariables(ctx context.Context, endpoint, action, token string) (*pendingClear, error) {
+	if token != "" {
+		return nil, s.confirmations.redeem(token, action)
+	}
+	current, err := s.awxClient.GetVariables(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if len(current) == 0 {
+		return nil, nil
+	}
+	pending := &pendingClear{current: current}
+	pending.token, pending.expires = s.confirmations.issue(action)
+	return pending, nil
+}
+
+// RemoveInventoryHost deletes a host in two calls, like DeleteJobTemplate: the
+// first shows the host and returns a confirmation token, the second deletes it.
+// To take a host out of one group only, use UpdateInventoryHost.
+func (s *AutomationService) RemoveInventoryHost(ctx context.Context, args models.RemoveInventoryHostArgs) (models.RemoveInventoryHostOutput, error) {
+	inventoryID, err := s.resolveInventory(ctx, args.Inventory)
+	if err != nil {
+		return models.RemoveInventoryHostOutput{}, err
+	}
+	host, err := s.awxClient.GetHost(ctx, inventoryID, args.Host)
+	if err != nil {
+		return models.RemoveInventoryHostOutput{}, err
+	}
+
+	output := models.RemoveInventoryHostOutput{ID: host.ID, Name: host.Name, InventoryID: inventoryID, Groups: host.GroupNames()}
+	action := fmt.Sprintf("delete host %d", host.ID)
+
+	if args.ConfirmationToken != "" {
+		if err := s.confirmations.redeem(args.ConfirmationToken, action); err != nil {
+			return models.RemoveInventoryHostOutput{}, err
+		}
+
+		log.Printf("Deleting host %s (ID: %d) from AWX inventory %d", host.Name, host.ID, inventoryID)
+		if err := s.awxClient.DeleteHost(ctx, host.ID); err != nil {
+			return models.RemoveInventoryHostOutput{}, err
+		}
+
+		output.Removed = true
+		output.Message = fmt.Sprintf("Host '%s' (ID: %d) removed from inventory %d", host.Name, host.ID, inventoryID)
+		return output, nil
+	}
+
+	token, expires := s.confirmations.issue(action)
+	output.ConfirmationToken = token
+	output.ExpiresAt = expires.Format(time.RFC3339)
+	output.Message = fmt.Sprintf("Removing host '%s' (ID: %d) deletes it with its variables and facts; an inventory source sync may bring it back. "+
+		"To take it out of a group only, use update_inventory_host with remove_groups. Call remove_inventory_host again with confirmation_token %s within %v to delete it.",
+		host.Name, host.ID, token, confirmationTTL)
+	return output, nil
+}
+
+// UpdateInventoryGroup renames or describes a group and edits its variables
+func (s *AutomationService) UpdateInventoryGroup(ctx context.Context, args models.UpdateInventoryGroupArgs) (models.UpdateInventoryGroupOutput, error) {
+	inventoryID, err := s.resolveInventory(ctx, args.Inventory)
+	if err != nil {
+		return models.UpdateInventoryGroupOutput{}, err
+	}
+	group, err := s.awxClient.GetGroup(ctx, inventoryID, args.Group)
+	if err != nil {
+		return models.UpdateInventoryGroupOutput{}, err
+	}
+
+	update := awx.GroupUpdate{Name: args.Name, Description: args.Description}
+	changeVariables := args.Variables != nil || len(args.RemoveVariables) > 0 || args.ReplaceVariables
+	if update == (awx.GroupUpdate{}) && !changeVariables {
+		return models.UpdateInventoryGroupOutput{}, fmt.Errorf("nothing to update: give a field or variables to change")
+	}
+
+	output := models.UpdateInventoryGroupOutput{ID: group.ID, Name: group.Name, InventoryID: inventoryID}
+	if args.ReplaceVariables && len(args.Variables) == 0 {
+		pending, err := s.confirmClearVariables(ctx, awx.GroupVariablesEndpoint(group.ID), fmt.Sprintf("clear the variables of group %d", group.ID), args.ConfirmationToken)
+		if err != nil {
+			return models.UpdateInventoryGroupOutput{}, err
+		}
+		if pending != nil {
+			output.Variables = pending.current
+			output.ConfirmationToken = pending.token
+			output.ExpiresAt = pending.expires.Format(time.RFC3339)
+			output.Message = fmt.Sprintf("Nothing changed: replace_variables without variables deletes every variable of group '%s' (%s). "+
+				"Pass the variables to keep, or call update_inventory_group again with the same arguments and confirmation_token %s within %v to clear them.",
+				group.Name, strings.Join(sortedVarNames(pending.current), ", "), pending.token, confirmationTTL)
+			return output, nil
+		}
+	}
+
+	log.Printf("Updating group %s (ID: %d) of AWX inventory %d", group.Name, group.ID, inventoryID)
+
+	if update != (awx.GroupUpdate{}) {
+		updated, err := s.awxClient.UpdateGroup(ctx, group.ID, update)
+		if err != nil {
+			return models.UpdateInventoryGroupOutput{}, err
+		}
+		output.Name = updated.Name
+		if args.Name != nil {
+			output.Changes = append(output.Changes, fmt.Sprintf("renamed from %s", group.Name))
+		}
+		if args.Description != nil {
+			output.Changes = append(output.Changes, "description changed")
+		}
+	}
+
+	if changeVariables {
+		vars, err := s.awxClient.SetVariables(ctx, awx.GroupVariablesEndpoint(group.ID), args.Variables, args.RemoveVariables, args.ReplaceVariables)
+		if err != nil {
+			return models.UpdateInventoryGroupOutput{}, appliedError(err, output.Changes)
+		}
+		output.Variables = vars
+		output.Changes = append(output.Changes, variablesChange(args.Variables, args.RemoveVariables, args.ReplaceVariables))
+	}
+
+	output.Message = fmt.Sprintf("Group '%s' (ID: %d) updated: %s", output.Name, output.ID, strings.Join(output.Changes, ", "))
+	return output, nil
+}
+
+// ListInventorySources lists the sources of an inventory with their last sync status
+func (s *AutomationService) ListInventorySources(ctx context.Context, args models.ListInventorySourcesArgs) (models.ListInventorySourcesOutput, error) {
+	inventoryID, err := s.resolveInventory(ctx, args.Inventory)
+	if err != nil {
+		return models.ListInventorySourcesOutput{}, err
+	}
+
+	sources, err := s.awxClient.GetInventorySources(ctx, inventoryID)
+	if err != nil {
+		return models.ListInventorySourcesOutput{}, fmt.Errorf("failed to list sources of inventory %d: %w", inventoryID, err)
+	}
+
+	output := models.ListInventorySourcesOutput{InventoryID: inventoryID, Total: sources.Count}
+	output.Sources = make([]models.InventorySourceSummary, len(sources.Results))
+	for i, source := range sources.Results {
+		summary := models.InventorySourceSummary{ID: source.ID, Name: source.Name, Source: source.Source, Status: source.Status}
+		if source.LastUpdated != nil {
+			summary.LastUpdated = source.LastUpdated.Format(time.RFC3339)
+		}
+		output.Sources[i] = summary
+	}
+	return output, nil
+}
+
+// SyncInventorySource starts a sync of one source, or of every source of the
+// inventory, and optionally waits for them to finish. onProgress (optional)
+// is called after every poll while waiting.
+func (s *AutomationService) SyncInventorySource(ctx context.Context, args models.SyncInventorySourceArgs, onProgress func(models.JobProgress)) (models.InventorySyncOutput, error) {
+	inventoryID, err := s.resolveInventory(ctx, args.Inventory)
+	if err != nil {
+		return models.InventorySyncOutput{}, err
+	}
+
+	sources, err := s.awxClient.GetInventorySources(ctx, inventoryID)
+	if err != nil {
+		return models.InventorySyncOutput{}, fmt.Errorf("failed to list sources of inventory %d: %w", inventoryID, err)
+	}
+
+	selected := sources.Results
+	if args.Source != "" {
+		selected = nil
+		for _, source := range sources.Results {
+			if source.Name == args.Source || fmt.Sprint(source.ID) == args.Source {
+				selected = append(selected, source)
+				break
+			}
+		}
+		if len(selected) == 0 {
+			names := make([]string, len(sources.Results))
+			for i, source := range sources.Results {
+				names[i] = fmt.Sprintf("%s (ID: %d)", source.Name, source.ID)
+			}
+			return models.InventorySyncOutput{}, fmt.Errorf("inventory source '%s' not found in inventory %d. Available sources: %s", args.Source, inventoryID, strings.Join(names, ", "))
+		}
+	}
+	if len(selected) == 0 {
+		return models.InventorySyncOutput{}, fmt.Errorf("inventory %d has no sources to sync; its hosts are managed by hand", inventoryID)
+	}
+
+	output := models.InventorySyncOutput{InventoryID: inventoryID}
+	names := make(map[int]string, len(selected))
+	for _, source := range selected {
+		names[source.ID] = source.Name
+		update, err := s.awxClient.SyncInventorySource(ctx, source.ID)
+		if err != nil {
+			started := make([]string, len(output.Updates))
+			for i, update := range output.Updates {
+				started[i] = fmt.Sprintf("sync %d of %s", update.UpdateID, update.SourceName)
+			}
+			return models.InventorySyncOutput{}, appliedError(err, started)
+		}
+		output.Updates = append(output.Updates, inventoryUpdateStatus(*update, source.Name))
+	}
+
+	if args.Wait {
+		if err := s.waitForInventoryUpdates(ctx, &output, args.Timeout, args.PollInterval, names, onProgress); err != nil {
+			return models.InventorySyncOutput{}, err
+		}
+	}
+
+	output.Message = inventorySyncMessage(output)
+	return output, nil
+}
+
+// CheckInventorySync reports the state of inventory syncs started earlier
+func (s *AutomationService) CheckInventorySync(ctx context.Context, args models.CheckInventorySyncArgs) (models.InventorySyncOutput, error) {
+	if len(args.UpdateIDs) == 0 {
+		return models.InventorySyncOutput{}, fmt.Errorf("at least one update_id is required")
+	}
+
+	output := models.InventorySyncOutput{}
+	for _, id := range args.UpdateIDs {
+		update, err := s.awxClient.GetInventoryUpdate(ctx, id)
+		if err != nil {
+			return models.InventorySyncOutput{}, fmt.Errorf("failed to get inventory update %d: %w", id, err)
+		}
+		output.Updates = append(output.Updates, inventoryUpdateStatus(*update, update.Name))
+	}
+
+	output.Message = inventorySyncMessage(output)
+	return output, nil
+}
+
+// waitForInventoryUpdates polls the syncs in output until all finished or the timeout expires
+func (s *AutomationService) waitForInventoryUpdates(ctx context.Context, output *models.InventorySyncOutput, timeoutSeconds, intervalSeconds int, names map[int]string, onProgress func(models.JobProgress)) error {
+	timeout, interval := waitDurations(timeoutSeconds, intervalSeconds)
+
+	log.Printf("Waiting for %d inventory syncs (timeout: %s, poll interval: %s)", len(output.Updates), timeout, interval)
+
+	start := time.Now()
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		running := 0
+		for i, status := range output.Updates {
+			if awx.IsFinishedStatus(status.Status) {
+				continue
+			}
+			update, err := s.awxClient.GetInventoryUpdate(ctx, status.UpdateID)
+			if err != nil {
+				return fmt.Errorf("failed to get inventory update %d: %w", status.UpdateID, err)
+			}
+			output.Updates[i] = inventoryUpdateStatus(*update, names[update.InventorySource])
+			if !update.IsFinished() {
+				running++
+				if onProgress != nil {
+					onProgress(models.JobProgress{
+						JobID:   update.ID,
+						Status:  update.Status,
+						Elapsed: time.Since(start),
+						Timeout: timeout,
+						Task:    fmt.Sprintf("syncing %s", names[update.InventorySource]),
+					})
+				}
+			}
+		}
+
+		if running == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for inventory syncs: %w", ctx.Err())
+		case <-deadline.C:
+			output.TimedOut = true
+		case <-ticker.C:
+		}
+
+		if output.TimedOut {
+			break
+		}
+	}
+
+	output.Waited = time.Since(start).Round(time.Second).String()
+	return nil
+}
+
+func inventoryUpdateStatus(update awx.InventoryUpdate, sourceName string) models.InventoryUpdateStatus {
+	return models.InventoryUpdateStatus{
+		UpdateID:    update.ID,
+		SourceID:    update.InventorySource,
+		SourceName:  sourceName,
+		Status:      update.Status,
+		Elapsed:     update.Elapsed,
+		Explanation: update.JobExplanation,
+	}
+}
+
+func inventorySyncMessage(output models.InventorySyncOutput) string {
+	counts := make(map[string]int)
+	for _, update := range output.Updates {
+		counts[update.Status]++
+	}
+	statuses := make([]string, 0, len(counts))
+	for status, count := range counts {
+		statuses = append(statuses, fmt.Sprintf("%d %s", count, status))
+	}
+	sort.Strings(statuses)
+
+	message := fmt.Sprintf("%d inventory syncs: %s", len(output.Updates), strings.Join(statuses, ", "))
+	if output.TimedOut {
+		message += fmt.Sprintf(". Stopped waiting after %s; check again with check_inventory_sync", output.Waited)
+	}
+	return message
+}
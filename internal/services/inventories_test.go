@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// fakeInventory serves inventory 3 of an AWX API: its hosts, groups, their
+// variables and memberships, and inventory sources whose syncs go through
+// the statuses listed in syncStatuses, one per poll
+type fakeInventory struct {
+	mu           sync.Mutex
+	hosts        map[int]*fakeNode
+	groups       map[int]*fakeNode
+	members      map[int]map[int]bool // Group ID to host IDs
+	sources      []awx.InventorySource
+	syncStatuses map[int][]string // Source ID to the statuses its update reports
+	polls        map[int]int      // Update ID to the number of GETs
+	requests     []string         // "METHOD path", without the lookups
+}
+
+type fakeNode struct {
+	Name      string
+	Enabled   bool
+	Variables map[string]interface{}
+}
+
+var fakeInventoryPath = regexp.MustCompile(`^/api/v2/(hosts|groups|inventory_sources|inventory_updates)/(\d+)/(\w+/)?$`)
+
+func newFakeInventory(t *testing.T) (*fakeInventory, *AutomationService) {
+	t.Helper()
+	fake := &fakeInventory{
+		hosts: map[int]*fakeNode{
+			11: {Name: "web1", Enabled: true, Variables: map[string]interface{}{
+				"env": "prod", "limits": map[string]interface{}{"cpu": "1"}, "zones": []interface{}{"a"},
+			}},
+			12: {Name: "web2", Enabled: true, Variables: map[string]interface{}{}},
+		},
+		groups: map[int]*fakeNode{
+			21: {Name: "webservers", Variables: map[string]interface{}{"http_port": 80.0}},
+			22: {Name: "canary", Variables: map[string]interface{}{}},
+		},
+		members:      map[int]map[int]bool{21: {11: true}, 22: {}},
+		syncStatuses: make(map[int][]string),
+		polls:        make(map[int]int),
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := awx.NewClient(awx.ClientConfig{BaseURL: server.URL, Token: "awx-token", MaxRetries: -1})
+	return fake, NewAutomationService(nil, client, server.URL, nil, nil, nil, AutoscaleSettings{})
+}
+
+func (f *fakeInventory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/api/v2/inventories/3/hosts/":
+		f.lookup(w, r.URL.Query(), f.hosts)
+		return
+	case "/api/v2/inventories/3/groups/":
+		f.lookup(w, r.URL.Query(), f.groups)
+		return
+	case "/api/v2/inventories/3/inventory_sources/":
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(f.sources), "results": f.sources})
+		return
+	}
+
+	match := fakeInventoryPath.FindStringSubmatch(r.URL.Path)
+	if match == nil {
+		http.NotFound(w, r)
+		return
+	}
+	collection, sub := match[1], match[3]
+	id, _ := strconv.Atoi(match[2])
+	if r.Method != http.MethodGet || sub == "variable_data/" {
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	}
+
+	nodes := f.hosts
+	if collection == "groups" {
+		nodes = f.groups
+	}
+	var body map[string]interface{}
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch {
+	case collection == "inventory_sources" && r.Method == http.MethodPost && sub == "update/":
+		updateID := 900 + id
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": updateID, "inventory_update": updateID, "status": "pending"})
+	case collection == "inventory_updates" && r.Method == http.MethodGet:
+		sourceID := id - 900
+		statuses := f.syncStatuses[sourceID]
+		status := statuses[min(f.polls[id], len(statuses)-1)]
+		f.polls[id]++
+		json.NewEncoder(w).Encode(awx.InventoryUpdate{ID: id, Status: status, InventorySource: sourceID, Elapsed: 1.5})
+	case nodes[id] == nil:
+		http.NotFound(w, r)
+	case sub == "variable_data/" && r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(nodes[id].Variables)
+	case sub == "variable_data/" && r.Method == http.MethodPut:
+		nodes[id].Variables = body
+		json.NewEncoder(w).Encode(body)
+	case collection == "groups" && sub == "hosts/" && r.Method == http.MethodPost:
+		hostID := int(body["id"].(float64))
+		if body["disassociate"] == true {
+			delete(f.members[id], hostID)
+		} else {
+			f.members[id][hostID] = true
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case sub == "" && r.Method == http.MethodGet:
+		f.writeNode(w, collection, id)
+	case sub == "" && r.Method == http.MethodPatch:
+		if name, ok := body["name"].(string); ok {
+			nodes[id].Name = name
+		}
+		if enabled, ok := body["enabled"].(bool); ok {
+			nodes[id].Enabled = enabled
+		}
+		f.writeNode(w, collection, id)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// lookup answers the ?name= and ?id= queries GetHost and GetGroup send
+func (f *fakeInventory) lookup(w http.ResponseWriter, query url.Values, nodes map[int]*fakeNode) {
+	results := []map[string]interface{}{}
+	for id, node := range nodes {
+		if node.Name == query.Get("name") || strconv.Itoa(id) == query.Get("id") {
+			results = append(results, map[string]interface{}{"id": id, "name": node.Name})
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+}
+
+func (f *fakeInventory) writeNode(w http.ResponseWriter, collection string, id int) {
+	node := f.hosts[id]
+	if collection == "groups" {
+		node = f.groups[id]
+	}
+	variables, _ := json.Marshal(node.Variables)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"id": id, "name": node.Name, "inventory": 3, "enabled": node.Enabled, "variables": string(variables),
+	})
+}
+
+func (f *fakeInventory) takeRequests() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	requests := strings.Join(f.requests, ", ")
+	f.requests = nil
+	return requests
+}
+
+func jsonString(t *testing.T, value interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestUpdateInventoryHostMergesVariablesAndGroups(t *testing.T) {
+	fake, service := newFakeInventory(t)
+	disabled := false
+
+	output, err := service.UpdateInventoryHost(context.Background(), models.UpdateInventoryHostArgs{
+		Inventory:       "3",
+		Host:            "web1",
+		Enabled:         &disabled,
+		Variables:       map[string]interface{}{"limits": map[string]interface{}{"memory": "2Gi"}, "zones": []interface{}{"b", "c"}},
+		RemoveVariables: []string{"env"},
+		AddGroups:       []string{"canary"},
+		RemoveGroups:    []string{"21"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateInventoryHost: %v", err)
+	}
+
+	want := `{"limits":{"cpu":"1","memory":"2Gi"},"zones":["b","c"]}`
+	if got := jsonString(t, fake.hosts[11].Variables); got != want {
+		t.Errorf("stored variables = %s, want %s", got, want)
+	}
+	if got := jsonString(t, output.Variables); got != want {
+		t.Errorf("output variables = %s, want %s", got, want)
+	}
+	if got := fake.takeRequests(); got != "PATCH /api/v2/hosts/11/, GET /api/v2/hosts/11/variable_data/, PUT /api/v2/hosts/11/variable_data/, "+
+		"POST /api/v2/groups/22/hosts/, POST /api/v2/groups/21/hosts/" {
+		t.Errorf("requests = %s", got)
+	}
+	if fake.hosts[11].Enabled || !fake.members[22][11] || fake.members[21][11] {
+		t.Errorf("host enabled %t, in canary %t, in webservers %t", fake.hosts[11].Enabled, fake.members[22][11], fake.members[21][11])
+	}
+	if got := strings.Join(output.Changes, "; "); got != "disabled; variables: set limits, zones; removed env; added to group canary; removed from group webservers" {
+		t.Errorf("changes = %s", got)
+	}
+}
+
+func TestUpdateInventoryHostReplacesVariables(t *testing.T) {
+	fake, service := newFakeInventory(t)
+
+	_, err := service.UpdateInventoryHost(context.Background(), models.UpdateInventoryHostArgs{
+		Inventory: "3", Host: "web1", ReplaceVariables: true,
+		Variables: map[string]interface{}{"zones": []interface{}{"b"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateInventoryHost: %v", err)
+	}
+	if got := jsonString(t, fake.hosts[11].Variables); got != `{"zones":["b"]}` {
+		t.Errorf("stored variables = %s", got)
+	}
+	if got := fake.takeRequests(); got != "PUT /api/v2/hosts/11/variable_data/" {
+		t.Errorf("requests = %s, want a single PUT without reading the current variables", got)
+	}
+}
+
+func TestClearingVariablesNeedsConfirmation(t *testing.T) {
+	fake, service := newFakeInventory(t)
+	ctx := context.Background()
+	clear := models.UpdateInventoryHostArgs{Inventory: "3", Host: "web1", ReplaceVariables: true, AddGroups: []string{"canary"}}
+
+	first, err := service.UpdateInventoryHost(ctx, clear)
+	if err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if first.ConfirmationToken == "" || first.ExpiresAt == "" || !strings.Contains(first.Message, "env, limits, zones") {
+		t.Fatalf("first call = %+v, want a token and the variables at stake", first)
+	}
+	if got := jsonString(t, first.Variables); got != `{"env":"prod","limits":{"cpu":"1"},"zones":["a"]}` {
+		t.Errorf("variables at stake = %s", got)
+	}
+	if got := fake.takeRequests(); got != "GET /api/v2/hosts/11/variable_data/" || fake.members[22][11] {
+		t.Fatalf("the first call changed something: %s", got)
+	}
+
+	// A token issued for another host is refused
+	other, err := service.UpdateInventoryHost(ctx, models.UpdateInventoryHostArgs{Inventory: "3", Host: "web1", ReplaceVariables: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.takeRequests()
+	fake.hosts[12].Variables = map[string]interface{}{"keep": true}
+	if _, err := service.UpdateInventoryHost(ctx, models.UpdateInventoryHostArgs{Inventory: "3", Host: "web2", ReplaceVariables: true, ConfirmationToken: other.ConfirmationToken}); err == nil || !strings.Contains(err.Error(), "was issued to clear the variables of host 11") {
+		t.Errorf("token of another host: %v", err)
+	}
+
+	clear.ConfirmationToken = first.ConfirmationToken
+	second, err := service.UpdateInventoryHost(ctx, clear)
+	if err != nil {
+		t.Fatalf("confirmed call: %v", err)
+	}
+	if len(fake.hosts[11].Variables) != 0 || !fake.members[22][11] || second.ConfirmationToken != "" {
+		t.Errorf("variables %v, in canary %t after confirming", fake.hosts[11].Variables, fake.members[22][11])
+	}
+	if got := strings.Join(second.Changes, "; "); got != "variables replaced (0 keys); added to group canary" {
+		t.Errorf("changes = %s", got)
+	}
+	if _, err := service.UpdateInventoryHost(ctx, clear); err == nil || !strings.Contains(err.Error(), "unknown or already used") {
+		t.Errorf("reused token: %v", err)
+	}
+
+	// Nothing to lose, nothing to confirm
+	fake.takeRequests()
+	if output, err := service.UpdateInventoryHost(ctx, models.UpdateInventoryHostArgs{Inventory: "3", Host: "web1", ReplaceVariables: true}); err != nil || output.ConfirmationToken != "" {
+		t.Errorf("clearing empty variables = %+v, %v", output, err)
+	}
+}
+
+func TestUpdateInventoryGroupVariables(t *testing.T) {
+	fake, service := newFakeInventory(t)
+	ctx := context.Background()
+
+	output, err := service.UpdateInventoryGroup(ctx, models.UpdateInventoryGroupArgs{
+		Inventory: "3", Group: "webservers", Variables: map[string]interface{}{"https_port": 443},
+	})
+	if err != nil {
+		t.Fatalf("UpdateInventoryGroup: %v", err)
+	}
+	if got := jsonString(t, fake.groups[21].Variables); got != `{"http_port":80,"https_port":443}` {
+		t.Errorf("stored variables = %s", got)
+	}
+	if output.Message != "Group 'webservers' (ID: 21) updated: variables: set https_port" {
+		t.Errorf("message = %s", output.Message)
+	}
+
+	clear := models.UpdateInventoryGroupArgs{Inventory: "3", Group: "webservers", ReplaceVariables: true}
+	first, err := service.UpdateInventoryGroup(ctx, clear)
+	if err != nil || first.ConfirmationToken == "" {
+		t.Fatalf("first clear = %+v, %v", first, err)
+	}
+	if len(fake.groups[21].Variables) != 2 {
+		t.Fatal("the group variables were cleared without confirmation")
+	}
+	clear.ConfirmationToken = first.ConfirmationToken
+	if _, err := service.UpdateInventoryGroup(ctx, clear); err != nil || len(fake.groups[21].Variables) != 0 {
+		t.Errorf("confirmed clear: %v, variables %v", err, fake.groups[21].Variables)
+	}
+
+	if _, err := service.UpdateInventoryGroup(ctx, models.UpdateInventoryGroupArgs{Inventory: "3", Group: "webservers"}); err == nil || !strings.Contains(err.Error(), "nothing to update") {
+		t.Errorf("empty update: %v", err)
+	}
+	if _, err := service.UpdateInventoryGroup(ctx, models.UpdateInventoryGroupArgs{Inventory: "3", Group: "dbservers", Variables: map[string]interface{}{"a": 1}}); err == nil || !strings.Contains(err.Error(), "group 'dbservers' not found") {
+		t.Errorf("unknown group: %v", err)
+	}
+}
+
+func TestSyncInventorySourceWaits(t *testing.T) {
+	fake, service := newFakeInventory(t)
+	fake.sources = []awx.InventorySource{{ID: 5, Name: "aws"}, {ID: 6, Name: "vmware"}}
+	fake.syncStatuses[5] = []string{"running", "successful"}
+	fake.syncStatuses[6] = []string{"running", "running", "failed"}
+
+	var progress []string
+	output, err := service.SyncInventorySource(context.Background(), models.SyncInventorySourceArgs{Inventory: "3", Wait: true, PollInterval: 1},
+		func(p models.JobProgress) { progress = append(progress, fmt.Sprintf("%d %s", p.JobID, p.Task)) })
+	if err != nil {
+		t.Fatalf("SyncInventorySource: %v", err)
+	}
+
+	var statuses []string
+	for _, update := range output.Updates {
+		statuses = append(statuses, fmt.Sprintf("%d %s %s", update.UpdateID, update.SourceName, update.Status))
+	}
+	if got := strings.Join(statuses, ", "); got != "905 aws successful, 906 vmware failed" {
+		t.Errorf("updates = %s", got)
+	}
+	if output.TimedOut || output.Waited == "" || output.Message != "2 inventory syncs: 1 failed, 1 successful" {
+		t.Errorf("output = %+v", output)
+	}
+	if got := strings.Join(progress, ", "); got != "905 syncing aws, 906 syncing vmware, 906 syncing vmware" {
+		t.Errorf("progress = %s", got)
+	}
+	// Finished updates are not polled again
+	if fake.polls[905] != 2 || fake.polls[906] != 3 {
+		t.Errorf("polls = %v", fake.polls)
+	}
+}
+
+func TestSyncInventorySourceTimesOut(t *testing.T) {
+	fake, service := newFakeInventory(t)
+	fake.sources = []awx.InventorySource{{ID: 5, Name: "aws"}, {ID: 6, Name: "vmware"}}
+	fake.syncStatuses[6] = []string{"running"}
+
+	output, err := service.SyncInventorySource(context.Background(), models.SyncInventorySourceArgs{Inventory: "3", Source: "vmware", Wait: true, Timeout: 1}, nil)
+	if err != nil {
+		t.Fatalf("SyncInventorySource: %v", err)
+	}
+	if len(output.Updates) != 1 || output.Updates[0].Status != "running" || !output.TimedOut {
+		t.Errorf("output = %+v, want the vmware sync still running", output)
+	}
+	if !strings.Contains(output.Message, "check again with check_inventory_sync") {
+		t.Errorf("message = %s", output.Message)
+	}
+	if got := fake.takeRequests(); got != "POST /api/v2/inventory_sources/6/update/" {
+		t.Errorf("requests = %s, want only the selected source synced", got)
+	}
+
+	if _, err := service.SyncInventorySource(context.Background(), models.SyncInventorySourceArgs{Inventory: "3", Source: "gcp"}, nil); err == nil ||
+		!strings.Contains(err.Error(), "Available sources: aws (ID: 5), vmware (ID: 6)") {
+		t.Errorf("unknown source: %v", err)
+	}
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+const (
+	// defaultHistoryDays and maxHistoryDays bound the analyzed window
+	defaultHistoryDays = 7
+	maxHistoryDays     = 90
+	// maxHistoryJobs caps the jobs analyzed; the oldest are dropped first
+	maxHistoryJobs = 5000
+	// maxProbedFailedJobs caps the failed jobs whose host results are read,
+	// one request each
+	maxProbedFailedJobs = 50
+	// A template is flaky when, over at least flakyMinRuns jobs, its outcome
+	// changed flakyMinFlips times or more and on at least flakyFlipRatio of
+	// the chances it had
+	flakyMinRuns   = 5
+	flakyMinFlips  = 3
+	flakyFlipRatio = 0.4
+	// repeatedHostFailures is how many failed jobs make a host repeatedly failing
+	repeatedHostFailures = 2
+)
+
+// templateHistory gathers the finished jobs of one template, oldest first
+type templateHistory struct {
+	stats        models.TemplateJobStats
+	durations    []float64
+	lastSuccess  *bool
+	failingSince time.Time
+	recovery     time.Duration
+}
+
+func (h *templateHistory) add(job awx.Job) {
+	h.stats.Runs++
+	h.stats.LastStatus = job.Status
+	h.stats.LastJobID = job.ID
+
+	var success bool
+	switch job.Status {
+	case "successful":
+		h.stats.Successful++
+		success = true
+	case "failed", "error":
+		h.stats.Failed++
+	default:
+		h.stats.Canceled++
+		return
+	}
+	if job.Elapsed > 0 {
+		h.durations = append(h.durations, job.Elapsed)
+	}
+
+	if h.lastSuccess != nil && *h.lastSuccess != success {
+		h.stats.OutcomeFlips++
+	}
+	h.lastSuccess = &success
+
+	finished := *job.Finished
+	switch {
+	case !success && h.failingSince.IsZero():
+		h.failingSince = finished
+	case success && !h.failingSince.IsZero():
+		h.stats.Recoveries++
+		h.recovery += finished.Sub(h.failingSince)
+		h.failingSince = time.Time{}
+	}
+}
+
+func (h *templateHistory) finish() models.TemplateJobStats {
+	stats := h.stats
+	if decided := stats.Successful + stats.Failed; decided > 0 {
+		stats.SuccessRate = percentage(stats.Successful, decided)
+		stats.FailureRate = percentage(stats.Failed, decided)
+		stats.Flaky = decided >= flakyMinRuns && stats.OutcomeFlips >= flakyMinFlips &&
+			float64(stats.OutcomeFlips)/float64(decided-1) >= flakyFlipRatio
+	}
+
+	sort.Float64s(h.durations)
+	stats.P50Seconds = percentile(h.durations, 50)
+	stats.P95Seconds = percentile(h.durations, 95)
+
+	if stats.Recoveries > 0 {
+		stats.MTTRSeconds = math.Round((h.recovery / time.Duration(stats.Recoveries)).Seconds())
+	}
+	if !h.failingSince.IsZero() {
+		stats.CurrentlyFailing = true
+		stats.FailingSince = h.failingSince.UTC().Format(time.RFC3339)
+	}
+	return stats
+}
+
+// percentage returns part of total as a percentage with one decimal
+func percentage(part, total int) float64 {
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return math.Round(sorted[rank-1]*10) / 10
+}
+
+// AnalyzeJobHistory computes per-template success rates, durations and
+// recovery times over the jobs that finished in the last days, flags flaky
+// templates and finds hosts that fail in several jobs
+func (s *AutomationService) AnalyzeJobHistory(ctx context.Context, args models.AnalyzeJobHistoryArgs) (models.JobHistoryAnalyticsOutput, error) {
+	days := args.Days
+	if days <= 0 {
+		days = defaultHistoryDays
+	}
+	if days > maxHistoryDays {
+		return models.JobHistoryAnalyticsOutput{}, fmt.Errorf("days must be at most %d", maxHistoryDays)
+	}
+
+	templateID := 0
+	if args.JobTemplate != "" {
+		template, err := s.awxClient.GetJobTemplateByName(ctx, args.JobTemplate)
+		if err != nil {
+			return models.JobHistoryAnalyticsOutput{}, fmt.Errorf("failed to find job template: %w", err)
+		}
+		templateID = template.ID
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+
+	log.Printf("Analyzing AWX job history since %s (template: %d)", from.Format(time.RFC3339), templateID)
+
+	history, err := s.awxClient.GetJobHistory(ctx, from, templateID, maxHistoryJobs)
+	if err != nil {
+		return models.JobHistoryAnalyticsOutput{}, fmt.Errorf("failed to get job history: %w", err)
+	}
+
+	output := models.JobHistoryAnalyticsOutput{
+		From:      from.UTC().Format(time.RFC3339),
+		To:        to.UTC().Format(time.RFC3339),
+		TotalJobs: history.Count,
+		Truncated: history.Truncated,
+		Templates: []models.TemplateJobStats{},
+	}
+	if history.Truncated {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("only the latest %d of %d jobs were analyzed", len(history.Results), history.Count))
+	}
+
+	// The history is newest first; outcomes are followed oldest first
+	templates := make(map[string]*templateHistory)
+	var order []string
+	var failedJobs []awx.Job
+	for i := len(history.Results) - 1; i >= 0; i-- {
+		job := history.Results[i]
+		if !job.IsFinished() || job.Finished == nil {
+			continue
+		}
+		key := fmt.Sprintf("id:%d", job.JobTemplate)
+		if job.JobTemplate == 0 {
+			key = "name:" + job.TemplateName()
+		}
+		template, ok := templates[key]
+		if !ok {
+			template = &templateHistory{stats: models.TemplateJobStats{TemplateID: job.JobTemplate, TemplateName: job.TemplateName()}}
+			templates[key] = template
+			order = append(order, key)
+		}
+		template.add(job)
+
+		output.JobsAnalyzed++
+		switch job.Status {
+		case "successful":
+			output.Successful++
+		case "failed", "error":
+			output.Failed++
+			failedJobs = append(failedJobs, job)
+		default:
+			output.Canceled++
+		}
+	}
+
+	for _, key := range order {
+		stats := templates[key].finish()
+		output.Templates = append(output.Templates, stats)
+		if stats.Flaky {
+			output.FlakyTemplates = append(output.FlakyTemplates, stats.TemplateName)
+		}
+	}
+	sort.SliceStable(output.Templates, func(i, j int) bool {
+		if output.Templates[i].Failed != output.Templates[j].Failed {
+			return output.Templates[i].Failed > output.Templates[j].Failed
+		}
+		return output.Templates[i].TemplateName < output.Templates[j].TemplateName
+	})
+	sort.Strings(output.FlakyTemplates)
+	if decided := output.Successful + output.Failed; decided > 0 {
+		output.SuccessRate = percentage(output.Successful, decided)
+	}
+
+	output.FailingHosts = s.failingHosts(ctx, failedJobs, &output)
+
+	output.Message = fmt.Sprintf("Analyzed %d jobs of %d templates over %d days: %.1f%% successful, %d flaky templates, %d hosts failing repeatedly",
+		output.JobsAnalyzed, len(output.Templates), days, output.SuccessRate, len(output.FlakyTemplates), len(output.FailingHosts))
+	log.Printf("%s", output.Message)
+	return output, nil
+}
+
+// failingHosts reads the host results of the latest failed jobs and returns
+// the hosts that failed or were unreachable in more than one of them
+func (s *AutomationService) failingHosts(ctx context.Context, failedJobs []awx.Job, output *models.JobHistoryAnalyticsOutput) []models.FailingHost {
+	// failedJobs is oldest first; the latest failures matter most
+	if len(failedJobs) > maxProbedFailedJobs {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("host results were read for the latest %d of %d failed jobs", maxProbedFailedJobs, len(failedJobs)))
+		failedJobs = failedJobs[len(failedJobs)-maxProbedFailedJobs:]
+	}
+
+	hosts := make(map[string]*models.FailingHost)
+	templates := make(map[string]map[string]bool)
+	for i := len(failedJobs) - 1; i >= 0; i-- {
+		job := failedJobs[i]
+		summaries, err := s.awxClient.GetJobHostSummaries(ctx, job.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				output.Warnings = append(output.Warnings, "stopped reading host results: "+ctx.Err().Error())
+				break
+			}
+			log.Printf("Failed to get host summaries of job %d: %v", job.ID, err)
+			output.Warnings = append(output.Warnings, fmt.Sprintf("could not read the host results of job %d", job.ID))
+			continue
+		}
+		output.FailedJobsProbed++
+
+		for _, summary := range summaries {
+			if !summary.Failed && summary.Failures == 0 && summary.Dark == 0 {
+				continue
+			}
+			host, ok := hosts[summary.HostName]
+			if !ok {
+				// Jobs are read newest first, so the first failure seen is the latest
+				host = &models.FailingHost{
+					Host:          summary.HostName,
+					LastFailedJob: job.ID,
+					LastFailure:   job.Finished.UTC().Format(time.RFC3339),
+				}
+				hosts[summary.HostName] = host
+				templates[summary.HostName] = make(map[string]bool)
+			}
+			host.FailedJobs++
+			if summary.Dark > 0 {
+				host.Unreachable++
+			}
+			templates[summary.HostName][job.TemplateName()] = true
+		}
+	}
+
+	var failing []models.FailingHost
+	for name, host := range hosts {
+		if host.FailedJobs < repeatedHostFailures {
+			continue
+		}
+		for template := range templates[name] {
+			host.Templates = append(host.Templates, template)
+		}
+		sort.Strings(host.Templates)
+		failing = append(failing, *host)
+	}
+	sort.Slice(failing, func(i, j int) bool {
+		if failing[i].FailedJobs != failing[j].FailedJobs {
+			return failing[i].FailedJobs > failing[j].FailedJobs
+		}
+		return failing[i].Host < failing[j].Host
+	})
+	return failing
+}
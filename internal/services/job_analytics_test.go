@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// historyStart is when the first job of the synthetic histories finished
+var historyStart = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+// historyJob is a job of template 7 that finished minutes after historyStart
+func historyJob(id int, status string, elapsed float64, minutes int) awx.Job {
+	finished := historyStart.Add(time.Duration(minutes) * time.Minute)
+	return awx.Job{ID: id, Name: "deploy", Status: status, Elapsed: elapsed, Finished: &finished, JobTemplate: 7}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		values []float64
+		p      float64
+		want   float64
+	}{
+		{values: nil, p: 50, want: 0},
+		{values: []float64{12.34}, p: 50, want: 12.3},
+		{values: []float64{12.34}, p: 95, want: 12.3},
+		{values: []float64{10, 20, 30, 40}, p: 50, want: 20},
+		{values: []float64{10, 20, 30, 40}, p: 95, want: 40},
+		{values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p: 50, want: 5},
+		{values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p: 95, want: 10},
+		{values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, p: 0, want: 1},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.values, tt.p); got != tt.want {
+			t.Errorf("percentile(%v, %v) = %v, want %v", tt.values, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestTemplateHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		jobs []awx.Job // Oldest first
+		want models.TemplateJobStats
+	}{
+		{
+			name: "all successful",
+			jobs: []awx.Job{
+				historyJob(1, "successful", 40, 0), historyJob(2, "successful", 10, 10),
+				historyJob(3, "successful", 30, 20), historyJob(4, "successful", 20, 30),
+			},
+			want: models.TemplateJobStats{Runs: 4, Successful: 4, SuccessRate: 100, P50Seconds: 20, P95Seconds: 40,
+				LastStatus: "successful", LastJobID: 4},
+		},
+		{
+			name: "recovery time runs from the first failure",
+			jobs: []awx.Job{
+				historyJob(1, "failed", 5, 0), historyJob(2, "error", 0, 5), historyJob(3, "successful", 60, 15),
+			},
+			want: models.TemplateJobStats{Runs: 3, Successful: 1, Failed: 2, SuccessRate: 33.3, FailureRate: 66.7,
+				P50Seconds: 5, P95Seconds: 60, Recoveries: 1, MTTRSeconds: 900, OutcomeFlips: 1,
+				LastStatus: "successful", LastJobID: 3},
+		},
+		{
+			name: "recovery times averaged",
+			jobs: []awx.Job{
+				historyJob(1, "failed", 1, 0), historyJob(2, "successful", 1, 10),
+				historyJob(3, "failed", 1, 20), historyJob(4, "successful", 1, 50),
+			},
+			want: models.TemplateJobStats{Runs: 4, Successful: 2, Failed: 2, SuccessRate: 50, FailureRate: 50,
+				P50Seconds: 1, P95Seconds: 1, Recoveries: 2, MTTRSeconds: 1200, OutcomeFlips: 3,
+				LastStatus: "successful", LastJobID: 4},
+		},
+		{
+			name: "currently failing",
+			jobs: []awx.Job{
+				historyJob(1, "successful", 1, 0), historyJob(2, "failed", 1, 10), historyJob(3, "failed", 1, 20),
+			},
+			want: models.TemplateJobStats{Runs: 3, Successful: 1, Failed: 2, SuccessRate: 33.3, FailureRate: 66.7,
+				P50Seconds: 1, P95Seconds: 1, OutcomeFlips: 1, CurrentlyFailing: true, FailingSince: "2026-03-02T08:10:00Z",
+				LastStatus: "failed", LastJobID: 3},
+		},
+		{
+			name: "canceled jobs leave the rates, durations and flips alone",
+			jobs: []awx.Job{
+				historyJob(1, "successful", 10, 0), historyJob(2, "canceled", 99, 5), historyJob(3, "successful", 20, 10),
+			},
+			want: models.TemplateJobStats{Runs: 3, Successful: 2, Canceled: 1, SuccessRate: 100,
+				P50Seconds: 10, P95Seconds: 20, LastStatus: "successful", LastJobID: 3},
+		},
+		{
+			name: "alternating outcomes are flaky",
+			jobs: []awx.Job{
+				historyJob(1, "successful", 1, 0), historyJob(2, "failed", 1, 10), historyJob(3, "successful", 1, 20),
+				historyJob(4, "failed", 1, 30), historyJob(5, "successful", 1, 40),
+			},
+			want: models.TemplateJobStats{Runs: 5, Successful: 3, Failed: 2, SuccessRate: 60, FailureRate: 40,
+				P50Seconds: 1, P95Seconds: 1, Recoveries: 2, MTTRSeconds: 600, OutcomeFlips: 4, Flaky: true,
+				LastStatus: "successful", LastJobID: 5},
+		},
+		{
+			name: "enough flips but too few of the chances",
+			jobs: []awx.Job{
+				historyJob(1, "successful", 1, 0), historyJob(2, "successful", 1, 10), historyJob(3, "successful", 1, 20),
+				historyJob(4, "successful", 1, 30), historyJob(5, "successful", 1, 40), historyJob(6, "successful", 1, 50),
+				historyJob(7, "successful", 1, 60), historyJob(8, "failed", 1, 70), historyJob(9, "successful", 1, 80),
+				historyJob(10, "failed", 1, 90),
+			},
+			// 3 flips out of 9 chances is below the 40% ratio
+			want: models.TemplateJobStats{Runs: 10, Successful: 8, Failed: 2, SuccessRate: 80, FailureRate: 20,
+				P50Seconds: 1, P95Seconds: 1, Recoveries: 1, MTTRSeconds: 600, OutcomeFlips: 3,
+				CurrentlyFailing: true, FailingSince: "2026-03-02T09:30:00Z", LastStatus: "failed", LastJobID: 10},
+		},
+		{
+			name: "too few runs to be flaky",
+			jobs: []awx.Job{
+				historyJob(1, "failed", 1, 0), historyJob(2, "successful", 1, 10),
+				historyJob(3, "failed", 1, 20), historyJob(4, "successful", 1, 30),
+			},
+			want: models.TemplateJobStats{Runs: 4, Successful: 2, Failed: 2, SuccessRate: 50, FailureRate: 50,
+				P50Seconds: 1, P95Seconds: 1, Recoveries: 2, MTTRSeconds: 600, OutcomeFlips: 3,
+				LastStatus: "successful", LastJobID: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var history templateHistory
+			for _, job := range tt.jobs {
+				history.add(job)
+			}
+			if got := history.finish(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("stats = %+v\nwant    %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeJobHistory serves a job list, newest first, and the host summaries of
+// failed jobs; jobs without summaries answer 500
+type fakeJobHistory struct {
+	jobs      []awx.Job
+	summaries map[int][]awx.JobHostSummary
+}
+
+func (f *fakeJobHistory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/api/v2/jobs/":
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(f.jobs), "results": f.jobs})
+	case r.URL.Path == "/api/v2/unified_jobs/":
+		w.Write([]byte(`{"count": 1, "results": [{"type": "job"}]}`))
+	case strings.HasSuffix(r.URL.Path, "/job_host_summaries/"):
+		for id, summaries := range f.summaries {
+			if r.URL.Path == fmt.Sprintf("/api/v2/jobs/%d/job_host_summaries/", id) {
+				json.NewEncoder(w).Encode(map[string]interface{}{"count": len(summaries), "results": summaries})
+				return
+			}
+		}
+		http.Error(w, `{"detail": "boom"}`, http.StatusInternalServerError)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestAnalyzeJobHistory(t *testing.T) {
+	backup := func(job awx.Job) awx.Job {
+		job.JobTemplate, job.Name = 8, "backup"
+		return job
+	}
+	fake := &fakeJobHistory{
+		// Newest first, as AWX returns them
+		jobs: []awx.Job{
+			historyJob(16, "running", 0, 0),
+			backup(historyJob(15, "failed", 30, 70)),
+			historyJob(14, "failed", 10, 60),
+			historyJob(13, "successful", 10, 50),
+			historyJob(12, "failed", 10, 40),
+			backup(historyJob(11, "successful", 30, 30)),
+			historyJob(10, "failed", 10, 20),
+			historyJob(9, "canceled", 10, 10),
+		},
+		summaries: map[int][]awx.JobHostSummary{
+			15: {{HostName: "db1", Failures: 1}, {HostName: "web1", OK: 4}},
+			14: {{HostName: "web1", Dark: 1}, {HostName: "web2", Failed: true}},
+			10: {{HostName: "web1", Failures: 2}, {HostName: "db1", Dark: 1}},
+			// Job 12 has no readable host results
+		},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client := awx.NewClient(awx.ClientConfig{BaseURL: server.URL, Token: "awx-token", MaxRetries: -1})
+	service := NewAutomationService(nil, client, server.URL, nil, nil, nil, AutoscaleSettings{})
+
+	output, err := service.AnalyzeJobHistory(context.Background(), models.AnalyzeJobHistoryArgs{Days: 30})
+	if err != nil {
+		t.Fatalf("AnalyzeJobHistory: %v", err)
+	}
+
+	if output.JobsAnalyzed != 7 || output.Successful != 2 || output.Failed != 4 || output.Canceled != 1 || output.SuccessRate != 33.3 {
+		t.Errorf("totals = %d analyzed, %d successful, %d failed, %d canceled, %.1f%%",
+			output.JobsAnalyzed, output.Successful, output.Failed, output.Canceled, output.SuccessRate)
+	}
+	var templates []string
+	for _, stats := range output.Templates {
+		templates = append(templates, stats.TemplateName)
+	}
+	if strings.Join(templates, ",") != "deploy,backup" {
+		t.Errorf("templates = %v, want the most failing first", templates)
+	}
+	if deploy := output.Templates[0]; deploy.Runs != 5 || deploy.Failed != 3 || deploy.OutcomeFlips != 2 || deploy.MTTRSeconds != 1800 || !deploy.CurrentlyFailing {
+		t.Errorf("deploy stats = %+v", deploy)
+	}
+
+	want := []models.FailingHost{
+		{Host: "db1", FailedJobs: 2, Unreachable: 1, Templates: []string{"backup", "deploy"}, LastFailedJob: 15, LastFailure: "2026-03-02T09:10:00Z"},
+		{Host: "web1", FailedJobs: 2, Unreachable: 1, Templates: []string{"deploy"}, LastFailedJob: 14, LastFailure: "2026-03-02T09:00:00Z"},
+	}
+	if !reflect.DeepEqual(output.FailingHosts, want) {
+		t.Errorf("failing hosts = %+v\nwant %+v", output.FailingHosts, want)
+	}
+	if output.FailedJobsProbed != 3 || strings.Join(output.Warnings, "; ") != "could not read the host results of job 12" {
+		t.Errorf("probed %d, warnings %v", output.FailedJobsProbed, output.Warnings)
+	}
+
+	if _, err := service.AnalyzeJobHistory(context.Background(), models.AnalyzeJobHistoryArgs{Days: 91}); err == nil ||
+		err.Error() != "days must be at most 90" {
+		t.Errorf("91 days: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// collectPlaybookResults fills the play recap of a finished job from the AWX
+// host summaries, plus the failing task of every failed host
+func (s *AutomationService) collectPlaybookResults(ctx context.Context, output *models.AWXStatusOutput) error {
+	summaries, err := s.awxClient.GetJobHostSummaries(ctx, output.JobID)
+	if err != nil {
+		return err
+	}
+
+	totals := map[string]int{"ok": 0, "changed": 0, "failed": 0, "unreachable": 0, "skipped": 0, "rescued": 0, "ignored": 0}
+	failedHosts := 0
+	for _, summary := range summaries {
+		output.HostResults = append(output.HostResults, models.HostResult{
+			Host:        summary.HostName,
+			OK:          summary.OK,
+			Changed:     summary.Changed,
+			Failed:      summary.Failures,
+			Unreachable: summary.Dark,
+			Skipped:     summary.Skipped,
+			Rescued:     summary.Rescued,
+			Ignored:     summary.Ignored,
+		})
+
+		totals["ok"] += summary.OK
+		totals["changed"] += summary.Changed
+		totals["failed"] += summary.Failures
+		totals["unreachable"] += summary.Dark
+		totals["skipped"] += summary.Skipped
+		totals["rescued"] += summary.Rescued
+		totals["ignored"] += summary.Ignored
+		if summary.Failed || summary.Failures > 0 || summary.Dark > 0 {
+			failedHosts++
+		}
+	}
+
+	for key, value := range totals {
+		output.PlaybookResults[key] = value
+	}
+	output.PlaybookResults["hosts"] = len(summaries)
+	output.PlaybookResults["failed_hosts"] = failedHosts
+
+	if failedHosts == 0 {
+		return nil
+	}
+
+	failures, err := s.awxClient.GetJobFailures(ctx, output.JobID)
+	if err != nil {
+		return err
+	}
+	for _, failure := range failures {
+		output.Failures = append(output.Failures, models.HostFailure{
+			Host:    failure.Host,
+			Task:    failure.Task,
+			Module:  failure.Module,
+			Message: failure.Message,
+			Event:   failure.Event,
+		})
+	}
+
+	return nil
+}
+
+func (s *AutomationService) GetJobEvents(ctx context.Context, args models.GetJobEventsArgs) (models.GetJobEventsOutput, error) {
+	if args.JobID <= 0 {
+		return models.GetJobEventsOutput{}, fmt.Errorf("valid job_id is required")
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	log.Printf("Getting events for AWX job %d (host: %s, event: %s, failed_only: %t)", args.JobID, args.Host, args.EventType, args.FailedOnly)
+
+	events, err := s.awxClient.GetJobEvents(ctx, args.JobID, awx.JobEventFilter{
+		Host:       args.Host,
+		EventType:  args.EventType,
+		FailedOnly: args.FailedOnly,
+		Limit:      limit,
+	})
+	if err != nil {
+		log.Printf("Failed to get job events: %v", err)
+		return models.GetJobEventsOutput{}, fmt.Errorf("failed to get job events: %w", err)
+	}
+
+	infos := make([]models.JobEventInfo, len(events.Results))
+	for i, event := range events.Results {
+		infos[i] = models.JobEventInfo{
+			ID:      event.ID,
+			Counter: event.Counter,
+			Event:   event.Event,
+			Host:    event.HostName,
+			Play:    event.Play,
+			Task:    event.Task,
+			Module:  event.Module(),
+			Changed: event.Changed,
+			Failed:  event.Failed,
+			Message: event.Message(),
+		}
+		if event.Created != nil {
+			infos[i].Created = event.Created.Format(time.RFC3339)
+		}
+	}
+
+	log.Printf("Retrieved %d of %d events for job %d", len(infos), events.Count, args.JobID)
+
+	return models.GetJobEventsOutput{
+		JobID:  args.JobID,
+		Events: infos,
+		Total:  events.Count,
+	}, nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// defaultOutputMaxBytes keeps a single get_job_output response small enough
+// for an MCP client context
+const defaultOutputMaxBytes = 64 << 10
+
+// outputLine is one stdout line with its absolute (0-based) line number
+type outputLine struct {
+	number int
+	text   string
+	match  bool
+}
+
+func (s *AutomationService) GetJobOutput(ctx context.Context, args models.GetJobOutputArgs) (models.GetJobOutputOutput, error) {
+	if args.JobID <= 0 {
+		return models.GetJobOutputOutput{}, fmt.Errorf("valid job_id is required")
+	}
+
+	maxBytes := args.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultOutputMaxBytes
+	}
+
+	var grep *regexp.Regexp
+	if args.Grep != "" {
+		re, err := regexp.Compile(args.Grep)
+		if err != nil {
+			return models.GetJobOutputOutput{}, fmt.Errorf("invalid grep pattern: %w", err)
+		}
+		grep = re
+	}
+
+	startLine, endLine := args.StartLine, args.EndLine
+	totalLines := 0
+
+	switch {
+	case args.Cursor != "":
+		cursorStart, cursorEnd, err := parseOutputCursor(args.Cursor)
+		if err != nil {
+			return models.GetJobOutputOutput{}, err
+		}
+		startLine, endLine = cursorStart, cursorEnd
+	case args.Tail > 0:
+		total, err := s.awxClient.GetJobStdoutLineCount(ctx, args.JobID)
+		if err != nil {
+			return models.GetJobOutputOutput{}, fmt.Errorf("failed to count job output lines: %w", err)
+		}
+		totalLines = total
+		startLine, endLine = total-args.Tail, 0
+		if startLine < 0 {
+			startLine = 0
+		}
+	}
+
+	if endLine > 0 && endLine <= startLine {
+		return models.GetJobOutputOutput{}, fmt.Errorf("end_line must be greater than start_line")
+	}
+
+	log.Printf("Getting output for AWX job: %d (format: %s, lines: %d-%d)", args.JobID, args.Format, startLine, endLine)
+
+	stdout, err := s.awxClient.GetJobStdout(ctx, args.JobID, awx.StdoutOptions{
+		Format:    args.Format,
+		StartLine: startLine,
+		EndLine:   endLine,
+	})
+	if err != nil {
+		log.Printf("Failed to get job output: %v", err)
+		return models.GetJobOutputOutput{}, fmt.Errorf("failed to get job output: %w", err)
+	}
+	if stdout.TotalLines >= 0 {
+		totalLines = stdout.TotalLines
+	}
+
+	content := stdout.Content
+	if args.StripANSI {
+		content = awx.StripANSI(content)
+	}
+
+	lines := splitOutputLines(content, stdout.StartLine)
+	// A read cut at the client byte cap may end in a partial line
+	if stdout.Truncated && len(lines) > 1 {
+		lines = lines[:len(lines)-1]
+	}
+
+	selected := lines
+	matches := 0
+	if grep != nil {
+		selected, matches = grepOutputLines(lines, grep, args.ContextLines)
+	}
+
+	output, lastLine, cut := renderOutputLines(selected, grep != nil, maxBytes)
+
+	result := models.GetJobOutputOutput{
+		JobID:      args.JobID,
+		Output:     output,
+		Format:     stdout.Format,
+		StartLine:  stdout.StartLine,
+		EndLine:    lastLine + 1,
+		TotalLines: totalLines,
+		Matches:    matches,
+		Truncated:  cut || stdout.Truncated,
+	}
+	if len(selected) == 0 {
+		result.EndLine = stdout.StartLine
+	}
+	switch {
+	case cut:
+		// Continue from the first line that did not fit
+		result.NextCursor = formatOutputCursor(lastLine+1, endLine)
+	case stdout.Truncated && len(lines) > 0:
+		// Continue after the last line scanned from AWX
+		result.NextCursor = formatOutputCursor(lines[len(lines)-1].number+1, endLine)
+	}
+
+	log.Printf("Retrieved output for job %d (%d bytes, truncated: %t)", args.JobID, len(output), result.Truncated)
+
+	return result, nil
+}
+
+func splitOutputLines(content string, firstLine int) []outputLine {
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return nil
+	}
+
+	raw := strings.Split(content, "\n")
+	lines := make([]outputLine, len(raw))
+	for i, text := range raw {
+		lines[i] = outputLine{number: firstLine + i, text: strings.TrimSuffix(text, "\r")}
+	}
+	return lines
+}
+
+// grepOutputLines keeps matching lines plus contextLines before and after each match
+func grepOutputLines(lines []outputLine, re *regexp.Regexp, contextLines int) ([]outputLine, int) {
+	if contextLines < 0 {
+		contextLines = 0
+	}
+
+	keep := make([]bool, len(lines))
+	matches := 0
+	for i := range lines {
+		if !re.MatchString(awx.StripANSI(lines[i].text)) {
+			continue
+		}
+		lines[i].match = true
+		matches++
+		for j := i - contextLines; j <= i+contextLines; j++ {
+			if j >= 0 && j < len(lines) {
+				keep[j] = true
+			}
+		}
+	}
+
+	var selected []outputLine
+	for i, line := range lines {
+		if keep[i] {
+			selected = append(selected, line)
+		}
+	}
+	return selected, matches
+}
+
+// renderOutputLines joins lines until maxBytes is reached. Grep results are
+// prefixed with their line number ("N:" for matches, "N-" for context) and
+// non-contiguous groups are separated by "--". It returns the rendered text,
+// the number of the last line included and whether lines were left out.
+func renderOutputLines(lines []outputLine, numbered bool, maxBytes int) (string, int, bool) {
+	var builder strings.Builder
+	lastLine := -1
+
+	for i, line := range lines {
+		text := line.text
+		if numbered {
+			separator := "-"
+			if line.match {
+				separator = ":"
+			}
+			text = fmt.Sprintf("%d%s %s", line.number+1, separator, text)
+			if i > 0 && line.number != lines[i-1].number+1 {
+				text = "--\n" + text
+			}
+		}
+
+		if builder.Len()+len(text)+1 > maxBytes && builder.Len() > 0 {
+			return builder.String(), lastLine, true
+		}
+		builder.WriteString(text)
+		builder.WriteString("\n")
+		lastLine = line.number
+	}
+
+	return builder.String(), lastLine, false
+}
+
+// Cursors are "<start_line>" or "<start_line>:<end_line>"
+func formatOutputCursor(startLine, endLine int) string {
+	if endLine > 0 {
+		return fmt.Sprintf("%d:%d", startLine, endLine)
+	}
+	return strconv.Itoa(startLine)
+}
+
+func parseOutputCursor(cursor string) (int, int, error) {
+	startPart, endPart, hasEnd := strings.Cut(cursor, ":")
+
+	startLine, err := strconv.Atoi(startPart)
+	if err != nil || startLine < 0 {
+		return 0, 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	endLine := 0
+	if hasEnd {
+		endLine, err = strconv.Atoi(endPart)
+		if err != nil || endLine < 0 {
+			return 0, 0, fmt.Errorf("invalid cursor %q", cursor)
+		}
+	}
+	return startLine, endLine, nil
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// launchPrompt is a launch_awx_job field, whether the template prompts for it
+// and the key AWX reports its default under
+type launchPrompt struct {
+	field, defaultKey string
+	asked             bool
+}
+
+func launchPrompts(requirements *awx.LaunchRequirements) []launchPrompt {
+	return []launchPrompt{
+		{"extra_vars", "extra_vars", requirements.AskVariablesOnLaunch},
+		{"inventory", "inventory", requirements.AskInventoryOnLaunch},
+		{"credentials", "credentials", requirements.AskCredentialOnLaunch},
+		{"limit", "limit", requirements.AskLimitOnLaunch},
+		{"tags", "job_tags", requirements.AskTagsOnLaunch},
+		{"skip_tags", "skip_tags", requirements.AskSkipTagsOnLaunch},
+		{"job_type", "job_type", requirements.AskJobTypeOnLaunch},
+		{"verbosity", "verbosity", requirements.AskVerbosityOnLaunch},
+		{"diff_mode", "diff_mode", requirements.AskDiffModeOnLaunch},
+		{"forks", "forks", requirements.AskForksOnLaunch},
+		{"timeout", "timeout", requirements.AskTimeoutOnLaunch},
+		{"execution_environment", "execution_environment", requirements.AskExecutionEnvironmentOnLaunch},
+		{"labels", "labels", requirements.AskLabelsOnLaunch},
+		{"instance_groups", "instance_groups", requirements.AskInstanceGroupsOnLaunch},
+		{"scm_branch", "scm_branch", requirements.AskScmBranchOnLaunch},
+		{"job_slice_count", "job_slice_count", requirements.AskJobSliceCountOnLaunch},
+	}
+}
+
+// DescribeJobTemplate shows what a launch of the template may and must set:
+// the prompted fields with their defaults and the survey as a JSON schema
+func (s *AutomationService) DescribeJobTemplate(ctx context.Context, args models.DescribeJobTemplateArgs) (models.DescribeJobTemplateOutput, error) {
+	if args.JobTemplate == "" {
+		return models.DescribeJobTemplateOutput{}, fmt.Errorf("job_template is required")
+	}
+
+	log.Printf("Describing AWX job template: %s", args.JobTemplate)
+
+	template, err := s.awxClient.GetJobTemplateByName(ctx, args.JobTemplate)
+	if err != nil {
+		return models.DescribeJobTemplateOutput{}, fmt.Errorf("failed to find job template: %w", err)
+	}
+
+	requirements, err := s.awxClient.GetLaunchRequirements(ctx, template.ID)
+	if err != nil {
+		return models.DescribeJobTemplateOutput{}, fmt.Errorf("failed to get launch requirements of template %d: %w", template.ID, err)
+	}
+
+	output := models.DescribeJobTemplateOutput{
+		ID:                       template.ID,
+		Name:                     template.Name,
+		Description:              template.Description,
+		Playbook:                 template.Playbook,
+		Prompts:                  []models.LaunchPrompt{},
+		SurveyEnabled:            requirements.SurveyEnabled,
+		CanStartWithoutUserInput: requirements.CanStartWithoutUserInput,
+		VariablesNeededToStart:   requirements.VariablesNeededToStart,
+		PasswordsNeededToStart:   requirements.PasswordsNeededToStart,
+		InventoryNeededToStart:   requirements.InventoryNeededToStart,
+		CredentialNeededToStart:  requirements.CredentialNeededToStart,
+	}
+
+	for _, prompt := range launchPrompts(requirements) {
+		if prompt.asked {
+			output.Prompts = append(output.Prompts, models.LaunchPrompt{
+				Field:   prompt.field,
+				Default: requirements.Defaults[prompt.defaultKey],
+			})
+		}
+	}
+
+	if requirements.SurveyEnabled {
+		survey, err := s.awxClient.GetSurveySpec(ctx, template.ID)
+		if err != nil {
+			return models.DescribeJobTemplateOutput{}, fmt.Errorf("failed to get survey of template %d: %w", template.ID, err)
+		}
+
+		for _, question := range survey.Spec {
+			summary := models.SurveyQuestionSummary{
+				Variable:    question.Variable,
+				Question:    question.QuestionName,
+				Description: question.QuestionDescription,
+				Type:        question.Type,
+				Required:    question.Required,
+				Choices:     question.Choices,
+				Min:         question.Min,
+				Max:         question.Max,
+			}
+			if question.Type != "password" && question.Default != "" {
+				summary.Default = question.Default
+			}
+			output.Survey = append(output.Survey, summary)
+		}
+		output.ExtraVarsSchema = survey.JSONSchema(requirements.AskVariablesOnLaunch)
+	}
+
+	log.Printf("Template %s prompts for %d fields, survey has %d questions", template.Name, len(output.Prompts), len(output.Survey))
+
+	return output, nil
+}
+
+// UpdateJobTemplate changes the given fields of a job template and attaches or
+// detaches credentials and labels. Every reference is resolved before anything
+// changes, so a bad name leaves the template untouched.
+func (s *AutomationService) UpdateJobTemplate(ctx context.Context, args models.UpdateJobTemplateArgs) (models.UpdateJobTemplateOutput, error) {
+	if args.JobTemplate == "" {
+		return models.UpdateJobTemplateOutput{}, fmt.Errorf("job_template is required")
+	}
+
+	template, err := s.awxClient.GetJobTemplateByName(ctx, args.JobTemplate)
+	if err != nil {
+		return models.UpdateJobTemplateOutput{}, fmt.Errorf("failed to find job template: %w", err)
+	}
+
+	update := awx.JobTemplateUpdate{
+		Name:              args.Name,
+		Description:       args.Description,
+		JobType:           args.JobType,
+		Playbook:          args.Playbook,
+		ScmBranch:         args.ScmBranch,
+		Limit:             args.Limit,
+		JobTags:           args.Tags,
+		SkipTags:          args.SkipTags,
+		Forks:             args.Forks,
+		Verbosity:         args.Verbosity,
+		Timeout:           args.Timeout,
+		JobSliceCount:     args.JobSliceCount,
+		DiffMode:          args.DiffMode,
+		AllowSimultaneous: args.AllowSimultaneous,
+		SurveyEnabled:     args.SurveyEnabled,
+	}
+
+	var problems []string
+	if args.JobType != nil && *args.JobType != "run" && *args.JobType != "check" {
+		problems = append(problems, fmt.Sprintf("job_type must be run or check, not %q", *args.JobType))
+	}
+	if args.Verbosity != nil && (*args.Verbosity < 0 || *args.Verbosity > 5) {
+		problems = append(problems, fmt.Sprintf("verbosity must be between 0 and 5, not %d", *args.Verbosity))
+	}
+	if args.Forks != nil && *args.Forks < 0 {
+		problems = append(problems, fmt.Sprintf("forks must not be negative, not %d", *args.Forks))
+	}
+	if args.Timeout != nil && *args.Timeout < 0 {
+		problems = append(problems, fmt.Sprintf("timeout must not be negative, not %d", *args.Timeout))
+	}
+	if args.JobSliceCount != nil && *args.JobSliceCount < 1 {
+		problems = append(problems, fmt.Sprintf("job_slice_count must be at least 1, not %d", *args.JobSliceCount))
+	}
+	for flag, value := range args.AskOnLaunch {
+		if !update.SetAskOnLaunch(flag, value) {
+			problems = append(problems, fmt.Sprintf("unknown prompt-on-launch flag %s", flag))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return models.UpdateJobTemplateOutput{}, fmt.Errorf("invalid update of job template '%s': %s", template.Name, strings.Join(problems, "; "))
+	}
+
+	if args.ExtraVars != nil {
+		encoded, err := json.Marshal(args.ExtraVars)
+		if err != nil {
+			return models.UpdateJobTemplateOutput{}, fmt.Errorf("failed to encode extra_vars: %w", err)
+		}
+		extraVars := string(encoded)
+		update.ExtraVars = &extraVars
+	}
+
+	// Resolve every reference first so a bad name leaves the template untouched
+	references := []struct {
+		value        *string
+		resourceType awx.ResourceType
+		target       **int
+	}{
+		{args.Inventory, awx.ResourceInventory, &update.Inventory},
+		{args.Project, awx.ResourceProject, &update.Project},
+		{args.ExecutionEnvironment, awx.ResourceExecutionEnvironment, &update.ExecutionEnvironment},
+	}
+	for _, reference := range references {
+		if reference.value == nil {
+			continue
+		}
+		id, err := s.awxClient.ResolveID(ctx, reference.resourceType, *reference.value)
+		if err != nil {
+			return models.UpdateJobTemplateOutput{}, err
+		}
+		*reference.target = &id
+	}
+	if args.Playbook != nil {
+		project := template.Project
+		if update.Project != nil {
+			project = *update.Project
+		}
+		if err := s.checkPlaybook(ctx, project, *args.Playbook); err != nil {
+			return models.UpdateJobTemplateOutput{}, err
+		}
+	}
+	addCredentials, err := s.awxClient.ResolveIDs(ctx, awx.ResourceCredential, args.AddCredentials)
+	if err != nil {
+		return models.UpdateJobTemplateOutput{}, err
+	}
+	removeCredentials, err := s.awxClient.ResolveIDs(ctx, awx.ResourceCredential, args.RemoveCredentials)
+	if err != nil {
+		return models.UpdateJobTemplateOutput{}, err
+	}
+	removeLabels, err := s.awxClient.ResolveIDs(ctx, awx.ResourceLabel, args.RemoveLabels)
+	if err != nil {
+		return models.UpdateJobTemplateOutput{}, err
+	}
+	// Labels to add may not exist yet; AWX creates those by name
+	addLabels := make(map[string]int, len(args.AddLabels))
+	for _, label := range args.AddLabels {
+		id, err := s.awxClient.ResolveID(ctx, awx.ResourceLabel, label)
+		var resolveErr *awx.ResolveError
+		switch {
+		case errors.As(err, &resolveErr) && resolveErr.Matches == 0:
+			addLabels[label] = 0
+		case err != nil:
+			return models.UpdateJobTemplateOutput{}, err
+		default:
+			addLabels[label] = id
+		}
+	}
+
+	output := models.UpdateJobTemplateOutput{ID: template.ID, Name: template.Name}
+	output.UpdatedFields = changedFields(update)
+	if len(output.UpdatedFields) == 0 && len(addCredentials)+len(removeCredentials)+len(addLabels)+len(removeLabels) == 0 {
+		return models.UpdateJobTemplateOutput{}, fmt.Errorf("nothing to update: give at least one field, credential or label")
+	}
+
+	log.Printf("Updating AWX job template %s (ID: %d): %v", template.Name, template.ID, output.UpdatedFields)
+
+	if len(output.UpdatedFields) > 0 {
+		updated, err := s.awxClient.UpdateJobTemplate(ctx, template.ID, update)
+		if err != nil {
+			return models.UpdateJobTemplateOutput{}, err
+		}
+		output.Name = updated.Name
+	}
+
+	// Associations are separate requests; report what was done before a failure
+	associate := func(change string, apply func() error) error {
+		if err := apply(); err != nil {
+			if len(output.UpdatedFields) > 0 || len(output.Associations) > 0 {
+				return fmt.Errorf("%w (already applied: %s)", err, strings.Join(append(output.UpdatedFields, output.Associations...), ", "))
+			}
+			return err
+		}
+		output.Associations = append(output.Associations, change)
+		return nil
+	}
+	for i, id := range addCredentials {
+		id := id
+		if err := associate(fmt.Sprintf("credential %s attached", args.AddCredentials[i]), func() error {
+			return s.awxClient.AssociateJobTemplateCredential(ctx, template.ID, id, false)
+		}); err != nil {
+			return models.UpdateJobTemplateOutput{}, err
+		}
+	}
+	for i, id := range removeCredentials {
+		id := id
+		if err := associate(fmt.Sprintf("credential %s detached", args.RemoveCredentials[i]), func() error {
+			return s.awxClient.AssociateJobTemplateCredential(ctx, template.ID, id, true)
+		}); err != nil {
+			return models.UpdateJobTemplateOutput{}, err
+		}
+	}
+	for _, label := range args.AddLabels {
+		id := addLabels[label]
+		label := label
+		if err := associate(fmt.Sprintf("label %s attached", label), func() error {
+			if id == 0 {
+				return s.awxClient.CreateJobTemplateLabel(ctx, template.ID, template.Organization, label)
+			}
+			return s.awxClient.AssociateJobTemplateLabel(ctx, template.ID, id, false)
+		}); err != nil {
+			return models.UpdateJobTemplateOutput{}, err
+		}
+	}
+	for i, id := range removeLabels {
+		id := id
+		if err := associate(fmt.Sprintf("label %s detached", args.RemoveLabels[i]), func() error {
+			return s.awxClient.AssociateJobTemplateLabel(ctx, template.ID, id, true)
+		}); err != nil {
+			return models.UpdateJobTemplateOutput{}, err
+		}
+	}
+
+	output.Message = fmt.Sprintf("Job template '%s' updated: %d fields changed, %d credentials and labels changed",
+		output.Name, len(output.UpdatedFields), len(output.Associations))
+	return output, nil
+}
+
+// changedFields lists the JSON names of the fields a PATCH body such as
+// awx.JobTemplateUpdate sets
+func changedFields(update interface{}) []string {
+	encoded, _ := json.Marshal(update)
+	var fields map[string]interface{}
+	_ = json.Unmarshal(encoded, &fields)
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CopyJobTemplate copies a job template under a new name, warning about the
+// related resources the copy could not keep
+func (s *AutomationService) CopyJobTemplate(ctx context.Context, args models.CopyJobTemplateArgs) (models.CopyJobTemplateOutput, error) {
+	if args.JobTemplate == "" {
+		return models.CopyJobTemplateOutput{}, fmt.Errorf("job_template is required")
+	}
+
+	template, err := s.awxClient.GetJobTemplateByName(ctx, args.JobTemplate)
+	if err != nil {
+		return models.CopyJobTemplateOutput{}, fmt.Errorf("failed to find job template: %w", err)
+	}
+
+	name := args.Name
+	if name == "" {
+		name = template.Name + " (copy)"
+	}
+
+	check, err := s.awxClient.GetJobTemplateCopyCheck(ctx, template.ID)
+	if err != nil {
+		return models.CopyJobTemplateOutput{}, fmt.Errorf("failed to check whether job template %d can be copied: %w", template.ID, err)
+	}
+	if !check.CanCopy {
+		return models.CopyJobTemplateOutput{}, fmt.Errorf("job template '%s' cannot be copied: the AWX user needs admin access to the template and use access to its related resources", template.Name)
+	}
+
+	var warnings []string
+	if len(check.CredentialsUnableToCopy) > 0 {
+		warnings = append(warnings, fmt.Sprintf("credentials left out of the copy (no use access): %s", strings.Join(check.CredentialsUnableToCopy, ", ")))
+	}
+	if len(check.InventoriesUnableToCopy) > 0 {
+		warnings = append(warnings, fmt.Sprintf("inventories left out of the copy (no use access): %s", strings.Join(check.InventoriesUnableToCopy, ", ")))
+	}
+
+	log.Printf("Copying AWX job template %s (ID: %d) to %s", template.Name, template.ID, name)
+
+	copied, err := s.awxClient.CopyJobTemplate(ctx, template.ID, name)
+	if err != nil {
+		return models.CopyJobTemplateOutput{}, err
+	}
+
+	return models.CopyJobTemplateOutput{
+		ID:         copied.ID,
+		Name:       copied.Name,
+		SourceID:   template.ID,
+		SourceName: template.Name,
+		Warnings:   warnings,
+		Message:    fmt.Sprintf("Job template '%s' copied to '%s' (ID: %d)", template.Name, copied.Name, copied.ID),
+	}, nil
+}
+
+// DeleteJobTemplate deletes a job template in two calls: the first shows what
+// would be deleted and returns a confirmation token, the second deletes
+// the template when given that token
+func (s *AutomationService) DeleteJobTemplate(ctx context.Context, args models.DeleteJobTemplateArgs) (models.DeleteJobTemplateOutput, error) {
+	if args.JobTemplate == "" {
+		return models.DeleteJobTemplateOutput{}, fmt.Errorf("job_template is required")
+	}
+
+	template, err := s.awxClient.GetJobTemplateByName(ctx, args.JobTemplate)
+	if err != nil {
+		return models.DeleteJobTemplateOutput{}, fmt.Errorf("failed to find job template: %w", err)
+	}
+
+	output := models.DeleteJobTemplateOutput{ID: template.ID, Name: template.Name}
+	action := fmt.Sprintf("delete job template %d", template.ID)
+
+	if args.ConfirmationToken != "" {
+		if err := s.confirmations.redeem(args.ConfirmationToken, action); err != nil {
+			return models.DeleteJobTemplateOutput{}, err
+		}
+
+		log.Printf("Deleting AWX job template %s (ID: %d)", template.Name, template.ID)
+		if err := s.awxClient.DeleteJobTemplate(ctx, template.ID); err != nil {
+			return models.DeleteJobTemplateOutput{}, err
+		}
+
+		output.Deleted = true
+		output.Message = fmt.Sprintf("Job template '%s' (ID: %d) deleted", template.Name, template.ID)
+		return output, nil
+	}
+
+	jobs, err := s.awxClient.GetRecentTemplateJobs(ctx, template.ID, 5)
+	if err != nil {
+		log.Printf("Failed to get recent jobs of template %d: %v", template.ID, err)
+		output.Warnings = append(output.Warnings, "could not check the jobs of the template")
+	}
+	active := 0
+	for _, job := range jobs {
+		output.RecentJobs = append(output.RecentJobs, models.JobBrief{ID: job.ID, Status: job.Status})
+		if !job.IsFinished() {
+			active++
+		}
+	}
+	if active > 0 {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("%d jobs of the template are still pending or running; AWX refuses to delete it until they finish", active))
+	}
+
+	token, expires := s.confirmations.issue(action)
+	output.ConfirmationToken = token
+	output.ExpiresAt = expires.Format(time.RFC3339)
+	output.Message = fmt.Sprintf("Deleting job template '%s' (ID: %d) cannot be undone. Call delete_job_template again with confirmation_token %s within %v to delete it.",
+		template.Name, template.ID, token, confirmationTTL)
+	return output, nil
+}
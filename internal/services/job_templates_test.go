@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// fakeTemplates serves job templates and counts the list requests, so tests
+// can tell whether the cached list was dropped after a change
+type fakeTemplates struct {
+	mu        sync.Mutex
+	templates map[int]*awx.JobTemplate
+	nextID    int
+	listGets  int
+	jobs      []awx.Job
+	requests  []string // Changes, as "METHOD path body"
+}
+
+var fakeTemplatePath = regexp.MustCompile(`^/api/v2/job_templates/(\d+)/(\w+/)?$`)
+
+func newFakeTemplates(t *testing.T) (*fakeTemplates, *AutomationService) {
+	t.Helper()
+	fake := &fakeTemplates{
+		templates: map[int]*awx.JobTemplate{
+			7: {ID: 7, Name: "deploy", Organization: 1, Project: 3},
+			8: {ID: 8, Name: "backup", Organization: 1, Project: 3},
+		},
+		nextID: 100,
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := awx.NewClient(awx.ClientConfig{BaseURL: server.URL, Token: "awx-token", MaxRetries: -1})
+	return fake, NewAutomationService(nil, client, server.URL, nil, nil, nil, AutoscaleSettings{})
+}
+
+func (f *fakeTemplates) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]interface{}
+	if r.Method != http.MethodGet {
+		json.NewDecoder(r.Body).Decode(&body)
+		encoded, _ := json.Marshal(body)
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path+" "+string(encoded))
+	}
+
+	switch {
+	case r.URL.Path == "/api/v2/job_templates/":
+		f.listGets++
+		var results []*awx.JobTemplate
+		for _, template := range f.templates {
+			results = append(results, template)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+		return
+	case r.URL.Path == "/api/v2/labels/" && r.URL.Query().Get("name") == "prod":
+		w.Write([]byte(`{"count": 1, "results": [{"id": 9, "name": "prod"}]}`))
+		return
+	case r.URL.Path == "/api/v2/labels/":
+		w.Write([]byte(`{"count": 0, "results": []}`))
+		return
+	}
+
+	match := fakeTemplatePath.FindStringSubmatch(r.URL.Path)
+	if match == nil {
+		http.NotFound(w, r)
+		return
+	}
+	id, _ := strconv.Atoi(match[1])
+	template := f.templates[id]
+	if template == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method + " " + match[2] {
+	case "PATCH ":
+		if name, ok := body["name"].(string); ok {
+			template.Name = name
+		}
+		json.NewEncoder(w).Encode(template)
+	case "DELETE ":
+		delete(f.templates, id)
+		w.WriteHeader(http.StatusNoContent)
+	case "POST credentials/", "POST labels/":
+		w.WriteHeader(http.StatusNoContent)
+	case "GET copy/":
+		w.Write([]byte(`{"can_copy": true, "credentials_unable_to_copy": ["vault"]}`))
+	case "POST copy/":
+		copied := &awx.JobTemplate{ID: f.nextID, Name: body["name"].(string), Organization: template.Organization, Project: template.Project}
+		f.templates[copied.ID] = copied
+		f.nextID++
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(copied)
+	case "GET jobs/":
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(f.jobs), "results": f.jobs})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeTemplates) takeRequests() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	requests := strings.Join(f.requests, ", ")
+	f.requests = nil
+	return requests
+}
+
+func TestUpdateJobTemplateDropsCachedList(t *testing.T) {
+	fake, service := newFakeTemplates(t)
+	ctx := context.Background()
+	name := "deploy-v2"
+	forks := 10
+
+	output, err := service.UpdateJobTemplate(ctx, models.UpdateJobTemplateArgs{
+		JobTemplate: "deploy", Name: &name, Forks: &forks,
+		AddCredentials: []string{"4"}, AddLabels: []string{"prod", "canary"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateJobTemplate: %v", err)
+	}
+	if output.Name != "deploy-v2" || strings.Join(output.UpdatedFields, ",") != "forks,name" ||
+		strings.Join(output.Associations, ", ") != "credential 4 attached, label prod attached, label canary attached" {
+		t.Errorf("output = %+v", output)
+	}
+	if got := fake.takeRequests(); got != `PATCH /api/v2/job_templates/7/ {"forks":10,"name":"deploy-v2"}, `+
+		`POST /api/v2/job_templates/7/credentials/ {"id":4}, POST /api/v2/job_templates/7/labels/ {"id":9}, `+
+		`POST /api/v2/job_templates/7/labels/ {"name":"canary","organization":1}` {
+		t.Errorf("requests = %s", got)
+	}
+
+	// The renamed template is found under its new name, from a fresh list
+	if _, err := service.UpdateJobTemplate(ctx, models.UpdateJobTemplateArgs{JobTemplate: "deploy-v2", Forks: &forks}); err != nil {
+		t.Errorf("update by the new name: %v", err)
+	}
+	if fake.listGets != 2 {
+		t.Errorf("job template list fetched %d times, want 2", fake.listGets)
+	}
+}
+
+func TestUpdateJobTemplateChecksBeforeChanging(t *testing.T) {
+	fake, service := newFakeTemplates(t)
+	jobType, verbosity, slices := "dry-run", 7, 0
+
+	_, err := service.UpdateJobTemplate(context.Background(), models.UpdateJobTemplateArgs{
+		JobTemplate: "deploy", JobType: &jobType, Verbosity: &verbosity, JobSliceCount: &slices,
+		AskOnLaunch: map[string]bool{"ask_everything_on_launch": true},
+	})
+	want := `invalid update of job template 'deploy': job_slice_count must be at least 1, not 0; job_type must be run or check, not "dry-run"; ` +
+		`unknown prompt-on-launch flag ask_everything_on_launch; verbosity must be between 0 and 5, not 7`
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %v\nwant %s", err, want)
+	}
+
+	if _, err := service.UpdateJobTemplate(context.Background(), models.UpdateJobTemplateArgs{JobTemplate: "deploy", RemoveLabels: []string{"staging"}}); err == nil ||
+		!strings.Contains(err.Error(), "label 'staging' not found") {
+		t.Errorf("unknown label to remove: %v", err)
+	}
+	if _, err := service.UpdateJobTemplate(context.Background(), models.UpdateJobTemplateArgs{JobTemplate: "deploy"}); err == nil ||
+		!strings.Contains(err.Error(), "nothing to update") {
+		t.Errorf("empty update: %v", err)
+	}
+	if got := fake.takeRequests(); got != "" {
+		t.Errorf("rejected updates changed the template: %s", got)
+	}
+}
+
+func TestCopyJobTemplateDropsCachedList(t *testing.T) {
+	fake, service := newFakeTemplates(t)
+	ctx := context.Background()
+
+	output, err := service.CopyJobTemplate(ctx, models.CopyJobTemplateArgs{JobTemplate: "deploy"})
+	if err != nil {
+		t.Fatalf("CopyJobTemplate: %v", err)
+	}
+	if output.ID != 100 || output.Name != "deploy (copy)" || output.SourceID != 7 ||
+		strings.Join(output.Warnings, "; ") != "credentials left out of the copy (no use access): vault" {
+		t.Errorf("output = %+v", output)
+	}
+
+	if _, err := service.CopyJobTemplate(ctx, models.CopyJobTemplateArgs{JobTemplate: "deploy (copy)", Name: "deploy-canary"}); err != nil {
+		t.Errorf("copy of the copy: %v", err)
+	}
+	if fake.listGets != 2 {
+		t.Errorf("job template list fetched %d times, want 2", fake.listGets)
+	}
+}
+
+func TestDeleteJobTemplateNeedsConfirmation(t *testing.T) {
+	fake, service := newFakeTemplates(t)
+	ctx := context.Background()
+	fake.jobs = []awx.Job{{ID: 51, Status: "running"}, {ID: 50, Status: "successful"}}
+
+	first, err := service.DeleteJobTemplate(ctx, models.DeleteJobTemplateArgs{JobTemplate: "deploy"})
+	if err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if first.Deleted || first.ConfirmationToken == "" || first.ExpiresAt == "" || len(first.RecentJobs) != 2 {
+		t.Fatalf("first call = %+v", first)
+	}
+	if len(first.Warnings) != 1 || !strings.Contains(first.Warnings[0], "1 jobs of the template are still pending or running") {
+		t.Errorf("warnings = %v", first.Warnings)
+	}
+	if got := fake.takeRequests(); got != "" || fake.templates[7] == nil {
+		t.Fatalf("the first call changed something: %s", got)
+	}
+
+	// The token only deletes the template it was issued for
+	if _, err := service.DeleteJobTemplate(ctx, models.DeleteJobTemplateArgs{JobTemplate: "backup", ConfirmationToken: first.ConfirmationToken}); err == nil ||
+		!strings.Contains(err.Error(), "was issued to delete job template 7, not to delete job template 8") {
+		t.Errorf("token of another template: %v", err)
+	}
+	if fake.templates[8] == nil {
+		t.Fatal("template 8 deleted with the token of template 7")
+	}
+
+	second, err := service.DeleteJobTemplate(ctx, models.DeleteJobTemplateArgs{JobTemplate: "deploy", ConfirmationToken: first.ConfirmationToken})
+	if err != nil {
+		t.Fatalf("confirmed call: %v", err)
+	}
+	if !second.Deleted || fake.templates[7] != nil {
+		t.Errorf("confirmed call = %+v, template still there: %t", second, fake.templates[7] != nil)
+	}
+
+	// The deleted template is gone from a fresh list, so a replay cannot reach it
+	if _, err := service.DeleteJobTemplate(ctx, models.DeleteJobTemplateArgs{JobTemplate: "deploy", ConfirmationToken: first.ConfirmationToken}); err == nil ||
+		!strings.Contains(err.Error(), "failed to find job template") {
+		t.Errorf("replayed deletion: %v", err)
+	}
+	if fake.listGets != 2 {
+		t.Errorf("job template list fetched %d times, want 2", fake.listGets)
+	}
+}
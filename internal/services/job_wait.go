@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+const (
+	defaultWaitTimeout      = 10 * time.Minute
+	maxWaitTimeout          = time.Hour
+	defaultWaitPollInterval = 10 * time.Second
+	minWaitPollInterval     = 2 * time.Second
+)
+
+// WaitForJob blocks until the job reaches a terminal state, the timeout
+// expires or ctx is canceled. onProgress (optional) is called after every poll.
+func (s *AutomationService) WaitForJob(ctx context.Context, args models.WaitForJobArgs, onProgress func(models.JobProgress)) (models.WaitForJobOutput, error) {
+	if args.JobID <= 0 {
+		return models.WaitForJobOutput{}, fmt.Errorf("valid job_id is required")
+	}
+
+	timeout, interval := waitDurations(args.Timeout, args.PollInterval)
+
+	log.Printf("Waiting for AWX job %d (timeout: %s, poll interval: %s)", args.JobID, timeout, interval)
+
+	start := time.Now()
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastTask := ""
+	timedOut := false
+
+	for {
+		// GetJob serves running jobs from a short-TTL cache entry
+		job, err := s.awxClient.GetJob(ctx, args.JobID)
+		if err != nil {
+			return models.WaitForJobOutput{}, fmt.Errorf("failed to get job status: %w", err)
+		}
+
+		if task, err := s.awxClient.GetLatestJobTask(ctx, args.JobID); err == nil && task != "" {
+			lastTask = task
+		}
+
+		if onProgress != nil {
+			onProgress(models.JobProgress{
+				JobID:   args.JobID,
+				Status:  job.Status,
+				Elapsed: time.Since(start),
+				Timeout: timeout,
+				Task:    lastTask,
+			})
+		}
+
+		if job.IsFinished() {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return models.WaitForJobOutput{}, fmt.Errorf("stopped waiting for job %d: %w", args.JobID, ctx.Err())
+		case <-deadline.C:
+			timedOut = true
+		case <-ticker.C:
+		}
+
+		if timedOut {
+			break
+		}
+	}
+
+	status, err := s.CheckJobStatus(ctx, models.AWXStatusArgs{JobID: args.JobID})
+	if err != nil {
+		return models.WaitForJobOutput{}, err
+	}
+
+	waited := time.Since(start).Round(time.Second)
+	if timedOut {
+		log.Printf("Gave up waiting for AWX job %d after %s (status: %s)", args.JobID, waited, status.Status)
+	} else {
+		log.Printf("AWX job %d finished with status %s after waiting %s", args.JobID, status.Status, waited)
+	}
+
+	return models.WaitForJobOutput{
+		Job:      status,
+		TimedOut: timedOut,
+		Waited:   waited.String(),
+		LastTask: lastTask,
+	}, nil
+}
+
+// waitDurations turns the timeout and poll interval of a wait, in seconds,
+// into durations, applying the defaults and bounds
+func waitDurations(timeoutSeconds, intervalSeconds int) (time.Duration, time.Duration) {
+	timeout := defaultWaitTimeout
+	if timeoutSeconds > 0 {
+		timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	if timeout > maxWaitTimeout {
+		timeout = maxWaitTimeout
+	}
+
+	interval := defaultWaitPollInterval
+	if intervalSeconds > 0 {
+		interval = time.Duration(intervalSeconds) * time.Second
+	}
+	if interval < minWaitPollInterval {
+		interval = minWaitPollInterval
+	}
+	return timeout, interval
+}
@@ -4,31 +4,26 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/NacerKH/autosphere-mcp-golang/internal/alertmanager"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
 	"github.com/NacerKH/autosphere-mcp-golang/internal/prometheus"
 )
 
 type ObservabilityService struct {
-	prometheusClient *prometheus.PrometheusClient
+	prometheusClient   *prometheus.PrometheusClient
+	alertmanagerClient *alertmanager.AlertmanagerClient
 }
 
-func NewObservabilityService(prometheusURL, username, password string) *ObservabilityService {
-	var promClient *prometheus.PrometheusClient
-	
-	if prometheusURL != "" {
-		promClient = prometheus.NewPrometheusClient(prometheus.PrometheusConfig{
-			BaseURL:  prometheusURL,
-			Username: username,
-			Password: password,
-			Timeout:  30 * time.Second,
-		})
-	}
-
+// NewObservabilityService creates the observability service. Either client may
+// be nil when the corresponding backend is not configured.
+func NewObservabilityService(prometheusClient *prometheus.PrometheusClient, alertmanagerClient *alertmanager.AlertmanagerClient) *ObservabilityService {
 	return &ObservabilityService{
-		prometheusClient: promClient,
+		prometheusClient:   prometheusClient,
+		alertmanagerClient: alertmanagerClient,
 	}
 }
 
@@ -41,36 +36,74 @@ func (s *ObservabilityService) QueryPrometheus(ctx context.Context, args models.
 		return models.QueryPrometheusOutput{}, fmt.Errorf("query is required")
 	}
 
+	maxPoints := args.MaxPoints
+	if maxPoints <= 0 {
+		maxPoints = defaultMaxPoints
+	}
+	if maxPoints > maxMaxPoints {
+		maxPoints = maxMaxPoints
+	}
+
 	log.Printf("Executing Prometheus query: %s", args.Query)
 
+	output := models.QueryPrometheusOutput{
+		Query:     args.Query,
+		QueryTime: time.Now().Format(time.RFC3339),
+	}
+
 	var result *prometheus.QueryResponse
 	var err error
 
-	// Determine if this is a range query
-	if args.StartTime != "" && args.EndTime != "" {
-		start, err := time.Parse(time.RFC3339, args.StartTime)
+	// Any time bound or step turns this into a range query
+	if args.StartTime != "" || args.EndTime != "" || args.Step != "" {
+		now := time.Now()
+
+		var start, end time.Time
+		var step time.Duration
+
+		end, err = parsePromTime(args.EndTime, now)
 		if err != nil {
-			return models.QueryPrometheusOutput{}, fmt.Errorf("invalid start_time format: %w", err)
+			return models.QueryPrometheusOutput{}, fmt.Errorf("invalid end_time: %w", err)
 		}
 
-		end, err := time.Parse(time.RFC3339, args.EndTime)
-		if err != nil {
-			return models.QueryPrometheusOutput{}, fmt.Errorf("invalid end_time format: %w", err)
+		start = end.Add(-defaultRangeDuration)
+		if args.StartTime != "" {
+			if start, err = parsePromTime(args.StartTime, now); err != nil {
+				return models.QueryPrometheusOutput{}, fmt.Errorf("invalid start_time: %w", err)
+			}
 		}
 
-		step, err := time.ParseDuration(args.Step)
-		if err != nil {
-			step = 5 * time.Minute // Default step
+		if !start.Before(end) {
+			return models.QueryPrometheusOutput{}, fmt.Errorf("start_time (%s) must be before end_time (%s)", start.Format(time.RFC3339), end.Format(time.RFC3339))
+		}
+
+		step = defaultRangeStep(start, end, maxPoints)
+		if args.Step != "" {
+			if step, err = parsePromDuration(args.Step); err != nil || step <= 0 {
+				return models.QueryPrometheusOutput{}, fmt.Errorf("invalid step %q: expected a duration such as '30s' or '5m'", args.Step)
+			}
+		}
+
+		if points := end.Sub(start) / step; points > maxRangeResolution {
+			return models.QueryPrometheusOutput{}, fmt.Errorf("step %s is too small for a %s range (%d points per series, Prometheus allows %d)",
+				step, end.Sub(start), points, maxRangeResolution)
 		}
 
+		output.Start = start.Format(time.RFC3339)
+		output.End = end.Format(time.RFC3339)
+		output.Step = step.String()
+
 		result, err = s.prometheusClient.QueryRange(ctx, args.Query, start, end, step)
+		if err != nil {
+			log.Printf("Prometheus range query failed: %v", err)
+			return models.QueryPrometheusOutput{}, fmt.Errorf("query failed: %w", err)
+		}
 	} else {
 		result, err = s.prometheusClient.Query(ctx, args.Query)
-	}
-
-	if err != nil {
-		log.Printf("Prometheus query failed: %v", err)
-		return models.QueryPrometheusOutput{}, fmt.Errorf("query failed: %w", err)
+		if err != nil {
+			log.Printf("Prometheus query failed: %v", err)
+			return models.QueryPrometheusOutput{}, fmt.Errorf("query failed: %w", err)
+		}
 	}
 
 	// Convert results to our format
@@ -80,28 +113,71 @@ func (s *ObservabilityService) QueryPrometheus(ctx context.Context, args models.
 			Labels: res.Metric,
 		}
 
-		if len(res.Value) >= 2 {
-			metric.Value = res.Value[1].(string)
-			if timestamp, ok := res.Value[0].(float64); ok {
-				metric.Time = time.Unix(int64(timestamp), 0).Format(time.RFC3339)
+		if result.Data.ResultType == "matrix" {
+			samples, err := res.RangeSamples()
+			if err != nil {
+				return models.QueryPrometheusOutput{}, fmt.Errorf("failed to parse series %v: %w", res.Metric, err)
 			}
+
+			// Statistics are computed at full resolution, before downsampling
+			metric.Stats = computeSeriesStats(samples)
+			if len(samples) > 0 {
+				latest := samples[len(samples)-1]
+				metric.Value = latest.Raw
+				metric.Time = latest.Time.Format(time.RFC3339)
+			}
+
+			if len(samples) > maxPoints {
+				samples = downsampleSeries(samples, maxPoints)
+				output.Downsampled = true
+			}
+
+			metric.Samples = make([]models.PrometheusSample, len(samples))
+			for i, sample := range samples {
+				metric.Samples[i] = models.PrometheusSample{
+					Timestamp: sample.Time.Format(time.RFC3339),
+					Value:     sample.Raw,
+				}
+			}
+		} else if len(res.Value) > 0 {
+			sample, err := res.InstantSample()
+			if err != nil {
+				return models.QueryPrometheusOutput{}, fmt.Errorf("failed to parse result %v: %w", res.Metric, err)
+			}
+			metric.Value = sample.Raw
+			metric.Time = sample.Time.Format(time.RFC3339)
 		}
 
 		metrics = append(metrics, metric)
 	}
 
-	// Generate summary
-	summary := s.generateQuerySummary(args.Query, result.Data.ResultType, len(metrics))
+	output.ResultType = result.Data.ResultType
+	output.Metrics = metrics
+	output.Summary = s.generateQuerySummary(args.Query, result.Data.ResultType, len(metrics))
+	if output.Downsampled {
+		output.Summary += fmt.Sprintf(" (downsampled to at most %d points per series)", maxPoints)
+	}
 
 	log.Printf("Prometheus query returned %d metrics", len(metrics))
 
-	return models.QueryPrometheusOutput{
-		Query:      args.Query,
-		ResultType: result.Data.ResultType,
-		Metrics:    metrics,
-		Summary:    summary,
-		QueryTime:  time.Now().Format(time.RFC3339),
-	}, nil
+	return output, nil
+}
+
+// defaultMetricsWindow is the averaging window of get_system_metrics
+const defaultMetricsWindow = 5 * time.Minute
+
+// systemMetricThresholds are the warning and critical levels of each system
+// metric, with the advice given when a metric turns critical
+var systemMetricThresholds = []struct {
+	metric         string
+	label          string
+	warning        float64
+	critical       float64
+	recommendation string
+}{
+	{"cpu_usage_percent", "CPU usage", 80, 90, "Consider scaling up or optimizing CPU-intensive processes"},
+	{"memory_usage_percent", "memory usage", 85, 95, "Consider adding memory or optimizing memory usage"},
+	{"disk_usage_percent", "disk usage", 85, 95, "Clean up disk space or add storage"},
 }
 
 func (s *ObservabilityService) GetSystemMetrics(ctx context.Context, args models.GetSystemMetricsArgs) (models.GetSystemMetricsOutput, error) {
@@ -109,130 +185,90 @@ func (s *ObservabilityService) GetSystemMetrics(ctx context.Context, args models
 		return models.GetSystemMetricsOutput{}, fmt.Errorf("Prometheus client not configured")
 	}
 
-	log.Printf("Retrieving system metrics")
+	window := defaultMetricsWindow
+	if args.TimeRange != "" {
+		var err error
+		if window, err = parsePromDuration(args.TimeRange); err != nil || window < time.Minute {
+			return models.GetSystemMetricsOutput{}, fmt.Errorf("invalid time_range %q: expected a duration of at least 1m such as '5m', '1h' or '24h'", args.TimeRange)
+		}
+	}
+	var nodes []string
+	for _, node := range strings.Split(args.Nodes, ",") {
+		if node = strings.TrimSpace(node); node != "" {
+			nodes = append(nodes, node)
+		}
+	}
 
-	metrics, err := s.prometheusClient.GetSystemMetrics(ctx)
+	log.Printf("Retrieving system metrics over %s (nodes: %v)", window, nodes)
+
+	metrics, err := s.prometheusClient.GetSystemMetrics(ctx, window, nodes)
 	if err != nil {
 		log.Printf("Failed to get system metrics: %v", err)
 		return models.GetSystemMetricsOutput{}, fmt.Errorf("failed to get system metrics: %w", err)
 	}
 
-	// Determine overall health
-	overallHealth := "healthy"
-	var alerts []string
-	var recommendations []string
-
-	// Check critical thresholds
-	if cpu, ok := metrics["cpu_usage_percent"]; ok && cpu > 90 {
-		overallHealth = "critical"
-		alerts = append(alerts, fmt.Sprintf("High CPU usage: %.1f%%", cpu))
-		recommendations = append(recommendations, "Consider scaling up or optimizing CPU-intensive processes")
-	} else if cpu > 80 {
-		overallHealth = "warning"
-		alerts = append(alerts, fmt.Sprintf("Elevated CPU usage: %.1f%%", cpu))
+	output := models.GetSystemMetricsOutput{
+		OverallHealth: "healthy",
+		TimeRange:     window.String(),
+		Metrics:       metrics.Overall,
+		Timestamp:     time.Now().Format(time.RFC3339),
+	}
+	if len(nodes) > 0 || len(metrics.Nodes) > 1 {
+		output.NodeMetrics = metrics.Nodes
 	}
 
-	if memory, ok := metrics["memory_usage_percent"]; ok && memory > 95 {
-		overallHealth = "critical"
-		alerts = append(alerts, fmt.Sprintf("High memory usage: %.1f%%", memory))
-		recommendations = append(recommendations, "Consider adding memory or optimizing memory usage")
-	} else if memory > 85 {
-		if overallHealth == "healthy" {
-			overallHealth = "warning"
+	// Check critical thresholds on every node
+	level := map[string]int{"healthy": 0, "warning": 1, "critical": 2}
+	recommended := make(map[string]bool)
+	for _, node := range sortedNodes(metrics.Nodes) {
+		for _, threshold := range systemMetricThresholds {
+			value, ok := metrics.Nodes[node][threshold.metric]
+			if !ok {
+				continue
+			}
+			status := "healthy"
+			switch {
+			case value > threshold.critical:
+				status = "critical"
+				output.Alerts = append(output.Alerts, fmt.Sprintf("High %s on %s: %.1f%%", threshold.label, node, value))
+				if !recommended[threshold.metric] {
+					recommended[threshold.metric] = true
+					output.Recommendations = append(output.Recommendations, threshold.recommendation)
+				}
+			case value > threshold.warning:
+				status = "warning"
+				output.Alerts = append(output.Alerts, fmt.Sprintf("Elevated %s on %s: %.1f%%", threshold.label, node, value))
+			}
+			if level[status] > level[output.OverallHealth] {
+				output.OverallHealth = status
+			}
 		}
-		alerts = append(alerts, fmt.Sprintf("Elevated memory usage: %.1f%%", memory))
 	}
 
-	if disk, ok := metrics["disk_usage_percent"]; ok && disk > 95 {
-		overallHealth = "critical"
-		alerts = append(alerts, fmt.Sprintf("High disk usage: %.1f%%", disk))
-		recommendations = append(recommendations, "Clean up disk space or add storage")
-	} else if disk > 85 {
-		if overallHealth == "healthy" {
-			overallHealth = "warning"
+	// Failed queries leave gaps that must not read as healthy
+	if len(metrics.Errors) > 0 {
+		output.Errors = metrics.Errors
+		if output.OverallHealth == "healthy" {
+			output.OverallHealth = "degraded"
 		}
-		alerts = append(alerts, fmt.Sprintf("Elevated disk usage: %.1f%%", disk))
 	}
-
-	log.Printf("System health: %s, %d alerts", overallHealth, len(alerts))
-
-	return models.GetSystemMetricsOutput{
-		OverallHealth:   overallHealth,
-		Metrics:         metrics,
-		Alerts:          alerts,
-		Timestamp:       time.Now().Format(time.RFC3339),
-		Recommendations: recommendations,
-	}, nil
-}
-
-func (s *ObservabilityService) GetAlerts(ctx context.Context, args models.GetAlertsArgs) (models.GetAlertsOutput, error) {
-	if s.prometheusClient == nil {
-		// Return mock alerts if Prometheus is not configured
-		return s.getMockAlerts(), nil
+	if len(metrics.Nodes) == 0 {
+		output.OverallHealth = "unknown"
+		output.Alerts = append(output.Alerts, "No node reported system metrics")
 	}
 
-	log.Printf("Retrieving alerts from Prometheus")
+	log.Printf("System health: %s, %d alerts", output.OverallHealth, len(output.Alerts))
 
-	// For now, we'll create mock alerts since AlertManager integration is more complex
-	// In a real implementation, you'd query AlertManager API
-	return s.getMockAlerts(), nil
+	return output, nil
 }
 
-func (s *ObservabilityService) getMockAlerts() models.GetAlertsOutput {
-	alerts := []models.AlertSummary{
-		{
-			Name:     "HighCPUUsage",
-			Severity: "warning",
-			Status:   "firing",
-			Labels: map[string]string{
-				"instance": "node-1",
-				"job":      "node-exporter",
-			},
-			Annotations: map[string]string{
-				"description": "CPU usage is above 80% for more than 5 minutes",
-				"summary":     "High CPU usage detected",
-			},
-			ActiveSince: "15m",
-			Value:       "85.2%",
-		},
-		{
-			Name:     "DiskSpaceLow",
-			Severity: "critical",
-			Status:   "firing",
-			Labels: map[string]string{
-				"instance":   "server-prod-1",
-				"mountpoint": "/",
-			},
-			Annotations: map[string]string{
-				"description": "Disk space is running low",
-				"summary":     "Less than 10% disk space remaining",
-			},
-			ActiveSince: "2h",
-			Value:       "92.1%",
-		},
-	}
-
-	critical := 0
-	warning := 0
-	for _, alert := range alerts {
-		switch alert.Severity {
-		case "critical":
-			critical++
-		case "warning":
-			warning++
-		}
-	}
-
-	summary := fmt.Sprintf("%d total alerts (%d critical, %d warning)", len(alerts), critical, warning)
-
-	return models.GetAlertsOutput{
-		ActiveAlerts: alerts,
-		TotalAlerts:  len(alerts),
-		Critical:     critical,
-		Warning:      warning,
-		Summary:      summary,
-		Timestamp:    time.Now().Format(time.RFC3339),
+func sortedNodes(nodes map[string]map[string]float64) []string {
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
 }
 
 func (s *ObservabilityService) generateQuerySummary(query, resultType string, count int) string {
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/prometheus"
+)
+
+// newPrometheusStub returns an observability service whose Prometheus is an
+// httptest server answering with respond
+func newPrometheusStub(t *testing.T, respond func(r *http.Request) (int, interface{})) *ObservabilityService {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, body := respond(r)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return NewObservabilityService(prometheus.NewPrometheusClient(prometheus.PrometheusConfig{BaseURL: server.URL}), nil)
+}
+
+func promResult(resultType string, result interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"status": "success",
+		"data":   map[string]interface{}{"resultType": resultType, "result": result},
+	}
+}
+
+func TestQueryPrometheusInstant(t *testing.T) {
+	service := newPrometheusStub(t, func(r *http.Request) (int, interface{}) {
+		if r.URL.Path != "/api/v1/query" || r.URL.Query().Get("query") != "node_load1" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		return http.StatusOK, promResult("vector", []interface{}{
+			map[string]interface{}{"metric": map[string]string{"instance": "web1"}, "value": []interface{}{1700000000.0, "0.5"}},
+		})
+	})
+
+	output, err := service.QueryPrometheus(context.Background(), models.QueryPrometheusArgs{Query: "node_load1"})
+	if err != nil {
+		t.Fatalf("QueryPrometheus: %v", err)
+	}
+	if output.ResultType != "vector" || len(output.Metrics) != 1 || output.Start != "" {
+		t.Fatalf("unexpected output %+v", output)
+	}
+	metric := output.Metrics[0]
+	if metric.Value != "0.5" || metric.Labels["instance"] != "web1" || metric.Time != time.Unix(1700000000, 0).Format(time.RFC3339) {
+		t.Errorf("unexpected metric %+v", metric)
+	}
+}
+
+func TestQueryPrometheusRange(t *testing.T) {
+	var step string
+	service := newPrometheusStub(t, func(r *http.Request) (int, interface{}) {
+		if r.URL.Path != "/api/v1/query_range" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		step = r.URL.Query().Get("step")
+
+		values := make([][]interface{}, 10)
+		for i := range values {
+			values[i] = []interface{}{float64(1700000000 + 60*i), []string{"1", "3"}[i%2]}
+		}
+		return http.StatusOK, promResult("matrix", []interface{}{
+			map[string]interface{}{"metric": map[string]string{"job": "node"}, "values": values},
+		})
+	})
+
+	output, err := service.QueryPrometheus(context.Background(), models.QueryPrometheusArgs{
+		Query:     "rate(x[5m])",
+		StartTime: "-10m",
+		Step:      "1m",
+		MaxPoints: 4,
+	})
+	if err != nil {
+		t.Fatalf("QueryPrometheus: %v", err)
+	}
+	if step != "60" || output.Step != "1m0s" {
+		t.Errorf("step = %q (output %q), want 60", step, output.Step)
+	}
+
+	metric := output.Metrics[0]
+	if !output.Downsampled || len(metric.Samples) > 4 {
+		t.Errorf("got %d samples (downsampled %t), want at most 4", len(metric.Samples), output.Downsampled)
+	}
+	if metric.Stats == nil || metric.Stats.Samples != 10 || metric.Stats.Min != 1 || metric.Stats.Max != 3 || metric.Stats.Avg != 2 || metric.Stats.Last != 3 {
+		t.Errorf("stats = %+v, want computed over all 10 samples", metric.Stats)
+	}
+	if metric.Value != "3" {
+		t.Errorf("value = %q, want the latest sample", metric.Value)
+	}
+}
+
+func TestQueryPrometheusErrors(t *testing.T) {
+	service := newPrometheusStub(t, func(r *http.Request) (int, interface{}) {
+		return http.StatusBadRequest, map[string]string{"status": "error", "errorType": "bad_data", "error": "parse error"}
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		args models.QueryPrometheusArgs
+		want string
+	}{
+		{"missing query", models.QueryPrometheusArgs{}, "query is required"},
+		{"prometheus error", models.QueryPrometheusArgs{Query: "up{"}, "parse error"},
+		{"bad start", models.QueryPrometheusArgs{Query: "up", StartTime: "yesterday"}, "invalid start_time"},
+		{"start after end", models.QueryPrometheusArgs{Query: "up", StartTime: "-1h", EndTime: "-2h"}, "must be before"},
+		{"bad step", models.QueryPrometheusArgs{Query: "up", Step: "fast"}, "invalid step"},
+		{"too many points", models.QueryPrometheusArgs{Query: "up", StartTime: "-30d", Step: "1s"}, "too small"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.QueryPrometheus(ctx, tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewObservabilityService(nil, nil).QueryPrometheus(ctx, models.QueryPrometheusArgs{Query: "up"}); err == nil {
+		t.Error("expected an error without a Prometheus client")
+	}
+}
+
+// nodeMetrics answers the system metric queries with the given values by instance
+func nodeMetrics(values map[string]map[string]string) func(r *http.Request) (int, interface{}) {
+	return func(r *http.Request) (int, interface{}) {
+		query := r.URL.Query().Get("query")
+		metric := "disk_usage_percent"
+		switch {
+		case strings.Contains(query, "node_cpu_seconds_total"):
+			metric = "cpu_usage_percent"
+		case strings.Contains(query, "node_memory"):
+			metric = "memory_usage_percent"
+		}
+
+		var result []interface{}
+		for instance, metrics := range values {
+			if value, ok := metrics[metric]; ok {
+				result = append(result, map[string]interface{}{
+					"metric": map[string]string{"instance": instance},
+					"value":  []interface{}{1700000000.0, value},
+				})
+			}
+		}
+		if result == nil {
+			return http.StatusServiceUnavailable, map[string]string{"status": "error", "error": "unavailable"}
+		}
+		return http.StatusOK, promResult("vector", result)
+	}
+}
+
+func TestGetSystemMetricsHealth(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]map[string]string
+		health string
+		alerts int
+	}{
+		{
+			name: "healthy",
+			values: map[string]map[string]string{
+				"web1:9100": {"cpu_usage_percent": "20", "memory_usage_percent": "40", "disk_usage_percent": "50"},
+			},
+			health: "healthy",
+		},
+		{
+			name: "one hot node",
+			values: map[string]map[string]string{
+				"web1:9100": {"cpu_usage_percent": "20", "memory_usage_percent": "40", "disk_usage_percent": "50"},
+				"web2:9100": {"cpu_usage_percent": "95", "memory_usage_percent": "88", "disk_usage_percent": "50"},
+			},
+			health: "critical",
+			alerts: 2,
+		},
+		{
+			name: "memory query failing",
+			values: map[string]map[string]string{
+				"web1:9100": {"cpu_usage_percent": "20", "disk_usage_percent": "50"},
+			},
+			health: "degraded",
+		},
+		{
+			name: "memory query failing on a warning node",
+			values: map[string]map[string]string{
+				"web1:9100": {"cpu_usage_percent": "85", "disk_usage_percent": "50"},
+			},
+			health: "warning",
+			alerts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newPrometheusStub(t, nodeMetrics(tt.values))
+
+			output, err := service.GetSystemMetrics(context.Background(), models.GetSystemMetricsArgs{})
+			if err != nil {
+				t.Fatalf("GetSystemMetrics: %v", err)
+			}
+			if output.OverallHealth != tt.health || len(output.Alerts) != tt.alerts {
+				t.Errorf("health %s with alerts %v, want %s with %d alerts", output.OverallHealth, output.Alerts, tt.health, tt.alerts)
+			}
+			if _, memoryFailed := output.Errors["memory_usage_percent"]; memoryFailed != strings.HasPrefix(tt.name, "memory") {
+				t.Errorf("errors = %v", output.Errors)
+			}
+		})
+	}
+}
+
+func TestGetSystemMetricsArguments(t *testing.T) {
+	var queries []string
+	service := newPrometheusStub(t, func(r *http.Request) (int, interface{}) {
+		queries = append(queries, r.URL.Query().Get("query"))
+		return nodeMetrics(map[string]map[string]string{
+			"db1:9100": {"cpu_usage_percent": "10", "memory_usage_percent": "10", "disk_usage_percent": "10"},
+		})(r)
+	})
+
+	output, err := service.GetSystemMetrics(context.Background(), models.GetSystemMetricsArgs{TimeRange: "1h", Nodes: "db1, "})
+	if err != nil {
+		t.Fatalf("GetSystemMetrics: %v", err)
+	}
+	if output.TimeRange != "1h0m0s" || output.NodeMetrics["db1:9100"]["cpu_usage_percent"] != 10 {
+		t.Errorf("unexpected output %+v", output)
+	}
+	for _, query := range queries {
+		if !strings.Contains(query, "[3600s]") || !strings.Contains(query, "instance=~`(db1)(:[0-9]+)?`") {
+			t.Errorf("query ignores time_range or nodes: %s", query)
+		}
+	}
+
+	if _, err := service.GetSystemMetrics(context.Background(), models.GetSystemMetricsArgs{TimeRange: "10s"}); err == nil {
+		t.Error("expected a time_range under 1m to be rejected")
+	}
+}
+
+func TestGetSystemMetricsPrometheusDown(t *testing.T) {
+	service := newPrometheusStub(t, nodeMetrics(nil))
+
+	if _, err := service.GetSystemMetrics(context.Background(), models.GetSystemMetricsArgs{}); err == nil {
+		t.Fatal("expected an error when Prometheus is down")
+	}
+}
@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// maxListedPlaybooks caps how many playbooks an unknown-playbook error lists
+const maxListedPlaybooks = 20
+
+// scmTypes maps the source control types tools accept to their AWX values
+var scmTypes = map[string]string{
+	"git":      "git",
+	"svn":      "svn",
+	"insights": "insights",
+	"archive":  "archive",
+	"manual":   "",
+}
+
+func scmType(value string) (string, error) {
+	scm, ok := scmTypes[strings.ToLower(value)]
+	if !ok {
+		return "", fmt.Errorf("scm_type must be git, svn, insights, archive or manual, not %q", value)
+	}
+	return scm, nil
+}
+
+// resolveProject turns a project name or ID into the project, read fresh from AWX
+func (s *AutomationService) resolveProject(ctx context.Context, reference string) (*awx.Project, error) {
+	if strings.TrimSpace(reference) == "" {
+		return nil, fmt.Errorf("project is required")
+	}
+	id, err := s.awxClient.ResolveID(ctx, awx.ResourceProject, reference)
+	if err != nil {
+		return nil, err
+	}
+	project, err := s.awxClient.GetProject(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %d: %w", id, err)
+	}
+	return project, nil
+}
+
+// checkPlaybook makes sure the last sync of a project found the playbook, so
+// a typo or an unsynced push fails before a template is saved
+func (s *AutomationService) checkPlaybook(ctx context.Context, projectID int, playbook string) error {
+	playbooks, err := s.awxClient.GetProjectPlaybooks(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range playbooks {
+		if candidate == playbook {
+			return nil
+		}
+	}
+
+	if len(playbooks) == 0 {
+		return fmt.Errorf("project %d lists no playbooks; run sync_project and check that it succeeds", projectID)
+	}
+
+	// Same file in another directory first, then the rest
+	var similar, others []string
+	for _, candidate := range playbooks {
+		if path.Base(candidate) == path.Base(playbook) {
+			similar = append(similar, candidate)
+		} else {
+			others = append(others, candidate)
+		}
+	}
+	listed := append(similar, others...)
+	more := ""
+	if len(listed) > maxListedPlaybooks {
+		more = fmt.Sprintf(" and %d more", len(listed)-maxListedPlaybooks)
+		listed = listed[:maxListedPlaybooks]
+	}
+	return fmt.Errorf("playbook '%s' not found in project %d. Available playbooks: %s%s. If it was just pushed, run sync_project first",
+		playbook, projectID, strings.Join(listed, ", "), more)
+}
+
+// ListProjectPlaybooks lists the playbooks the last sync of a project found
+func (s *AutomationService) ListProjectPlaybooks(ctx context.Context, args models.ListProjectPlaybooksArgs) (models.ListProjectPlaybooksOutput, error) {
+	project, err := s.resolveProject(ctx, args.Project)
+	if err != nil {
+		return models.ListProjectPlaybooksOutput{}, err
+	}
+
+	playbooks, err := s.awxClient.GetProjectPlaybooks(ctx, project.ID)
+	if err != nil {
+		return models.ListProjectPlaybooksOutput{}, err
+	}
+
+	return models.ListProjectPlaybooksOutput{
+		ProjectID:   project.ID,
+		Name:        project.Name,
+		Status:      project.Status,
+		ScmRevision: project.SCMRevision,
+		Playbooks:   playbooks,
+	}, nil
+}
+
+// SyncProject starts an SCM update of a project and optionally waits for it.
+// onProgress (optional) is called after every poll while waiting.
+func (s *AutomationService) SyncProject(ctx context.Context, args models.SyncProjectArgs, onProgress func(models.JobProgress)) (models.ProjectSyncOutput, error) {
+	project, err := s.resolveProject(ctx, args.Project)
+	if err != nil {
+		return models.ProjectSyncOutput{}, err
+	}
+	if project.SCMType == "" {
+		return models.ProjectSyncOutput{}, fmt.Errorf("project '%s' is a manual project; its playbooks are read from the AWX servers and cannot be synced", project.Name)
+	}
+
+	log.Printf("Syncing AWX project %s (ID: %d)", project.Name, project.ID)
+
+	sync, err := s.awxClient.SyncProject(ctx, project.ID)
+	if err != nil {
+		return models.ProjectSyncOutput{}, err
+	}
+
+	output := projectSyncOutput(*sync, project.Name)
+	if args.Wait {
+		timeout, interval := waitDurations(args.Timeout, args.PollInterval)
+		start := time.Now()
+		deadline := time.NewTimer(timeout)
+		defer deadline.Stop()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for !awx.IsFinishedStatus(output.Status) && !output.TimedOut {
+			select {
+			case <-ctx.Done():
+				return models.ProjectSyncOutput{}, fmt.Errorf("stopped waiting for project sync %d: %w", sync.ID, ctx.Err())
+			case <-deadline.C:
+				output.TimedOut = true
+				continue
+			case <-ticker.C:
+			}
+
+			current, err := s.awxClient.GetProjectSync(ctx, sync.ID)
+			if err != nil {
+				return models.ProjectSyncOutput{}, fmt.Errorf("failed to get project sync %d: %w", sync.ID, err)
+			}
+			output = projectSyncOutput(*current, project.Name)
+			if onProgress != nil {
+				onProgress(models.JobProgress{
+					JobID:   sync.ID,
+					Status:  current.Status,
+					Elapsed: time.Since(start),
+					Timeout: timeout,
+					Task:    fmt.Sprintf("syncing %s", project.Name),
+				})
+			}
+		}
+		output.Waited = time.Since(start).Round(time.Second).String()
+	}
+
+	s.finishProjectSync(ctx, &output)
+	return output, nil
+}
+
+// CheckProjectSync reports the state of a project sync started earlier
+func (s *AutomationService) CheckProjectSync(ctx context.Context, args models.CheckProjectSyncArgs) (models.ProjectSyncOutput, error) {
+	if args.SyncID <= 0 {
+		return models.ProjectSyncOutput{}, fmt.Errorf("valid sync_id is required")
+	}
+
+	sync, err := s.awxClient.GetProjectSync(ctx, args.SyncID)
+	if err != nil {
+		return models.ProjectSyncOutput{}, fmt.Errorf("failed to get project sync %d: %w", args.SyncID, err)
+	}
+
+	output := projectSyncOutput(*sync, "")
+	s.finishProjectSync(ctx, &output)
+	return output, nil
+}
+
+// finishProjectSync lists the playbooks of a successful sync and sets the message
+func (s *AutomationService) finishProjectSync(ctx context.Context, output *models.ProjectSyncOutput) {
+	switch {
+	case output.Status == "successful":
+		playbooks, err := s.awxClient.GetProjectPlaybooks(ctx, output.ProjectID)
+		if err != nil {
+			log.Printf("Failed to list playbooks of project %d: %v", output.ProjectID, err)
+		}
+		output.Playbooks = playbooks
+		output.Message = fmt.Sprintf("Project %d synced to revision %s; %d playbooks available", output.ProjectID, output.ScmRevision, len(playbooks))
+	case awx.IsFinishedStatus(output.Status):
+		output.Message = fmt.Sprintf("Project %d sync %d ended %s; the sync output in AWX shows the SCM error", output.ProjectID, output.SyncID, output.Status)
+	case output.TimedOut:
+		output.Message = fmt.Sprintf("Project %d sync %d still %s after %s; check again with check_project_sync", output.ProjectID, output.SyncID, output.Status, output.Waited)
+	default:
+		output.Message = fmt.Sprintf("Project %d sync %d is %s; follow it with check_project_sync", output.ProjectID, output.SyncID, output.Status)
+	}
+}
+
+func projectSyncOutput(sync awx.ProjectSync, name string) models.ProjectSyncOutput {
+	return models.ProjectSyncOutput{
+		SyncID:      sync.ID,
+		ProjectID:   sync.Project,
+		Name:        name,
+		Status:      sync.Status,
+		ScmRevision: sync.ScmRevision,
+		Elapsed:     sync.Elapsed,
+		Explanation: sync.JobExplanation,
+	}
+}
+
+// CreateProject creates a project; AWX syncs new SCM projects right away
+func (s *AutomationService) CreateProject(ctx context.Context, args models.CreateProjectArgs) (models.ProjectChangeOutput, error) {
+	if args.Name == "" {
+		return models.ProjectChangeOutput{}, fmt.Errorf("project name is required")
+	}
+	if args.Organization == "" {
+		return models.ProjectChangeOutput{}, fmt.Errorf("organization is required")
+	}
+
+	scmValue := "git"
+	if args.ScmType != "" {
+		var err error
+		if scmValue, err = scmType(args.ScmType); err != nil {
+			return models.ProjectChangeOutput{}, err
+		}
+	}
+	if scmValue != "" && args.ScmURL == "" {
+		return models.ProjectChangeOutput{}, fmt.Errorf("scm_url is required for %s projects", scmValue)
+	}
+
+	organization, err := s.awxClient.ResolveID(ctx, awx.ResourceOrganization, args.Organization)
+	if err != nil {
+		return models.ProjectChangeOutput{}, err
+	}
+
+	request := awx.ProjectRequest{
+		Name:              args.Name,
+		Description:       args.Description,
+		Organization:      organization,
+		ScmType:           scmValue,
+		ScmURL:            args.ScmURL,
+		ScmBranch:         args.ScmBranch,
+		ScmUpdateOnLaunch: args.UpdateOnLaunch,
+		AllowOverride:     args.AllowOverride,
+	}
+	if args.Credential != "" {
+		credential, err := s.awxClient.ResolveID(ctx, awx.ResourceCredential, args.Credential)
+		if err != nil {
+			return models.ProjectChangeOutput{}, err
+		}
+		request.Credential = &credential
+	}
+
+	log.Printf("Creating AWX project: %s", args.Name)
+
+	project, err := s.awxClient.CreateProject(ctx, request)
+	if err != nil {
+		return models.ProjectChangeOutput{}, err
+	}
+
+	output := projectChangeOutput(*project)
+	output.Message = fmt.Sprintf("Project '%s' created with ID %d", project.Name, project.ID)
+	if project.SCMType != "" {
+		output.Message += "; AWX is syncing it, list_project_playbooks shows the playbooks once it is done"
+	}
+	return output, nil
+}
+
+// UpdateProject changes the given fields of a project
+func (s *AutomationService) UpdateProject(ctx context.Context, args models.UpdateProjectArgs) (models.ProjectChangeOutput, error) {
+	project, err := s.resolveProject(ctx, args.Project)
+	if err != nil {
+		return models.ProjectChangeOutput{}, err
+	}
+
+	update := awx.ProjectUpdate{
+		Name:              args.Name,
+		Description:       args.Description,
+		ScmURL:            args.ScmURL,
+		ScmBranch:         args.ScmBranch,
+		ScmUpdateOnLaunch: args.UpdateOnLaunch,
+		AllowOverride:     args.AllowOverride,
+	}
+	if args.ScmType != nil {
+		scmValue, err := scmType(*args.ScmType)
+		if err != nil {
+			return models.ProjectChangeOutput{}, err
+		}
+		update.ScmType = &scmValue
+	}
+	if args.Credential != nil {
+		credential, err := s.awxClient.ResolveID(ctx, awx.ResourceCredential, *args.Credential)
+		if err != nil {
+			return models.ProjectChangeOutput{}, err
+		}
+		update.Credential = &credential
+	}
+
+	fields := changedFields(update)
+	if len(fields) == 0 {
+		return models.ProjectChangeOutput{}, fmt.Errorf("nothing to update: give at least one project field")
+	}
+
+	log.Printf("Updating AWX project %s (ID: %d): %v", project.Name, project.ID, fields)
+
+	updated, err := s.awxClient.UpdateProject(ctx, project.ID, update)
+	if err != nil {
+		return models.ProjectChangeOutput{}, err
+	}
+
+	output := projectChangeOutput(*updated)
+	output.UpdatedFields = fields
+	output.Message = fmt.Sprintf("Project '%s' updated: %s", updated.Name, strings.Join(fields, ", "))
+	if args.ScmURL != nil || args.ScmBranch != nil || args.ScmType != nil {
+		output.Message += "; run sync_project to check out the new source"
+	}
+	return output, nil
+}
+
+func projectChangeOutput(project awx.Project) models.ProjectChangeOutput {
+	return models.ProjectChangeOutput{
+		ID:        project.ID,
+		Name:      project.Name,
+		Status:    project.Status,
+		ScmType:   project.SCMType,
+		ScmURL:    project.SCMURL,
+		ScmBranch: project.SCMBranch,
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/prometheus"
+)
+
+const (
+	defaultRangeDuration = time.Hour
+	defaultMaxPoints     = 120
+	maxMaxPoints         = 1000
+	// Prometheus rejects range queries resolving to more than 11000 points per series
+	maxRangeResolution = 11000
+	minRangeStep       = 15 * time.Second
+)
+
+// parsePromTime parses an absolute or relative point in time. Accepted forms:
+// RFC3339, unix seconds, "now", and offsets from now such as "-1h", "now-30m",
+// "-7d" or "-3600". A signed number is an offset in seconds, an unsigned one a
+// unix time.
+func parsePromTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "now" {
+		return now, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	signed := strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+")
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && !signed {
+		whole, fraction := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(fraction*1e9)), nil
+	}
+
+	offset := strings.TrimPrefix(value, "now")
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(offset, "-"):
+		sign = -1
+		offset = offset[1:]
+	case strings.HasPrefix(offset, "+"):
+		offset = offset[1:]
+	case offset == value:
+		// A bare duration such as "1h" means that long ago
+		sign = -1
+	}
+
+	duration, err := parsePromDuration(offset)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: expected RFC3339, unix seconds, 'now' or a relative time such as '-1h' or '-3600'", value)
+	}
+	return now.Add(sign * duration), nil
+}
+
+// parsePromDuration parses a Go duration, additionally accepting day and week
+// units as used by PromQL (e.g. "7d", "2w") and seconds as a bare number, as
+// the Prometheus API does for steps (e.g. "60" or "0.5")
+func parsePromDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds < 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+			return 0, fmt.Errorf("invalid duration %q: must be a non-negative number of seconds", value)
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if !strings.HasSuffix(value, suffix) {
+			continue
+		}
+		count, err := strconv.ParseFloat(strings.TrimSuffix(value, suffix), 64)
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return time.Duration(count * float64(unit)), nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("invalid duration %q: must not be negative", value)
+	}
+	return duration, nil
+}
+
+// defaultRangeStep picks a step yielding about maxPoints samples over the range,
+// so that the range is not needlessly fetched at full resolution
+func defaultRangeStep(start, end time.Time, maxPoints int) time.Duration {
+	step := end.Sub(start) / time.Duration(maxPoints)
+	if step < minRangeStep {
+		step = minRangeStep
+	}
+	return step.Round(time.Second)
+}
+
+// computeSeriesStats summarizes the numeric samples of a series. It returns
+// nil when the series has no numeric samples.
+func computeSeriesStats(samples []prometheus.Sample) *models.SeriesStats {
+	var stats *models.SeriesStats
+	var first prometheus.Sample
+	var last prometheus.Sample
+	sum := 0.0
+
+	for _, sample := range samples {
+		if !sample.IsNumber() {
+			continue
+		}
+		if stats == nil {
+			stats = &models.SeriesStats{Min: sample.Value, Max: sample.Value}
+			first = sample
+		}
+		stats.Samples++
+		stats.Min = math.Min(stats.Min, sample.Value)
+		stats.Max = math.Max(stats.Max, sample.Value)
+		sum += sample.Value
+		last = sample
+	}
+
+	if stats == nil {
+		return nil
+	}
+
+	stats.Avg = sum / float64(stats.Samples)
+	stats.Last = last.Value
+	if elapsed := last.Time.Sub(first.Time).Seconds(); elapsed > 0 {
+		stats.RateOfChange = (last.Value - first.Value) / elapsed
+	}
+	return stats
+}
+
+// downsampleSeries reduces samples to at most maxPoints by averaging
+// consecutive buckets. Each bucket is reported at the time of its last sample
+// so that the most recent point of the series is preserved.
+func downsampleSeries(samples []prometheus.Sample, maxPoints int) []prometheus.Sample {
+	if maxPoints <= 0 || len(samples) <= maxPoints {
+		return samples
+	}
+
+	result := make([]prometheus.Sample, 0, maxPoints)
+	for bucket := 0; bucket < maxPoints; bucket++ {
+		from := bucket * len(samples) / maxPoints
+		to := (bucket + 1) * len(samples) / maxPoints
+
+		sum := 0.0
+		count := 0
+		for _, sample := range samples[from:to] {
+			if sample.IsNumber() {
+				sum += sample.Value
+				count++
+			}
+		}
+
+		point := prometheus.Sample{Time: samples[to-1].Time, Value: math.NaN(), Raw: "NaN"}
+		if count > 0 {
+			point.Value = sum / float64(count)
+			point.Raw = formatSampleValue(point.Value)
+		}
+		result = append(result, point)
+	}
+	return result
+}
+
+func formatSampleValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
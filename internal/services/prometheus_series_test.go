@@ -0,0 +1,81 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePromTime(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+		err   string
+	}{
+		{value: "", want: now},
+		{value: "now", want: now},
+		{value: "2026-03-01T08:30:00Z", want: time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{value: "1700000000", want: time.Unix(1700000000, 0)},
+		{value: "1700000000.5", want: time.Unix(1700000000, 5e8)},
+		{value: "-1h", want: now.Add(-time.Hour)},
+		{value: "now-30m", want: now.Add(-30 * time.Minute)},
+		{value: "now+5m", want: now.Add(5 * time.Minute)},
+		{value: "-7d", want: now.AddDate(0, 0, -7)},
+		{value: "2h", want: now.Add(-2 * time.Hour)},
+		// Signed numbers are offsets in seconds, not unix times
+		{value: "-3600", want: now.Add(-time.Hour)},
+		{value: "+90", want: now.Add(90 * time.Second)},
+		{value: "now-1.5", want: now.Add(-1500 * time.Millisecond)},
+		{value: "yesterday", err: `invalid time "yesterday"`},
+		{value: "--1h", err: `invalid time "--1h"`},
+	}
+	for _, tt := range tests {
+		got, err := parsePromTime(tt.value, now)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("parsePromTime(%q) error = %v, want %q", tt.value, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parsePromTime(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestParsePromDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		err   string
+	}{
+		{value: "5m", want: 5 * time.Minute},
+		{value: " 1h30m ", want: 90 * time.Minute},
+		{value: "7d", want: 7 * 24 * time.Hour},
+		{value: "1.5d", want: 36 * time.Hour},
+		{value: "2w", want: 14 * 24 * time.Hour},
+		{value: "60", want: time.Minute},
+		{value: "0.5", want: 500 * time.Millisecond},
+		{value: "0", want: 0},
+		{value: "", err: "empty duration"},
+		{value: "-60", err: "must be a non-negative number of seconds"},
+		{value: "Inf", err: "must be a non-negative number of seconds"},
+		{value: "-5m", err: "must not be negative"},
+		{value: "-1d", err: `invalid duration "-1d"`},
+		{value: "fast", err: `invalid duration "fast"`},
+	}
+	for _, tt := range tests {
+		got, err := parsePromDuration(tt.value)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("parsePromDuration(%q) error = %v, want %q", tt.value, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parsePromDuration(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
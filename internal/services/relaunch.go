@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+// relaunchChains remembers which job each relaunch retried, so a retry of a
+// retry can be traced back to the first launch. AWX does not record it, so
+// the chains only cover relaunches made through this server since it started.
+type relaunchChains struct {
+	mu      sync.Mutex
+	parents map[int]int
+}
+
+func newRelaunchChains() *relaunchChains {
+	return &relaunchChains{parents: make(map[int]int)}
+}
+
+// record links jobID to the job it retried and returns the chain from the
+// first launch to jobID
+func (r *relaunchChains) record(jobID, original int) []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parents[jobID] = original
+
+	chain := []int{jobID}
+	for id, ok := original, true; ok; id, ok = r.parents[id] {
+		chain = append(chain, id)
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
+
+// RelaunchJob runs a job again on all or only its failed hosts. Changed
+// extra_vars cannot go through the AWX relaunch endpoint, so such a relaunch
+// launches the template with the launch-time fields the job ran with.
+func (s *AutomationService) RelaunchJob(ctx context.Context, args models.RelaunchJobArgs) (models.RelaunchJobOutput, error) {
+	if args.JobID <= 0 {
+		return models.RelaunchJobOutput{}, fmt.Errorf("valid job_id is required")
+	}
+	hosts := strings.ToLower(args.Hosts)
+	if hosts == "" {
+		hosts = awx.RelaunchAllHosts
+	}
+	if hosts != awx.RelaunchAllHosts && hosts != awx.RelaunchFailedHosts {
+		return models.RelaunchJobOutput{}, fmt.Errorf("hosts must be all or failed, not %q", args.Hosts)
+	}
+
+	adHoc, err := s.awxClient.IsAdHocCommand(ctx, args.JobID)
+	if err != nil {
+		return models.RelaunchJobOutput{}, err
+	}
+	if adHoc {
+		return models.RelaunchJobOutput{}, fmt.Errorf("job %d is an ad hoc command; run it again with run_ad_hoc_command", args.JobID)
+	}
+
+	requirements, err := s.awxClient.GetRelaunchRequirements(ctx, args.JobID)
+	if err != nil {
+		return models.RelaunchJobOutput{}, fmt.Errorf("failed to get relaunch requirements of job %d: %w", args.JobID, err)
+	}
+	if hosts == awx.RelaunchFailedHosts && requirements.RetryCounts[awx.RelaunchFailedHosts] == 0 {
+		return models.RelaunchJobOutput{}, fmt.Errorf("job %d has no failed hosts; relaunch it with hosts=all", args.JobID)
+	}
+
+	output := models.RelaunchJobOutput{
+		RelaunchOf: args.JobID,
+		Hosts:      hosts,
+		HostCount:  requirements.RetryCounts[hosts],
+		Status:     "pending",
+	}
+
+	log.Printf("Relaunching AWX job %d on %s hosts", args.JobID, hosts)
+
+	if len(args.ExtraVars) == 0 {
+		var missing []string
+		for _, name := range requirements.PasswordsNeededToStart {
+			if args.CredentialPasswords[name] == "" {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return models.RelaunchJobOutput{}, fmt.Errorf("the credentials of job %d prompt for passwords; give credential_passwords for %s", args.JobID, strings.Join(missing, ", "))
+		}
+
+		jobID, err := s.awxClient.RelaunchJob(ctx, args.JobID, hosts, args.CredentialPasswords)
+		if err != nil {
+			return models.RelaunchJobOutput{}, fmt.Errorf("failed to relaunch job %d: %w", args.JobID, err)
+		}
+		output.JobID = jobID
+		output.Method = "relaunch"
+	} else if err := s.relaunchWithVars(ctx, args, &output); err != nil {
+		return models.RelaunchJobOutput{}, err
+	}
+
+	output.URL = fmt.Sprintf("%s/#/jobs/playbook/%d", s.awxBaseURL, output.JobID)
+	output.RetryChain = s.relaunches.record(output.JobID, args.JobID)
+	output.Message = fmt.Sprintf("Job %d relaunched as job %d on %s hosts", args.JobID, output.JobID, hosts)
+	if len(output.RetryChain) > 2 {
+		output.Message += fmt.Sprintf(" (attempt %d since job %d)", len(output.RetryChain), output.RetryChain[0])
+	}
+	output.Message += "; follow it with wait_for_awx_job"
+
+	log.Printf("AWX job %d relaunched as job %d (chain: %v)", args.JobID, output.JobID, output.RetryChain)
+	return output, nil
+}
+
+// relaunchWithVars launches the template of a job again with the job's
+// launch-time fields, its extra_vars overridden and, for failed hosts, a
+// limit naming them
+func (s *AutomationService) relaunchWithVars(ctx context.Context, args models.RelaunchJobArgs, output *models.RelaunchJobOutput) error {
+	config, err := s.awxClient.GetJobLaunchConfig(ctx, args.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job %d: %w", args.JobID, err)
+	}
+	if config.JobTemplate == 0 {
+		return fmt.Errorf("job %d was not launched from a job template, so its extra_vars cannot be changed", args.JobID)
+	}
+
+	prompts, err := s.awxClient.GetLaunchRequirements(ctx, config.JobTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to get launch requirements of template %d: %w", config.JobTemplate, err)
+	}
+
+	vars, masked, err := config.Vars()
+	if err != nil {
+		return err
+	}
+	// The job's extra_vars include the template's own; only launch-time
+	// values are sent again, since the template may not prompt for the rest
+	if defaults, ok := prompts.Defaults["extra_vars"].(string); ok {
+		if templateVars, err := awx.ParseExtraVars(defaults); err == nil {
+			for name, value := range templateVars {
+				if reflect.DeepEqual(vars[name], value) {
+					delete(vars, name)
+				}
+			}
+		}
+	}
+
+	options := awx.LaunchJobOptions{
+		TemplateNameOrID:    strconv.Itoa(config.JobTemplate),
+		ExtraVars:           awx.MergeExtraVars(vars, args.ExtraVars),
+		CredentialPasswords: args.CredentialPasswords,
+		Timeout:             60 * time.Second,
+	}
+
+	// Carry over every prompted field the job ran with
+	if prompts.AskLimitOnLaunch {
+		options.Limit = config.Limit
+	}
+	if prompts.AskInventoryOnLaunch && config.Inventory != 0 {
+		options.Inventory = strconv.Itoa(config.Inventory)
+	}
+	if prompts.AskCredentialOnLaunch {
+		for _, credential := range config.SummaryFields.Credentials {
+			options.Credentials = append(options.Credentials, strconv.Itoa(credential.ID))
+		}
+	}
+	if prompts.AskTagsOnLaunch {
+		options.Tags = config.JobTags
+	}
+	if prompts.AskSkipTagsOnLaunch {
+		options.SkipTags = config.SkipTags
+	}
+	if prompts.AskJobTypeOnLaunch {
+		options.JobType = config.JobType
+	}
+	if prompts.AskVerbosityOnLaunch {
+		options.Verbosity = &config.Verbosity
+	}
+	if prompts.AskDiffModeOnLaunch {
+		options.DiffMode = &config.DiffMode
+	}
+	if prompts.AskScmBranchOnLaunch {
+		options.ScmBranch = config.ScmBranch
+	}
+	if prompts.AskForksOnLaunch {
+		options.Forks = &config.Forks
+	}
+	if prompts.AskTimeoutOnLaunch {
+		options.JobTimeout = &config.Timeout
+	}
+	if prompts.AskJobSliceCountOnLaunch && config.JobSliceCount > 0 {
+		options.JobSliceCount = &config.JobSliceCount
+	}
+	if prompts.AskExecutionEnvironmentOnLaunch && config.ExecutionEnvironment != 0 {
+		options.ExecutionEnvironment = strconv.Itoa(config.ExecutionEnvironment)
+	}
+	if prompts.AskLabelsOnLaunch {
+		for _, label := range config.SummaryFields.Labels.Results {
+			options.Labels = append(options.Labels, strconv.Itoa(label.ID))
+		}
+	}
+	if prompts.AskInstanceGroupsOnLaunch && config.InstanceGroup != 0 {
+		options.InstanceGroups = []string{strconv.Itoa(config.InstanceGroup)}
+	}
+
+	if output.Hosts == awx.RelaunchFailedHosts {
+		failed, err := s.failedHosts(ctx, args.JobID)
+		if err != nil {
+			return err
+		}
+		// A limit the template does not prompt for is reported by the launch validation
+		options.Limit = strings.Join(failed, ",")
+		output.FailedHosts = failed
+		output.HostCount = len(failed)
+	}
+
+	result, err := s.awxClient.LaunchJob(ctx, options)
+	if err != nil {
+		return fmt.Errorf("failed to launch template %d again with the changed extra_vars: %w", config.JobTemplate, err)
+	}
+
+	output.JobID = result.JobID
+	output.Method = "launch"
+	output.TemplateID = result.TemplateID
+	output.TemplateName = result.TemplateName
+	output.Limit = options.Limit
+	for name := range args.ExtraVars {
+		output.OverriddenVars = append(output.OverriddenVars, name)
+	}
+	sort.Strings(output.OverriddenVars)
+	sort.Strings(masked)
+	output.DroppedVars = masked
+	return nil
+}
+
+// failedHosts returns the hosts of a job that failed or were unreachable
+func (s *AutomationService) failedHosts(ctx context.Context, jobID int) ([]string, error) {
+	summaries, err := s.awxClient.GetJobHostSummaries(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	var failed []string
+	for _, summary := range summaries {
+		if summary.Failed || summary.Failures > 0 || summary.Dark > 0 {
+			failed = append(failed, summary.HostName)
+		}
+	}
+	if len(failed) == 0 {
+		return nil, fmt.Errorf("job %d has no failed hosts; relaunch it with hosts=all", jobID)
+	}
+	sort.Strings(failed)
+	return failed, nil
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+func TestRelaunchChainsRecord(t *testing.T) {
+	chains := newRelaunchChains()
+
+	steps := []struct {
+		jobID, original int
+		want            []int
+	}{
+		{jobID: 11, original: 10, want: []int{10, 11}},
+		{jobID: 12, original: 11, want: []int{10, 11, 12}},
+		{jobID: 20, original: 5, want: []int{5, 20}},
+		{jobID: 13, original: 12, want: []int{10, 11, 12, 13}},
+		// A second retry of the same job branches off the chain
+		{jobID: 14, original: 11, want: []int{10, 11, 14}},
+	}
+	for _, step := range steps {
+		if got := chains.record(step.jobID, step.original); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("record(%d, %d) = %v, want %v", step.jobID, step.original, got, step.want)
+		}
+	}
+}
+
+// fakeRelaunch serves job 40, launched from template 7 with every prompt,
+// and records the relaunch and launch requests made for it
+type fakeRelaunch struct {
+	mu        sync.Mutex
+	summaries []awx.JobHostSummary
+	launches  []map[string]interface{}
+	relaunch  map[string]interface{}
+}
+
+func newFakeRelaunch(t *testing.T) (*fakeRelaunch, *AutomationService) {
+	t.Helper()
+	fake := &fakeRelaunch{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := awx.NewClient(awx.ClientConfig{BaseURL: server.URL, Token: "awx-token", MaxRetries: -1})
+	return fake, NewAutomationService(nil, client, server.URL, nil, nil, nil, AutoscaleSettings{})
+}
+
+func (f *fakeRelaunch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch r.Method + " " + r.URL.Path {
+	case "GET /api/v2/unified_jobs/":
+		w.Write([]byte(`{"count": 1, "results": [{"type": "job", "url": "/api/v2/jobs/40/"}]}`))
+	case "GET /api/v2/jobs/40/relaunch/":
+		w.Write([]byte(`{"passwords_needed_to_start": [], "retry_counts": {"all": 4, "failed": 2}}`))
+	case "POST /api/v2/jobs/40/relaunch/":
+		f.relaunch = body
+		w.Write([]byte(`{"id": 41, "job": 41}`))
+	case "GET /api/v2/jobs/40/":
+		w.Write([]byte(`{
+			"id": 40, "job_template": 7, "inventory": 2, "limit": "web*",
+			"extra_vars": "{\"region\": \"eu\", \"release\": \"1.2\", \"db_password\": \"$encrypted$\"}",
+			"job_tags": "deploy", "skip_tags": "slow", "job_type": "check", "verbosity": 2, "diff_mode": true,
+			"scm_branch": "main", "forks": 8, "timeout": 600, "job_slice_count": 2,
+			"execution_environment": 6, "instance_group": 3,
+			"summary_fields": {
+				"credentials": [{"id": 4, "name": "ssh"}],
+				"labels": {"count": 2, "results": [{"id": 8, "name": "prod"}, {"id": 9, "name": "web"}]}
+			}
+		}`))
+	case "GET /api/v2/jobs/40/job_host_summaries/":
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(f.summaries), "results": f.summaries})
+	case "GET /api/v2/job_templates/":
+		w.Write([]byte(`{"count": 1, "results": [{"id": 7, "name": "deploy"}]}`))
+	case "GET /api/v2/job_templates/7/launch/":
+		w.Write([]byte(`{
+			"ask_variables_on_launch": true, "ask_inventory_on_launch": true, "ask_credential_on_launch": true,
+			"ask_limit_on_launch": true, "ask_tags_on_launch": true, "ask_skip_tags_on_launch": true,
+			"ask_job_type_on_launch": true, "ask_verbosity_on_launch": true, "ask_diff_mode_on_launch": true,
+			"ask_scm_branch_on_launch": true, "ask_execution_environment_on_launch": true, "ask_labels_on_launch": true,
+			"ask_forks_on_launch": true, "ask_job_slice_count_on_launch": true, "ask_timeout_on_launch": true,
+			"ask_instance_groups_on_launch": true,
+			"defaults": {"extra_vars": "region: eu"}
+		}`))
+	case "POST /api/v2/job_templates/7/launch/":
+		f.launches = append(f.launches, body)
+		w.Write([]byte(`{"id": 42, "job": 42}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestRelaunchJobWithVarsCarriesLaunchFields(t *testing.T) {
+	fake, service := newFakeRelaunch(t)
+
+	output, err := service.RelaunchJob(context.Background(), models.RelaunchJobArgs{
+		JobID: 40, ExtraVars: map[string]interface{}{"release": "1.3"},
+	})
+	if err != nil {
+		t.Fatalf("RelaunchJob: %v", err)
+	}
+	if len(fake.launches) != 1 {
+		t.Fatalf("launches = %v, want one", fake.launches)
+	}
+
+	// The template default and the masked secret are not sent again
+	want := `{"credentials":[4],"diff_mode":true,"execution_environment":6,"extra_vars":{"release":"1.3"},"forks":8,` +
+		`"instance_groups":[3],"inventory":2,"job_slice_count":2,"job_tags":"deploy","job_type":"check","labels":[8,9],` +
+		`"limit":"web*","scm_branch":"main","skip_tags":"slow","timeout":600,"verbosity":2}`
+	if got := jsonString(t, fake.launches[0]); got != want {
+		t.Errorf("launch body = %s\nwant %s", got, want)
+	}
+	if output.JobID != 42 || output.Method != "launch" || output.HostCount != 4 || output.Limit != "web*" {
+		t.Errorf("output = %+v", output)
+	}
+	if !reflect.DeepEqual(output.DroppedVars, []string{"db_password"}) || !reflect.DeepEqual(output.OverriddenVars, []string{"release"}) {
+		t.Errorf("dropped %v, overridden %v", output.DroppedVars, output.OverriddenVars)
+	}
+	if !reflect.DeepEqual(output.RetryChain, []int{40, 42}) {
+		t.Errorf("retry chain = %v", output.RetryChain)
+	}
+}
+
+func TestRelaunchJobFailedHostsLimit(t *testing.T) {
+	fake, service := newFakeRelaunch(t)
+	fake.summaries = []awx.JobHostSummary{
+		{HostName: "web3", Failures: 1},
+		{HostName: "web1", OK: 3},
+		{HostName: "web2", Dark: 1},
+		{HostName: "web4", Failed: true},
+	}
+
+	output, err := service.RelaunchJob(context.Background(), models.RelaunchJobArgs{
+		JobID: 40, Hosts: "FAILED", ExtraVars: map[string]interface{}{"release": "1.3"},
+	})
+	if err != nil {
+		t.Fatalf("RelaunchJob: %v", err)
+	}
+	if got := fake.launches[0]["limit"]; got != "web2,web3,web4" {
+		t.Errorf("limit = %v, want the failed and unreachable hosts in place of the job limit", got)
+	}
+	if !reflect.DeepEqual(output.FailedHosts, []string{"web2", "web3", "web4"}) || output.HostCount != 3 || output.Limit != "web2,web3,web4" {
+		t.Errorf("output = %+v", output)
+	}
+
+	fake.summaries = []awx.JobHostSummary{{HostName: "web1", OK: 3}}
+	if _, err := service.RelaunchJob(context.Background(), models.RelaunchJobArgs{
+		JobID: 40, Hosts: "failed", ExtraVars: map[string]interface{}{"release": "1.3"},
+	}); err == nil || !strings.Contains(err.Error(), "job 40 has no failed hosts") {
+		t.Errorf("no failed hosts in the summaries: %v", err)
+	}
+}
+
+func TestRelaunchJobWithoutVarsUsesRelaunchEndpoint(t *testing.T) {
+	fake, service := newFakeRelaunch(t)
+
+	output, err := service.RelaunchJob(context.Background(), models.RelaunchJobArgs{JobID: 40, Hosts: "failed"})
+	if err != nil {
+		t.Fatalf("RelaunchJob: %v", err)
+	}
+	if output.JobID != 41 || output.Method != "relaunch" || output.HostCount != 2 || len(fake.launches) != 0 {
+		t.Errorf("output = %+v, launches = %v", output, fake.launches)
+	}
+	if fake.relaunch["hosts"] != "failed" {
+		t.Errorf("relaunch body = %v", fake.relaunch)
+	}
+
+	if _, err := service.RelaunchJob(context.Background(), models.RelaunchJobArgs{JobID: 40, Hosts: "some"}); err == nil || !strings.Contains(err.Error(), "hosts must be all or failed") {
+		t.Errorf("bad host selection: %v", err)
+	}
+}
@@ -0,0 +1,441 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+const (
+	// defaultPreviewRuns and maxPreviewRuns bound the runs a schedule preview shows
+	defaultPreviewRuns = 10
+	maxPreviewRuns     = 100
+	// changePreviewRuns is how many next runs a created or updated schedule shows
+	changePreviewRuns = 5
+	// defaultUpcomingHours and maxUpcomingHours bound the upcoming runs window
+	defaultUpcomingHours = 24
+	maxUpcomingHours     = 7 * 24
+	// maxUpcomingRuns caps the runs listed, since a minutely schedule alone fills a day with 1440
+	maxUpcomingRuns = 200
+)
+
+// scheduleTemplate resolves the job template or workflow a schedule belongs to
+func (s *AutomationService) scheduleTemplate(ctx context.Context, jobTemplate, workflowTemplate string) (int, string, error) {
+	switch {
+	case jobTemplate != "" && workflowTemplate != "":
+		return 0, "", fmt.Errorf("give either job_template or workflow_template, not both")
+	case jobTemplate != "":
+		template, err := s.awxClient.GetJobTemplateByName(ctx, jobTemplate)
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to find job template: %w", err)
+		}
+		return template.ID, template.Name, nil
+	case workflowTemplate != "":
+		template, err := s.awxClient.GetWorkflowJobTemplateByName(ctx, workflowTemplate)
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to find workflow job template: %w", err)
+		}
+		return template.ID, template.Name, nil
+	}
+	return 0, "", nil
+}
+
+// resolveSchedule finds a schedule by name or ID. Names are only unique per
+// template, so a shared name asks for the ID.
+func (s *AutomationService) resolveSchedule(ctx context.Context, reference string) (*awx.Schedule, error) {
+	id, err := s.awxClient.ResolveID(ctx, awx.ResourceSchedule, reference)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := s.awxClient.GetSchedule(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule %d: %w", id, err)
+	}
+	return schedule, nil
+}
+
+// scheduleLocation loads the time zone runs are shown in, falling back to the
+// zone of the rule
+func scheduleLocation(zone string, fallback *time.Location) (*time.Location, error) {
+	if zone == "" {
+		return fallback, nil
+	}
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time_zone %q, use an IANA name such as Europe/Paris", zone)
+	}
+	return location, nil
+}
+
+// nextRuns formats the next runs of a rule in its own time zone
+func nextRuns(rule *awx.ScheduleRule, count int) []string {
+	var runs []string
+	for _, run := range rule.Occurrences(time.Now(), time.Time{}, count) {
+		runs = append(runs, run.Format(time.RFC3339))
+	}
+	return runs
+}
+
+func scheduleSummary(schedule awx.Schedule) models.ScheduleSummary {
+	summary := models.ScheduleSummary{
+		ID:           schedule.ID,
+		Name:         schedule.Name,
+		Description:  schedule.Description,
+		TemplateID:   schedule.UnifiedJobTemplate,
+		TemplateName: schedule.SummaryFields.UnifiedJobTemplate.Name,
+		TemplateType: schedule.SummaryFields.UnifiedJobTemplate.UnifiedJobType,
+		Enabled:      schedule.Enabled,
+		Rrule:        schedule.Rrule,
+		Timezone:     schedule.Timezone,
+		Until:        schedule.Until,
+		ExtraData:    schedule.ExtraData,
+	}
+	if schedule.NextRun != nil {
+		summary.NextRun = schedule.NextRun.UTC().Format(time.RFC3339)
+	}
+	return summary
+}
+
+// ListSchedules lists schedules, optionally of one template, by next run
+func (s *AutomationService) ListSchedules(ctx context.Context, args models.ListSchedulesArgs) (models.ListSchedulesOutput, error) {
+	templateID, templateName, err := s.scheduleTemplate(ctx, args.JobTemplate, args.WorkflowTemplate)
+	if err != nil {
+		return models.ListSchedulesOutput{}, err
+	}
+
+	if templateName != "" {
+		log.Printf("Listing AWX schedules of template %s (ID: %d)", templateName, templateID)
+	} else {
+		log.Printf("Listing AWX schedules")
+	}
+
+	schedules, err := s.awxClient.GetSchedules(ctx, awx.ScheduleFilter{UnifiedJobTemplate: templateID, Enabled: args.Enabled})
+	if err != nil {
+		return models.ListSchedulesOutput{}, fmt.Errorf("failed to get schedules: %w", err)
+	}
+
+	output := models.ListSchedulesOutput{
+		Schedules: make([]models.ScheduleSummary, len(schedules.Results)),
+		Total:     schedules.Count,
+	}
+	for i, schedule := range schedules.Results {
+		output.Schedules[i] = scheduleSummary(schedule)
+	}
+	return output, nil
+}
+
+// CreateSchedule schedules a job template or workflow. The rule is checked
+// before anything is resolved, so a bad rule never reaches AWX.
+func (s *AutomationService) CreateSchedule(ctx context.Context, args models.CreateScheduleArgs) (models.ScheduleChangeOutput, error) {
+	if args.Name == "" {
+		return models.ScheduleChangeOutput{}, fmt.Errorf("schedule name is required")
+	}
+	if args.JobTemplate == "" && args.WorkflowTemplate == "" {
+		return models.ScheduleChangeOutput{}, fmt.Errorf("job_template or workflow_template is required")
+	}
+	rule, err := awx.ParseScheduleRule(args.Rrule)
+	if err != nil {
+		return models.ScheduleChangeOutput{}, err
+	}
+
+	templateID, templateName, err := s.scheduleTemplate(ctx, args.JobTemplate, args.WorkflowTemplate)
+	if err != nil {
+		return models.ScheduleChangeOutput{}, err
+	}
+
+	request := awx.ScheduleRequest{
+		Name:               args.Name,
+		Description:        args.Description,
+		Rrule:              strings.Join(strings.Fields(args.Rrule), " "),
+		UnifiedJobTemplate: templateID,
+		Enabled:            args.Enabled == nil || *args.Enabled,
+		ExtraData:          args.ExtraData,
+	}
+
+	log.Printf("Creating AWX schedule %s on template %s (ID: %d): %s", args.Name, templateName, templateID, request.Rrule)
+
+	schedule, err := s.awxClient.CreateSchedule(ctx, request)
+	if err != nil {
+		return models.ScheduleChangeOutput{}, err
+	}
+
+	output := models.ScheduleChangeOutput{
+		Schedule: scheduleSummary(*schedule),
+		NextRuns: nextRuns(rule, changePreviewRuns),
+	}
+	output.Message = fmt.Sprintf("Schedule '%s' (ID: %d) created on '%s'", schedule.Name, schedule.ID, templateName)
+	switch {
+	case len(output.NextRuns) == 0:
+		output.Message += "; its rule has no runs left, so it will never run"
+	case !schedule.Enabled:
+		output.Message += "; it is disabled, set_schedule_enabled starts it"
+	}
+	return output, nil
+}
+
+// UpdateSchedule changes the given fields of a schedule
+func (s *AutomationService) UpdateSchedule(ctx context.Context, args models.UpdateScheduleArgs) (models.ScheduleChangeOutput, error) {
+	var rule *awx.ScheduleRule
+	update := awx.ScheduleUpdate{
+		Name:        args.Name,
+		Description: args.Description,
+		Enabled:     args.Enabled,
+		ExtraData:   args.ExtraData,
+	}
+	if args.Rrule != nil {
+		var err error
+		if rule, err = awx.ParseScheduleRule(*args.Rrule); err != nil {
+			return models.ScheduleChangeOutput{}, err
+		}
+		normalized := strings.Join(strings.Fields(*args.Rrule), " ")
+		update.Rrule = &normalized
+	}
+
+	fields := changedFields(update)
+	if len(fields) == 0 {
+		return models.ScheduleChangeOutput{}, fmt.Errorf("nothing to update: give at least one schedule field")
+	}
+
+	schedule, err := s.resolveSchedule(ctx, args.Schedule)
+	if err != nil {
+		return models.ScheduleChangeOutput{}, err
+	}
+
+	log.Printf("Updating AWX schedule %s (ID: %d): %v", schedule.Name, schedule.ID, fields)
+
+	updated, err := s.awxClient.UpdateSchedule(ctx, schedule.ID, update)
+	if err != nil {
+		return models.ScheduleChangeOutput{}, err
+	}
+
+	output := models.ScheduleChangeOutput{
+		Schedule:      scheduleSummary(*updated),
+		UpdatedFields: fields,
+		Message:       fmt.Sprintf("Schedule '%s' (ID: %d) updated: %s", updated.Name, updated.ID, strings.Join(fields, ", ")),
+	}
+	if rule == nil {
+		// Show the runs of the unchanged rule too; AWX only stores rules it accepts
+		rule, _ = awx.ParseScheduleRule(updated.Rrule)
+	}
+	if rule != nil {
+		output.NextRuns = nextRuns(rule, changePreviewRuns)
+	}
+	return output, nil
+}
+
+// SetScheduleEnabled enables or disables a schedule, leaving it alone when it
+// already is
+func (s *AutomationService) SetScheduleEnabled(ctx context.Context, args models.SetScheduleEnabledArgs) (models.ScheduleChangeOutput, error) {
+	schedule, err := s.resolveSchedule(ctx, args.Schedule)
+	if err != nil {
+		return models.ScheduleChangeOutput{}, err
+	}
+
+	state := "disabled"
+	if args.Enabled {
+		state = "enabled"
+	}
+
+	if schedule.Enabled == args.Enabled {
+		return models.ScheduleChangeOutput{
+			Schedule: scheduleSummary(*schedule),
+			Message:  fmt.Sprintf("Schedule '%s' (ID: %d) is already %s", schedule.Name, schedule.ID, state),
+		}, nil
+	}
+
+	log.Printf("Setting AWX schedule %s (ID: %d) %s", schedule.Name, schedule.ID, state)
+
+	updated, err := s.awxClient.UpdateSchedule(ctx, schedule.ID, awx.ScheduleUpdate{Enabled: &args.Enabled})
+	if err != nil {
+		return models.ScheduleChangeOutput{}, err
+	}
+
+	output := models.ScheduleChangeOutput{
+		Schedule:      scheduleSummary(*updated),
+		UpdatedFields: []string{"enabled"},
+		Message:       fmt.Sprintf("Schedule '%s' (ID: %d) %s", updated.Name, updated.ID, state),
+	}
+	if args.Enabled {
+		if rule, err := awx.ParseScheduleRule(updated.Rrule); err == nil {
+			output.NextRuns = nextRuns(rule, changePreviewRuns)
+		}
+	}
+	return output, nil
+}
+
+// DeleteSchedule deletes a schedule in two calls: the first shows what would
+// be deleted and returns a confirmation token, the second deletes the
+// schedule when given that token
+func (s *AutomationService) DeleteSchedule(ctx context.Context, args models.DeleteScheduleArgs) (models.DeleteScheduleOutput, error) {
+	schedule, err := s.resolveSchedule(ctx, args.Schedule)
+	if err != nil {
+		return models.DeleteScheduleOutput{}, err
+	}
+
+	summary := scheduleSummary(*schedule)
+	output := models.DeleteScheduleOutput{
+		ID:           schedule.ID,
+		Name:         schedule.Name,
+		TemplateName: summary.TemplateName,
+		Enabled:      schedule.Enabled,
+		NextRun:      summary.NextRun,
+	}
+	action := fmt.Sprintf("delete schedule %d", schedule.ID)
+
+	if args.ConfirmationToken != "" {
+		if err := s.confirmations.redeem(args.ConfirmationToken, action); err != nil {
+			return models.DeleteScheduleOutput{}, err
+		}
+
+		log.Printf("Deleting AWX schedule %s (ID: %d)", schedule.Name, schedule.ID)
+		if err := s.awxClient.DeleteSchedule(ctx, schedule.ID); err != nil {
+			return models.DeleteScheduleOutput{}, err
+		}
+
+		output.Deleted = true
+		output.Message = fmt.Sprintf("Schedule '%s' (ID: %d) of '%s' deleted", schedule.Name, schedule.ID, output.TemplateName)
+		return output, nil
+	}
+
+	token, expires := s.confirmations.issue(action)
+	output.ConfirmationToken = token
+	output.ExpiresAt = expires.Format(time.RFC3339)
+	output.Message = fmt.Sprintf("Deleting schedule '%s' (ID: %d) of '%s' cannot be undone; set_schedule_enabled can pause it instead. Call delete_schedule again with confirmation_token %s within %v to delete it.",
+		schedule.Name, schedule.ID, output.TemplateName, token, confirmationTTL)
+	return output, nil
+}
+
+// PreviewSchedule lists the next runs of a rule, or of an existing schedule's rule
+func (s *AutomationService) PreviewSchedule(ctx context.Context, args models.PreviewScheduleArgs) (models.PreviewScheduleOutput, error) {
+	if (args.Rrule == "") == (args.Schedule == "") {
+		return models.PreviewScheduleOutput{}, fmt.Errorf("give either rrule or schedule")
+	}
+	count := args.Count
+	if count <= 0 {
+		count = defaultPreviewRuns
+	}
+	if count > maxPreviewRuns {
+		return models.PreviewScheduleOutput{}, fmt.Errorf("count must be at most %d", maxPreviewRuns)
+	}
+
+	text := args.Rrule
+	if args.Schedule != "" {
+		schedule, err := s.resolveSchedule(ctx, args.Schedule)
+		if err != nil {
+			return models.PreviewScheduleOutput{}, err
+		}
+		text = schedule.Rrule
+	}
+
+	rule, err := awx.ParseScheduleRule(text)
+	if err != nil {
+		return models.PreviewScheduleOutput{}, err
+	}
+	location, err := scheduleLocation(args.TimeZone, rule.Location)
+	if err != nil {
+		return models.PreviewScheduleOutput{}, err
+	}
+
+	output := models.PreviewScheduleOutput{
+		Rrule:       strings.Join(strings.Fields(text), " "),
+		TimeZone:    location.String(),
+		Occurrences: []models.ScheduleOccurrence{},
+	}
+	for _, run := range rule.Occurrences(time.Now(), time.Time{}, count) {
+		output.Occurrences = append(output.Occurrences, models.ScheduleOccurrence{
+			Local: run.In(location).Format(time.RFC3339),
+			UTC:   run.UTC().Format(time.RFC3339),
+		})
+	}
+
+	switch {
+	case len(output.Occurrences) == 0:
+		output.Message = "The rule has no runs left"
+	case len(output.Occurrences) < count:
+		output.Message = fmt.Sprintf("The rule has only %d runs left", len(output.Occurrences))
+	default:
+		output.Message = fmt.Sprintf("Next %d runs, first at %s", len(output.Occurrences), output.Occurrences[0].Local)
+	}
+	return output, nil
+}
+
+// GetUpcomingScheduleRuns lists the runs of every enabled schedule in the
+// coming hours, earliest first, so maintenance can be fitted around them
+func (s *AutomationService) GetUpcomingScheduleRuns(ctx context.Context, args models.UpcomingScheduleRunsArgs) (models.UpcomingScheduleRunsOutput, error) {
+	hours := args.Hours
+	if hours <= 0 {
+		hours = defaultUpcomingHours
+	}
+	if hours > maxUpcomingHours {
+		return models.UpcomingScheduleRunsOutput{}, fmt.Errorf("hours must be at most %d", maxUpcomingHours)
+	}
+	location, err := scheduleLocation(args.TimeZone, time.UTC)
+	if err != nil {
+		return models.UpcomingScheduleRunsOutput{}, err
+	}
+
+	enabled := true
+	schedules, err := s.awxClient.GetSchedules(ctx, awx.ScheduleFilter{Enabled: &enabled})
+	if err != nil {
+		return models.UpcomingScheduleRunsOutput{}, fmt.Errorf("failed to get schedules: %w", err)
+	}
+
+	from := time.Now()
+	to := from.Add(time.Duration(hours) * time.Hour)
+	output := models.UpcomingScheduleRunsOutput{
+		From:      from.In(location).Format(time.RFC3339),
+		To:        to.In(location).Format(time.RFC3339),
+		TimeZone:  location.String(),
+		Runs:      []models.UpcomingScheduleRun{},
+		Schedules: len(schedules.Results),
+	}
+	if schedules.Truncated {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("only %d of %d enabled schedules were checked", len(schedules.Results), schedules.Count))
+	}
+
+	for _, schedule := range schedules.Results {
+		summary := scheduleSummary(schedule)
+		var runs []time.Time
+		rule, err := awx.ParseScheduleRule(schedule.Rrule)
+		switch {
+		case err == nil:
+			runs = rule.Occurrences(from, to, 0)
+		case schedule.NextRun != nil && schedule.NextRun.After(from) && schedule.NextRun.Before(to):
+			// AWX still knows the next run of a rule this server cannot expand;
+			// a later next run means the schedule has none in the window
+			runs = []time.Time{*schedule.NextRun}
+			output.Warnings = append(output.Warnings, fmt.Sprintf("schedule '%s' (ID: %d): only its next run is shown, %v", schedule.Name, schedule.ID, err))
+		}
+
+		for _, run := range runs {
+			output.Runs = append(output.Runs, models.UpcomingScheduleRun{
+				Time:         run.In(location).Format(time.RFC3339),
+				UTC:          run.UTC().Format(time.RFC3339),
+				ScheduleID:   schedule.ID,
+				ScheduleName: schedule.Name,
+				TemplateID:   summary.TemplateID,
+				TemplateName: summary.TemplateName,
+				TemplateType: summary.TemplateType,
+			})
+		}
+	}
+
+	sort.SliceStable(output.Runs, func(i, j int) bool { return output.Runs[i].UTC < output.Runs[j].UTC })
+	output.TotalRuns = len(output.Runs)
+	if len(output.Runs) > maxUpcomingRuns {
+		output.Runs = output.Runs[:maxUpcomingRuns]
+		output.Truncated = true
+	}
+
+	output.Message = fmt.Sprintf("%d runs of %d enabled schedules in the next %d hours", output.TotalRuns, output.Schedules, hours)
+	if output.Truncated {
+		output.Message += fmt.Sprintf("; the first %d are listed", maxUpcomingRuns)
+	}
+	return output, nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/NacerKH/autosphere-mcp-golang/internal/awx"
+	"github.com/NacerKH/autosphere-mcp-golang/internal/models"
+)
+
+func (s *AutomationService) ListWorkflowTemplates(ctx context.Context, args models.ListWorkflowTemplatesArgs) (models.ListWorkflowTemplatesOutput, error) {
+	log.Printf("Listing AWX workflow job templates")
+
+	templates, err := s.awxClient.GetWorkflowJobTemplateList(ctx)
+	if err != nil {
+		log.Printf("Failed to get workflow job templates: %v", err)
+		return models.ListWorkflowTemplatesOutput{}, fmt.Errorf("failed to get workflow job templates: %w", err)
+	}
+
+	summaries := make([]models.WorkflowTemplateSummary, len(templates.Results))
+	for i, template := range templates.Results {
+		summaries[i] = models.WorkflowTemplateSummary{
+			ID:          template.ID,
+			Name:        template.Name,
+			Description: template.Description,
+			Status:      template.Status,
+		}
+	}
+
+	log.Printf("Retrieved %d of %d workflow job templates", len(summaries), templates.Count)
+
+	return models.ListWorkflowTemplatesOutput{
+		Templates: summaries,
+		Total:     templates.Count,
+	}, nil
+}
+
+func (s *AutomationService) LaunchWorkflow(ctx context.Context, args models.LaunchWorkflowArgs) (models.LaunchWorkflowOutput, error) {
+	if args.WorkflowTemplate == "" {
+		return models.LaunchWorkflowOutput{}, fmt.Errorf("workflow_template is required")
+	}
+
+	log.Printf("Launching AWX workflow with template: %s", args.WorkflowTemplate)
+
+	extraVars, err := s.varSets.Merge(args.VarSets, args.ExtraVars)
+	if err != nil {
+		return models.LaunchWorkflowOutput{}, err
+	}
+
+	options := awx.LaunchWorkflowOptions{
+		TemplateNameOrID: args.WorkflowTemplate,
+		ExtraVars:        extraVars,
+		Inventory:        args.Inventory,
+		Limit:            args.Limit,
+	}
+
+	result, err := s.awxClient.LaunchWorkflow(ctx, options)
+	if err != nil {
+		log.Printf("Failed to launch AWX workflow: %v", err)
+		return models.LaunchWorkflowOutput{}, fmt.Errorf("failed to launch AWX workflow: %w", err)
+	}
+
+	return models.LaunchWorkflowOutput{
+		WorkflowJobID: result.JobID,
+		Status:        result.Status,
+		URL:           result.URL,
+		Message:       result.Message,
+	}, nil
+}
+
+func (s *AutomationService) GetWorkflowStatus(ctx context.Context, args models.WorkflowStatusArgs) (models.WorkflowStatusOutput, error) {
+	if args.WorkflowJobID <= 0 {
+		return models.WorkflowStatusOutput{}, fmt.Errorf("valid workflow_job_id is required")
+	}
+
+	log.Printf("Checking AWX workflow job status for ID: %d", args.WorkflowJobID)
+
+	job, err := s.awxClient.GetWorkflowJob(ctx, args.WorkflowJobID)
+	if err != nil {
+		log.Printf("Failed to get AWX workflow job: %v", err)
+		return models.WorkflowStatusOutput{}, fmt.Errorf("failed to get workflow job: %w", err)
+	}
+
+	nodes, err := s.awxClient.GetWorkflowJobNodes(ctx, args.WorkflowJobID)
+	if err != nil {
+		log.Printf("Failed to get AWX workflow nodes: %v", err)
+		return models.WorkflowStatusOutput{}, fmt.Errorf("failed to get workflow nodes: %w", err)
+	}
+
+	output := models.WorkflowStatusOutput{
+		WorkflowJobID: job.ID,
+		Name:          job.Name,
+		Status:        job.Status,
+		URL:           job.URL,
+		Nodes:         make([]models.WorkflowNodeSummary, 0, len(nodes)),
+	}
+
+	if job.Started != nil {
+		output.StartedAt = job.Started.Format("2006-01-02 15:04:05")
+		if job.Finished != nil {
+			output.FinishedAt = job.Finished.Format("2006-01-02 15:04:05")
+			output.ElapsedTime = job.Finished.Sub(*job.Started).Round(time.Second).String()
+		} else {
+			output.ElapsedTime = time.Since(*job.Started).Round(time.Second).String()
+		}
+	}
+
+	for _, node := range nodes {
+		summary := models.WorkflowNodeSummary{
+			NodeID:       node.NodeID,
+			Identifier:   node.Identifier,
+			Template:     node.TemplateName,
+			TemplateType: node.TemplateType,
+			JobID:        node.JobID,
+			Status:       node.Status,
+			OnSuccess:    node.SuccessNodes,
+			OnFailure:    node.FailureNodes,
+			Always:       node.AlwaysNodes,
+		}
+		if node.Elapsed > 0 {
+			summary.ElapsedTime = (time.Duration(node.Elapsed * float64(time.Second))).Round(time.Second).String()
+		}
+		output.Nodes = append(output.Nodes, summary)
+
+		if node.IsPendingApproval() {
+			output.PendingApprovals = append(output.PendingApprovals, node.JobID)
+		}
+	}
+
+	log.Printf("Workflow job %d is %s (%d nodes, %d pending approvals)", job.ID, job.Status, len(nodes), len(output.PendingApprovals))
+
+	return output, nil
+}
+
+func (s *AutomationService) DecideWorkflowApproval(ctx context.Context, args models.WorkflowApprovalArgs) (models.WorkflowApprovalOutput, error) {
+	if args.ApprovalID <= 0 {
+		return models.WorkflowApprovalOutput{}, fmt.Errorf("valid approval_id is required")
+	}
+
+	action := strings.ToLower(args.Action)
+	if action != "approve" && action != "deny" {
+		return models.WorkflowApprovalOutput{}, fmt.Errorf("unknown action: %s. Supported actions: approve, deny", args.Action)
+	}
+
+	approval, err := s.awxClient.GetWorkflowApproval(ctx, args.ApprovalID)
+	if err != nil {
+		return models.WorkflowApprovalOutput{}, fmt.Errorf("failed to get workflow approval: %w", err)
+	}
+	if approval.Status != "pending" {
+		return models.WorkflowApprovalOutput{}, fmt.Errorf("workflow approval %d is not pending (status: %s)", args.ApprovalID, approval.Status)
+	}
+
+	log.Printf("Workflow approval %d (%s): %s", approval.ID, approval.Name, action)
+
+	if action == "approve" {
+		err = s.awxClient.ApproveWorkflowApproval(ctx, args.ApprovalID)
+	} else {
+		err = s.awxClient.DenyWorkflowApproval(ctx, args.ApprovalID)
+	}
+	if err != nil {
+		return models.WorkflowApprovalOutput{}, err
+	}
+
+	status, verb := "successful", "approved"
+	if action == "deny" {
+		status, verb = "failed", "denied"
+	}
+
+	return models.WorkflowApprovalOutput{
+		ApprovalID: approval.ID,
+		Name:       approval.Name,
+		Action:     action,
+		Status:     status,
+		Message:    fmt.Sprintf("Workflow approval '%s' (ID: %d) %s", approval.Name, approval.ID, verb),
+	}, nil
+}